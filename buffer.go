@@ -0,0 +1,533 @@
+//
+// otto.module :: buffer.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/robertkrimen/otto"
+)
+
+// Buffers are objects on Buffer.prototype which hold a property for each
+// byte. otto creates Go slices on Array.prototype without a way to change it,
+// and assigning a value which does not fit in a byte to an element of a Go
+// slice panics outside of the VM, so the bytes cannot be held on the Go side.
+type buffer struct {
+	create   otto.Value
+	isBuffer otto.Value
+}
+
+const kMaxLength = 1 << 32
+
+type rangeError struct {
+	name     string
+	rng      string
+	received string
+}
+
+func (e *rangeError) Error() string {
+	s := e.received
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && f == math.Trunc(f) && math.Abs(f) > kMaxLength {
+		i := len(s)
+		start := 0
+		if s[0] == '-' {
+			start = 1
+		}
+		var t string
+		for ; i >= start+4; i -= 3 {
+			t = "_" + s[i-3:i] + t
+		}
+		s = s[:i] + t
+	}
+	return fmt.Sprintf("The value of %q is out of range. It must be %v. Received %v", e.name, e.rng, s)
+}
+
+func (vm *Otto) NewBuffer(b []byte) (otto.Value, error) {
+	v, err := vm.buffer.create.Call(otto.UndefinedValue(), len(b))
+	if err != nil {
+		return otto.UndefinedValue(), err
+	}
+	putBytes(v.Object(), 0, b)
+	return v, nil
+}
+
+func (vm *Otto) Bytes(v otto.Value) ([]byte, error) {
+	return vm.toBytes("value", v)
+}
+
+func (vm *Otto) isBuffer(v otto.Value) bool {
+	if !v.IsObject() {
+		return false
+	}
+	rv, err := vm.buffer.isBuffer.Call(otto.UndefinedValue(), v)
+	if err != nil {
+		return false
+	}
+	ok, _ := rv.ToBoolean()
+	return ok
+}
+
+func (vm *Otto) bufferOf(v otto.Value) ([]byte, bool) {
+	b, err := vm.toBuffer("value", v)
+	return b, err == nil
+}
+
+func (vm *Otto) buffer_binding(o *otto.Object) error {
+	o.Set("setup", vm.buffer_setup)
+	o.Set("alloc", vm.buffer_alloc)
+	o.Set("from", vm.buffer_from)
+	o.Set("byteLength", vm.buffer_byteLength)
+	o.Set("toString", vm.buffer_toString)
+	o.Set("write", vm.buffer_write)
+	o.Set("fill", vm.buffer_fill)
+	o.Set("copy", vm.buffer_copy)
+	o.Set("compare", vm.buffer_compare)
+	o.Set("indexOf", vm.buffer_indexOf)
+	o.Set("slice", vm.buffer_slice)
+	o.Set("readFloat", vm.buffer_readFloat)
+	o.Set("writeFloat", vm.buffer_writeFloat)
+	return nil
+}
+
+func (vm *Otto) buffer_setup(call otto.FunctionCall) otto.Value {
+	create := call.Argument(0)
+	isBuffer := call.Argument(1)
+	if !create.IsFunction() || !isBuffer.IsFunction() {
+		return vm.throw(fmt.Errorf("create and isBuffer must be a Function"))
+	}
+
+	vm.buffer.create = create
+	vm.buffer.isBuffer = isBuffer
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) buffer_alloc(call otto.FunctionCall) otto.Value {
+	n, err := call.Argument(0).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	} else if n < 0 {
+		return vm.throw(fmt.Errorf("size must be >= 0"))
+	}
+
+	v, err := vm.NewBuffer(make([]byte, n))
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) buffer_from(call otto.FunctionCall) otto.Value {
+	var b []byte
+	if v := call.Argument(0); v.IsString() {
+		enc, err := vm.toEncoding(call.Argument(1))
+		if err != nil {
+			return vm.throw(err)
+		}
+		s, _ := v.ToString()
+		b, _ = encode(s, enc)
+	} else {
+		var err error
+		b, err = vm.toBytes("value", v)
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
+
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) buffer_byteLength(call otto.FunctionCall) otto.Value {
+	s, err := vm.toString("string", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	enc, err := vm.toEncoding(call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	b, _ := encode(s, enc)
+	v, _ := vm.ToValue(len(b))
+	return v
+}
+
+func (vm *Otto) buffer_toString(call otto.FunctionCall) otto.Value {
+	o, n, err := vm.toBufferObject("buf", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	enc, err := vm.toEncoding(call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	i, j := span(n, call.Argument(2), call.Argument(3))
+	b, err := getBytes(o, i, j)
+	if err != nil {
+		return vm.throw(err)
+	}
+	s, _ := decode(b, enc)
+	v, _ := vm.ToValue(s)
+	return v
+}
+
+func (vm *Otto) buffer_write(call otto.FunctionCall) otto.Value {
+	o, n, err := vm.toBufferObject("buf", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	s, err := vm.toString("string", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	enc, err := vm.toEncoding(call.Argument(4))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	i, j := span(n, call.Argument(2), otto.UndefinedValue())
+	if l, err := call.Argument(3).ToInteger(); err == nil && 0 <= l && l < int64(j-i) {
+		j = i + int(l)
+	}
+	src, _ := encode(s, enc)
+	n = min(len(src), j-i)
+	if n < len(src) {
+		// do not write partial characters
+		switch enc {
+		case "utf8":
+			for n > 0 && !utf8.RuneStart(src[n]) {
+				n--
+			}
+		case "utf16le":
+			n &^= 1
+		}
+	}
+	putBytes(o, i, src[:n])
+	v, _ := vm.ToValue(n)
+	return v
+}
+
+func (vm *Otto) buffer_fill(call otto.FunctionCall) otto.Value {
+	o, n, err := vm.toBufferObject("buf", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	enc, err := vm.toEncoding(call.Argument(4))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	var pat []byte
+	switch v := call.Argument(1); {
+	case v.IsString():
+		s, _ := v.ToString()
+		pat, _ = encode(s, enc)
+	case vm.isBuffer(v):
+		pat, err = vm.toBuffer("value", v)
+		if err != nil {
+			return vm.throw(err)
+		}
+	default:
+		i, _ := v.ToInteger()
+		pat = []byte{byte(i)}
+	}
+	if len(pat) == 0 {
+		v, _ := vm.ToValue(-1)
+		return v
+	}
+	i, j := span(n, call.Argument(2), call.Argument(3))
+	b := make([]byte, j-i)
+	for k := 0; k < len(b); {
+		k += copy(b[k:], pat)
+	}
+	putBytes(o, i, b)
+	v, _ := vm.ToValue(0)
+	return v
+}
+
+func (vm *Otto) buffer_copy(call otto.FunctionCall) otto.Value {
+	src, n, err := vm.toBufferObject("source", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	dst, l, err := vm.toBufferObject("target", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	k, _ := span(l, call.Argument(2), otto.UndefinedValue())
+	i, j := span(n, call.Argument(3), call.Argument(4))
+	b, err := getBytes(src, i, min(j, i+l-k))
+	if err != nil {
+		return vm.throw(err)
+	}
+	putBytes(dst, k, b)
+	v, _ := vm.ToValue(len(b))
+	return v
+}
+
+func (vm *Otto) buffer_compare(call otto.FunctionCall) otto.Value {
+	a, err := vm.toBufferRange("a", call.Argument(0), call.Argument(2), call.Argument(3))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := vm.toBufferRange("b", call.Argument(1), call.Argument(4), call.Argument(5))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	v, _ := vm.ToValue(bytes.Compare(a, b))
+	return v
+}
+
+func (vm *Otto) buffer_indexOf(call otto.FunctionCall) otto.Value {
+	b, err := vm.toBuffer("buf", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	enc, err := vm.toEncoding(call.Argument(3))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	var needle []byte
+	switch v := call.Argument(1); {
+	case v.IsString():
+		s, _ := v.ToString()
+		needle, _ = encode(s, enc)
+	case v.IsNumber():
+		i, _ := v.ToInteger()
+		needle = []byte{byte(i)}
+	default:
+		needle, err = vm.toBuffer("value", v)
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
+	offset, _ := call.Argument(2).ToInteger()
+	forward, _ := call.Argument(4).ToBoolean()
+	v, _ := vm.ToValue(indexOf(b, needle, offset, forward))
+	return v
+}
+
+func indexOf(b, needle []byte, offset int64, forward bool) int {
+	l := int64(len(b))
+	n := int64(len(needle))
+	var i int64
+	switch {
+	case offset < 0:
+		switch {
+		case l+offset >= 0:
+			i = l + offset
+		case forward || n == 0:
+			i = 0
+		default:
+			return -1
+		}
+	case offset+n <= l:
+		i = offset
+	case n == 0:
+		i = l
+	case forward:
+		return -1
+	default:
+		i = l - 1
+	}
+	switch {
+	case n == 0:
+		return int(i)
+	case l == 0 || n > l:
+		return -1
+	case forward:
+		if j := bytes.Index(b[i:], needle); j != -1 {
+			return int(i) + j
+		}
+		return -1
+	}
+	return bytes.LastIndex(b[:min(i+n, l)], needle)
+}
+
+func (vm *Otto) buffer_slice(call otto.FunctionCall) otto.Value {
+	b, err := vm.toBufferRange("buf", call.Argument(0), call.Argument(1), call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) buffer_readFloat(call otto.FunctionCall) otto.Value {
+	o, n, err := vm.toBufferObject("buf", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	i, j, le, err := vm.toFloat(n, call.Argument(1), call.Argument(2), call.Argument(3))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := getBytes(o, i, j)
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	var bo binary.ByteOrder = binary.BigEndian
+	if le {
+		bo = binary.LittleEndian
+	}
+	var f float64
+	if len(b) == 4 {
+		f = float64(math.Float32frombits(bo.Uint32(b)))
+	} else {
+		f = math.Float64frombits(bo.Uint64(b))
+	}
+	v, _ := vm.ToValue(f)
+	return v
+}
+
+func (vm *Otto) buffer_writeFloat(call otto.FunctionCall) otto.Value {
+	o, n, err := vm.toBufferObject("buf", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	f, err := call.Argument(1).ToFloat()
+	if err != nil {
+		return vm.throw(err)
+	}
+	i, j, le, err := vm.toFloat(n, call.Argument(2), call.Argument(3), call.Argument(4))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b := make([]byte, j-i)
+
+	var bo binary.ByteOrder = binary.BigEndian
+	if le {
+		bo = binary.LittleEndian
+	}
+	if len(b) == 4 {
+		bo.PutUint32(b, math.Float32bits(float32(f)))
+	} else {
+		u := math.Float64bits(f)
+		if math.IsNaN(f) {
+			u = 0x7ff8000000000000
+		}
+		bo.PutUint64(b, u)
+	}
+	putBytes(o, i, b)
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) toFloat(l int, offset, size, le otto.Value) (int, int, bool, error) {
+	i, _ := offset.ToInteger()
+	n, _ := size.ToInteger()
+	if n != 4 && n != 8 {
+		return 0, 0, false, fmt.Errorf("size must be 4 or 8")
+	} else if i < 0 || int64(l) < i+n {
+		return 0, 0, false, fmt.Errorf("offset is out of bounds")
+	}
+	v, _ := le.ToBoolean()
+	return int(i), int(i + n), v, nil
+}
+
+func (vm *Otto) toBuffer(name string, v otto.Value) ([]byte, error) {
+	return vm.toBufferRange(name, v, otto.UndefinedValue(), otto.UndefinedValue())
+}
+
+func (vm *Otto) toBufferRange(name string, v, start, end otto.Value) ([]byte, error) {
+	o, n, err := vm.toBufferObject(name, v)
+	if err != nil {
+		return nil, err
+	}
+	i, j := span(n, start, end)
+	return getBytes(o, i, j)
+}
+
+func (vm *Otto) toBufferObject(name string, v otto.Value) (*otto.Object, int, error) {
+	if !vm.isBuffer(v) {
+		return nil, 0, fmt.Errorf("%v must be a Buffer", name)
+	}
+	o := v.Object()
+	n, err := lengthOf(name, o)
+	if err != nil {
+		return nil, 0, err
+	}
+	return o, n, nil
+}
+
+func lengthOf(name string, o *otto.Object) (int, error) {
+	v, err := o.Get("length")
+	if err != nil {
+		return 0, err
+	}
+	f, err := v.ToFloat()
+	switch {
+	case err != nil:
+		return 0, err
+	case f > kMaxLength:
+		s, _ := v.ToString()
+		return 0, &rangeError{name + ".length", fmt.Sprintf(">= 0 && <= %v", kMaxLength), s}
+	case math.IsNaN(f) || f < 0:
+		return 0, nil
+	}
+	return int(f), nil
+}
+
+func getBytes(o *otto.Object, i, j int) ([]byte, error) {
+	b := make([]byte, j-i)
+	for k := range b {
+		v, err := o.Get(strconv.Itoa(i + k))
+		if err != nil {
+			return nil, err
+		}
+		f, err := v.ToFloat()
+		if err != nil {
+			return nil, err
+		}
+		// wrap around like Uint8Array
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			f = math.Mod(math.Trunc(f), 256)
+			if f < 0 {
+				f += 256
+			}
+			b[k] = byte(f)
+		}
+	}
+	return b, nil
+}
+
+func putBytes(o *otto.Object, i int, b []byte) {
+	for k, c := range b {
+		o.Set(strconv.Itoa(i+k), c)
+	}
+}
+
+func span(n int, start, end otto.Value) (int, int) {
+	i, j := int64(0), int64(n)
+	if start.IsDefined() {
+		i, _ = start.ToInteger()
+		i = max(min(i, int64(n)), 0)
+	}
+	if end.IsDefined() {
+		j, _ = end.ToInteger()
+		j = max(min(j, int64(n)), i)
+	}
+	return int(i), int(j)
+}
@@ -0,0 +1,163 @@
+//
+// otto.module :: buffer_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+	"github.com/robertkrimen/otto"
+)
+
+func TestBuffer(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var b = Buffer.from('héllo €');
+		var s = b.subarray(1, 3);
+		var w = Buffer.alloc(5);
+		w[0] = 300;
+		w[1] = -1;
+		w[2] = 1.5;
+		w[3] = 'x';
+		w[4] = 257;
+		w[5] = 1;
+		var n = Buffer.alloc(8);
+		n.writeUInt16BE(0xcafe, 0);
+		n.writeInt32LE(-2, 2);
+		n.writeUIntBE(0x123456, 5, 3);
+		[
+			b.length,
+			b.toString(),
+			b.toString('hex'),
+			b.toString('base64'),
+			Buffer.from('aGk=', 'base64').toString(),
+			Buffer.from('_-8', 'base64url').toString('hex'),
+			Buffer.from('é', 'latin1').toString('hex'),
+			Buffer.from('a', 'utf16le').toString('hex'),
+			Buffer.from([0xe2, 0x82, 0x41]).toString(),
+			Buffer.isBuffer(b) && Buffer.isBuffer(s) && !Buffer.isBuffer([]),
+			b instanceof Buffer && s instanceof Buffer,
+			Object.keys(Buffer.from('ab')).join(),
+			w.toString('hex') + ':' + w.readUInt8(0) + ':' + JSON.stringify(w.toJSON().data) + ':' + w.length,
+			Buffer.concat([Buffer.from('ab'), Buffer.from('cd')], 3).toString(),
+			Buffer.compare(Buffer.from('a'), Buffer.from('b')),
+			Buffer.from('abc').equals(Buffer.from('abc')),
+			b.indexOf('l') + ':' + b.lastIndexOf('l') + ':' + b.indexOf(Buffer.from('€')) + ':' + b.indexOf(0x6f, -5),
+			n.toString('hex'),
+			n.readUInt16BE(0) + ':' + n.readInt32LE(2) + ':' + n.readUIntBE(5, 3) + ':' + n.readInt8(1),
+			JSON.stringify(Buffer.from([1, 2]).toJSON().data),
+			require('util').inspect(Buffer.from('ab')),
+			require('buffer').Buffer === Buffer,
+			(function() {
+				try {
+					Buffer.from({ length: 1e15 });
+				} catch (e) {
+					return [e.name, e.code, e.message].join();
+				}
+			})(),
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"10",
+		"héllo €",
+		"68c3a96c6c6f20e282ac",
+		"aMOpbGxvIOKCrA==",
+		"hi",
+		"ffef",
+		"e9",
+		"6100",
+		"�A",
+		"true",
+		"true",
+		"0,1",
+		"2cff010001:44:[44,255,1,0,1]:5",
+		"abc",
+		"-1",
+		"true",
+		"3:4:7:5",
+		"cafefeffff123456",
+		"51966:318767102:1193046:-2",
+		"[1,2]",
+		"<Buffer 61 62>",
+		"true",
+		`RangeError,ERR_OUT_OF_RANGE,The value of "value.length" is out of range. It must be >= 0 && <= 4294967296. Received 1_000_000_000_000_000`,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, src := range []string{
+		`Buffer.alloc(-1);`,
+		`Buffer.from(1);`,
+		`Buffer.from('a', 'utf7');`,
+		`Buffer.alloc(1).readUInt16LE(0);`,
+		`Buffer.alloc(1).writeUInt8(256);`,
+		`Buffer.concat([Buffer.alloc(1), 'a']);`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", strings.Trim(src, ";"))
+		}
+	}
+}
+
+func TestBufferConversion(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	b := []byte("abc")
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("b", v)
+	vm.Set("upper", func(call otto.FunctionCall) otto.Value {
+		b, err := vm.Bytes(call.Argument(0))
+		if err != nil {
+			return module.Throw(call.Otto, err)
+		}
+		v, _ := vm.NewBuffer(bytes.ToUpper(b))
+		return v
+	})
+	if v, err := vm.Run(`b[0] = 0x41; Buffer.isBuffer(b) + ':' + b.toString('hex') + ':' + upper(b).toString();`); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "true:416263:ABC"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := string(b), "abc"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, src := range []string{
+		`Buffer.from('xyz').subarray(1)`,
+		`[0x79, 0x7a]`,
+	} {
+		v, err := vm.Run(src)
+		if err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		switch b, err := vm.Bytes(v); {
+		case err != nil:
+			t.Error(err)
+		case string(b) != "yz":
+			t.Errorf("%v: expected %q, got %q", src, "yz", b)
+		}
+	}
+	if _, err := vm.Bytes(otto.NullValue()); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,583 @@
+//
+// otto.module :: child_process.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+var errExecDisabled = errors.New("Access to this API has been restricted")
+
+type Cmd struct {
+	Name  string
+	Args  []string
+	Env   []string
+	Dir   string
+	Shell string
+}
+
+type ExecPolicy interface {
+	Check(cmd *Cmd) error
+}
+
+type ExecPolicyFunc func(cmd *Cmd) error
+
+func (f ExecPolicyFunc) Check(cmd *Cmd) error {
+	return f(cmd)
+}
+
+// CommandPolicy allows the commands which match Commands by name or by path.
+// Allowing a shell, either by Shell or by a shell in Commands, allows any
+// command line that the shell runs. Dir is a directory on the host.
+type CommandPolicy struct {
+	Commands []string
+	Env      []string
+	Dir      string
+	Shell    bool
+}
+
+func (p *CommandPolicy) Check(cmd *Cmd) error {
+	if cmd.Shell != "" && !p.Shell {
+		return fmt.Errorf("shell command not allowed: %v", cmd.Shell)
+	} else if !slices.ContainsFunc(p.Commands, func(s string) bool {
+		if strings.ContainsRune(s, '/') || strings.ContainsRune(s, filepath.Separator) {
+			return filepath.Clean(s) == filepath.Clean(cmd.Name)
+		}
+		return s == cmd.Name
+	}) {
+		return fmt.Errorf("command not allowed: %v", cmd.Name)
+	}
+
+	env := make([]string, 0, len(p.Env))
+	for _, kv := range cmd.Env {
+		k, _, _ := strings.Cut(kv, "=")
+		if slices.Contains(p.Env, k) {
+			env = append(env, kv)
+		}
+	}
+	cmd.Env = env
+
+	if p.Dir != "" {
+		root, err := filepath.Abs(p.Dir)
+		if err != nil {
+			return err
+		}
+		if cmd.Dir == "" {
+			cmd.Dir = root
+		}
+		dir, err := filepath.Abs(cmd.Dir)
+		if err != nil {
+			return err
+		}
+		if rel, err := filepath.Rel(root, dir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("working directory not allowed: %v", cmd.Dir)
+		}
+	}
+	return nil
+}
+
+func WithExecPolicy(p ExecPolicy) Option {
+	return func(vm *Otto) {
+		vm.exec = p
+	}
+}
+
+func (vm *Otto) child_process_binding(o *otto.Object) error {
+	o.Set("spawnSync", vm.child_process_spawnSync)
+	o.Set("spawn", vm.child_process_spawn)
+	return nil
+}
+
+func (vm *Otto) child_process_spawnSync(call otto.FunctionCall) otto.Value {
+	c, err := vm.newChild(call)
+	if err != nil {
+		return vm.throwPermission(err, "ChildProcess")
+	}
+
+	if err := c.start(); err != nil {
+		return c.result(vm, err)
+	}
+	return c.result(vm, c.wait())
+}
+
+func (vm *Otto) child_process_spawn(call otto.FunctionCall) otto.Value {
+	c, err := vm.newChild(call)
+	if err != nil {
+		return vm.throwPermission(err, "ChildProcess")
+	}
+	onexit := call.Argument(3)
+
+	if err := c.start(); err != nil {
+		return c.result(vm, err)
+	}
+	vm.Ref()
+	go func() {
+		err := c.wait()
+		vm.Enqueue(func() error {
+			vm.Unref()
+			_, err := onexit.Call(otto.UndefinedValue(), c.result(vm, err))
+			return err
+		})
+	}()
+	o, _ := vm.Object(`({})`)
+	o.Set("pid", c.cmd.Process.Pid)
+	for i, name := range []string{"stdin", "stdout", "stderr"} {
+		v := otto.NullValue()
+		if f := c.pipes[i]; f != nil {
+			var err error
+			if i == 0 {
+				v, err = vm.newWritable(f, f, false)
+			} else {
+				v, err = vm.newReadable(f, f)
+			}
+			if err != nil {
+				return vm.throw(err)
+			}
+		}
+		o.Set(name, v)
+	}
+	o.Set("kill", func(call otto.FunctionCall) otto.Value {
+		sig, err := toSignal(call.Argument(0))
+		if err != nil {
+			return vm.throw(err)
+		}
+
+		v, _ := vm.ToValue(c.kill(sig) == nil)
+		return v
+	})
+	return o.Value()
+}
+
+// workDir resolves the cwd option through the FS, so that a child does not
+// run outside of a DirFS.
+func (vm *Otto) workDir(opts *otto.Object) (string, error) {
+	var dir string
+	if v, _ := opts.Get("cwd"); v.IsString() {
+		dir = v.String()
+	}
+	switch fsys := vm.fs.(type) {
+	case OSFS:
+		if dir == "" {
+			return "", nil
+		}
+		return filepath.Abs(dir)
+	case *DirFS:
+		p, err := fsys.realpath(dir)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// spawn fails with ENOENT
+			p = vpath(dir)
+		case err != nil:
+			return "", &permissionError{fmt.Errorf("working directory not allowed: %v", dir)}
+		}
+		return filepath.Join(fsys.dir, fsys.rel(p)), nil
+	}
+	if dir != "" {
+		return "", &permissionError{fmt.Errorf("working directory not allowed: %v", dir)}
+	}
+	return "", nil
+}
+
+func (vm *Otto) throwPermission(err error, permission string) otto.Value {
+	v, ok := vm.accessDenied(err, permission)
+	if !ok {
+		return vm.throw(err)
+	}
+	panic(v)
+}
+
+func (vm *Otto) accessDenied(err error, permission string) (otto.Value, bool) {
+	var perm *permissionError
+	if !errors.As(err, &perm) {
+		return otto.UndefinedValue(), false
+	}
+
+	v := vm.MakeCustomError("Error", perm.err.Error())
+	v.Object().Set("code", "ERR_ACCESS_DENIED")
+	v.Object().Set("permission", permission)
+	return v, true
+}
+
+type permissionError struct {
+	err error
+}
+
+func (e *permissionError) Error() string {
+	return e.err.Error()
+}
+
+type child struct {
+	cmd        *exec.Cmd
+	stdout     *outputBuffer
+	stderr     *outputBuffer
+	timeout    time.Duration
+	killSignal syscall.Signal
+	timer      *time.Timer
+	stream     bool
+	stdio      [3]string
+	pipes      [3]*os.File
+
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	timedOut bool
+	overflow bool
+}
+
+func (vm *Otto) newChild(call otto.FunctionCall) (*child, error) {
+	name, err := vm.toString("file", call.Argument(0))
+	if err != nil {
+		return nil, err
+	}
+	var args []string
+	if v := call.Argument(1); v.IsObject() {
+		for _, k := range v.Object().Keys() {
+			a, _ := v.Object().Get(k)
+			args = append(args, a.String())
+		}
+	}
+	opts := call.Argument(2).Object()
+	if opts == nil {
+		opts, _ = vm.Object(`({})`)
+	}
+
+	cmd := &Cmd{
+		Name: name,
+		Args: args,
+		Env:  os.Environ(),
+	}
+	if v, _ := opts.Get("env"); v.IsObject() {
+		cmd.Env = nil
+		for _, k := range v.Object().Keys() {
+			kv, _ := v.Object().Get(k)
+			cmd.Env = append(cmd.Env, kv.String())
+		}
+	}
+	if v, _ := opts.Get("shell"); v.IsString() {
+		cmd.Shell = v.String()
+	}
+	if vm.exec == nil {
+		return nil, &permissionError{errExecDisabled}
+	} else if cmd.Dir, err = vm.workDir(opts); err != nil {
+		return nil, err
+	} else if err := vm.exec.Check(cmd); err != nil {
+		return nil, &permissionError{err}
+	}
+
+	c := &child{
+		cmd:        exec.Command(cmd.Name, cmd.Args...),
+		killSignal: syscall.SIGTERM,
+	}
+	c.cmd.Env = cmd.Env
+	c.cmd.Dir = cmd.Dir
+	if v, _ := opts.Get("stream"); v.IsBoolean() {
+		c.stream, _ = v.ToBoolean()
+	}
+	if v, _ := opts.Get("timeout"); v.IsNumber() {
+		if ms, _ := v.ToInteger(); ms > 0 {
+			c.timeout = time.Duration(ms) * time.Millisecond
+		}
+	}
+	var max int64
+	if v, _ := opts.Get("maxBuffer"); v.IsNumber() {
+		if f, _ := v.ToFloat(); f > 0 && f < 1<<53 {
+			max = int64(f)
+		}
+	}
+	if v, _ := opts.Get("killSignal"); v.IsDefined() {
+		if c.killSignal, err = toSignal(v); err != nil {
+			return nil, err
+		}
+	}
+	stdio := [3]string{"pipe", "pipe", "pipe"}
+	if v, _ := opts.Get("stdio"); v.IsObject() {
+		for i := range stdio {
+			if s, _ := v.Object().Get(fmt.Sprint(i)); s.IsString() {
+				stdio[i] = s.String()
+			}
+		}
+	}
+	c.stdio = stdio
+	switch stdio[0] {
+	case "pipe":
+		if c.stream {
+			break
+		}
+		if v, _ := opts.Get("input"); v.IsDefined() {
+			b, err := vm.toBuffer("input", v)
+			if err != nil {
+				return nil, err
+			}
+			c.cmd.Stdin = bytes.NewReader(b)
+		}
+	case "inherit":
+		c.cmd.Stdin = vm.stdin
+	}
+	switch stdio[1] {
+	case "pipe":
+		if c.stream {
+			break
+		}
+		c.stdout = &outputBuffer{c: c, max: max}
+	case "inherit":
+		c.cmd.Stdout = vm.stdout
+	}
+	switch stdio[2] {
+	case "pipe":
+		if c.stream {
+			break
+		}
+		c.stderr = &outputBuffer{c: c, max: max}
+	case "inherit":
+		c.cmd.Stderr = vm.stderr
+	}
+	return c, nil
+}
+
+func (c *child) start() error {
+	// the pipes are read by the child so that they can be abandoned after
+	// the process is killed
+	var w []*os.File
+	abort := func(err error) error {
+		c.closePipes()
+		for _, f := range w {
+			f.Close()
+		}
+		for _, f := range c.pipes {
+			if f != nil {
+				f.Close()
+			}
+		}
+		return err
+	}
+	for _, s := range []struct {
+		buf *outputBuffer
+		out *io.Writer
+	}{
+		{c.stdout, &c.cmd.Stdout},
+		{c.stderr, &c.cmd.Stderr},
+	} {
+		if s.buf == nil {
+			continue
+		}
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			return abort(err)
+		}
+		s.buf.r = pr
+		*s.out = pw
+		w = append(w, pw)
+	}
+	if c.stream {
+		// the parent ends of the pipes are handed over to the streams
+		for i, s := range c.stdio {
+			if s != "pipe" {
+				continue
+			}
+			pr, pw, err := os.Pipe()
+			if err != nil {
+				return abort(err)
+			}
+			switch i {
+			case 0:
+				c.cmd.Stdin = pr
+				c.pipes[i] = pw
+				w = append(w, pr)
+			case 1:
+				c.cmd.Stdout = pw
+				c.pipes[i] = pr
+				w = append(w, pw)
+			case 2:
+				c.cmd.Stderr = pw
+				c.pipes[i] = pr
+				w = append(w, pw)
+			}
+		}
+	}
+	if err := c.cmd.Start(); err != nil {
+		return abort(err)
+	}
+	for _, f := range w {
+		f.Close()
+	}
+	for _, b := range []*outputBuffer{c.stdout, c.stderr} {
+		if b != nil {
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+				io.Copy(b, b.r)
+			}()
+		}
+	}
+	if c.timeout > 0 {
+		c.timer = time.AfterFunc(c.timeout, func() {
+			c.mu.Lock()
+			c.timedOut = true
+			c.mu.Unlock()
+			c.kill(c.killSignal)
+		})
+	}
+	return nil
+}
+
+func (c *child) wait() error {
+	err := c.cmd.Wait()
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	c.mu.Lock()
+	killed := c.timedOut || c.overflow
+	c.mu.Unlock()
+	if killed {
+		// do not wait for the descendants which inherited the pipes
+		c.closePipes()
+	}
+	c.wg.Wait()
+	c.closePipes()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.timedOut:
+		return syscall.ETIMEDOUT
+	case c.overflow:
+		return syscall.ENOBUFS
+	}
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return nil
+	}
+	return err
+}
+
+func (c *child) closePipes() {
+	for _, b := range []*outputBuffer{c.stdout, c.stderr} {
+		if b != nil && b.r != nil {
+			b.r.Close()
+		}
+	}
+}
+
+func (c *child) kill(sig syscall.Signal) error {
+	if sig == syscall.SIGKILL {
+		return c.cmd.Process.Kill()
+	}
+	return c.cmd.Process.Signal(sig)
+}
+
+func (c *child) result(vm *Otto, err error) otto.Value {
+	o, _ := vm.Object(`({})`)
+	o.Set("pid", 0)
+	o.Set("status", otto.NullValue())
+	o.Set("signal", otto.NullValue())
+	if p := c.cmd.Process; p != nil {
+		o.Set("pid", p.Pid)
+	}
+	if ps := c.cmd.ProcessState; ps != nil {
+		if ws, ok := ps.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			o.Set("signal", signalName(ws.Signal()))
+		} else {
+			o.Set("status", ps.ExitCode())
+		}
+	}
+	for _, s := range []struct {
+		name string
+		buf  *outputBuffer
+	}{
+		{"stdout", c.stdout},
+		{"stderr", c.stderr},
+	} {
+		if s.buf != nil {
+			v, _ := vm.NewBuffer(s.buf.buf.Bytes())
+			o.Set(s.name, v)
+		} else {
+			o.Set(s.name, otto.NullValue())
+		}
+	}
+	if err != nil {
+		var errno syscall.Errno
+		switch {
+		case errors.As(err, &errno):
+		case errors.Is(err, exec.ErrNotFound), errors.Is(err, os.ErrNotExist):
+			errno = syscall.ENOENT
+		case errors.Is(err, os.ErrPermission):
+			errno = syscall.EACCES
+		default:
+			errno = syscall.EINVAL
+		}
+		code := errno.Error()
+		for _, e := range errnos {
+			if e.errno == errno {
+				code = e.code
+				break
+			}
+		}
+		o.Set("errno", -int(errno))
+		o.Set("error", code)
+	}
+	return o.Value()
+}
+
+func toSignal(v otto.Value) (syscall.Signal, error) {
+	switch {
+	case !v.IsDefined():
+		return syscall.SIGTERM, nil
+	case v.IsNumber():
+		n, err := v.ToInteger()
+		return syscall.Signal(n), err
+	}
+	if n, ok := signals[v.String()]; ok {
+		return syscall.Signal(n), nil
+	}
+	return 0, fmt.Errorf("unknown signal: %v", v.String())
+}
+
+func signalName(sig syscall.Signal) string {
+	for k, v := range signals {
+		if syscall.Signal(v) == sig {
+			return k
+		}
+	}
+	return sig.String()
+}
+
+type outputBuffer struct {
+	c   *child
+	r   *os.File
+	buf bytes.Buffer
+	max int64
+}
+
+func (b *outputBuffer) Write(p []byte) (int, error) {
+	if b.max > 0 && int64(b.buf.Len()+len(p)) > b.max {
+		b.buf.Write(p[:b.max-int64(b.buf.Len())])
+		b.c.mu.Lock()
+		overflow := b.c.overflow
+		b.c.overflow = true
+		b.c.mu.Unlock()
+		if !overflow {
+			b.c.kill(b.c.killSignal)
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
@@ -0,0 +1,326 @@
+//
+// otto.module :: child_process_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestChildProcess(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+
+	var stderr strings.Builder
+	var cmds []string
+	vm, err := module.New(module.WithStderr(&stderr), module.WithExecPolicy(module.ExecPolicyFunc(func(cmd *module.Cmd) error {
+		cmds = append(cmds, cmd.Name)
+		return nil
+	})))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var cp = require('child_process');
+		var out = [];
+		var r = cp.spawnSync('sh', ['-c', 'echo out; echo err >&2; exit 3']);
+		out.push([r.pid > 0, r.status, r.signal, r.stdout, r.stderr].join());
+		r = cp.spawnSync('sh', ['-c', 'cat; echo $FOO'], { input: 'in:', env: { FOO: 'foo' }, encoding: 'utf8' });
+		out.push(JSON.stringify(r.output));
+		r = cp.spawnSync('sh', ['-c', 'sleep 5'], { timeout: 50 });
+		out.push([r.status, r.signal, r.error.code].join());
+		r = cp.spawnSync('sh', ['-c', 'echo 0123456789'], { maxBuffer: 4 });
+		out.push([r.stdout, r.error.code].join());
+		r = cp.spawnSync('nonexistent-command', ['a']);
+		out.push([r.pid, r.status, r.output, r.error.message, r.error.code, r.error.syscall, r.error.path, r.error.spawnargs].join());
+		out.push(cp.execSync('echo $0; echo err >&2', { encoding: 'utf8' }).trim());
+		out.push(cp.execFileSync('echo', ['a', 'b']).toString().trim());
+		try {
+			cp.execSync('echo x; echo y >&2; exit 2', { stdio: 'pipe' });
+		} catch (e) {
+			out.push([e.message, e.status, e.stdout, e.stderr].join());
+		}
+		try {
+			cp.execFileSync('nonexistent-command');
+		} catch (e) {
+			out.push([e.message, e.code].join());
+		}
+		out.join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"true,3,,out\n,err\n",
+		`[null,"in:foo\n",""]`,
+		",SIGTERM,ETIMEDOUT",
+		"0123,ENOBUFS",
+		"0,,,spawnSync nonexistent-command ENOENT,ENOENT,spawnSync nonexistent-command,nonexistent-command,a",
+		"/bin/sh",
+		"a b",
+		"Command failed: echo x; echo y >&2; exit 2\ny\n,2,x\n,y\n",
+		"spawnSync nonexistent-command ENOENT,ENOENT",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := stderr.String(), "err\n"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := cmds, []string{"sh", "sh", "sh", "sh", "nonexistent-command", "/bin/sh", "echo", "/bin/sh", "nonexistent-command"}; !slices.Equal(g, e) {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	src = `
+		var out = [];
+		var c = cp.exec('echo x; echo y >&2; exit 2', function(e, stdout, stderr) {
+			out.push(['exec', e.message, e.code, e.killed, e.signal, e.cmd, stdout, stderr].join());
+		});
+		c.on('exit', function(code, signal) {
+			out.push(['exit', code, signal].join());
+		});
+		var k = cp.execFile('sleep', ['5'], function(e, stdout, stderr) {
+			out.push(['kill', e.code, e.killed, e.signal, k.killed].join());
+		});
+		k.kill();
+		cp.execFile('nonexistent-command', function(e, stdout, stderr) {
+			out.push(['error', e.message, e.code, e.cmd, JSON.stringify(stdout)].join());
+		});
+		cp.exec('printf abc', { encoding: 'buffer' }, function(e, stdout) {
+			out.push(['buffer', e, Buffer.isBuffer(stdout), stdout].join());
+		});
+		var s = cp.spawn('sh', ['-c', 'cat; echo err >&2; exit 3']);
+		var data = '';
+		s.stdout.setEncoding('utf8');
+		s.stdout.on('data', function(chunk) { data += chunk; });
+		s.on('close', function(code, signal) {
+			out.push(['spawn', code, signal, JSON.stringify(data), s.stdio[0] === s.stdin].join());
+		});
+		s.stdin.write('hello ');
+		s.stdin.end('world');
+		cp.spawn('nonexistent-command').on('error', function(e) {
+			out.push(['spawn error', e.message, e.code].join());
+		}).on('close', function(code) {
+			out.push(['spawn close', code].join());
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.sort().join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"buffer,,true,abc",
+		"error,spawn nonexistent-command ENOENT,ENOENT,nonexistent-command,\"\"",
+		"exec,Command failed: echo x; echo y >&2; exit 2\ny\n,2,false,,echo x; echo y >&2; exit 2,x\n,y\n",
+		"exit,2,",
+		"kill,,true,SIGTERM,true",
+		"spawn close,-2",
+		"spawn error,spawn nonexistent-command ENOENT,ENOENT",
+		`spawn,3,,"hello world",true`,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestChildProcess_Policy(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	for _, src := range []string{
+		`require('child_process').execSync('echo')`,
+		`require('child_process').spawnSync('echo')`,
+		`require('child_process').exec('echo')`,
+		`require('child_process').spawn('echo')`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", src)
+		} else if g, e := module.Wrap(err).Error(), "Error: Access to this API has been restricted"; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", src, e, g)
+		}
+	}
+
+	vm, err = module.New(module.WithExecPolicy(&module.CommandPolicy{Commands: []string{"echo", "/bin/sh"}}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	src := `
+		var out = [];
+		try {
+			require('child_process').execSync('echo $HOME; id -un');
+		} catch (e) {
+			out.push([e.message, e.code, e.permission].join());
+		}
+		try {
+			require('child_process').spawnSync('echo', ['a'], { shell: true });
+		} catch (e) {
+			out.push([e.message, e.code, e.permission].join());
+		}
+		out.join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"shell command not allowed: echo $HOME; id -un,ERR_ACCESS_DENIED,ChildProcess",
+		"shell command not allowed: echo a,ERR_ACCESS_DENIED,ChildProcess",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	var cmd *module.Cmd
+	vm, err = module.New(module.WithPlatform("win32"), module.WithExecPolicy(module.ExecPolicyFunc(func(c *module.Cmd) error {
+		cmd = c
+		return errors.New("denied")
+	})))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Run(`process.env.ComSpec = 'cmd.exe'; require('child_process').execSync('echo a')`)
+	switch {
+	case cmd == nil:
+		t.Error("expected Cmd")
+	case cmd.Name != "cmd.exe" || !slices.Equal(cmd.Args, []string{"/d", "/s", "/c", `"echo a"`}):
+		t.Errorf("unexpected command: %v %q", cmd.Name, cmd.Args)
+	}
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		return
+	}
+	vm, err = module.New(module.WithExecPolicy(&module.CommandPolicy{Commands: []string{"/bin/sh"}, Shell: true}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`require('child_process').execSync('echo a').toString()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "a\n"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestChildProcess_Dir(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink(dir, filepath.Join(root, "link")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fsys, err := module.NewDirFS(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+	root, _ = filepath.EvalSymlinks(root)
+
+	var cmd *module.Cmd
+	policy := module.ExecPolicyFunc(func(c *module.Cmd) error {
+		cmd = c
+		return errors.New("denied")
+	})
+	vm, err := module.New(module.WithFS(fsys), module.WithExecPolicy(policy))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	for _, tt := range []struct {
+		cwd, dir string
+	}{
+		{"undefined", root},
+		{"'/sub'", filepath.Join(root, "sub")},
+		{"'sub'", filepath.Join(root, "sub")},
+		{"'/../..'", root},
+		{"'/none'", filepath.Join(root, "none")},
+	} {
+		cmd = nil
+		vm.Run(`require('child_process').spawnSync('echo', [], { cwd: ` + tt.cwd + ` })`)
+		switch {
+		case cmd == nil:
+			t.Errorf("%v: expected Cmd", tt.cwd)
+		case cmd.Dir != tt.dir:
+			t.Errorf("%v: expected %q, got %q", tt.cwd, tt.dir, cmd.Dir)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		if _, err := vm.Run(`require('child_process').spawnSync('echo', [], { cwd: '/link' })`); err == nil {
+			t.Error("expected error")
+		} else if g, e := module.Wrap(err).Error(), "Error: working directory not allowed: /link"; !strings.HasPrefix(g, e) {
+			t.Errorf("expected %q, got %q", e, g)
+		}
+	}
+
+	vm, err = module.New(module.WithFS(module.NewMemFS()), module.WithExecPolicy(policy))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if _, err := vm.Run(`require('child_process').spawnSync('echo', [], { cwd: '/' })`); err == nil {
+		t.Error("expected error")
+	} else if g, e := module.Wrap(err).Error(), "Error: working directory not allowed: /"; !strings.HasPrefix(g, e) {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestCommandPolicy(t *testing.T) {
+	dir := t.TempDir()
+	p := &module.CommandPolicy{
+		Commands: []string{"git", filepath.Join(dir, "bin", "tool")},
+		Env:      []string{"HOME", "LANG"},
+		Dir:      dir,
+	}
+
+	cmd := &module.Cmd{
+		Name: "git",
+		Env:  []string{"HOME=/home/user", "SECRET=x", "LANG=C", "PATH=/bin"},
+	}
+	if err := p.Check(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if g, e := cmd.Env, []string{"HOME=/home/user", "LANG=C"}; !slices.Equal(g, e) {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := cmd.Dir, dir; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, cmd := range []*module.Cmd{
+		{Name: filepath.Join(dir, "bin", "..", "bin", "tool")},
+		{Name: "git", Dir: filepath.Join(dir, "sub")},
+	} {
+		if err := p.Check(cmd); err != nil {
+			t.Errorf("%v: unexpected error: %v", cmd.Name, err)
+		}
+	}
+
+	for _, cmd := range []*module.Cmd{
+		{Name: "sh"},
+		{Name: "tool"},
+		{Name: filepath.Join(dir, "git")},
+		{Name: "git", Dir: filepath.Dir(dir)},
+		{Name: "git", Dir: filepath.Join(dir, "..", filepath.Base(dir)+"x")},
+		{Name: "git", Shell: "git status"},
+	} {
+		if err := p.Check(cmd); err == nil {
+			t.Errorf("%v: expected error", cmd.Name)
+		}
+	}
+}
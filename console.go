@@ -0,0 +1,89 @@
+//
+// otto.module :: console.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+func WithStdout(w io.Writer) Option {
+	return func(vm *Otto) {
+		vm.stdout = w
+	}
+}
+
+func WithStderr(w io.Writer) Option {
+	return func(vm *Otto) {
+		vm.stderr = w
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(vm *Otto) {
+		vm.logger = logger
+	}
+}
+
+func (vm *Otto) console_binding(o *otto.Object) error {
+	epoch := time.Now()
+	o.Set("now", func(call otto.FunctionCall) otto.Value {
+		v, _ := vm.ToValue(float64(time.Since(epoch)) / float64(time.Millisecond))
+		return v
+	})
+	o.Set("write", vm.console_write)
+	return nil
+}
+
+func (vm *Otto) console_write(call otto.FunctionCall) otto.Value {
+	method, err := vm.toString("method", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	s, err := vm.toString("data", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	indent, err := vm.toString("indent", call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	if vm.logger != nil {
+		vm.logger.Log(context.Background(), consoleLevel(method), s)
+		return otto.UndefinedValue()
+	}
+	w := vm.stdout
+	switch method {
+	case "warn", "error", "trace", "assert":
+		w = vm.stderr
+	}
+	if indent != "" {
+		s = indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+	}
+	io.WriteString(w, s+"\n")
+	return otto.UndefinedValue()
+}
+
+func consoleLevel(method string) slog.Level {
+	switch method {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "assert":
+		return slog.LevelWarn
+	case "error", "trace":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
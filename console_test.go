@@ -0,0 +1,210 @@
+//
+// otto.module :: console_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestConsole(t *testing.T) {
+	var stdout, stderr strings.Builder
+	vm, err := module.New(module.WithStdout(&stdout), module.WithStderr(&stderr))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		console.log('%s:%d', 'log', 1);
+		console.info({ a: [1, 'b'] });
+		console.debug('debug');
+		console.warn('warn');
+		console.error(new Error('error').message);
+		console.group('group');
+		console.log('a\nb');
+		console.group();
+		console.error('nested');
+		console.groupEnd();
+		console.groupEnd();
+		console.dir({ a: { b: { c: {} } } }, { depth: 0 });
+		console.table([{ a: 1, b: 'x' }, { a: 22, c: true }]);
+		console.table([1, 'ab']);
+		console.table(null);
+		console.count();
+		console.count();
+		console.count('label');
+		console.countReset();
+		console.count();
+		console.assert(true, 'true');
+		console.assert(false, 'false %d', 0);
+		console.assert(false);
+		console.time('time');
+		console.timeEnd('time');
+		var log = console.log;
+		log('bound');
+		console.trace('trace');
+		require('console') === console;
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "true"; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+	if g, e := regexp.MustCompile(`time: [0-9.]+ms`).ReplaceAllString(stdout.String(), "time: Xms"), strings.Join([]string{
+		"log:1",
+		"{ a: [ 1, 'b' ] }",
+		"debug",
+		"group",
+		"  a",
+		"  b",
+		"{ a: [Object] }",
+		"┌─────────┬────┬─────┬──────┐",
+		"│ (index) │ a  │ b   │ c    │",
+		"├─────────┼────┼─────┼──────┤",
+		"│ 0       │ 1  │ 'x' │      │",
+		"│ 1       │ 22 │     │ true │",
+		"└─────────┴────┴─────┴──────┘",
+		"┌─────────┬────────┐",
+		"│ (index) │ Values │",
+		"├─────────┼────────┤",
+		"│ 0       │ 1      │",
+		"│ 1       │ 'ab'   │",
+		"└─────────┴────────┘",
+		"null",
+		"default: 1",
+		"default: 2",
+		"label: 1",
+		"default: 1",
+		"time: Xms",
+		"bound",
+		"",
+	}, "\n"); g != e {
+		t.Errorf("stdout: expected %q, got %q", e, g)
+	}
+	if g, e := stderr.String(), strings.Join([]string{
+		"warn",
+		"error",
+		"    nested",
+		"Assertion failed: false 0",
+		"Assertion failed",
+		"Trace: trace",
+		"    at <anonymous>:29:3",
+		"",
+	}, "\n"); g != e {
+		t.Errorf("stderr: expected %q, got %q", e, g)
+	}
+
+	// warnings are written to stderr
+	stderr.Reset()
+	if _, err := vm.Run(`console.countReset('label'); console.countReset('label');`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if g, e := stderr.String(), "Warning: Count for 'label' does not exist\n"; !strings.HasSuffix(g, e) || strings.Count(g, "\n") != 1 {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestConsole_Console(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var out = [];
+		function stream(name) {
+			return {
+				write: function(s) {
+					out.push(name + ':' + s);
+				},
+			};
+		}
+		var c = new console.Console({
+			stdout: stream('stdout'),
+			stderr: stream('stderr'),
+			groupIndentation: 4,
+		});
+		c.group('group');
+		c.log(1);
+		c.warn(2);
+		c.groupEnd();
+		c.info(3);
+		c = console.Console(stream('out'));
+		c.error('error');
+		JSON.stringify(out);
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), `["stdout:group\n","stdout:    1\n","stderr:    2\n","stdout:3\n","out:error\n"]`; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+
+	for _, src := range []string{
+		`new console.Console()`,
+		`new console.Console({})`,
+		`new console.Console({ write: function() {} }, {})`,
+		`new console.Console({ stdout: { write: function() {} }, groupIndentation: -1 })`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", src)
+		}
+	}
+}
+
+func TestConsole_Logger(t *testing.T) {
+	h := new(recordHandler)
+	vm, err := module.New(module.WithLogger(slog.New(h)))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		console.log('log');
+		console.info('info');
+		console.debug('debug');
+		console.warn('warn');
+		console.error('error');
+		console.group();
+		console.assert(false, 'assert');
+		console.groupEnd();
+		console.trace('trace');
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if g, e := strings.Join(h.records, "\n"), strings.Join([]string{
+		"INFO log",
+		"INFO info",
+		"DEBUG debug",
+		"WARN warn",
+		"ERROR error",
+		"WARN Assertion failed: assert",
+		"ERROR Trace: trace\n    at <anonymous>:10:3",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+type recordHandler struct {
+	records []string
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r.Level.String()+" "+r.Message)
+	return nil
+}
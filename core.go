@@ -25,528 +25,13134 @@ func (*coreLoader) Resolve(id, _ string) (string, error) {
 }
 
 var files = map[string][]byte{
-	"internal/bootstrap.js": []byte(`//
-// otto.module :: internal/bootstrap.go
+	"assert.js": []byte(`//
+// otto.module :: assert.js
 //
-//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
 //
 //   SPDX-License-Identifier: MIT
 //
 
 'use strict';
 
-(function(process) {
-  var vm = process.binding('vm');
+var _inspect = require('./internal/util/inspect');
+var comparisons = require('./internal/util/comparisons');
 
-  function NativeModule(id) {
-    this.id = id;
-    this.exports = {};
-    this.filename = id + '.js';
-    this.loaded = false;
-  }
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+var inspect = _inspect.inspect;
 
-  NativeModule._source = process.binding('natives');
-  NativeModule._cache = Object.create(null);
+var headers = {
+  deepStrictEqual: 'Expected values to be strictly deep-equal:',
+  strictEqual: 'Expected values to be strictly equal:',
+  deepEqual: 'Expected values to be loosely deep-equal:',
+  notDeepStrictEqual: 'Expected "actual" not to be strictly deep-equal to:',
+  notStrictEqual: 'Expected "actual" to be strictly unequal to:',
+  notDeepEqual: 'Expected "actual" not to be loosely deep-equal to:',
+};
 
-  NativeModule.exists = function exists(id) {
-    return Object.prototype.hasOwnProperty.call(NativeModule._source, id);
-  };
+var operators = {
+  equal: '==',
+  notEqual: '!=',
+};
 
-  NativeModule.isInternal = function isInternal(id) {
-    var s = 'internal/';
-    return id.slice(0, s.length) === s;
-  };
+function inspectValue(v) {
+  return inspect(v, {
+    depth: 1000,
+    customInspect: false,
+    compact: false,
+    sorted: true,
+    breakLength: Infinity,
+  });
+}
 
-  NativeModule.require = function require(id) {
-    if (id === 'native_module') {
-      return NativeModule;
+function diff(a, b) {
+  // longest common subsequence
+  var n = a.length;
+  var m = b.length;
+  var lcs = [];
+  var i;
+  var j;
+  for (i = 0; i <= n; i++) {
+    lcs.push(new Array(m + 1));
+    lcs[i][m] = 0;
+  }
+  for (j = 0; j <= m; j++) {
+    lcs[n][j] = 0;
+  }
+  for (i = n - 1; i >= 0; i--) {
+    for (j = m - 1; j >= 0; j--) {
+      lcs[i][j] = a[i] === b[j] ? lcs[i + 1][j + 1] + 1 : Math.max(lcs[i + 1][j], lcs[i][j + 1]);
     }
+  }
 
-    var m = NativeModule._cache[id];
-    if (!m) {
-      NativeModule._cache[id] = m = new NativeModule(id);
-      m.compile();
+  var lines = [];
+  i = 0;
+  j = 0;
+  while (i < n || j < m) {
+    if (i < n
+        && j < m
+        && a[i] === b[j]) {
+      lines.push('  ' + a[i++]);
+      j++;
+    } else if (j >= m
+               || (i < n && lcs[i + 1][j] >= lcs[i][j + 1])) {
+      lines.push('+ ' + a[i++]);
+    } else {
+      lines.push('- ' + b[j++]);
     }
-    return m.exports;
-  };
+  }
+  return lines;
+}
 
-  NativeModule.prototype.compile = function compile() {
-    var fn = vm.compile(this.filename);
-    fn(this.exports, NativeModule.require, this, this.filename);
+function createMessage(actual, expected, operator) {
+  var a = inspectValue(actual);
+  var b = inspectValue(expected);
+  if (hasOwnProperty.call(operators, operator)) {
+    return a + ' ' + operators[operator] + ' ' + b;
+  } else if (operator.slice(0, 3) === 'not') {
+    if (a.indexOf('\n') === -1) {
+      return headers[operator] + ' ' + a;
+    }
+    return headers[operator] + '\n\n' + a + '\n';
+  }
 
-    this.loaded = true;
-  };
+  var header = headers[operator] || 'Expected values to be equal:';
+  var al = a.split('\n');
+  var bl = b.split('\n');
+  if (al.length === 1
+      && bl.length === 1
+      && a !== b) {
+    return header + '\n\n' + a + ' !== ' + b + '\n';
+  }
+  return header + '\n+ actual - expected\n\n' + diff(al, bl).join('\n') + '\n';
+}
 
-  var g = (0, eval)('this');
-  g.process = process;
+function AssertionError(options) {
+  if (options === null
+      || typeof options !== 'object') {
+    throw new TypeError('options must be an Object');
+  }
 
-  var Module = NativeModule.require('module');
-  var _module = NativeModule.require('internal/module');
+  var message = options.message;
+  if (message !== undefined) {
+    this.message = String(message);
+    this.generatedMessage = false;
+  } else {
+    this.message = createMessage(options.actual, options.expected, options.operator);
+    this.generatedMessage = true;
+  }
+  this.code = 'ERR_ASSERTION';
+  this.actual = options.actual;
+  this.expected = options.expected;
+  this.operator = options.operator;
 
-  var m = new Module('<otto>');
-  g.module = m;
-  g.require = _module.require(m);
+  var stack = new Error(this.message).stack;
+  if (typeof stack === 'string') {
+    var i = stack.indexOf('\n    at ');
+    this.stack = this.name + ' [' + this.code + ']: ' + this.message + (i !== -1 ? stack.slice(i) : '');
+  }
+}
+
+AssertionError.prototype = Object.create(Error.prototype, {
+  constructor: {
+    value: AssertionError,
+    enumerable: false,
+    writable: true,
+    configurable: true,
+  },
 });
-`),
-	"internal/module.js": []byte(`//
-// otto.module :: internal/module.js
-//
-//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
-//
-//   SPDX-License-Identifier: MIT
-//
+AssertionError.prototype.name = 'AssertionError';
 
-'use strict';
+AssertionError.prototype.toString = function toString() {
+  return this.name + ' [' + this.code + ']: ' + this.message;
+};
 
-exports.require = function require_(m) {
-  var Module = m.constructor;
+function innerFail(obj) {
+  if (obj.message instanceof Error) {
+    throw obj.message;
+  }
+  throw new AssertionError(obj);
+}
 
-  function require(id) {
-    return m.require(id);
+function innerOk(args, name) {
+  if (args.length === 0) {
+    innerFail({
+      message: 'No value argument passed to ` + "`" + `assert.ok()` + "`" + `',
+      actual: undefined,
+      expected: true,
+      operator: '==',
+    });
+  } else if (!args[0]) {
+    innerFail({
+      message: args[1] !== undefined ? args[1] : 'The expression evaluated to a falsy value:\n\n  ' + name + '(' + inspectValue(args[0]) + ')\n',
+      actual: args[0],
+      expected: true,
+      operator: '==',
+    });
   }
+}
 
-  require.cache = Module._cache;
-  require.extensions = Module._extensions;
+function assert() {
+  innerOk(arguments, 'assert');
+}
 
-  require.resolve = function resolve(id) {
-    return Module._resolve(id, m);
-  };
+assert.AssertionError = AssertionError;
 
-  return require;
+assert.ok = function ok() {
+  innerOk(arguments, 'assert.ok');
 };
-`),
-	"module.js": []byte(`//
-// otto.module :: module.js
-//
-//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
-//
-//   SPDX-License-Identifier: MIT
-//
-
-'use strict';
 
-var NativeModule = require('native_module');
-var _module = require('internal/module');
-var path = require('path');
+assert.fail = function fail(message) {
+  if (message instanceof Error) {
+    throw message;
+  }
+  throw new AssertionError({
+    message: message !== undefined ? message : 'Failed',
+    operator: 'fail',
+  });
+};
 
-var vm = process.binding('vm');
+function is(a, b) {
+  if (a === b) {
+    return a !== 0 || 1 / a === 1 / b;
+  }
+  return a !== a && b !== b;
+}
 
-function update(parent, child, scan) {
-  if (parent
-      && !(scan
-           && parent.children.indexOf(child) === -1)) {
-    parent.children.push(child);
+function checkArgs(args) {
+  if (args.length < 2) {
+    throw new TypeError('"actual" and "expected" arguments must be specified');
   }
 }
 
-function Module(id, parent) {
-  this.id = id;
-  this.exports = {};
-  this.parent = parent;
-  this.filename = null;
-  this.loaded = false;
-  this.children = [];
+assert.equal = function equal(actual, expected, message) {
+  checkArgs(arguments);
+  if (!(actual == expected || (actual !== actual && expected !== expected))) { // eslint-disable-line eqeqeq
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'equal',
+    });
+  }
+};
 
-  update(parent, this, false);
-}
+assert.notEqual = function notEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (actual == expected || (actual !== actual && expected !== expected)) { // eslint-disable-line eqeqeq
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notEqual',
+    });
+  }
+};
 
-Module._cache = Object.create(null);
-Module._pathCache = Object.create(null);
-Module._extensions = Object.create(null);
+assert.strictEqual = function strictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (!is(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'strictEqual',
+    });
+  }
+};
 
-Module._resolve = function _resolve(id, parent) {
-  var dir = '';
-  if (parent
-      && parent.filename) {
-    dir = path.dirname(parent.filename);
+assert.notStrictEqual = function notStrictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (is(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notStrictEqual',
+    });
   }
+};
 
-  var k = id + '\x00' + dir;
-  var p = Module._pathCache[k];
-  if (!p) {
-    Module._pathCache[k] = p = vm.resolve(id, dir);
+assert.deepEqual = function deepEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (!comparisons.isDeepEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'deepEqual',
+    });
   }
-  return p;
 };
 
-Module.prototype.require = function require(id) {
-  if (NativeModule.exists(id)
-      && !NativeModule.isInternal(id)) {
-    return NativeModule.require(id);
+assert.notDeepEqual = function notDeepEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (comparisons.isDeepEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notDeepEqual',
+    });
   }
+};
 
-  var n = Module._resolve(id, this);
-  var m = Module._cache[n];
-  if (m) {
-    update(this, m, true);
-  } else {
-    Module._cache[n] = m = new Module(id, this);
-    m.filename = n;
-    m.paths = [];
-    m._load();
+assert.deepStrictEqual = function deepStrictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (!comparisons.isDeepStrictEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'deepStrictEqual',
+    });
   }
-  return m.exports;
 };
 
-Module.prototype._compile = function _compile() {
-  var fn = vm.compile(this.filename);
-  fn.call(this.exports, this.exports, _module.require(this), this, this.filename, path.dirname(this.filename));
+assert.notDeepStrictEqual = function notDeepStrictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (comparisons.isDeepStrictEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notDeepStrictEqual',
+    });
+  }
 };
 
-Module.prototype._load = function _load() {
-  if (this.loaded) {
-    throw new Error('already loaded');
+var NO_EXCEPTION = {};
+
+function getActual(fn) {
+  if (typeof fn !== 'function') {
+    throw new TypeError('fn must be a Function');
+  }
+  try {
+    fn();
+  } catch (e) {
+    return e;
   }
+  return NO_EXCEPTION;
+}
 
-  var ext = path.extname(this.filename);
-  if (!(ext in Module._extensions)) {
-    ext = '.js';
+function isRegExp(v) {
+  return Object.prototype.toString.call(v) === '[object RegExp]';
+}
+
+function Comparison() {}
+
+function expectedException(actual, expected, message, operator) {
+  if (typeof expected === 'function') {
+    if (expected.prototype !== undefined
+        && actual instanceof expected) {
+      return;
+    } else if (Error.prototype.isPrototypeOf(expected.prototype)
+               || expected === Error) {
+      innerFail({
+        message: message !== undefined ? message : 'The error is expected to be an instance of "' + _inspect.getName(expected) + '". Received "' + (actual !== null && typeof actual === 'object' && actual.name ? actual.name : inspectValue(actual)) + '"' + (actual !== null && typeof actual === 'object' && actual.message !== undefined ? '\n\nError message:\n\n' + actual.message : ''),
+        actual: actual,
+        expected: expected,
+        operator: operator,
+      });
+    } else if (expected.call({}, actual) !== true) {
+      innerFail({
+        message: message !== undefined ? message : 'The "' + (_inspect.getName(expected) || 'validate') + '" validation function is expected to return "true". Received ' + inspectValue(actual),
+        actual: actual,
+        expected: expected,
+        operator: operator,
+      });
+    }
+  } else if (isRegExp(expected)) {
+    if (!expected.test(String(actual))) {
+      innerFail({
+        message: message !== undefined ? message : 'The input did not match the regular expression ' + String(expected) + '. Input:\n\n' + inspectValue(String(actual)) + '\n',
+        actual: actual,
+        expected: expected,
+        operator: operator,
+      });
+    }
+  } else if (expected !== null
+             && typeof expected === 'object') {
+    if (actual === null
+        || typeof actual !== 'object') {
+      innerFail({
+        message: message,
+        actual: actual,
+        expected: expected,
+        operator: 'deepStrictEqual',
+      });
+    }
+    var keys = Object.keys(expected);
+    if (expected instanceof Error) {
+      keys.push('name', 'message');
+    }
+    for (var i = 0; i < keys.length; i++) {
+      var k = keys[i];
+      var v = expected[k];
+      if (typeof actual[k] === 'string'
+          && isRegExp(v)
+          && v.test(actual[k])) {
+        continue;
+      } else if (!(k in actual)
+                 || !comparisons.isDeepStrictEqual(actual[k], v)) {
+        if (message === undefined) {
+          var a = new Comparison();
+          var b = new Comparison();
+          keys.forEach(function(k) {
+            if (k in actual) {
+              a[k] = actual[k];
+            }
+            b[k] = expected[k];
+          });
+          message = createMessage(a, b, 'deepStrictEqual');
+        }
+        innerFail({
+          message: message,
+          actual: actual,
+          expected: expected,
+          operator: operator,
+        });
+      }
+    }
+  } else {
+    throw new TypeError('expected must be a Function, RegExp or Object');
+  }
+}
+
+assert.throws = function throws(fn, expected, message) {
+  var actual = getActual(fn);
+  if (typeof expected === 'string') {
+    message = expected;
+    expected = undefined;
+  }
+  if (actual === NO_EXCEPTION) {
+    var details = '';
+    if (expected !== undefined
+        && _inspect.getName(expected)) {
+      details += ' (' + _inspect.getName(expected) + ')';
+    }
+    details += message !== undefined ? ': ' + message : '.';
+    innerFail({
+      message: 'Missing expected exception' + details,
+      actual: undefined,
+      expected: expected,
+      operator: 'throws',
+    });
+  } else if (expected !== undefined) {
+    expectedException(actual, expected, message, 'throws');
   }
-  Module._extensions[ext](this);
-  this.loaded = true;
 };
 
-Module._extensions['.js'] = function _extensions$js(module) {
-  module._compile();
+assert.doesNotThrow = function doesNotThrow(fn, expected, message) {
+  var actual = getActual(fn);
+  if (typeof expected === 'string') {
+    message = expected;
+    expected = undefined;
+  }
+  if (actual === NO_EXCEPTION) {
+    return;
+  } else if (expected === undefined
+             || (typeof expected === 'function' && expected.prototype !== undefined && actual instanceof expected)
+             || (isRegExp(expected) && expected.test(String(actual)))) {
+    var details = message !== undefined ? ': ' + message : '.';
+    innerFail({
+      message: 'Got unwanted exception' + details + '\nActual message: "' + (actual && actual.message) + '"',
+      actual: actual,
+      expected: expected,
+      operator: 'doesNotThrow',
+    });
+  }
+  throw actual;
 };
 
-Module._extensions['.json'] = function _extensions$json(module) {
-  try {
-    module.exports = JSON.parse(vm.load(module.filename));
-  } catch (err) {
-    throw new err.constructor(module.filename + ': ' + err.message);
+function match(s, re, message, operator) {
+  if (!isRegExp(re)) {
+    throw new TypeError('regexp must be a RegExp');
   }
+  var ok = operator === 'match';
+  if (typeof s !== 'string'
+      || re.test(s) !== ok) {
+    innerFail({
+      message: message !== undefined ? message : (typeof s !== 'string' ? 'The "string" argument must be of type string. Received type ' + typeof s + ' (' + inspectValue(s) + ')' : (ok ? 'The input did not match the regular expression ' : 'The input was expected to not match the regular expression ') + String(re) + '. Input:\n\n' + inspectValue(s) + '\n'),
+      actual: s,
+      expected: re,
+      operator: operator,
+    });
+  }
+}
+
+assert.match = function match_(s, re, message) {
+  match(s, re, message, 'match');
 };
 
-module.exports = Module;
+assert.doesNotMatch = function doesNotMatch(s, re, message) {
+  match(s, re, message, 'doesNotMatch');
+};
+
+assert.ifError = function ifError(err) {
+  if (err !== null
+      && err !== undefined) {
+    var message = 'ifError got unwanted exception: ';
+    if (err !== null
+        && typeof err === 'object'
+        && typeof err.message === 'string') {
+      message += err.message.length === 0 && err.constructor ? _inspect.getName(err.constructor) : err.message;
+    } else {
+      message += inspectValue(err);
+    }
+    var e = new AssertionError({
+      actual: err,
+      expected: null,
+      operator: 'ifError',
+      message: message,
+    });
+    e.generatedMessage = true;
+    throw e;
+  }
+};
+
+function strict() {
+  innerOk(arguments, 'assert');
+}
+
+strict.AssertionError = AssertionError;
+Object.keys(assert).forEach(function(k) {
+  strict[k] = assert[k];
+});
+strict.equal = assert.strictEqual;
+strict.notEqual = assert.notStrictEqual;
+strict.deepEqual = assert.deepStrictEqual;
+strict.notDeepEqual = assert.notDeepStrictEqual;
+strict.strict = strict;
+assert.strict = strict;
+
+module.exports = assert;
 `),
-	"path.js": []byte(`//
-// otto.module :: path.js
+	"buffer.js": []byte(`//
+// otto.module :: buffer.js
 //
-//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
 //
 //   SPDX-License-Identifier: MIT
 //
 
 'use strict';
 
-function assert(path) {
-  if (typeof path !== 'string') {
-    throw new TypeError('path must be a String');
+var binding = process.binding('buffer');
+var _inspect = require('./internal/util/inspect');
+var util = require('./internal/util');
+
+var inspect = _inspect.inspect;
+var invalidArgType = util.invalidArgType;
+var invalidArgValue = util.invalidArgValue;
+var outOfRange = util.outOfRange;
+
+var kMaxLength = 0x100000000;
+var kStringMaxLength = 0x1fffffe8;
+
+exports.kMaxLength = kMaxLength;
+exports.kStringMaxLength = kStringMaxLength;
+exports.constants = {
+  MAX_LENGTH: kMaxLength,
+  MAX_STRING_LENGTH: kStringMaxLength,
+};
+exports.INSPECT_MAX_BYTES = 50;
+
+//
+// errors
+//
+
+function outOfBounds() {
+  var e = new RangeError('Attempt to access memory outside buffer bounds');
+  e.code = 'ERR_BUFFER_OUT_OF_BOUNDS';
+  return e;
+}
+
+function validateNumber(v, name) {
+  if (typeof v !== 'number') {
+    throw invalidArgType(name, 'of type number', v);
   }
 }
 
-var posix = {
-  delimiter: ':',
-  sep: '/',
+function validateOffset(v, name, min, max) {
+  if (min === undefined) {
+    min = 0;
+  }
+  if (max === undefined) {
+    max = kMaxLength;
+  }
+  validateNumber(v, name);
+  if (Math.floor(v) !== v) {
+    throw outOfRange(name, 'an integer', v);
+  } else if (v < min
+             || v > max) {
+    throw outOfRange(name, '>= ' + min + ' && <= ' + max, v);
+  }
+}
 
-  _isSep: function _isSep(c) {
-    return c === '/';
-  },
+function validateBuffer(v, name) {
+  if (!isBuffer(v)) {
+    throw invalidArgType(name, 'an instance of Buffer or Uint8Array', v);
+  }
+}
 
-  _volname: function _volname() {
-    return '';
-  },
-};
+function boundsError(v, length, name) {
+  if (Math.floor(v) !== v) {
+    validateNumber(v, name || 'offset');
+    throw outOfRange(name || 'offset', 'an integer', v);
+  } else if (length < 0) {
+    throw outOfBounds();
+  }
+  throw outOfRange(name || 'offset', '>= ' + (name ? 1 : 0) + ' and <= ' + length, v);
+}
 
-var win32 = {
-  delimiter: ';',
-  sep: '\\',
+function normalizeEncoding(enc) {
+  var s = util.normalizeEncoding(enc);
+  if (s === undefined) {
+    throw util.unknownEncoding(enc);
+  }
+  return s;
+}
 
-  _isSep: function _isSep(c) {
-    return c === '\\'
-           || c === '/';
-  },
+function trunc(n) {
+  n = +n;
+  return n < 0 ? Math.ceil(n) : Math.floor(n);
+}
 
-  _volname: function _volname(path) {
-    if (path.length > 1) {
-      if (path[1] === ':'
-          && ('A' <= path[0] && path[0] <= 'Z'
-              || 'a' <= path[0] && path[0] <= 'z')) {
-        // drive letter
-        return path.slice(0, 2);
-      } else if (path.length > 4
-                 && win32._isSep(path[0])
-                 && win32._isSep(path[1])
-                 && !(win32._isSep(path[2])
-                      || path[2] === '.')) {
-        // UNC
-        for (var i = 3; i < path.length - 1; i++) {
-          if (win32._isSep(path[i])
-              && !win32._isSep(path[++i])) {
-            for (; i < path.length; i++) {
-              if (win32._isSep(path[i])) {
-                break;
-              }
-            }
-            return path.slice(0, i);
-          }
-        }
-      }
+//
+// Buffer
+//
+
+function isBuffer(b) {
+  return b instanceof Buffer;
+}
+
+function Buffer(arg, encodingOrOffset, length) {
+  process.emitWarning('Buffer() is deprecated due to security and usability issues. Please use the Buffer.alloc(), Buffer.allocUnsafe(), or Buffer.from() methods instead.', 'DeprecationWarning', 'DEP0005');
+  if (typeof arg === 'number') {
+    if (typeof encodingOrOffset === 'string') {
+      throw invalidArgType('string', 'of type string', arg);
     }
-    return '';
-  },
+    return Buffer.alloc(arg);
+  }
+  return Buffer.from(arg, encodingOrOffset, length);
+}
+
+exports.Buffer = Buffer;
+
+function createBuffer(length) {
+  return Object.create(Buffer.prototype, {
+    length: { value: length },
+  });
+}
+
+binding.setup(createBuffer, isBuffer);
+
+Buffer.poolSize = 8192;
+
+Buffer.isBuffer = isBuffer;
+
+Buffer.isEncoding = function isEncoding(enc) {
+  return typeof enc === 'string'
+         && enc.length !== 0
+         && util.normalizeEncoding(enc) !== undefined;
 };
 
-function _basename(os) {
-  return function basename(path, ext) {
-    assert(path);
-    if (ext !== undefined) {
-      assert(ext);
+function validateSize(size) {
+  validateNumber(size, 'size');
+  if (!(size >= 0 && size <= kMaxLength)) {
+    throw outOfRange('size', '>= 0 && <= ' + kMaxLength, size);
+  }
+}
+
+Buffer.alloc = function alloc(size, fill, encoding) {
+  validateSize(size);
+  var b = binding.alloc(size);
+  if (fill !== undefined
+      && fill !== 0
+      && size > 0) {
+    return b.fill(fill, encoding);
+  }
+  return b;
+};
+
+Buffer.allocUnsafe = function allocUnsafe(size) {
+  validateSize(size);
+  return binding.alloc(size);
+};
+
+Buffer.allocUnsafeSlow = Buffer.allocUnsafe;
+
+Buffer.from = function from(value, encodingOrOffset, length) {
+  if (typeof value === 'string') {
+    if (typeof encodingOrOffset !== 'string'
+        || encodingOrOffset.length === 0) {
+      return binding.from(value, 'utf8');
     }
-    // volume
-    var vol = os._volname(path);
-    if (vol
-        && vol === path) {
-      return '';
+    return binding.from(value, normalizeEncoding(encodingOrOffset));
+  } else if (value !== null
+             && typeof value === 'object') {
+    if (isBuffer(value)) {
+      return binding.from(value);
     }
-    // trim trailing separators
-    var end;
-    for (end = path.length; end > vol.length && os._isSep(path[end - 1]); end--);
+    var v = typeof value.valueOf === 'function' ? value.valueOf() : value;
+    if (v !== null
+        && v !== undefined
+        && v !== value
+        && (typeof v === 'string' || typeof v === 'object')) {
+      return Buffer.from(v, encodingOrOffset, length);
+    } else if (value.length !== undefined) {
+      return typeof value.length === 'number' ? binding.from(value) : binding.alloc(0);
+    } else if (value.type === 'Buffer'
+               && Array.isArray(value.data)) {
+      return binding.from(value.data);
+    }
+  }
+  throw invalidArgType('first argument', 'of type string or an instance of Buffer, ArrayBuffer, or Array or an Array-like Object', value);
+};
 
-    for (var i = end - 1; i > vol.length; i--) {
-      if (os._isSep(path[i])) {
-        i++;
-        if (ext
-            && path.indexOf(ext, end - ext.length) !== -1) {
-          return path.slice(i, end - ext.length);
-        }
-        return path.slice(i, end);
+Buffer.of = function of() {
+  return binding.from(arguments);
+};
+
+Buffer.byteLength = function byteLength(string, encoding) {
+  if (typeof string !== 'string') {
+    if (isBuffer(string)) {
+      return string.length;
+    }
+    throw invalidArgType('string', 'of type string or an instance of Buffer or ArrayBuffer', string);
+  }
+  var n = string.length;
+  switch (util.normalizeEncoding(encoding)) {
+  case 'latin1':
+  case 'ascii':
+    return n;
+  case 'utf16le':
+    return n * 2;
+  case 'hex':
+    return n >>> 1;
+  case 'base64':
+  case 'base64url':
+    if (string.charAt(n - 1) === '=') {
+      n--;
+    }
+    if (n > 1
+        && string.charAt(n - 1) === '=') {
+      n--;
+    }
+    return (n * 3) >>> 2;
+  }
+  return binding.byteLength(string, 'utf8');
+};
+
+Buffer.compare = function compare(buf1, buf2) {
+  validateBuffer(buf1, 'buf1');
+  validateBuffer(buf2, 'buf2');
+  return binding.compare(buf1, buf2);
+};
+
+Buffer.concat = function concat(list, length) {
+  if (!Array.isArray(list)) {
+    throw invalidArgType('list', 'an instance of Array', list);
+  } else if (list.length === 0) {
+    return binding.alloc(0);
+  }
+
+  var i;
+  if (length === undefined) {
+    length = 0;
+    for (i = 0; i < list.length; i++) {
+      if (list[i].length) {
+        length += list[i].length;
       }
     }
+  } else {
+    validateOffset(length, 'length');
+  }
+
+  var b = binding.alloc(length);
+  var pos = 0;
+  for (i = 0; i < list.length; i++) {
+    if (!isBuffer(list[i])) {
+      throw invalidArgType('list[' + i + ']', 'an instance of Buffer or Uint8Array', list[i]);
+    }
+    pos += binding.copy(list[i], b, pos);
+  }
+  return b;
+};
+
+Buffer.prototype.toString = function toString(encoding, start, end) {
+  if (arguments.length === 0) {
+    return binding.toString(this, 'utf8');
+  }
+
+  var len = this.length;
+  if (start <= 0) {
+    start = 0;
+  } else if (start >= len) {
+    return '';
+  } else {
+    start = trunc(start) || 0;
+  }
+  if (end === undefined
+      || end > len) {
+    end = len;
+  } else {
+    end = trunc(end) || 0;
+  }
+  if (end <= start) {
     return '';
+  }
+  return binding.toString(this, encoding === undefined ? 'utf8' : normalizeEncoding(encoding), start, end);
+};
+
+Buffer.prototype.toLocaleString = Buffer.prototype.toString;
+
+Buffer.prototype.toJSON = function toJSON() {
+  var data = new Array(this.length);
+  for (var i = 0; i < data.length; i++) {
+    data[i] = this[i] & 0xff;
+  }
+  return {
+    type: 'Buffer',
+    data: data,
   };
+};
+
+Buffer.prototype[inspect.custom] = function(depth, options) {
+  var max = exports.INSPECT_MAX_BYTES;
+  var s = binding.toString(this, 'hex', 0, Math.min(max, this.length)).replace(/(.{2})/g, '$1 ').replace(/ $/, '');
+  var n = this.length - max;
+  if (n > 0) {
+    s += ' ... ' + n + ' more byte' + (n > 1 ? 's' : '');
+  }
+  return '<' + this.constructor.name + ' ' + s + '>';
+};
+
+Buffer.prototype.equals = function equals(otherBuffer) {
+  validateBuffer(otherBuffer, 'otherBuffer');
+  return this === otherBuffer
+         || binding.compare(this, otherBuffer) === 0;
+};
+
+Buffer.prototype.compare = function compare(target, targetStart, targetEnd, sourceStart, sourceEnd) {
+  validateBuffer(target, 'target');
+  if (targetStart === undefined) {
+    targetStart = 0;
+  } else {
+    validateOffset(targetStart, 'targetStart');
+  }
+  if (targetEnd === undefined) {
+    targetEnd = target.length;
+  } else {
+    validateOffset(targetEnd, 'targetEnd', 0, target.length);
+  }
+  if (sourceStart === undefined) {
+    sourceStart = 0;
+  } else {
+    validateOffset(sourceStart, 'sourceStart');
+  }
+  if (sourceEnd === undefined) {
+    sourceEnd = this.length;
+  } else {
+    validateOffset(sourceEnd, 'sourceEnd', 0, this.length);
+  }
+
+  if (sourceStart >= sourceEnd) {
+    return targetStart >= targetEnd ? 0 : -1;
+  } else if (targetStart >= targetEnd) {
+    return 1;
+  }
+  return binding.compare(this, target, sourceStart, sourceEnd, targetStart, targetEnd);
+};
+
+function indexOf(buf, v, byteOffset, encoding, forward) {
+  if (typeof byteOffset === 'string') {
+    encoding = byteOffset;
+    byteOffset = undefined;
+  } else if (byteOffset > 0x7fffffff) {
+    byteOffset = 0x7fffffff;
+  } else if (byteOffset < -0x80000000) {
+    byteOffset = -0x80000000;
+  }
+  byteOffset = +byteOffset;
+  if (byteOffset !== byteOffset) {
+    byteOffset = forward ? 0 : buf.length;
+  }
+
+  if (typeof v === 'number') {
+    return binding.indexOf(buf, (v >>> 0) & 0xff, byteOffset, 'latin1', forward);
+  }
+  var enc = encoding === undefined ? 'utf8' : util.normalizeEncoding(encoding);
+  if (typeof v === 'string') {
+    if (enc === undefined) {
+      throw util.unknownEncoding(encoding);
+    }
+    return binding.indexOf(buf, v, byteOffset, enc, forward);
+  } else if (isBuffer(v)) {
+    return binding.indexOf(buf, v, byteOffset, 'utf8', forward);
+  }
+  throw invalidArgType('value', 'one of type number or string or an instance of Buffer or Uint8Array', v);
 }
 
-posix.basename = _basename(posix);
-win32.basename = _basename(win32);
+Buffer.prototype.indexOf = function(value, byteOffset, encoding) {
+  return indexOf(this, value, byteOffset, encoding, true);
+};
 
-function _dirname(os) {
-  return function dirname(path) {
-    assert(path);
-    if (!path) {
-      return '.';
+Buffer.prototype.lastIndexOf = function(value, byteOffset, encoding) {
+  return indexOf(this, value, byteOffset, encoding, false);
+};
+
+Buffer.prototype.includes = function includes(value, byteOffset, encoding) {
+  return this.indexOf(value, byteOffset, encoding) !== -1;
+};
+
+Buffer.prototype.fill = function fill(value, offset, end, encoding) {
+  var enc;
+  if (typeof value === 'string') {
+    if (offset === undefined
+        || typeof offset === 'string') {
+      encoding = offset;
+      offset = 0;
+      end = this.length;
+    } else if (typeof end === 'string') {
+      encoding = end;
+      end = this.length;
     }
-    // volume
-    var vol = os._volname(path);
-    if (vol
-        && vol === path) {
-      return vol;
+    enc = util.normalizeEncoding(encoding);
+    if (enc === undefined) {
+      if (typeof encoding !== 'string') {
+        throw invalidArgType('encoding', 'of type string', encoding);
+      }
+      throw util.unknownEncoding(encoding);
+    } else if (value.length === 0) {
+      value = 0;
     }
-    // trim trailing separators
-    var end;
-    for (end = path.length; end > vol.length && os._isSep(path[end - 1]); end--);
+  }
 
-    for (var i = end - 1; i > vol.length; i--) {
-      if (os._isSep(path[i])) {
-        return path.slice(0, i);
-      }
+  if (offset === undefined) {
+    offset = 0;
+    end = this.length;
+  } else {
+    validateOffset(offset, 'offset');
+    if (end === undefined) {
+      end = this.length;
+    } else {
+      validateOffset(end, 'end', 0, this.length);
     }
-    return end < path.length ? vol + path[end] : '.';
-  };
-}
+    if (offset >= end) {
+      return this;
+    }
+  }
 
-posix.dirname = _dirname(posix);
-win32.dirname = _dirname(win32);
+  if (typeof value === 'number') {
+    value &= 0xff;
+  } else if (typeof value !== 'string'
+             && !isBuffer(value)) {
+    value = (value >>> 0) & 0xff;
+  }
+  if (binding.fill(this, value, offset, end, enc) < 0) {
+    throw invalidArgValue('value', value);
+  }
+  return this;
+};
 
-function _extname(os) {
-  return function extname(path) {
-    assert(path);
-    for (var i = path.length - 1; i > 0 && !os._isSep(path[i]); i--) {
-      if (path[i] === '.'
-          && !os._isSep(path[i - 1])) {
-        return path.slice(i);
+Buffer.prototype.write = function write(string, offset, length, encoding) {
+  if (typeof string !== 'string') {
+    var e = new TypeError('argument must be a string');
+    e.code = 'ERR_INVALID_ARG_TYPE';
+    throw e;
+  }
+
+  if (offset === undefined) {
+    return binding.write(this, string, 0, this.length, 'utf8');
+  } else if (length === undefined
+             && typeof offset === 'string') {
+    encoding = offset;
+    length = this.length;
+    offset = 0;
+  } else {
+    validateOffset(offset, 'offset', 0, this.length);
+    var remaining = this.length - offset;
+    if (length === undefined) {
+      length = remaining;
+    } else if (typeof length === 'string') {
+      encoding = length;
+      length = remaining;
+    } else {
+      validateOffset(length, 'length', 0, this.length);
+      if (length > remaining) {
+        length = remaining;
       }
     }
-    return '';
-  };
-}
+  }
+  return binding.write(this, string, offset, length, encoding ? normalizeEncoding(encoding) : 'utf8');
+};
 
-posix.extname = _extname(posix);
-win32.extname = _extname(win32);
+function toInteger(n, defaultValue) {
+  n = +n;
+  if (n === n
+      && n >= -9007199254740991
+      && n <= 9007199254740991) {
+    return n % 1 === 0 ? n : Math.floor(n);
+  }
+  return defaultValue;
+}
 
-function _format(os) {
-  return function format(pathObject) {
-    if (pathObject === null
-        || typeof pathObject !== 'object') {
-      throw new TypeError('pathObject must be an Object');
+Buffer.prototype.copy = function copy(target, targetStart, sourceStart, sourceEnd) {
+  validateBuffer(this, 'source');
+  validateBuffer(target, 'target');
+  if (targetStart === undefined) {
+    targetStart = 0;
+  } else {
+    targetStart = toInteger(targetStart, 0);
+    if (targetStart < 0) {
+      throw outOfRange('targetStart', '>= 0', targetStart);
     }
-    var dir = pathObject.dir || pathObject.root;
-    var base = pathObject.base || '';
-    if (!base) {
-      if (pathObject.name) {
-        base += pathObject.name;
-      }
-      if (pathObject.ext) {
-        base += pathObject.ext;
-      }
+  }
+  if (sourceStart === undefined) {
+    sourceStart = 0;
+  } else {
+    sourceStart = toInteger(sourceStart, 0);
+    if (sourceStart < 0
+        || sourceStart > this.length) {
+      throw outOfRange('sourceStart', '>= 0 && <= ' + this.length, sourceStart);
     }
-    if (!dir) {
-      return base;
+  }
+  if (sourceEnd === undefined) {
+    sourceEnd = this.length;
+  } else {
+    sourceEnd = toInteger(sourceEnd, 0);
+    if (sourceEnd < 0) {
+      throw outOfRange('sourceEnd', '>= 0', sourceEnd);
     }
-    return dir === pathObject.root ? dir + base : dir + os.sep + base;
-  };
-}
+  }
 
-posix.format = _format(posix);
-win32.format = _format(win32);
+  if (targetStart >= target.length
+      || sourceStart >= sourceEnd) {
+    return 0;
+  }
+  return binding.copy(this, target, targetStart, sourceStart, sourceEnd);
+};
 
-function _isAbsolute(os) {
-  return function isAbsolute(path) {
-    assert(path);
-    return !!path
-           && os._isSep(path[os._volname(path).length]);
-  };
+function adjustOffset(offset, length) {
+  offset = trunc(offset);
+  if (offset === 0
+      || offset !== offset) {
+    return 0;
+  } else if (offset < 0) {
+    offset += length;
+    return offset > 0 ? offset : 0;
+  }
+  return offset < length ? offset : length;
 }
 
-posix.isAbsolute = _isAbsolute(posix);
-win32.isAbsolute = _isAbsolute(win32);
+Buffer.prototype.subarray = function subarray(start, end) {
+  var len = this.length;
+  start = adjustOffset(start, len);
+  end = end !== undefined ? adjustOffset(end, len) : len;
+  return binding.slice(this, start, end > start ? end : start);
+};
 
-function _join(os) {
-  return function join() {
-    var path = '';
-    for (var i = 0; i < arguments.length; i++) {
-      var a = arguments[i];
-      assert(a);
-      if (a) {
-        if (path
-            && !os._isSep(a[0])) {
-          path += os.sep;
-        }
-        path += a;
-      }
-    }
-    if (!path) {
-      return '.';
+Buffer.prototype.slice = Buffer.prototype.subarray;
+
+function swap(b, n) {
+  if (b.length % n !== 0) {
+    var e = new RangeError('Buffer size must be a multiple of ' + n * 8 + '-bits');
+    e.code = 'ERR_INVALID_BUFFER_SIZE';
+    throw e;
+  }
+  for (var i = 0; i < b.length; i += n) {
+    for (var j = 0; j < n / 2; j++) {
+      var c = b[i + j];
+      b[i + j] = b[i + n - 1 - j];
+      b[i + n - 1 - j] = c;
     }
-    return os.normalize(path);
-  };
+  }
+  return b;
 }
 
-posix.join = _join(posix);
-win32.join = _join(win32);
+Buffer.prototype.swap16 = function swap16() {
+  return swap(this, 2);
+};
 
-function _normalize(os) {
-  return function normalize(path) {
-    assert(path);
-    // volume
-    var vol = os._volname(path);
-    if (vol === path) {
-      if (!vol) {
-        return '.';
-      }
+Buffer.prototype.swap32 = function swap32() {
+  return swap(this, 4);
+};
+
+Buffer.prototype.swap64 = function swap64() {
+  return swap(this, 8);
+};
+
+//
+// read/write
+//
+
+function readInt(b, offset, n, le, signed) {
+  validateNumber(offset, 'offset');
+  if (b[offset] === undefined
+      || b[offset + n - 1] === undefined) {
+    boundsError(offset, b.length - n);
+  }
+
+  var v = 0;
+  for (var i = 0; i < n; i++) {
+    v += (b[offset + (le ? i : n - 1 - i)] & 0xff) * Math.pow(2, 8 * i);
+  }
+  if (signed
+      && v >= Math.pow(2, 8 * n - 1)) {
+    v -= Math.pow(2, 8 * n);
+  }
+  return v;
+}
+
+function writeInt(b, value, offset, n, le, signed) {
+  value = +value;
+  var min = signed ? -Math.pow(2, 8 * n - 1) : 0;
+  var max = signed ? Math.pow(2, 8 * n - 1) - 1 : Math.pow(2, 8 * n) - 1;
+  if (n === 1) {
+    validateNumber(offset, 'offset');
+  }
+  if (value > max
+      || value < min) {
+    var range;
+    if (n > 4) {
+      range = signed ? '>= -(2 ** ' + (8 * n - 1) + ') and < 2 ** ' + (8 * n - 1) : '>= 0 and < 2 ** ' + 8 * n;
+    } else {
+      range = '>= ' + min + ' and <= ' + max;
+    }
+    throw outOfRange('value', range, value);
+  }
+  validateNumber(offset, 'offset');
+  if (b[offset] === undefined
+      || b[offset + n - 1] === undefined) {
+    boundsError(offset, b.length - n);
+  }
+
+  value = value === value ? trunc(value) : 0;
+  if (value < 0) {
+    value += Math.pow(2, 8 * n);
+  }
+  for (var i = 0; i < n; i++) {
+    b[offset + (le ? i : n - 1 - i)] = Math.floor(value / Math.pow(2, 8 * i)) % 256;
+  }
+  return offset + n;
+}
+
+function validateByteLength(offset, byteLength) {
+  if (offset === undefined) {
+    throw invalidArgType('offset', 'of type number', offset);
+  } else if (typeof byteLength !== 'number'
+             || !(byteLength >= 1 && byteLength <= 6 && byteLength % 1 === 0)) {
+    boundsError(byteLength, 6, 'byteLength');
+  }
+}
+
+[
+  ['UInt8', 1, false],
+  ['UInt16', 2, false],
+  ['UInt32', 4, false],
+  ['Int8', 1, true],
+  ['Int16', 2, true],
+  ['Int32', 4, true],
+].forEach(function(a) {
+  var name = a[0];
+  var n = a[1];
+  var signed = a[2];
+  [n === 1 ? [''] : ['LE', true], n === 1 ? null : ['BE', false]].forEach(function(o) {
+    if (o) {
+      var le = n === 1 || o[1];
+      Buffer.prototype['read' + name + o[0]] = function(offset) {
+        return readInt(this, offset === undefined ? 0 : offset, n, le, signed);
+      };
+      Buffer.prototype['write' + name + o[0]] = function(value, offset) {
+        return writeInt(this, value, offset === undefined ? 0 : offset, n, le, signed);
+      };
+    }
+  });
+});
+
+['LE', 'BE'].forEach(function(o) {
+  var le = o === 'LE';
+  Buffer.prototype['readUInt' + o] = function(offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return readInt(this, offset, byteLength, le, false);
+  };
+  Buffer.prototype['readInt' + o] = function(offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return readInt(this, offset, byteLength, le, true);
+  };
+  Buffer.prototype['writeUInt' + o] = function(value, offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return writeInt(this, value, offset, byteLength, le, false);
+  };
+  Buffer.prototype['writeInt' + o] = function(value, offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return writeInt(this, value, offset, byteLength, le, true);
+  };
+
+  [['Float', 4], ['Double', 8]].forEach(function(a) {
+    var n = a[1];
+    Buffer.prototype['read' + a[0] + o] = function(offset) {
+      if (offset === undefined) {
+        offset = 0;
+      }
+      validateNumber(offset, 'offset');
+      if (this[offset] === undefined
+          || this[offset + n - 1] === undefined) {
+        boundsError(offset, this.length - n);
+      }
+      return binding.readFloat(this, offset, n, le);
+    };
+    Buffer.prototype['write' + a[0] + o] = function(value, offset) {
+      if (offset === undefined) {
+        offset = 0;
+      }
+      value = +value;
+      validateNumber(offset, 'offset');
+      if (this[offset] === undefined
+          || this[offset + n - 1] === undefined) {
+        boundsError(offset, this.length - n);
+      }
+      binding.writeFloat(this, value, offset, n, le);
+      return offset + n;
+    };
+  });
+});
+
+Object.getOwnPropertyNames(Buffer.prototype).forEach(function(k) {
+  if (/^(read|write)UInt/.test(k)) {
+    Buffer.prototype[k.replace('UInt', 'Uint')] = Buffer.prototype[k];
+  }
+});
+`),
+	"child_process.js": []byte(`//
+// otto.module :: child_process.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('child_process');
+var EventEmitter = require('./events');
+var util = require('./internal/util');
+
+var MAX_BUFFER = 1024 * 1024;
+
+//
+// errors
+//
+
+function errnoException(ret, syscall, file, args) {
+  var e = new Error(syscall + ' ' + file + ' ' + ret.error);
+  e.errno = ret.errno;
+  e.code = ret.error;
+  e.syscall = syscall + ' ' + file;
+  e.path = file;
+  e.spawnargs = args.slice(1);
+  return e;
+}
+
+function validateString(v, name) {
+  if (typeof v !== 'string') {
+    throw util.invalidArgType(name, 'of type string', v);
+  }
+}
+
+function validateObject(v, name) {
+  if (v === null
+      || typeof v !== 'object'
+      || Array.isArray(v)) {
+    throw util.invalidArgType(name, 'of type object', v);
+  }
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function validateTimeout(v) {
+  if (v !== undefined
+      && !(typeof v === 'number' && Math.floor(v) === v && v >= 0)) {
+    throw util.outOfRange('timeout', 'an unsigned integer', v);
+  }
+}
+
+function validateMaxBuffer(v) {
+  if (v !== undefined
+      && !(typeof v === 'number' && v >= 0)) {
+    throw util.outOfRange('options.maxBuffer', 'a positive number', v);
+  }
+}
+
+function assign(dst, src) {
+  Object.keys(src).forEach(function(k) {
+    dst[k] = src[k];
+  });
+  return dst;
+}
+
+function decode(buf, enc) {
+  if (buf
+      && enc
+      && enc !== 'buffer') {
+    return buf.toString(util.normalizeEncoding(enc) || 'utf8');
+  }
+  return buf;
+}
+
+//
+// arguments
+//
+
+function normalizeSpawnArguments(file, args, options) {
+  validateString(file, 'file');
+  if (file.length === 0) {
+    var e = new TypeError("The argument 'file' cannot be empty. Received ''");
+    e.code = 'ERR_INVALID_ARG_VALUE';
+    throw e;
+  }
+  if (Array.isArray(args)) {
+    args = args.slice();
+  } else if (args === undefined
+             || args === null) {
+    args = [];
+  } else if (typeof args !== 'object') {
+    throw util.invalidArgType('args', 'an instance of Array', args);
+  } else {
+    options = args;
+    args = [];
+  }
+  if (options === undefined) {
+    options = {};
+  } else {
+    validateObject(options, 'options');
+  }
+  options = assign({}, options || {});
+
+  if (options.cwd !== undefined
+      && options.cwd !== null) {
+    validateString(options.cwd, 'options.cwd');
+  }
+  validateTimeout(options.timeout);
+  validateMaxBuffer(options.maxBuffer);
+
+  var command;
+  if (options.shell) {
+    command = [file].concat(args).join(' ');
+    if (process.platform === 'win32') {
+      file = typeof options.shell === 'string' ? options.shell : process.env.ComSpec || 'cmd.exe';
+      args = ['/d', '/s', '/c', '"' + command + '"'];
+    } else {
+      file = typeof options.shell === 'string' ? options.shell : '/bin/sh';
+      args = ['-c', command];
+    }
+  }
+
+  var env;
+  if (options.env) {
+    env = Object.keys(options.env).filter(function(k) {
+      return options.env[k] !== undefined;
+    }).map(function(k) {
+      return k + '=' + options.env[k];
+    });
+  }
+
+  if (typeof options.stdio === 'string') {
+    options.stdio = [options.stdio, options.stdio, options.stdio];
+  }
+  if (Array.isArray(options.stdio)) {
+    options.stdio = options.stdio.map(function(s) {
+      if (s === null
+          || s === undefined) {
+        return 'pipe';
+      } else if (s === 'pipe'
+                 || s === 'inherit'
+                 || s === 'ignore') {
+        return s;
+      } else if (s === process.stdout
+                 || s === process.stderr
+                 || s === 1
+                 || s === 2) {
+        return 'inherit';
+      }
+      throw util.invalidArgValue('stdio', s);
+    });
+  }
+
+  return {
+    file: file,
+    args: [file].concat(args),
+    options: options,
+    binding: {
+      cwd: options.cwd,
+      env: env,
+      input: options.input === undefined ? undefined : Buffer.from(options.input),
+      timeout: options.timeout,
+      maxBuffer: options.maxBuffer,
+      killSignal: options.killSignal,
+      stdio: options.stdio,
+      shell: command,
+    },
+  };
+}
+
+function normalizeExecArgs(command, options, callback) {
+  validateString(command, 'command');
+  if (typeof options === 'function') {
+    callback = options;
+    options = undefined;
+  }
+  options = assign({}, options || {});
+  options.shell = typeof options.shell === 'string' ? options.shell : true;
+  return {
+    file: command,
+    options: options,
+    callback: callback,
+  };
+}
+
+//
+// sync
+//
+
+function spawnSync(file, args, options) {
+  return spawnSyncImpl(normalizeSpawnArguments(file, args, options));
+}
+
+function spawnSyncImpl(opts) {
+  if (opts.options.maxBuffer === undefined) {
+    opts.binding.maxBuffer = MAX_BUFFER;
+  }
+  var ret = binding.spawnSync(opts.file, opts.args.slice(1), opts.binding);
+
+  var result = {
+    status: ret.status,
+    signal: ret.signal,
+    output: null,
+    pid: ret.pid,
+    stdout: null,
+    stderr: null,
+  };
+  if (ret.pid !== 0) {
+    result.stdout = decode(ret.stdout, opts.options.encoding);
+    result.stderr = decode(ret.stderr, opts.options.encoding);
+    result.output = [null, result.stdout, result.stderr];
+  }
+  if (ret.error) {
+    result = assign({ error: errnoException(ret, 'spawnSync', opts.file, opts.args) }, result);
+  }
+  return result;
+}
+
+function checkExecSyncError(ret, args, cmd) {
+  var e;
+  if (ret.error) {
+    e = ret.error;
+    assign(e, ret);
+  } else if (ret.status !== 0) {
+    var msg = 'Command failed: ' + (cmd || args.join(' '));
+    if (ret.stderr
+        && ret.stderr.length > 0) {
+      msg += '\n' + ret.stderr.toString();
+    }
+    e = assign(new Error(msg), ret);
+  }
+  return e;
+}
+
+function execSyncImpl(opts, cmd) {
+  if (opts.options.stdio === undefined) {
+    // stderr is written to the parent
+    opts.binding.stdio = ['pipe', 'pipe', 'inherit'];
+  }
+  var ret = spawnSyncImpl(opts);
+
+  var e = checkExecSyncError(ret, opts.args, cmd);
+  if (e) {
+    throw e;
+  }
+  return ret.stdout;
+}
+
+function execFileSync(file, args, options) {
+  return execSyncImpl(normalizeSpawnArguments(file, args, options));
+}
+
+function execSync(command, options) {
+  var opts = normalizeExecArgs(command, options);
+  return execSyncImpl(normalizeSpawnArguments(opts.file, opts.options), command);
+}
+
+exports.spawnSync = spawnSync;
+exports.execFileSync = execFileSync;
+exports.execSync = execSync;
+
+//
+// ChildProcess
+//
+
+function ChildProcess() {
+  EventEmitter.call(this);
+
+  this.connected = false;
+  this.signalCode = null;
+  this.exitCode = null;
+  this.killed = false;
+  this.spawnfile = null;
+  this.spawnargs = [];
+  this.pid = undefined;
+  this.stdin = null;
+  this.stdout = null;
+  this.stderr = null;
+  this.stdio = [null, null, null];
+  Object.defineProperty(this, '_handle', {
+    value: null,
+    writable: true,
+    configurable: true,
+  });
+}
+
+ChildProcess.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: ChildProcess,
+    writable: true,
+    configurable: true,
+  },
+});
+
+ChildProcess.prototype.kill = function kill(sig) {
+  if (!this._handle) {
+    return false;
+  }
+  if (this._handle.kill(sig)) {
+    this.killed = true;
+    return true;
+  }
+  return false;
+};
+
+exports.ChildProcess = ChildProcess;
+
+function flushStdio(child) {
+  // unconsumed output would otherwise keep the child from closing
+  for (var i = 1; i < child.stdio.length; i++) {
+    var stream = child.stdio[i];
+    if (stream
+        && stream.readable
+        && !stream._readableState.readableListening
+        && stream.readableFlowing === null) {
+      stream.resume();
+    }
+  }
+}
+
+function spawn(file, args, options) {
+  var opts = normalizeSpawnArguments(file, args, options);
+  opts.binding.stream = true;
+
+  var child = new ChildProcess();
+  child.spawnfile = opts.file;
+  child.spawnargs = opts.args;
+
+  var closes = 1;
+  function maybeClose() {
+    if (--closes === 0) {
+      child.emit('close', child.exitCode, child.signalCode);
+    }
+  }
+
+  var handle = binding.spawn(opts.file, opts.args.slice(1), opts.binding, function onexit(ret) {
+    child._handle = null;
+    if (ret.error === 'ETIMEDOUT') {
+      child.killed = true;
+    }
+    child.exitCode = ret.status;
+    child.signalCode = ret.signal;
+    child.emit('exit', ret.status, ret.signal);
+    process.nextTick(flushStdio, child);
+    maybeClose();
+  });
+  if (handle.error) {
+    var e = errnoException(handle, 'spawn', opts.file, opts.args);
+    child.exitCode = handle.errno;
+    process.nextTick(function() {
+      child.emit('error', e);
+      maybeClose();
+    });
+    return child;
+  }
+
+  child.pid = handle.pid;
+  child._handle = handle;
+  child.stdin = handle.stdin;
+  child.stdout = handle.stdout;
+  child.stderr = handle.stderr;
+  child.stdio = [child.stdin, child.stdout, child.stderr];
+  [child.stdout, child.stderr].forEach(function(stream) {
+    if (stream) {
+      closes++;
+      stream.on('close', maybeClose);
+    }
+  });
+  return child;
+}
+
+//
+// async
+//
+
+function execFile(file, args, options, callback) {
+  if (typeof args === 'function') {
+    callback = args;
+    args = undefined;
+    options = undefined;
+  } else if (typeof options === 'function') {
+    callback = options;
+    options = undefined;
+  }
+  if (!Array.isArray(args)
+      && args !== null
+      && typeof args === 'object') {
+    options = args;
+    args = undefined;
+  }
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  options = assign({
+    encoding: 'utf8',
+    timeout: 0,
+    maxBuffer: MAX_BUFFER,
+    killSignal: 'SIGTERM',
+  }, options || {});
+
+  var opts = normalizeSpawnArguments(file, args, options);
+  opts.binding.stdio = ['ignore', 'pipe', 'pipe'];
+  var cmd = [file].concat(args || []).join(' ');
+
+  var child = new ChildProcess();
+  child.spawnfile = opts.file;
+  child.spawnargs = opts.args;
+
+  function done(e, stdout, stderr) {
+    if (callback) {
+      callback(e, stdout, stderr);
+    }
+  }
+
+  var handle = binding.spawn(opts.file, opts.args.slice(1), opts.binding, function onexit(ret) {
+    child._handle = null;
+    var stdout = decode(ret.stdout, options.encoding);
+    var stderr = decode(ret.stderr, options.encoding);
+    var e = null;
+    switch (ret.error) {
+    case 'ETIMEDOUT':
+      child.killed = true;
+      break;
+    case 'ENOBUFS':
+      e = new RangeError('stdout maxBuffer length exceeded');
+      e.code = 'ERR_CHILD_PROCESS_STDIO_MAXBUFFER';
+      child.killed = true;
+      break;
+    }
+    child.exitCode = ret.status;
+    child.signalCode = ret.signal;
+    if (!e
+        && (ret.status !== 0 || ret.signal)) {
+      e = new Error('Command failed: ' + cmd + '\n' + (ret.stderr ? ret.stderr.toString() : ''));
+      e.code = ret.status;
+      e.killed = child.killed;
+      e.signal = ret.signal;
+      e.cmd = cmd;
+    }
+    child.emit('exit', ret.status, ret.signal);
+    child.emit('close', ret.status, ret.signal);
+    done(e, stdout, stderr);
+  });
+  if (handle.error) {
+    var e = errnoException(handle, 'spawn', opts.file, opts.args);
+    e.cmd = cmd;
+    process.nextTick(function() {
+      if (!callback
+          || child.listenerCount('error') > 0) {
+        child.emit('error', e);
+      }
+      done(e, decode(Buffer.alloc(0), options.encoding), decode(Buffer.alloc(0), options.encoding));
+    });
+  } else {
+    child.pid = handle.pid;
+    child._handle = handle;
+  }
+  return child;
+}
+
+function exec(command, options, callback) {
+  var opts = normalizeExecArgs(command, options, callback);
+  return execFile(opts.file, opts.options, opts.callback);
+}
+
+exports.spawn = spawn;
+exports.execFile = execFile;
+exports.exec = exec;
+`),
+	"console.js": []byte(`//
+// otto.module :: console.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('console');
+var _inspect = require('./internal/util/inspect');
+var util = require('./internal/util');
+
+var inspect = _inspect.inspect;
+var formatWithOptions = _inspect.formatWithOptions;
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+
+var stderrMethods = {
+  warn: true,
+  error: true,
+  trace: true,
+  assert: true,
+};
+
+function writableStream(name) {
+  var e = new TypeError('Console expects a writable stream instance for ' + name);
+  e.code = 'ERR_CONSOLE_WRITABLE_STREAM';
+  return e;
+}
+
+function hidden(o, k, v) {
+  Object.defineProperty(o, k, {
+    value: v,
+    writable: true,
+    configurable: true,
+  });
+}
+
+function repeat(s, n) {
+  return new Array(n + 1).join(s);
+}
+
+function stringWidth(s) {
+  var n = 0;
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charCodeAt(i);
+    if (c >= 0xd800 && c <= 0xdbff) {
+      i++;
+      n += 2;
+    } else if ((c >= 0x1100 && c <= 0x115f)
+               || (c >= 0x2e80 && c <= 0xa4cf && c !== 0x303f)
+               || (c >= 0xac00 && c <= 0xd7a3)
+               || (c >= 0xf900 && c <= 0xfaff)
+               || (c >= 0xfe30 && c <= 0xfe4f)
+               || (c >= 0xff00 && c <= 0xff60)
+               || (c >= 0xffe0 && c <= 0xffe6)) {
+      n += 2;
+    } else if (c >= 0x20
+               && !(c >= 0x7f && c <= 0x9f)
+               && !(c >= 0x300 && c <= 0x36f)
+               && c !== 0x200b) {
+      n++;
+    }
+  }
+  return n;
+}
+
+function formatTime(ms) {
+  var hours = 0;
+  var minutes = 0;
+  var seconds = 0;
+  if (ms >= 1000) {
+    if (ms >= 60000) {
+      if (ms >= 3600000) {
+        hours = Math.floor(ms / 3600000);
+        ms %= 3600000;
+      }
+      minutes = Math.floor(ms / 60000);
+      ms %= 60000;
+    }
+    seconds = ms / 1000;
+  }
+  if (hours !== 0
+      || minutes !== 0) {
+    var a = seconds.toFixed(3).split('.');
+    var pad = function(n) {
+      return ('0' + n).slice(-2);
+    };
+    return (hours !== 0 ? hours + ':' + pad(minutes) : minutes) + ':' + pad(a[0]) + '.' + a[1] + ' (' + (hours !== 0 ? 'h:m' : '') + 'm:ss.mmm)';
+  } else if (seconds !== 0) {
+    return seconds.toFixed(3) + 's';
+  }
+  return Number(ms.toFixed(3)) + 'ms';
+}
+
+function renderRow(row, widths) {
+  var out = '│ ';
+  for (var i = 0; i < row.length; i++) {
+    out += row[i] + repeat(' ', widths[i] - stringWidth(row[i]));
+    if (i !== row.length - 1) {
+      out += ' │ ';
+    }
+  }
+  return out + ' │';
+}
+
+function renderTable(head, columns) {
+  var rows = [];
+  var widths = head.map(stringWidth);
+  var longest = Math.max.apply(Math, columns.map(function(a) {
+    return a.length;
+  }));
+  for (var i = 0; i < head.length; i++) {
+    for (var j = 0; j < longest; j++) {
+      if (rows[j] === undefined) {
+        rows[j] = [];
+      }
+      var v = rows[j][i] = hasOwnProperty.call(columns[i], j) ? columns[i][j] : '';
+      widths[i] = Math.max(widths[i], stringWidth(v));
+    }
+  }
+  var divider = widths.map(function(n) {
+    return repeat('─', n + 2);
+  });
+  var s = '┌' + divider.join('┬') + '┐\n'
+        + renderRow(head, widths) + '\n'
+        + '├' + divider.join('┼') + '┤\n';
+  rows.forEach(function(row) {
+    s += renderRow(row, widths) + '\n';
+  });
+  return s + '└' + divider.join('┴') + '┘';
+}
+
+//
+// Console
+//
+
+function Console(options) {
+  if (!(this instanceof Console)) {
+    var self = Object.create(Console.prototype);
+    Console.apply(self, arguments);
+    return self;
+  }
+
+  if (!options
+      || typeof options.write === 'function') {
+    options = {
+      stdout: options,
+      stderr: arguments[1],
+      ignoreErrors: arguments[2],
+    };
+  }
+  var stdout = options.stdout;
+  var stderr = options.stderr || stdout;
+  if (!stdout
+      || typeof stdout.write !== 'function') {
+    throw writableStream('stdout');
+  } else if (typeof stderr.write !== 'function') {
+    throw writableStream('stderr');
+  }
+  var groupIndentation = options.groupIndentation === undefined ? 2 : options.groupIndentation;
+  if (typeof groupIndentation !== 'number') {
+    throw util.invalidArgType('groupIndentation', 'of type number', groupIndentation);
+  } else if (Math.floor(groupIndentation) !== groupIndentation
+             || groupIndentation < 0
+             || groupIndentation > 1000) {
+    throw util.outOfRange('groupIndentation', '>= 0 && <= 1000', groupIndentation);
+  }
+
+  hidden(this, '_stdout', stdout);
+  hidden(this, '_stderr', stderr);
+  hidden(this, '_ignoreErrors', options.ignoreErrors !== false);
+  hidden(this, '_inspectOptions', options.inspectOptions);
+  init(this, groupIndentation);
+}
+
+function init(console, groupIndentation) {
+  hidden(console, '_groupIndent', '');
+  hidden(console, '_groupIndentation', groupIndentation);
+  hidden(console, '_counts', Object.create(null));
+  hidden(console, '_times', Object.create(null));
+  // bind methods
+  for (var k in Console.prototype) {
+    if (typeof Console.prototype[k] !== 'function') {
+      continue;
+    } else if (k.charAt(0) === '_') {
+      if (!(console instanceof Console)) {
+        hidden(console, k, Console.prototype[k]);
+      }
+    } else {
+      console[k] = Console.prototype[k].bind(console);
+    }
+  }
+}
+
+Console.prototype._write = function _write(method, s) {
+  if (this._groupIndent) {
+    s = this._groupIndent + s.replace(/\n/g, '\n' + this._groupIndent);
+  }
+  var stream = stderrMethods[method] ? this._stderr : this._stdout;
+  if (!this._ignoreErrors) {
+    stream.write(s + '\n');
+    return;
+  }
+  try {
+    stream.write(s + '\n');
+  } catch (e) {
+    // ignore
+  }
+};
+
+Console.prototype._format = function _format(args) {
+  return formatWithOptions.apply(undefined, [this._inspectOptions].concat(Array.prototype.slice.call(args)));
+};
+
+Console.prototype.log = function log() {
+  this._write('log', this._format(arguments));
+};
+
+Console.prototype.info = function info() {
+  this._write('info', this._format(arguments));
+};
+
+Console.prototype.debug = function debug() {
+  this._write('debug', this._format(arguments));
+};
+
+Console.prototype.warn = function warn() {
+  this._write('warn', this._format(arguments));
+};
+
+Console.prototype.error = function error() {
+  this._write('error', this._format(arguments));
+};
+
+Console.prototype.dirxml = Console.prototype.log;
+
+Console.prototype.dir = function dir(obj, options) {
+  var opts = {};
+  var k;
+  for (k in this._inspectOptions) {
+    if (hasOwnProperty.call(this._inspectOptions, k)) {
+      opts[k] = this._inspectOptions[k];
+    }
+  }
+  opts.customInspect = false;
+  for (k in options) {
+    if (hasOwnProperty.call(options, k)) {
+      opts[k] = options[k];
+    }
+  }
+  this._write('dir', inspect(obj, opts));
+};
+
+Console.prototype.table = function table(data, properties) {
+  if (properties !== undefined
+      && !Array.isArray(properties)) {
+    throw util.invalidArgType('properties', 'an instance of Array', properties);
+  }
+  if (data === null
+      || typeof data !== 'object') {
+    this._write('table', this._format([data]));
+    return;
+  }
+
+  var fmt = function(v) {
+    var depth = v !== null && typeof v === 'object' && !Array.isArray(v) && Object.keys(v).length > 2 ? -1 : 0;
+    return inspect(v, {
+      depth: depth,
+      maxArrayLength: 3,
+      breakLength: Infinity,
+    });
+  };
+  var map = Object.create(null);
+  var keys = [];
+  var primitives = false;
+  var values = [];
+  var index = Object.keys(data);
+  index.forEach(function(k, i) {
+    var item = data[k];
+    var primitive = item === null || (typeof item !== 'function' && typeof item !== 'object');
+    if (properties === undefined
+        && primitive) {
+      primitives = true;
+      values[i] = fmt(item);
+    } else {
+      (properties || Object.keys(item)).forEach(function(key) {
+        if (!map[key]) {
+          map[key] = [];
+          keys.push(key);
+        }
+        map[key][i] = primitive || !hasOwnProperty.call(item, key) ? '' : fmt(item[key]);
+      });
+    }
+  });
+  var columns = keys.map(function(k) {
+    return map[k];
+  });
+  if (primitives) {
+    keys.push('Values');
+    columns.push(values);
+  }
+  this._write('table', renderTable(['(index)'].concat(keys), [index].concat(columns)));
+};
+
+Console.prototype.time = function time(label) {
+  label = label === undefined ? 'default' : String(label);
+  if (hasOwnProperty.call(this._times, label)) {
+    process.emitWarning("Label '" + label + "' already exists for console.time()");
+    return;
+  }
+  this._times[label] = binding.now();
+};
+
+Console.prototype.timeEnd = function timeEnd(label) {
+  label = label === undefined ? 'default' : String(label);
+  if (this._timeLog('timeEnd', label, [])) {
+    delete this._times[label];
+  }
+};
+
+Console.prototype.timeLog = function timeLog(label) {
+  label = label === undefined ? 'default' : String(label);
+  this._timeLog('timeLog', label, Array.prototype.slice.call(arguments, 1));
+};
+
+Console.prototype._timeLog = function _timeLog(method, label, data) {
+  if (!hasOwnProperty.call(this._times, label)) {
+    process.emitWarning("No such label '" + label + "' for console." + method + '()');
+    return false;
+  }
+  var s = label + ': ' + formatTime(binding.now() - this._times[label]);
+  this._write(method, this._format([s].concat(data)));
+  return true;
+};
+
+Console.prototype.count = function count(label) {
+  label = label === undefined ? 'default' : String(label);
+  var n = this._counts[label] = (this._counts[label] || 0) + 1;
+  this._write('count', label + ': ' + n);
+};
+
+Console.prototype.countReset = function countReset(label) {
+  label = label === undefined ? 'default' : String(label);
+  if (!hasOwnProperty.call(this._counts, label)) {
+    process.emitWarning("Count for '" + label + "' does not exist");
+    return;
+  }
+  delete this._counts[label];
+};
+
+Console.prototype.assert = function assert(expression) {
+  if (!expression) {
+    var args = Array.prototype.slice.call(arguments, 1);
+    args[0] = 'Assertion failed' + (args.length === 0 ? '' : ': ' + args[0]);
+    this._write('assert', this._format(args));
+  }
+};
+
+Console.prototype.group = function group() {
+  if (arguments.length > 0) {
+    this._write('group', this._format(arguments));
+  }
+  this._groupIndent += repeat(' ', this._groupIndentation);
+};
+
+Console.prototype.groupCollapsed = Console.prototype.group;
+
+Console.prototype.groupEnd = function groupEnd() {
+  this._groupIndent = this._groupIndent.slice(0, this._groupIndent.length - this._groupIndentation);
+};
+
+Console.prototype.trace = function trace() {
+  var s = 'Trace';
+  if (arguments.length > 0) {
+    s += ': ' + this._format(arguments);
+  }
+  var stack = String(new Error().stack).split('\n').slice(2).filter(Boolean);
+  if (stack.length > 0) {
+    s += '\n' + stack.join('\n');
+  }
+  this._write('trace', s);
+};
+
+//
+// global console
+//
+
+var globalConsole = {};
+init(globalConsole, 2);
+
+hidden(globalConsole, '_write', function _write(method, s) {
+  binding.write(method, s, this._groupIndent);
+});
+
+globalConsole.Console = Console;
+
+module.exports = globalConsole;
+`),
+	"crypto.js": []byte(`//
+// otto.module :: crypto.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('crypto');
+var Buffer = require('./buffer').Buffer;
+var StringDecoder = require('./string_decoder').StringDecoder;
+var util = require('./internal/util');
+
+var RAND_MAX = 0xffffffffffff;
+var MAX_SAFE_INTEGER = 9007199254740991;
+
+//
+// errors
+//
+
+function hashFinalized() {
+  var e = new Error('Digest already called');
+  e.code = 'ERR_CRYPTO_HASH_FINALIZED';
+  return e;
+}
+
+function invalidDigest(name) {
+  var e = new TypeError('Invalid digest: ' + name);
+  e.code = 'ERR_CRYPTO_INVALID_DIGEST';
+  return e;
+}
+
+function invalidState(op) {
+  var e = new Error('Invalid state for operation ' + op);
+  e.code = 'ERR_CRYPTO_INVALID_STATE';
+  return e;
+}
+
+function invalidAuthTag(n) {
+  var e = new TypeError('Invalid authentication tag length: ' + n);
+  e.code = 'ERR_CRYPTO_INVALID_AUTH_TAG';
+  return e;
+}
+
+function validateString(v, name) {
+  if (typeof v !== 'string') {
+    throw util.invalidArgType(name, 'of type string', v);
+  }
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function validateInt32(v, name, min, max) {
+  if (typeof v !== 'number') {
+    throw util.invalidArgType(name, 'of type number', v);
+  } else if (Math.floor(v) !== v) {
+    throw util.outOfRange(name, 'an integer', v);
+  } else if (!(v >= min && v <= max)) {
+    throw util.outOfRange(name, '>= ' + min + ' && <= ' + max, v);
+  }
+}
+
+function validateSafeInteger(v, name) {
+  if (typeof v !== 'number'
+      || Math.floor(v) !== v
+      || Math.abs(v) > MAX_SAFE_INTEGER) {
+    throw util.invalidArgType(name, 'a safe integer', v);
+  }
+}
+
+function hidden(o, k, v) {
+  Object.defineProperty(o, k, {
+    value: v,
+    writable: true,
+    configurable: true,
+  });
+}
+
+function toBuffer(data, enc, name, type) {
+  if (typeof data === 'string') {
+    enc = util.normalizeEncoding(enc) || 'utf8';
+    if (enc === 'hex'
+        && data.length % 2 !== 0) {
+      var e = new TypeError("The argument 'encoding' is invalid for data of length " + data.length + '. Received ' + "'hex'");
+      e.code = 'ERR_INVALID_ARG_VALUE';
+      throw e;
+    }
+    return Buffer.from(data, enc);
+  } else if (!Buffer.isBuffer(data)) {
+    throw util.invalidArgType(name, type, data);
+  }
+  return data;
+}
+
+var KEY_TYPE = 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, DataView, KeyObject, or CryptoKey';
+var DATA_TYPE = 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, or DataView';
+
+function encode(buf, enc) {
+  if (enc === undefined
+      || enc === 'buffer') {
+    return buf;
+  }
+  return buf.toString(util.normalizeEncoding(enc) || 'utf8');
+}
+
+//
+// Hash
+//
+
+function Hash(algorithm, options) {
+  if (!(this instanceof Hash)) {
+    return new Hash(algorithm, options);
+  }
+
+  var handle;
+  if (algorithm instanceof Hash) {
+    handle = algorithm._handle.copy();
+  } else {
+    validateString(algorithm, 'algorithm');
+    handle = binding.createHash(algorithm);
+    if (!handle) {
+      throw new Error('Digest method not supported');
+    }
+  }
+  hidden(this, '_handle', handle);
+  hidden(this, '_finalized', false);
+}
+
+Hash.prototype.copy = function copy() {
+  if (this._finalized) {
+    throw hashFinalized();
+  }
+  return new Hash(this);
+};
+
+Hash.prototype.update = function update(data, inputEncoding) {
+  if (this._finalized) {
+    throw hashFinalized();
+  }
+  this._handle.update(toBuffer(data, inputEncoding, 'data', 'of type string or an instance of Buffer, TypedArray, or DataView'));
+  return this;
+};
+
+Hash.prototype.digest = function digest(outputEncoding) {
+  if (this._finalized) {
+    throw hashFinalized();
+  }
+  this._finalized = true;
+  return encode(this._handle.digest(), outputEncoding);
+};
+
+//
+// Hmac
+//
+
+function Hmac(hmac, key, options) {
+  if (!(this instanceof Hmac)) {
+    return new Hmac(hmac, key, options);
+  }
+
+  validateString(hmac, 'hmac');
+  key = toBuffer(key, options && options.encoding, 'key', KEY_TYPE);
+  var handle = binding.createHmac(hmac, key);
+  if (!handle) {
+    throw invalidDigest(hmac);
+  }
+  hidden(this, '_handle', handle);
+  hidden(this, '_finalized', false);
+}
+
+Hmac.prototype.update = Hash.prototype.update;
+
+Hmac.prototype.digest = function digest(outputEncoding) {
+  if (this._finalized) {
+    return encode(Buffer.alloc(0), outputEncoding);
+  }
+  this._finalized = true;
+  return encode(this._handle.digest(), outputEncoding);
+};
+
+exports.Hash = Hash;
+exports.Hmac = Hmac;
+
+exports.createHash = function createHash(algorithm, options) {
+  return new Hash(algorithm, options);
+};
+
+exports.createHmac = function createHmac(hmac, key, options) {
+  return new Hmac(hmac, key, options);
+};
+
+exports.getHashes = function getHashes() {
+  return binding.getHashes();
+};
+
+//
+// Cipheriv, Decipheriv
+//
+
+function Cipher(cipher, key, iv, options, decrypt) {
+  validateString(cipher, 'cipher');
+  key = toBuffer(key, options && options.encoding, 'key', KEY_TYPE);
+  if (iv !== null) {
+    iv = toBuffer(iv, options && options.encoding, 'iv', 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, or DataView');
+  }
+
+  var info = binding.getCipherInfo(cipher);
+  if (!info) {
+    var e = new Error('Unknown cipher');
+    e.code = 'ERR_CRYPTO_UNKNOWN_CIPHER';
+    throw e;
+  } else if (key.length !== info.keyLength) {
+    e = new RangeError('Invalid key length');
+    e.code = 'ERR_CRYPTO_INVALID_KEYLEN';
+    throw e;
+  } else if (iv === null
+             || (info.mode === 'gcm' ? iv.length === 0 : iv.length !== info.ivLength)) {
+    e = new TypeError('Invalid initialization vector');
+    e.code = 'ERR_CRYPTO_INVALID_IV';
+    throw e;
+  }
+  var authTagLength = options && options.authTagLength;
+  if (info.mode !== 'gcm'
+      || authTagLength === undefined) {
+    authTagLength = 16;
+  } else if (!(authTagLength === 4
+               || authTagLength === 8
+               || (authTagLength >= 12 && authTagLength <= 16 && Math.floor(authTagLength) === authTagLength))) {
+    throw invalidAuthTag(authTagLength);
+  }
+  hidden(this, '_handle', binding.createCipher(cipher, key, iv, decrypt, authTagLength));
+  hidden(this, '_mode', info.mode);
+  hidden(this, '_authTagLength', options && options.authTagLength);
+  hidden(this, '_decoder', null);
+  hidden(this, '_state', 0);
+}
+
+Cipher.prototype.update = function update(data, inputEncoding, outputEncoding) {
+  if (this._state === 2) {
+    throw new Error('Trying to add data in unsupported state');
+  }
+  this._state = 1;
+  return this._decode(this._handle.update(toBuffer(data, inputEncoding, 'data', DATA_TYPE)), outputEncoding, false);
+};
+
+Cipher.prototype.final = function final(outputEncoding) {
+  if (this._state === 2) {
+    var e = new Error('Invalid state');
+    e.code = 'ERR_CRYPTO_INVALID_STATE';
+    throw e;
+  }
+  this._state = 2;
+  return this._decode(this._handle.final(), outputEncoding, true);
+};
+
+Cipher.prototype.setAutoPadding = function setAutoPadding(autoPadding) {
+  if (this._state === 2) {
+    throw invalidState('setAutoPadding');
+  }
+  this._handle.setAutoPadding(autoPadding !== false);
+  return this;
+};
+
+Cipher.prototype.setAAD = function setAAD(buffer, options) {
+  if (this._mode !== 'gcm'
+      || this._state !== 0) {
+    throw invalidState('setAAD');
+  }
+  this._handle.setAAD(toBuffer(buffer, options && options.encoding, 'buffer', 'an instance of ArrayBuffer, Buffer, TypedArray, or DataView'));
+  return this;
+};
+
+Cipher.prototype._decode = function _decode(buf, enc, end) {
+  if (enc === undefined
+      || enc === 'buffer') {
+    return buf;
+  }
+  enc = util.normalizeEncoding(enc) || 'utf8';
+  if (!this._decoder) {
+    this._decoder = new StringDecoder(enc);
+  } else if (this._decoder.encoding !== enc) {
+    throw new Error('Cannot change encoding');
+  }
+  return end ? this._decoder.end(buf) : this._decoder.write(buf);
+};
+
+function Cipheriv(cipher, key, iv, options) {
+  if (!(this instanceof Cipheriv)) {
+    return new Cipheriv(cipher, key, iv, options);
+  }
+  Cipher.call(this, cipher, key, iv, options, false);
+}
+
+Cipheriv.prototype = Object.create(Cipher.prototype, {
+  constructor: {
+    value: Cipheriv,
+    writable: true,
+    configurable: true,
+  },
+});
+
+Cipheriv.prototype.getAuthTag = function getAuthTag() {
+  if (this._mode !== 'gcm'
+      || this._state !== 2) {
+    throw invalidState('getAuthTag');
+  }
+  return this._handle.getAuthTag();
+};
+
+function Decipheriv(cipher, key, iv, options) {
+  if (!(this instanceof Decipheriv)) {
+    return new Decipheriv(cipher, key, iv, options);
+  }
+  Cipher.call(this, cipher, key, iv, options, true);
+  hidden(this, '_authTag', false);
+}
+
+Decipheriv.prototype = Object.create(Cipher.prototype, {
+  constructor: {
+    value: Decipheriv,
+    writable: true,
+    configurable: true,
+  },
+});
+
+Decipheriv.prototype.setAuthTag = function setAuthTag(tagbuf, encoding) {
+  tagbuf = toBuffer(tagbuf, encoding, 'buffer', 'an instance of Buffer, TypedArray, or DataView');
+  if (this._mode !== 'gcm'
+      || this._authTag) {
+    throw invalidState('setAuthTag');
+  } else if (this._state === 2) {
+    throw new Error('Unsupported state or unable to authenticate data');
+  }
+  var n = tagbuf.length;
+  if (!(n === 4 || n === 8 || (n >= 12 && n <= 16))
+      || (this._authTagLength !== undefined && n !== this._authTagLength)) {
+    throw invalidAuthTag(n);
+  }
+  this._authTag = true;
+  this._handle.setAuthTag(tagbuf);
+  return this;
+};
+
+exports.Cipheriv = Cipheriv;
+exports.Decipheriv = Decipheriv;
+
+exports.createCipheriv = function createCipheriv(cipher, key, iv, options) {
+  return new Cipheriv(cipher, key, iv, options);
+};
+
+exports.createDecipheriv = function createDecipheriv(cipher, key, iv, options) {
+  return new Decipheriv(cipher, key, iv, options);
+};
+
+exports.getCiphers = function getCiphers() {
+  return binding.getCiphers();
+};
+
+exports.getCipherInfo = function getCipherInfo(nameOrNid, options) {
+  validateString(nameOrNid, 'nameOrNid');
+  return binding.getCipherInfo(nameOrNid);
+};
+
+//
+// key derivation
+//
+
+exports.pbkdf2Sync = function pbkdf2Sync(password, salt, iterations, keylen, digest) {
+  password = toBuffer(password, undefined, 'password', DATA_TYPE);
+  salt = toBuffer(salt, undefined, 'salt', DATA_TYPE);
+  validateInt32(iterations, 'iterations', 1, 0x7fffffff);
+  validateInt32(keylen, 'keylen', 0, 0x7fffffff);
+  validateString(digest, 'digest');
+
+  var buf = binding.pbkdf2(password, salt, iterations, keylen, digest);
+  if (!buf) {
+    throw invalidDigest(digest);
+  }
+  return buf;
+};
+
+exports.scryptSync = function scryptSync(password, salt, keylen, options) {
+  password = toBuffer(password, undefined, 'password', DATA_TYPE);
+  salt = toBuffer(salt, undefined, 'salt', DATA_TYPE);
+  validateInt32(keylen, 'keylen', 0, 0x7fffffff);
+
+  var N = 16384;
+  var r = 8;
+  var p = 1;
+  var maxmem = 32 << 20;
+  if (options) {
+    [['N', 'cost'], ['r', 'blockSize'], ['p', 'parallelization']].forEach(function(k) {
+      var v = options[k[0]];
+      if (options[k[1]] !== undefined) {
+        if (v !== undefined) {
+          var e = new Error('Invalid scrypt parameter');
+          e.code = 'ERR_CRYPTO_SCRYPT_INVALID_PARAMETER';
+          throw e;
+        }
+        v = options[k[1]];
+      }
+      if (v !== undefined) {
+        validateInt32(v, k[1], 0, 0x7fffffff);
+        switch (k[0]) {
+        case 'N':
+          N = v;
+          break;
+        case 'r':
+          r = v;
+          break;
+        case 'p':
+          p = v;
+          break;
+        }
+      }
+    });
+    if (options.maxmem !== undefined) {
+      validateSafeInteger(options.maxmem, 'maxmem');
+      maxmem = options.maxmem;
+    }
+  }
+  var msg;
+  if (N < 2
+      || (N & (N - 1)) !== 0
+      || r < 1
+      || p < 1) {
+    msg = 'Invalid scrypt params';
+  } else if (128 * r * (N + 2) + 128 * r * p > maxmem) {
+    msg = 'Invalid scrypt params: error:030000AC:digital envelope routines::memory limit exceeded';
+  }
+  if (msg) {
+    var e = new RangeError(msg);
+    e.code = 'ERR_CRYPTO_INVALID_SCRYPT_PARAMS';
+    throw e;
+  }
+  return binding.scrypt(password, salt, keylen, N, r, p);
+};
+
+exports.hkdfSync = function hkdfSync(digest, ikm, salt, info, keylen) {
+  validateString(digest, 'digest');
+  ikm = toBuffer(ikm, undefined, 'ikm', KEY_TYPE);
+  salt = toBuffer(salt, undefined, 'salt', DATA_TYPE);
+  info = toBuffer(info, undefined, 'info', DATA_TYPE);
+  validateInt32(keylen, 'length', 0, 0x7fffffff);
+  if (info.length > 1024) {
+    throw util.outOfRange('info', 'must not contain more than 1024 bytes', info.length);
+  }
+
+  var buf = binding.hkdf(digest, ikm, salt, info, keylen);
+  if (!buf) {
+    throw invalidDigest(digest);
+  }
+  return buf;
+};
+
+//
+// Sign, Verify
+//
+
+function signArgs(algorithm, data, key) {
+  var options = {};
+  if (key !== null
+      && typeof key === 'object'
+      && !Buffer.isBuffer(key)) {
+    options = key;
+    key = key.key;
+  }
+  key = toBuffer(key, options.encoding, 'key', KEY_TYPE);
+  if (options.dsaEncoding !== undefined
+      && options.dsaEncoding !== 'der'
+      && options.dsaEncoding !== 'ieee-p1363') {
+    var e = new TypeError("The property 'options.dsaEncoding' is invalid. Received '" + options.dsaEncoding + "'");
+    e.code = 'ERR_INVALID_ARG_VALUE';
+    throw e;
+  }
+  return [
+    algorithm === undefined ? null : algorithm,
+    data,
+    key,
+    undefined,
+    options.padding,
+    options.saltLength,
+    options.dsaEncoding,
+  ];
+}
+
+exports.sign = function sign(algorithm, data, key, callback) {
+  if (algorithm !== null
+      && algorithm !== undefined) {
+    validateString(algorithm, 'algorithm');
+  }
+  data = toBuffer(data, undefined, 'data', 'an instance of Buffer, TypedArray, or DataView');
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+
+  var sig = binding.sign.apply(binding, signArgs(algorithm, data, key));
+  if (callback === undefined) {
+    return sig;
+  }
+  process.nextTick(callback, null, sig);
+};
+
+exports.verify = function verify(algorithm, data, key, signature, callback) {
+  if (algorithm !== null
+      && algorithm !== undefined) {
+    validateString(algorithm, 'algorithm');
+  }
+  data = toBuffer(data, undefined, 'data', 'an instance of Buffer, TypedArray, or DataView');
+  signature = toBuffer(signature, undefined, 'signature', 'an instance of ArrayBuffer, Buffer, TypedArray, or DataView');
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+
+  var args = signArgs(algorithm, data, key);
+  args[3] = signature;
+  var ok = binding.verify.apply(binding, args);
+  if (callback === undefined) {
+    return ok;
+  }
+  process.nextTick(callback, null, ok);
+};
+
+function Sign(algorithm, options) {
+  if (!(this instanceof Sign)) {
+    return new Sign(algorithm, options);
+  }
+  initSign(this, algorithm);
+}
+
+function initSign(self, algorithm) {
+  validateString(algorithm, 'algorithm');
+  var handle = binding.createHash(algorithm);
+  if (!handle) {
+    var e = new TypeError('Invalid digest');
+    e.code = 'ERR_CRYPTO_INVALID_DIGEST';
+    throw e;
+  }
+  hidden(self, '_algorithm', algorithm);
+  hidden(self, '_data', []);
+  hidden(self, '_finalized', false);
+}
+
+Sign.prototype.update = function update(data, inputEncoding) {
+  this._data.push(toBuffer(data, inputEncoding, 'data', 'of type string or an instance of Buffer, TypedArray, or DataView'));
+  return this;
+};
+
+Sign.prototype._final = function _final() {
+  if (this._finalized) {
+    var e = new Error('Not initialised');
+    e.code = 'ERR_CRYPTO_INVALID_STATE';
+    throw e;
+  }
+  this._finalized = true;
+  return Buffer.concat(this._data);
+};
+
+Sign.prototype.sign = function sign(privateKey, outputEncoding) {
+  var data = this._final();
+  return encode(binding.sign.apply(binding, signArgs(this._algorithm, data, privateKey)), outputEncoding);
+};
+
+function Verify(algorithm, options) {
+  if (!(this instanceof Verify)) {
+    return new Verify(algorithm, options);
+  }
+  initSign(this, algorithm);
+}
+
+Verify.prototype.update = Sign.prototype.update;
+Verify.prototype._final = Sign.prototype._final;
+
+Verify.prototype.verify = function verify(object, signature, signatureEncoding) {
+  signature = toBuffer(signature, signatureEncoding, 'signature', 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, or DataView');
+  var args = signArgs(this._algorithm, this._final(), object);
+  args[3] = signature;
+  return binding.verify.apply(binding, args);
+};
+
+exports.Sign = Sign;
+exports.Verify = Verify;
+
+exports.createSign = function createSign(algorithm, options) {
+  return new Sign(algorithm, options);
+};
+
+exports.createVerify = function createVerify(algorithm, options) {
+  return new Verify(algorithm, options);
+};
+
+exports.constants = {
+  RSA_PKCS1_PADDING: 1,
+  RSA_PKCS1_PSS_PADDING: 6,
+  RSA_PSS_SALTLEN_DIGEST: -1,
+  RSA_PSS_SALTLEN_MAX_SIGN: -2,
+  RSA_PSS_SALTLEN_AUTO: -2,
+};
+
+//
+// random
+//
+
+exports.randomBytes = function randomBytes(size, callback) {
+  if (typeof size !== 'number') {
+    throw util.invalidArgType('size', 'of type number', size);
+  } else if (!(size >= 0 && size <= 0x7fffffff)) {
+    throw util.outOfRange('size', '>= 0 && <= 2147483647', size);
+  }
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+
+  var buf = binding.randomBytes(size);
+  if (callback === undefined) {
+    return buf;
+  }
+  process.nextTick(callback, null, buf);
+};
+
+exports.randomInt = function randomInt(min, max, callback) {
+  var minNotSpecified = typeof max === 'undefined' || typeof max === 'function';
+  if (minNotSpecified) {
+    callback = max;
+    max = min;
+    min = 0;
+  }
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  validateSafeInteger(min, 'min');
+  validateSafeInteger(max, 'max');
+  if (max <= min) {
+    throw util.outOfRange('max', 'greater than the value of "min" (' + min + ')', max);
+  } else if (!(max - min <= RAND_MAX)) {
+    throw util.outOfRange('max' + (minNotSpecified ? '' : ' - min'), '<= ' + RAND_MAX, max - min);
+  }
+
+  var n = binding.randomInt(min, max);
+  if (callback === undefined) {
+    return n;
+  }
+  process.nextTick(callback, null, n);
+};
+
+exports.randomUUID = function randomUUID() {
+  return binding.randomUUID();
+};
+
+exports.timingSafeEqual = function timingSafeEqual(buf1, buf2) {
+  [buf1, buf2].forEach(function(v, i) {
+    if (!Buffer.isBuffer(v)) {
+      var e = new TypeError('The "buf' + (i + 1) + '" argument must be an instance of ArrayBuffer, Buffer, TypedArray, or DataView.');
+      e.code = 'ERR_INVALID_ARG_TYPE';
+      throw e;
+    }
+  });
+  if (buf1.length !== buf2.length) {
+    var e = new RangeError('Input buffers must have the same byte length');
+    e.code = 'ERR_CRYPTO_TIMING_SAFE_EQUAL_LENGTH';
+    throw e;
+  }
+  return binding.timingSafeEqual(buf1, buf2);
+};
+`),
+	"events.js": []byte(`//
+// otto.module :: events.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var defaultMaxListeners = 10;
+
+function assertListener(listener) {
+  if (typeof listener !== 'function') {
+    throw new TypeError('listener must be a Function');
+  }
+}
+
+function assertMaxListeners(n) {
+  if (typeof n !== 'number'
+      || n < 0
+      || n !== n) {
+    throw new RangeError('n must be a non-negative Number');
+  }
+}
+
+function EventEmitter() {
+  EventEmitter.init.call(this);
+}
+
+Object.defineProperty(EventEmitter, 'defaultMaxListeners', {
+  enumerable: true,
+
+  get: function get() {
+    return defaultMaxListeners;
+  },
+
+  set: function set(n) {
+    assertMaxListeners(n);
+    defaultMaxListeners = n;
+  },
+});
+
+EventEmitter.init = function init() {
+  if (!this._events
+      || this._events === Object.getPrototypeOf(this)._events) {
+    this._events = Object.create(null);
+    this._eventsCount = 0;
+  }
+  this._maxListeners = this._maxListeners || undefined;
+};
+
+EventEmitter.listenerCount = function listenerCount(emitter, type) {
+  return emitter.listenerCount(type);
+};
+
+EventEmitter.prototype._events = undefined;
+EventEmitter.prototype._eventsCount = 0;
+EventEmitter.prototype._maxListeners = undefined;
+
+EventEmitter.prototype.setMaxListeners = function setMaxListeners(n) {
+  assertMaxListeners(n);
+  this._maxListeners = n;
+  return this;
+};
+
+EventEmitter.prototype.getMaxListeners = function getMaxListeners() {
+  return this._maxListeners === undefined ? defaultMaxListeners : this._maxListeners;
+};
+
+EventEmitter.prototype.emit = function emit(type) {
+  var events = this._events;
+  var list = events !== undefined ? events[type] : undefined;
+  if (list === undefined) {
+    if (type === 'error') {
+      var er = arguments[1];
+      if (er instanceof Error) {
+        throw er;
+      }
+      var err = new Error('Unhandled error. (' + String(er) + ')');
+      err.code = 'ERR_UNHANDLED_ERROR';
+      err.context = er;
+      throw err;
+    }
+    return false;
+  }
+
+  var args = Array.prototype.slice.call(arguments, 1);
+  list = list.slice();
+  for (var i = 0; i < list.length; i++) {
+    list[i].apply(this, args);
+  }
+  return true;
+};
+
+function addListener(target, type, listener, prepend) {
+  assertListener(listener);
+
+  var events = target._events;
+  if (events === undefined) {
+    EventEmitter.init.call(target);
+    events = target._events;
+  } else if (events.newListener !== undefined) {
+    target.emit('newListener', type, listener.listener ? listener.listener : listener);
+    events = target._events;
+  }
+
+  var list = events[type];
+  if (list === undefined) {
+    list = events[type] = [];
+    target._eventsCount++;
+  }
+  if (prepend) {
+    list.unshift(listener);
+  } else {
+    list.push(listener);
+  }
+
+  var m = target.getMaxListeners();
+  if (m > 0
+      && list.length > m
+      && !list.warned) {
+    list.warned = true;
+    process.emitWarning('Possible EventEmitter memory leak detected. ' + list.length + ' ' + String(type) + ' listeners added. Use emitter.setMaxListeners() to increase limit', 'MaxListenersExceededWarning');
+  }
+  return target;
+}
+
+EventEmitter.prototype.on = EventEmitter.prototype.addListener = function addListener_(type, listener) {
+  return addListener(this, type, listener, false);
+};
+
+EventEmitter.prototype.prependListener = function prependListener(type, listener) {
+  return addListener(this, type, listener, true);
+};
+
+function onceWrapper(target, type, listener) {
+  var fired = false;
+
+  function wrapper() {
+    if (!fired) {
+      target.removeListener(type, wrapper);
+      fired = true;
+      return listener.apply(target, arguments);
+    }
+    return undefined;
+  }
+  wrapper.listener = listener;
+
+  return wrapper;
+}
+
+EventEmitter.prototype.once = function once(type, listener) {
+  assertListener(listener);
+  return this.on(type, onceWrapper(this, type, listener));
+};
+
+EventEmitter.prototype.prependOnceListener = function prependOnceListener(type, listener) {
+  assertListener(listener);
+  return this.prependListener(type, onceWrapper(this, type, listener));
+};
+
+EventEmitter.prototype.off = EventEmitter.prototype.removeListener = function removeListener(type, listener) {
+  assertListener(listener);
+
+  var events = this._events;
+  var list = events !== undefined ? events[type] : undefined;
+  if (list === undefined) {
+    return this;
+  }
+
+  for (var i = list.length - 1; i >= 0; i--) {
+    if (list[i] === listener
+        || list[i].listener === listener) {
+      listener = list[i].listener || list[i];
+      list.splice(i, 1);
+      if (list.length === 0) {
+        delete events[type];
+        this._eventsCount--;
+      }
+      if (events.removeListener !== undefined) {
+        this.emit('removeListener', type, listener);
+      }
+      break;
+    }
+  }
+  return this;
+};
+
+EventEmitter.prototype.removeAllListeners = function removeAllListeners(type) {
+  var events = this._events;
+  if (events === undefined) {
+    return this;
+  }
+
+  var k;
+  if (events.removeListener === undefined) {
+    if (arguments.length === 0) {
+      this._events = Object.create(null);
+      this._eventsCount = 0;
+    } else if (events[type] !== undefined) {
+      delete events[type];
+      this._eventsCount--;
+    }
+  } else if (arguments.length === 0) {
+    for (k in events) {
+      if (k !== 'removeListener') {
+        this.removeAllListeners(k);
+      }
+    }
+    this.removeAllListeners('removeListener');
+    this._events = Object.create(null);
+    this._eventsCount = 0;
+  } else {
+    var list = events[type];
+    if (list !== undefined) {
+      for (var i = list.length - 1; i >= 0; i--) {
+        this.removeListener(type, list[i]);
+      }
+    }
+  }
+  return this;
+};
+
+EventEmitter.prototype.listeners = function listeners(type) {
+  var list = this._events !== undefined ? this._events[type] : undefined;
+  if (list === undefined) {
+    return [];
+  }
+  return list.map(function(fn) {
+    return fn.listener || fn;
+  });
+};
+
+EventEmitter.prototype.rawListeners = function rawListeners(type) {
+  var list = this._events !== undefined ? this._events[type] : undefined;
+  return list !== undefined ? list.slice() : [];
+};
+
+EventEmitter.prototype.listenerCount = function listenerCount(type, listener) {
+  var list = this._events !== undefined ? this._events[type] : undefined;
+  if (list === undefined) {
+    return 0;
+  } else if (listener === undefined) {
+    return list.length;
+  }
+  return list.filter(function(fn) {
+    return fn === listener
+           || fn.listener === listener;
+  }).length;
+};
+
+EventEmitter.prototype.eventNames = function eventNames() {
+  return this._eventsCount > 0 ? Object.keys(this._events) : [];
+};
+
+EventEmitter.getEventListeners = function getEventListeners(emitter, type) {
+  return emitter.listeners(type);
+};
+
+EventEmitter.EventEmitter = EventEmitter;
+
+module.exports = EventEmitter;
+`),
+	"fs.js": []byte(`//
+// otto.module :: fs.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('fs');
+var Buffer = require('./buffer').Buffer;
+var util = require('./internal/util');
+
+var constants = {
+  F_OK: 0,
+  R_OK: 4,
+  W_OK: 2,
+  X_OK: 1,
+  S_IFMT: 0xf000,
+  S_IFREG: 0x8000,
+  S_IFDIR: 0x4000,
+  S_IFCHR: 0x2000,
+  S_IFBLK: 0x6000,
+  S_IFIFO: 0x1000,
+  S_IFLNK: 0xa000,
+  S_IFSOCK: 0xc000,
+};
+
+exports.constants = constants;
+
+function assertPath(p, name) {
+  if (typeof p !== 'string') {
+    throw new TypeError((name || 'path') + ' must be a String');
+  }
+  return p;
+}
+
+function getOptions(options, defaults) {
+  if (options === undefined
+      || options === null) {
+    options = {};
+  } else if (typeof options === 'string') {
+    options = { encoding: options };
+  } else if (typeof options !== 'object') {
+    throw new TypeError('options must be a String or Object');
+  }
+
+  var o = {};
+  Object.keys(defaults).forEach(function(k) {
+    o[k] = options[k] !== undefined ? options[k] : defaults[k];
+  });
+  return o;
+}
+
+function modeChecker(type) {
+  return function() {
+    return (this.mode & constants.S_IFMT) === type;
+  };
+}
+
+function Stats(st) {
+  this.dev = st.dev;
+  this.mode = st.mode;
+  this.nlink = st.nlink;
+  this.uid = st.uid;
+  this.gid = st.gid;
+  this.rdev = st.rdev;
+  this.blksize = st.blksize;
+  this.ino = st.ino;
+  this.size = st.size;
+  this.blocks = st.blocks;
+  this.atimeMs = st.atimeMs;
+  this.mtimeMs = st.mtimeMs;
+  this.ctimeMs = st.ctimeMs;
+  this.birthtimeMs = st.birthtimeMs;
+  this.atime = new Date(st.atimeMs);
+  this.mtime = new Date(st.mtimeMs);
+  this.ctime = new Date(st.ctimeMs);
+  this.birthtime = new Date(st.birthtimeMs);
+}
+
+Stats.prototype.isFile = modeChecker(constants.S_IFREG);
+Stats.prototype.isDirectory = modeChecker(constants.S_IFDIR);
+Stats.prototype.isSymbolicLink = modeChecker(constants.S_IFLNK);
+Stats.prototype.isFIFO = modeChecker(constants.S_IFIFO);
+Stats.prototype.isSocket = modeChecker(constants.S_IFSOCK);
+Stats.prototype.isCharacterDevice = modeChecker(constants.S_IFCHR);
+Stats.prototype.isBlockDevice = modeChecker(constants.S_IFBLK);
+
+exports.Stats = Stats;
+
+function Dirent(name, mode, parentPath) {
+  this.name = name;
+  this.parentPath = parentPath;
+  this.path = parentPath;
+  this.mode = mode;
+}
+
+['isFile', 'isDirectory', 'isSymbolicLink', 'isFIFO', 'isSocket', 'isCharacterDevice', 'isBlockDevice'].forEach(function(k) {
+  Dirent.prototype[k] = Stats.prototype[k];
+});
+
+exports.Dirent = Dirent;
+
+exports.existsSync = function existsSync(p) {
+  return typeof p === 'string'
+         && binding.exists(p);
+};
+
+var readFlags = ['r', 'rs', 'sr', 'r+', 'rs+', 'sr+', 'w+', 'wx+', 'xw+', 'a+', 'as+', 'sa+', 'ax+', 'xa+'];
+
+function assertEncoding(enc) {
+  if (enc
+      && enc !== 'buffer'
+      && !Buffer.isEncoding(enc)) {
+    throw util.invalidArgValue('encoding', enc);
+  }
+  return enc === 'buffer' ? null : enc;
+}
+
+exports.readFileSync = function readFileSync(p, options) {
+  options = getOptions(options, { encoding: null, flag: 'r' });
+  if (readFlags.indexOf(options.flag) === -1) {
+    throw util.invalidArgValue('flags', options.flag);
+  }
+  return binding.readFile(assertPath(p), assertEncoding(options.encoding), options.flag);
+};
+
+function writeFile(p, data, options, flag) {
+  options = getOptions(options, { encoding: 'utf8', mode: 438, flag: flag });
+  if (typeof data !== 'string'
+      && !Buffer.isBuffer(data)) {
+    data = String(data);
+  }
+  binding.writeFile(assertPath(p), data, options.encoding, options.flag, options.mode);
+}
+
+exports.writeFileSync = function writeFileSync(p, data, options) {
+  writeFile(p, data, options, 'w');
+};
+
+exports.appendFileSync = function appendFileSync(p, data, options) {
+  writeFile(p, data, options, 'a');
+};
+
+function stat(fn, p, options) {
+  options = getOptions(options, { throwIfNoEntry: true });
+  try {
+    return new Stats(fn(assertPath(p)));
+  } catch (e) {
+    if (!options.throwIfNoEntry
+        && e.code === 'ENOENT') {
+      return undefined;
+    }
+    throw e;
+  }
+}
+
+exports.statSync = function statSync(p, options) {
+  return stat(binding.stat, p, options);
+};
+
+exports.lstatSync = function lstatSync(p, options) {
+  return stat(binding.lstat, p, options);
+};
+
+exports.readdirSync = function readdirSync(p, options) {
+  options = getOptions(options, { encoding: 'utf8', withFileTypes: false });
+  var enc = assertEncoding(options.encoding);
+  var list = binding.readdir(assertPath(p));
+  var ents = [];
+  for (var i = 0; i < list.length; i++) {
+    var name = list[i][0];
+    if (enc === null) {
+      name = Buffer.from(name);
+    } else if (util.normalizeEncoding(enc) !== 'utf8') {
+      name = Buffer.from(name).toString(enc);
+    }
+    ents.push(options.withFileTypes ? new Dirent(name, list[i][1], p) : name);
+  }
+  return ents;
+};
+
+exports.mkdirSync = function mkdirSync(p, options) {
+  if (typeof options === 'number') {
+    options = { mode: options };
+  }
+  options = getOptions(options, { recursive: false, mode: 511 });
+  return binding.mkdir(assertPath(p), Boolean(options.recursive), options.mode);
+};
+
+exports.rmSync = function rmSync(p, options) {
+  options = getOptions(options, { recursive: false, force: false });
+  binding.rm(assertPath(p), Boolean(options.recursive), Boolean(options.force));
+};
+
+exports.renameSync = function renameSync(oldPath, newPath) {
+  binding.rename(assertPath(oldPath, 'oldPath'), assertPath(newPath, 'newPath'));
+};
+
+exports.realpathSync = function realpathSync(p) {
+  return binding.realpath(assertPath(p));
+};
+`),
+	"http.js": []byte(`//
+// otto.module :: http.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('http');
+var EventEmitter = require('./events');
+var timers = require('./timers');
+var url = require('./url');
+var common = require('./internal/http');
+var util = require('./internal/util');
+var inspect = require('./internal/util/inspect').inspect;
+
+var IncomingMessage = common.IncomingMessage;
+var OutgoingMessage = common.OutgoingMessage;
+
+function assign(dst, src) {
+  Object.keys(src).forEach(function(k) {
+    dst[k] = src[k];
+  });
+  return dst;
+}
+
+function validateHost(host, name) {
+  if (host !== null
+      && host !== undefined
+      && typeof host !== 'string') {
+    throw util.invalidArgType('options.' + name, 'of type string or one of undefined or null', host);
+  }
+  return host;
+}
+
+function validateTimeout(v, name) {
+  if (typeof v !== 'number') {
+    throw util.invalidArgType(name, 'of type number', v);
+  } else if (!(v >= 0)) {
+    throw util.outOfRange(name, '>= 0', v);
+  }
+}
+
+//
+// Agent
+//
+
+function Agent(options) {
+  if (!(this instanceof Agent)) {
+    return new Agent(options);
+  }
+
+  EventEmitter.call(this);
+
+  this.options = assign({}, options || {});
+  this.defaultPort = 80;
+  this.protocol = 'http:';
+  this.keepAlive = Boolean(this.options.keepAlive);
+  this.maxSockets = this.options.maxSockets || Agent.defaultMaxSockets;
+  this.maxFreeSockets = this.options.maxFreeSockets || 256;
+  this.maxTotalSockets = this.options.maxTotalSockets || Infinity;
+}
+
+Agent.defaultMaxSockets = Infinity;
+
+Agent.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Agent,
+    writable: true,
+    configurable: true,
+  },
+});
+
+// connections are managed by the http.RoundTripper of the host
+Agent.prototype.destroy = function destroy() {};
+
+var globalAgent = new Agent();
+
+//
+// ClientRequest
+//
+
+function ClientRequest(input, options, cb) {
+  if (!(this instanceof ClientRequest)) {
+    return new ClientRequest(input, options, cb);
+  }
+
+  OutgoingMessage.call(this, { autoDestroy: false });
+
+  if (typeof input === 'string') {
+    input = url.urlToHttpOptions(new url.URL(input));
+  } else if (input instanceof url.URL) {
+    input = url.urlToHttpOptions(input);
+  } else {
+    cb = options;
+    options = input;
+    input = null;
+  }
+  if (typeof options === 'function') {
+    cb = options;
+    options = input || {};
+  } else {
+    options = assign(input || {}, options || {});
+  }
+
+  var agent = options.agent;
+  var defaultAgent = options._defaultAgent || globalAgent;
+  if (agent === false) {
+    agent = new defaultAgent.constructor();
+  } else if (agent === null
+             || agent === undefined) {
+    agent = defaultAgent;
+  } else if (!(agent instanceof Agent)) {
+    throw util.invalidArgType('options.agent', 'of type Agent-like Object, undefined, or false', agent);
+  }
+  this.agent = agent;
+
+  var protocol = options.protocol || defaultAgent.protocol;
+  var expectedProtocol = agent.protocol || defaultAgent.protocol;
+  if (protocol !== expectedProtocol) {
+    var e = new TypeError('Protocol "' + protocol + '" not supported. Expected "' + expectedProtocol + '"');
+    e.code = 'ERR_INVALID_PROTOCOL';
+    throw e;
+  }
+
+  var defaultPort = options.defaultPort || agent.defaultPort;
+  var port = options.port || defaultPort || 80;
+  var host = validateHost(options.hostname, 'hostname') || validateHost(options.host, 'host') || 'localhost';
+  var setHost = options.setHost === undefined || Boolean(options.setHost);
+
+  if (options.timeout !== undefined) {
+    validateTimeout(options.timeout, 'timeout');
+  }
+
+  if (options.path) {
+    var path = String(options.path);
+    if (/[^\u0021-\u00ff]/.test(path)) {
+      e = new TypeError('Request path contains unescaped characters');
+      e.code = 'ERR_UNESCAPED_CHARACTERS';
+      throw e;
+    }
+  }
+
+  var method = options.method;
+  if (method !== null
+      && method !== undefined
+      && typeof method !== 'string') {
+    throw util.invalidArgType('options.method', 'of type string', method);
+  }
+  if (method) {
+    if (!common.checkIsHttpToken(method)) {
+      throw common.invalidHttpToken('Method', method);
+    }
+    method = method.toUpperCase();
+  } else {
+    method = 'GET';
+  }
+
+  this.method = method;
+  this.path = options.path || '/';
+  this.host = host;
+  this.protocol = protocol;
+  this.aborted = false;
+  this.res = null;
+  this.reusedSocket = false;
+  this.timeout = options.timeout;
+  Object.defineProperties(this, {
+    _port: {
+      value: port,
+      writable: true,
+      configurable: true,
+    },
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _fixedLength: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+    _timer: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+  });
+  if (cb) {
+    this.once('response', cb);
+  }
+
+  var headers = options.headers;
+  if (Array.isArray(headers)) {
+    for (var i = 0; i + 1 < headers.length; i += 2) {
+      this.appendHeader(headers[i], headers[i + 1]);
+    }
+  } else if (headers) {
+    for (var k in headers) {
+      this.setHeader(k, headers[k]);
+    }
+  }
+  if (host
+      && setHost
+      && !this.getHeader('host')) {
+    var hostHeader = host;
+    // wrap IPv6 addresses in brackets
+    if (hostHeader.indexOf(':') !== hostHeader.lastIndexOf(':')
+        && hostHeader.charAt(0) !== '[') {
+      hostHeader = '[' + hostHeader + ']';
+    }
+    if (port
+        && +port !== defaultPort) {
+      hostHeader += ':' + port;
+    }
+    this.setHeader('Host', hostHeader);
+  }
+  if (options.auth
+      && !this.getHeader('authorization')) {
+    this.setHeader('Authorization', 'Basic ' + Buffer.from(options.auth).toString('base64'));
+  }
+
+  if (options.timeout !== undefined) {
+    this.setTimeout(options.timeout);
+  }
+}
+
+ClientRequest.prototype = Object.create(OutgoingMessage.prototype, {
+  constructor: {
+    value: ClientRequest,
+    writable: true,
+    configurable: true,
+  },
+});
+
+ClientRequest.prototype._send = function _send(body, chunked) {
+  var host = this.host;
+  if (host.indexOf(':') !== -1) {
+    host = '[' + host + ']';
+  }
+  this._header = true;
+  this.chunkedEncoding = chunked;
+  this._refreshTimeout();
+  this._handle = binding.request({
+    method: this.method,
+    url: this.protocol + '//' + host + ':' + this._port + this.path,
+    headers: this._headerPairs(),
+    body: body,
+    chunked: chunked,
+  }, onresponse.bind(null, this));
+};
+
+function onresponse(req, err, r) {
+  if (req.destroyed) {
+    return;
+  } else if (err) {
+    req.destroy(err);
+    return;
+  }
+
+  var res = new IncomingMessage(r.body);
+  res.statusCode = r.statusCode;
+  res.statusMessage = r.statusMessage;
+  res.httpVersionMajor = r.httpVersionMajor;
+  res.httpVersionMinor = r.httpVersionMinor;
+  res.httpVersion = r.httpVersionMajor + '.' + r.httpVersionMinor;
+  res._addHeaderLines(r.rawHeaders);
+  res.req = req;
+  req.res = res;
+  res.on('activity', function() {
+    req._refreshTimeout();
+  });
+  res.on('end', function() {
+    req.destroy();
+  });
+  res.on('close', function() {
+    if (res.aborted) {
+      req.destroy();
+    }
+  });
+  req._refreshTimeout();
+  if (!req.emit('response', res)) {
+    res._dump();
+  }
+}
+
+ClientRequest.prototype.end = function end(chunk, encoding, cb) {
+  if (!this._header) {
+    // the whole body is known, and is sent with Content-Length
+    this._fixedLength = true;
+  }
+  return OutgoingMessage.prototype.end.call(this, chunk, encoding, cb);
+};
+
+ClientRequest.prototype._write = function _write(chunk, encoding, cb) {
+  this._writev([{ chunk: chunk, encoding: encoding }], cb);
+};
+
+ClientRequest.prototype._writev = function _writev(chunks, cb) {
+  var body = Buffer.concat(chunks.map(function(c) {
+    return c.chunk;
+  }));
+  if (!this._header) {
+    if (this._fixedLength) {
+      this._send(body, false);
+      cb();
+      return;
+    }
+    this._send(undefined, true);
+  } else if (!this.chunkedEncoding) {
+    var e = new Error('write after end');
+    e.code = 'ERR_STREAM_WRITE_AFTER_END';
+    cb(e);
+    return;
+  }
+  this._handle.write(body, cb);
+};
+
+ClientRequest.prototype._final = function _final(cb) {
+  if (!this._header) {
+    this._send(undefined, false);
+    cb();
+  } else if (this.chunkedEncoding) {
+    this._handle.close(cb);
+  } else {
+    cb();
+  }
+};
+
+ClientRequest.prototype._destroy = function _destroy(err, cb) {
+  this._clearTimeout();
+  if (this._handle) {
+    this._handle.abort();
+  }
+  if (this.res) {
+    if (!this.res.complete) {
+      this.res.destroy(common.connResetException('aborted'));
+    }
+  } else if (!err) {
+    err = common.connResetException('socket hang up');
+  }
+  cb(err);
+};
+
+ClientRequest.prototype.flushHeaders = function flushHeaders() {
+  if (!this._header
+      && !this.destroyed) {
+    this._send(undefined, true);
+  }
+};
+
+ClientRequest.prototype.abort = function abort() {
+  if (this.aborted) {
+    return;
+  }
+  this.aborted = true;
+  process.nextTick(emitAbortNT, this);
+  this.destroy();
+};
+
+function emitAbortNT(self) {
+  self.emit('abort');
+}
+
+ClientRequest.prototype.setTimeout = function setTimeout(msecs, cb) {
+  validateTimeout(msecs, 'msecs');
+  if (cb !== undefined) {
+    if (typeof cb !== 'function') {
+      throw util.invalidArgType('callback', 'of type function', cb);
+    }
+    this.once('timeout', cb);
+  }
+  this.timeout = msecs;
+  this._clearTimeout();
+  if (msecs > 0
+      && !this.destroyed) {
+    var self = this;
+    this._timer = timers.setTimeout(function() {
+      self._timer = null;
+      self.emit('timeout');
+    }, msecs);
+    this._timer.unref();
+  }
+  return this;
+};
+
+ClientRequest.prototype._refreshTimeout = function _refreshTimeout() {
+  if (this._timer) {
+    this._timer.refresh();
+  }
+};
+
+ClientRequest.prototype._clearTimeout = function _clearTimeout() {
+  if (this._timer) {
+    timers.clearTimeout(this._timer);
+    this._timer = null;
+  }
+};
+
+ClientRequest.prototype.setNoDelay = function setNoDelay() {};
+
+ClientRequest.prototype.setSocketKeepAlive = function setSocketKeepAlive() {};
+
+//
+// ServerResponse
+//
+
+function ServerResponse(req, handle) {
+  if (!(this instanceof ServerResponse)) {
+    return new ServerResponse(req, handle);
+  }
+
+  OutgoingMessage.call(this);
+
+  this.req = req;
+  this.statusCode = 200;
+  this.statusMessage = undefined;
+  this.sendDate = true;
+  Object.defineProperties(this, {
+    _handle: {
+      value: handle,
+      writable: true,
+      configurable: true,
+    },
+    _hasBody: {
+      value: req.method !== 'HEAD',
+      writable: true,
+      configurable: true,
+    },
+    _fixedLength: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+ServerResponse.prototype = Object.create(OutgoingMessage.prototype, {
+  constructor: {
+    value: ServerResponse,
+    writable: true,
+    configurable: true,
+  },
+});
+
+ServerResponse.prototype.writeHead = function writeHead(statusCode, reason, obj) {
+  if (this._header) {
+    throw common.headersSent('write');
+  }
+  var originalStatusCode = statusCode;
+  statusCode |= 0;
+  if (statusCode < 100
+      || statusCode > 999) {
+    var e = new RangeError('Invalid status code: ' + originalStatusCode);
+    e.code = 'ERR_HTTP_INVALID_STATUS_CODE';
+    throw e;
+  }
+
+  if (typeof reason === 'string') {
+    // net/http always sends the reason phrase for the status code
+    this.statusMessage = reason;
+  } else {
+    this.statusMessage = this.statusMessage || common.STATUS_CODES[statusCode] || 'unknown';
+    obj = reason;
+  }
+  this.statusCode = statusCode;
+  if (Array.isArray(obj)) {
+    for (var i = 0; i + 1 < obj.length; i += 2) {
+      this.appendHeader(obj[i], obj[i + 1]);
+    }
+  } else if (obj) {
+    for (var k in obj) {
+      if (k) {
+        this.setHeader(k, obj[k]);
+      }
+    }
+  }
+  if (statusCode === 204
+      || statusCode === 304
+      || statusCode < 200) {
+    this._hasBody = false;
+  }
+
+  this._header = true;
+  this._handle.writeHead(statusCode, this._headerPairs(), this.sendDate);
+  return this;
+};
+
+ServerResponse.prototype._implicitHeader = function _implicitHeader() {
+  this.writeHead(this.statusCode);
+};
+
+ServerResponse.prototype.end = function end(chunk, encoding, cb) {
+  if (!this._header) {
+    // the whole body is known, and is sent with Content-Length
+    this._fixedLength = true;
+  }
+  return OutgoingMessage.prototype.end.call(this, chunk, encoding, cb);
+};
+
+ServerResponse.prototype._write = function _write(chunk, encoding, cb) {
+  this._writev([{ chunk: chunk, encoding: encoding }], cb);
+};
+
+ServerResponse.prototype._writev = function _writev(chunks, cb) {
+  var body = Buffer.concat(chunks.map(function(c) {
+    return c.chunk;
+  }));
+  if (!this._header) {
+    if (this._fixedLength
+        && this._hasBody
+        && !this.hasHeader('content-length')
+        && !this.hasHeader('transfer-encoding')) {
+      this.setHeader('Content-Length', body.length);
+    }
+    this._implicitHeader();
+  }
+  if (!this._hasBody
+      || body.length === 0) {
+    cb();
+    return;
+  }
+  this._handle.write(body, cb);
+};
+
+ServerResponse.prototype._final = function _final(cb) {
+  if (!this._header) {
+    this._implicitHeader();
+  }
+  this._handle.close(cb);
+};
+
+ServerResponse.prototype._destroy = function _destroy(err, cb) {
+  this._handle.close(function() {
+    cb(err);
+  });
+};
+
+ServerResponse.prototype.flushHeaders = function flushHeaders() {
+  if (!this._header) {
+    this._implicitHeader();
+  }
+};
+
+ServerResponse.prototype.writeContinue = function writeContinue(cb) {
+  if (typeof cb === 'function') {
+    process.nextTick(cb);
+  }
+};
+
+ServerResponse.prototype.setTimeout = function setTimeout(msecs, cb) {
+  if (typeof cb === 'function') {
+    this.once('timeout', cb);
+  }
+  return this;
+};
+
+//
+// Server
+//
+
+function Server(options, requestListener) {
+  if (!(this instanceof Server)) {
+    return new Server(options, requestListener);
+  }
+
+  if (typeof options === 'function') {
+    requestListener = options;
+    options = {};
+  } else if (options === null
+             || options === undefined) {
+    options = {};
+  } else if (typeof options !== 'object'
+             || Array.isArray(options)) {
+    throw util.invalidArgType('options', 'of type object', options);
+  }
+
+  EventEmitter.call(this);
+
+  this.timeout = 0;
+  this.keepAliveTimeout = 5000;
+  this.headersTimeout = 60000;
+  this.requestTimeout = 300000;
+  this.maxHeadersCount = null;
+  this.maxRequestsPerSocket = 0;
+  Object.defineProperties(this, {
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _unref: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+  if (requestListener) {
+    this.on('request', requestListener);
+  }
+}
+
+Server.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Server,
+    writable: true,
+    configurable: true,
+  },
+  listening: {
+    get: function() {
+      return this._handle !== null;
+    },
+    configurable: true,
+  },
+});
+
+Server.prototype.listen = function listen() {
+  var args = Array.prototype.slice.call(arguments);
+  var cb = typeof args[args.length - 1] === 'function' ? args.pop() : null;
+  var options;
+  if (args[0] !== null
+      && typeof args[0] === 'object') {
+    options = args[0];
+  } else {
+    options = {
+      port: args[0],
+      host: typeof args[1] === 'string' ? args[1] : undefined,
+    };
+  }
+  var port = options.port === undefined || options.port === null ? 0 : util.validatePort(options.port, 'options.port', inspect);
+
+  if (this._handle) {
+    var e = new Error('Listen method has been called more than once without closing.');
+    e.code = 'ERR_SERVER_ALREADY_LISTEN';
+    throw e;
+  }
+  if (cb) {
+    this.once('listening', cb);
+  }
+
+  try {
+    this._handle = binding.listen(options.host, port, onrequest.bind(null, this));
+  } catch (err) {
+    process.nextTick(emitErrorNT, this, err);
+    return this;
+  }
+  if (this._unref) {
+    this._handle.unref();
+  }
+  process.nextTick(emitListeningNT, this);
+  return this;
+};
+
+function emitErrorNT(self, err) {
+  self.emit('error', err);
+}
+
+function emitListeningNT(self) {
+  if (self._handle) {
+    self.emit('listening');
+  }
+}
+
+function onrequest(server, r, handle) {
+  var req = new IncomingMessage(r.body);
+  req.method = r.method;
+  req.url = r.url;
+  req.httpVersionMajor = r.httpVersionMajor;
+  req.httpVersionMinor = r.httpVersionMinor;
+  req.httpVersion = r.httpVersionMajor + '.' + r.httpVersionMinor;
+  req._addHeaderLines(r.rawHeaders);
+  req.socket = {
+    remoteAddress: r.remoteAddress,
+    remotePort: r.remotePort,
+    localAddress: r.localAddress,
+    localPort: r.localPort,
+  };
+  var res = new ServerResponse(req, handle);
+  handle.onabort = function() {
+    if (!req.complete) {
+      req.destroy(common.connResetException('aborted'));
+    }
+    res.destroy();
+  };
+  server.emit('request', req, res);
+}
+
+Server.prototype.address = function address() {
+  return this._handle ? this._handle.address : null;
+};
+
+Server.prototype.close = function close(cb) {
+  if (typeof cb === 'function') {
+    if (this._handle) {
+      this.once('close', cb);
+    } else {
+      this.once('close', function() {
+        var e = new Error('Server is not running.');
+        e.code = 'ERR_SERVER_NOT_RUNNING';
+        cb(e);
+      });
+    }
+  }
+
+  var self = this;
+  if (this._handle) {
+    this._handle.close(function() {
+      self.emit('close');
+    });
+    this._handle = null;
+  } else {
+    process.nextTick(function() {
+      self.emit('close');
+    });
+  }
+  return this;
+};
+
+Server.prototype.closeAllConnections = function closeAllConnections() {
+  if (this._handle) {
+    this._handle.closeAll();
+  }
+};
+
+Server.prototype.closeIdleConnections = function closeIdleConnections() {};
+
+Server.prototype.setTimeout = function setTimeout(msecs, cb) {
+  this.timeout = msecs;
+  if (typeof cb === 'function') {
+    this.on('timeout', cb);
+  }
+  return this;
+};
+
+Server.prototype.ref = function ref() {
+  this._unref = false;
+  if (this._handle) {
+    this._handle.ref();
+  }
+  return this;
+};
+
+Server.prototype.unref = function unref() {
+  this._unref = true;
+  if (this._handle) {
+    this._handle.unref();
+  }
+  return this;
+};
+
+//
+// API
+//
+
+function request(input, options, cb) {
+  return new ClientRequest(input, options, cb);
+}
+
+function get(input, options, cb) {
+  var req = request(input, options, cb);
+  req.end();
+  return req;
+}
+
+function createServer(options, requestListener) {
+  return new Server(options, requestListener);
+}
+
+exports.METHODS = common.METHODS.slice();
+exports.STATUS_CODES = common.STATUS_CODES;
+exports.Agent = Agent;
+exports.ClientRequest = ClientRequest;
+exports.IncomingMessage = IncomingMessage;
+exports.OutgoingMessage = OutgoingMessage;
+exports.Server = Server;
+exports.ServerResponse = ServerResponse;
+exports.globalAgent = globalAgent;
+exports.request = request;
+exports.get = get;
+exports.createServer = createServer;
+exports.validateHeaderName = common.validateHeaderName;
+exports.validateHeaderValue = common.validateHeaderValue;
+`),
+	"https.js": []byte(`//
+// otto.module :: https.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var http = require('./http');
+var url = require('./url');
+
+function Agent(options) {
+  if (!(this instanceof Agent)) {
+    return new Agent(options);
+  }
+
+  http.Agent.call(this, options);
+
+  this.defaultPort = 443;
+  this.protocol = 'https:';
+}
+
+Agent.prototype = Object.create(http.Agent.prototype, {
+  constructor: {
+    value: Agent,
+    writable: true,
+    configurable: true,
+  },
+});
+
+var globalAgent = new Agent();
+
+function request(input, options, cb) {
+  var opts = {};
+  var args = Array.prototype.slice.call(arguments);
+  if (typeof args[0] === 'string') {
+    opts = url.urlToHttpOptions(new url.URL(args.shift()));
+  } else if (args[0] instanceof url.URL) {
+    opts = url.urlToHttpOptions(args.shift());
+  }
+  if (args[0]
+      && typeof args[0] !== 'function') {
+    var o = args.shift();
+    for (var k in o) {
+      opts[k] = o[k];
+    }
+  }
+  opts._defaultAgent = exports.globalAgent;
+  return new http.ClientRequest(opts, args[0]);
+}
+
+function get(input, options, cb) {
+  var req = request.apply(null, arguments);
+  req.end();
+  return req;
+}
+
+exports.Agent = Agent;
+exports.globalAgent = globalAgent;
+exports.request = request;
+exports.get = get;
+`),
+	"internal/bootstrap.js": []byte(`//
+// otto.module :: internal/bootstrap.go
+//
+//   Copyright (c) 2017-2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+(function(process) {
+  var vm = process.binding('vm');
+
+  function NativeModule(id) {
+    this.id = id;
+    this.exports = {};
+    this.filename = id + '.js';
+    this.loaded = false;
+  }
+
+  NativeModule._source = process.binding('natives');
+  NativeModule._cache = Object.create(null);
+
+  NativeModule.exists = function exists(id) {
+    return Object.prototype.hasOwnProperty.call(NativeModule._source, id);
+  };
+
+  NativeModule.isInternal = function isInternal(id) {
+    var s = 'internal/';
+    return id.slice(0, s.length) === s;
+  };
+
+  NativeModule.require = function require(id) {
+    if (id === 'native_module') {
+      return NativeModule;
+    }
+
+    var m = NativeModule._cache[id];
+    if (!m) {
+      NativeModule._cache[id] = m = new NativeModule(id);
+      m.compile();
+    }
+    return m.exports;
+  };
+
+  NativeModule.resolve = function resolve(id, parent) {
+    if (id.slice(0, 2) !== './'
+        && id.slice(0, 3) !== '../') {
+      return id;
+    }
+
+    var list = parent.id.split('/').slice(0, -1);
+    id.split('/').forEach(function(s) {
+      if (s === '..') {
+        list.pop();
+      } else if (s !== '.') {
+        list.push(s);
+      }
+    });
+    return list.join('/');
+  };
+
+  NativeModule.prototype.compile = function compile() {
+    var self = this;
+    var fn = vm.compile(this.filename);
+    fn(this.exports, function require(id) {
+      return NativeModule.require(NativeModule.resolve(id, self));
+    }, this, this.filename);
+
+    this.loaded = true;
+  };
+
+  var g = (0, eval)('this');
+  var EventEmitter = NativeModule.require('events');
+  g.process = process = setupProcess(process);
+
+  setupStdio(process);
+
+  var warning = NativeModule.require('internal/process/warning');
+  process.emitWarning = warning.emitWarning;
+  process.on('warning', warning.onWarning);
+
+  var taskQueues = NativeModule.require('internal/process/task_queues');
+  process.nextTick = taskQueues.nextTick;
+  g.queueMicrotask = taskQueues.queueMicrotask;
+
+  g.Buffer = NativeModule.require('buffer').Buffer;
+  g.console = NativeModule.require('console');
+
+  var timers = NativeModule.require('timers');
+  g.setTimeout = timers.setTimeout;
+  g.clearTimeout = timers.clearTimeout;
+  g.setInterval = timers.setInterval;
+  g.clearInterval = timers.clearInterval;
+  g.setImmediate = timers.setImmediate;
+  g.clearImmediate = timers.clearImmediate;
+
+  var url = NativeModule.require('internal/url');
+  g.URL = url.URL;
+  g.URLSearchParams = url.URLSearchParams;
+
+  var Module = NativeModule.require('module');
+  var _module = NativeModule.require('internal/module');
+
+  var m = new Module('<otto>');
+  g.module = m;
+  g.require = _module.require(m);
+
+  function setupProcess(o) {
+    function process() {
+      EventEmitter.call(this);
+    }
+    process.prototype = Object.create(EventEmitter.prototype, {
+      constructor: {
+        value: process,
+        writable: true,
+        configurable: true,
+      },
+    });
+
+    var p = new process();
+    Object.keys(o).forEach(function(k) {
+      p[k] = o[k];
+    });
+    return p;
+  }
+
+  function setupStdio(p) {
+    ['stdin', 'stdout', 'stderr'].forEach(function(name) {
+      var stream;
+      Object.defineProperty(p, name, {
+        get: function() {
+          if (!stream) {
+            NativeModule.require('stream');
+            stream = p.binding('stream')[name]();
+          }
+          return stream;
+        },
+        enumerable: true,
+        configurable: true,
+      });
+    });
+  }
+});
+`),
+	"internal/http.js": []byte(`//
+// otto.module :: internal/http.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var Readable = require('../stream').Readable;
+var Writable = require('../stream').Writable;
+var util = require('./util');
+
+exports.METHODS = [
+  'ACL',
+  'BIND',
+  'CHECKOUT',
+  'CONNECT',
+  'COPY',
+  'DELETE',
+  'GET',
+  'HEAD',
+  'LINK',
+  'LOCK',
+  'M-SEARCH',
+  'MERGE',
+  'MKACTIVITY',
+  'MKCALENDAR',
+  'MKCOL',
+  'MOVE',
+  'NOTIFY',
+  'OPTIONS',
+  'PATCH',
+  'POST',
+  'PROPFIND',
+  'PROPPATCH',
+  'PURGE',
+  'PUT',
+  'QUERY',
+  'REBIND',
+  'REPORT',
+  'SEARCH',
+  'SOURCE',
+  'SUBSCRIBE',
+  'TRACE',
+  'UNBIND',
+  'UNLINK',
+  'UNLOCK',
+  'UNSUBSCRIBE',
+];
+
+exports.STATUS_CODES = {
+  100: 'Continue',
+  101: 'Switching Protocols',
+  102: 'Processing',
+  103: 'Early Hints',
+  200: 'OK',
+  201: 'Created',
+  202: 'Accepted',
+  203: 'Non-Authoritative Information',
+  204: 'No Content',
+  205: 'Reset Content',
+  206: 'Partial Content',
+  207: 'Multi-Status',
+  208: 'Already Reported',
+  226: 'IM Used',
+  300: 'Multiple Choices',
+  301: 'Moved Permanently',
+  302: 'Found',
+  303: 'See Other',
+  304: 'Not Modified',
+  305: 'Use Proxy',
+  307: 'Temporary Redirect',
+  308: 'Permanent Redirect',
+  400: 'Bad Request',
+  401: 'Unauthorized',
+  402: 'Payment Required',
+  403: 'Forbidden',
+  404: 'Not Found',
+  405: 'Method Not Allowed',
+  406: 'Not Acceptable',
+  407: 'Proxy Authentication Required',
+  408: 'Request Timeout',
+  409: 'Conflict',
+  410: 'Gone',
+  411: 'Length Required',
+  412: 'Precondition Failed',
+  413: 'Payload Too Large',
+  414: 'URI Too Long',
+  415: 'Unsupported Media Type',
+  416: 'Range Not Satisfiable',
+  417: 'Expectation Failed',
+  418: 'I\'m a Teapot',
+  421: 'Misdirected Request',
+  422: 'Unprocessable Entity',
+  423: 'Locked',
+  424: 'Failed Dependency',
+  425: 'Too Early',
+  426: 'Upgrade Required',
+  428: 'Precondition Required',
+  429: 'Too Many Requests',
+  431: 'Request Header Fields Too Large',
+  451: 'Unavailable For Legal Reasons',
+  500: 'Internal Server Error',
+  501: 'Not Implemented',
+  502: 'Bad Gateway',
+  503: 'Service Unavailable',
+  504: 'Gateway Timeout',
+  505: 'HTTP Version Not Supported',
+  506: 'Variant Also Negotiates',
+  507: 'Insufficient Storage',
+  508: 'Loop Detected',
+  509: 'Bandwidth Limit Exceeded',
+  510: 'Not Extended',
+  511: 'Network Authentication Required',
+};
+
+//
+// errors
+//
+
+function invalidHttpToken(name, field) {
+  var e = new TypeError(name + ' must be a valid HTTP token ["' + field + '"]');
+  e.code = 'ERR_INVALID_HTTP_TOKEN';
+  return e;
+}
+
+function headersSent(action) {
+  var e = new Error('Cannot ' + action + ' headers after they are sent to the client');
+  e.code = 'ERR_HTTP_HEADERS_SENT';
+  return e;
+}
+
+function connResetException(msg) {
+  var e = new Error(msg);
+  e.code = 'ECONNRESET';
+  return e;
+}
+
+exports.invalidHttpToken = invalidHttpToken;
+exports.headersSent = headersSent;
+exports.connResetException = connResetException;
+
+//
+// validators
+//
+
+var tokenRegExp = /^[\^_` + "`" + `a-zA-Z\-0-9!#$%&'*+.|~]+$/;
+var headerCharRegExp = /[^\t\x20-\x7e\x80-\xff]/;
+
+function checkIsHttpToken(s) {
+  return tokenRegExp.test(s);
+}
+
+function checkInvalidHeaderChar(s) {
+  return headerCharRegExp.test(s);
+}
+
+function validateHeaderName(name) {
+  if (typeof name !== 'string'
+      || !name
+      || !checkIsHttpToken(name)) {
+    throw invalidHttpToken('Header name', name);
+  }
+}
+
+function validateHeaderValue(name, value) {
+  if (value === undefined) {
+    var e = new TypeError('Invalid value "' + value + '" for header "' + name + '"');
+    e.code = 'ERR_HTTP_INVALID_HEADER_VALUE';
+    throw e;
+  }
+  if (checkInvalidHeaderChar(String(value))) {
+    e = new TypeError('Invalid character in header content ["' + name + '"]');
+    e.code = 'ERR_INVALID_CHAR';
+    throw e;
+  }
+}
+
+exports.checkIsHttpToken = checkIsHttpToken;
+exports.checkInvalidHeaderChar = checkInvalidHeaderChar;
+exports.validateHeaderName = validateHeaderName;
+exports.validateHeaderValue = validateHeaderValue;
+
+//
+// IncomingMessage
+//
+
+// fields for which duplicates are discarded
+var singleFields = [
+  'age',
+  'authorization',
+  'content-length',
+  'content-type',
+  'etag',
+  'expires',
+  'from',
+  'host',
+  'if-modified-since',
+  'if-unmodified-since',
+  'last-modified',
+  'location',
+  'max-forwards',
+  'proxy-authorization',
+  'referer',
+  'retry-after',
+  'server',
+  'user-agent',
+];
+
+function IncomingMessage(handle) {
+  if (!(this instanceof IncomingMessage)) {
+    return new IncomingMessage(handle);
+  }
+
+  Readable.call(this);
+
+  this.aborted = false;
+  this.complete = false;
+  this.httpVersionMajor = null;
+  this.httpVersionMinor = null;
+  this.httpVersion = null;
+  this.headers = {};
+  this.rawHeaders = [];
+  this.trailers = {};
+  this.rawTrailers = [];
+  this.method = null;
+  this.url = '';
+  this.statusCode = null;
+  this.statusMessage = null;
+  this.socket = null;
+  Object.defineProperty(this, '_handle', {
+    value: handle,
+    writable: true,
+    configurable: true,
+  });
+}
+
+IncomingMessage.prototype = Object.create(Readable.prototype, {
+  constructor: {
+    value: IncomingMessage,
+    writable: true,
+    configurable: true,
+  },
+});
+
+IncomingMessage.prototype._addHeaderLines = function _addHeaderLines(raw) {
+  for (var i = 0; i + 1 < raw.length; i += 2) {
+    this.rawHeaders.push(raw[i], raw[i + 1]);
+    this._addHeaderLine(raw[i], raw[i + 1], this.headers);
+  }
+};
+
+IncomingMessage.prototype._addHeaderLine = function _addHeaderLine(field, value, dest) {
+  field = field.toLowerCase();
+  if (field === 'set-cookie') {
+    if (dest[field] === undefined) {
+      dest[field] = [value];
+    } else {
+      dest[field].push(value);
+    }
+  } else if (dest[field] === undefined) {
+    dest[field] = value;
+  } else if (singleFields.indexOf(field) === -1) {
+    dest[field] += (field === 'cookie' ? '; ' : ', ') + value;
+  }
+};
+
+IncomingMessage.prototype._read = function _read(n) {
+  var self = this;
+  this._handle.read(n, function(err, buf) {
+    if (err) {
+      self.destroy(err);
+    } else if (buf === null) {
+      self.complete = true;
+      self.push(null);
+    } else {
+      self.emit('activity');
+      self.push(buf);
+    }
+  });
+};
+
+IncomingMessage.prototype._destroy = function _destroy(err, cb) {
+  if (!this.readableEnded
+      || !this.complete) {
+    this.aborted = true;
+    this.emit('aborted');
+  }
+  this._handle.close();
+  // an error is emitted only if there are listeners
+  if (this.listenerCount('error') === 0) {
+    err = null;
+  }
+  process.nextTick(cb, err);
+};
+
+IncomingMessage.prototype._dump = function _dump() {
+  this.resume();
+};
+
+IncomingMessage.prototype.setTimeout = function setTimeout(msecs, cb) {
+  if (this.req) {
+    this.req.setTimeout(msecs, cb);
+  }
+  return this;
+};
+
+exports.IncomingMessage = IncomingMessage;
+
+//
+// OutgoingMessage
+//
+
+function OutgoingMessage(options) {
+  if (!(this instanceof OutgoingMessage)) {
+    return new OutgoingMessage(options);
+  }
+
+  Writable.call(this, options);
+
+  this.chunkedEncoding = false;
+  this.sendDate = false;
+  this.socket = null;
+  Object.defineProperties(this, {
+    _headers: {
+      value: {},
+      writable: true,
+      configurable: true,
+    },
+    _header: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+OutgoingMessage.prototype = Object.create(Writable.prototype, {
+  constructor: {
+    value: OutgoingMessage,
+    writable: true,
+    configurable: true,
+  },
+  headersSent: {
+    get: function() {
+      return this._header;
+    },
+    configurable: true,
+  },
+});
+
+OutgoingMessage.prototype.setHeader = function setHeader(name, value) {
+  if (this._header) {
+    throw headersSent('set');
+  }
+  validateHeaderName(name);
+  validateHeaderValue(name, value);
+  this._headers[name.toLowerCase()] = [name, value];
+  return this;
+};
+
+OutgoingMessage.prototype.appendHeader = function appendHeader(name, value) {
+  if (this._header) {
+    throw headersSent('append');
+  }
+  validateHeaderName(name);
+  validateHeaderValue(name, value);
+  var k = name.toLowerCase();
+  var e = this._headers[k];
+  if (e === undefined) {
+    this._headers[k] = [name, value];
+  } else {
+    e[1] = [].concat(e[1], value);
+  }
+  return this;
+};
+
+OutgoingMessage.prototype.getHeader = function getHeader(name) {
+  if (typeof name !== 'string') {
+    throw util.invalidArgType('name', 'of type string', name);
+  }
+  var e = this._headers[name.toLowerCase()];
+  return e && e[1];
+};
+
+OutgoingMessage.prototype.getHeaders = function getHeaders() {
+  var headers = {};
+  for (var k in this._headers) {
+    headers[k] = this._headers[k][1];
+  }
+  return headers;
+};
+
+OutgoingMessage.prototype.getHeaderNames = function getHeaderNames() {
+  return Object.keys(this._headers);
+};
+
+OutgoingMessage.prototype.getRawHeaderNames = function getRawHeaderNames() {
+  var headers = this._headers;
+  return Object.keys(headers).map(function(k) {
+    return headers[k][0];
+  });
+};
+
+OutgoingMessage.prototype.hasHeader = function hasHeader(name) {
+  if (typeof name !== 'string') {
+    throw util.invalidArgType('name', 'of type string', name);
+  }
+  return this._headers[name.toLowerCase()] !== undefined;
+};
+
+OutgoingMessage.prototype.removeHeader = function removeHeader(name) {
+  if (typeof name !== 'string') {
+    throw util.invalidArgType('name', 'of type string', name);
+  }
+  if (this._header) {
+    throw headersSent('remove');
+  }
+  delete this._headers[name.toLowerCase()];
+};
+
+// returns the headers as a list of [name, value] pairs
+OutgoingMessage.prototype._headerPairs = function _headerPairs() {
+  var pairs = [];
+  for (var k in this._headers) {
+    var e = this._headers[k];
+    [].concat(e[1]).forEach(function(v) {
+      pairs.push([e[0], String(v)]);
+    });
+  }
+  return pairs;
+};
+
+exports.OutgoingMessage = OutgoingMessage;
+`),
+	"internal/idna.js": []byte(`//
+// otto.module :: internal/idna.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var base = 36;
+var tMin = 1;
+var tMax = 26;
+var skew = 38;
+var damp = 700;
+var initialBias = 72;
+var initialN = 0x80;
+var maxInt = 0x7fffffff;
+
+function adapt(delta, numPoints, firstTime) {
+  var k = 0;
+  delta = firstTime ? Math.floor(delta / damp) : delta >> 1;
+  delta += Math.floor(delta / numPoints);
+  for (; delta > ((base - tMin) * tMax) >> 1; k += base) {
+    delta = Math.floor(delta / (base - tMin));
+  }
+  return Math.floor(k + (base - tMin + 1) * delta / (delta + skew));
+}
+
+function threshold(k, bias) {
+  if (k <= bias) {
+    return tMin;
+  } else if (k >= bias + tMax) {
+    return tMax;
+  }
+  return k - bias;
+}
+
+function encodeDigit(d) {
+  return String.fromCharCode(d < 26 ? d + 0x61 : d + 0x16);
+}
+
+function decodeDigit(c) {
+  if (0x30 <= c && c <= 0x39) {
+    return c - 0x16;
+  } else if (0x41 <= c && c <= 0x5a) {
+    return c - 0x41;
+  } else if (0x61 <= c && c <= 0x7a) {
+    return c - 0x61;
+  }
+  return base;
+}
+
+function ucs2decode(s) {
+  var list = [];
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charCodeAt(i);
+    if (0xd800 <= c && c <= 0xdbff && i + 1 < s.length) {
+      var d = s.charCodeAt(i + 1);
+      if (0xdc00 <= d && d <= 0xdfff) {
+        list.push(((c - 0xd800) << 10) + (d - 0xdc00) + 0x10000);
+        i++;
+        continue;
+      }
+    }
+    list.push(c);
+  }
+  return list;
+}
+
+function ucs2encode(list) {
+  var s = '';
+  for (var i = 0; i < list.length; i++) {
+    var cp = list[i];
+    if (cp > 0xffff) {
+      cp -= 0x10000;
+      s += String.fromCharCode(0xd800 + (cp >> 10), 0xdc00 + (cp & 0x3ff));
+    } else {
+      s += String.fromCharCode(cp);
+    }
+  }
+  return s;
+}
+
+function encode(s) {
+  var input = ucs2decode(s);
+  var output = '';
+  var i;
+  for (i = 0; i < input.length; i++) {
+    if (input[i] < 0x80) {
+      output += String.fromCharCode(input[i]);
+    }
+  }
+
+  var n = initialN;
+  var delta = 0;
+  var bias = initialBias;
+  var b = output.length;
+  var h = b;
+  if (b) {
+    output += '-';
+  }
+  while (h < input.length) {
+    var m = maxInt;
+    for (i = 0; i < input.length; i++) {
+      if (input[i] >= n && input[i] < m) {
+        m = input[i];
+      }
+    }
+    if (m - n > Math.floor((maxInt - delta) / (h + 1))) {
+      throw new RangeError('Overflow: input needs wider integers to process');
+    }
+    delta += (m - n) * (h + 1);
+    n = m;
+    for (i = 0; i < input.length; i++) {
+      if (input[i] < n) {
+        delta++;
+      } else if (input[i] === n) {
+        var q = delta;
+        for (var k = base; ; k += base) {
+          var t = threshold(k, bias);
+          if (q < t) {
+            break;
+          }
+          output += encodeDigit(t + (q - t) % (base - t));
+          q = Math.floor((q - t) / (base - t));
+        }
+        output += encodeDigit(q);
+        bias = adapt(delta, h + 1, h === b);
+        delta = 0;
+        h++;
+      }
+    }
+    delta++;
+    n++;
+  }
+  return output;
+}
+
+function decode(s) {
+  var output = [];
+  var basic = s.lastIndexOf('-');
+  if (basic < 0) {
+    basic = 0;
+  }
+  for (var j = 0; j < basic; j++) {
+    if (s.charCodeAt(j) >= 0x80) {
+      throw new RangeError('Illegal input >= 0x80 (not a basic code point)');
+    }
+    output.push(s.charCodeAt(j));
+  }
+
+  var n = initialN;
+  var bias = initialBias;
+  var i = 0;
+  for (var index = basic > 0 ? basic + 1 : 0; index < s.length;) {
+    var oldi = i;
+    for (var w = 1, k = base; ; k += base) {
+      if (index >= s.length) {
+        throw new RangeError('Invalid input');
+      }
+      var digit = decodeDigit(s.charCodeAt(index++));
+      if (digit >= base || digit > Math.floor((maxInt - i) / w)) {
+        throw new RangeError('Invalid input');
+      }
+      i += digit * w;
+      var t = threshold(k, bias);
+      if (digit < t) {
+        break;
+      }
+      w *= base - t;
+    }
+    var out = output.length + 1;
+    bias = adapt(i - oldi, out, oldi === 0);
+    if (Math.floor(i / out) > maxInt - n) {
+      throw new RangeError('Overflow: input needs wider integers to process');
+    }
+    n += Math.floor(i / out);
+    i %= out;
+    output.splice(i++, 0, n);
+  }
+  return ucs2encode(output);
+}
+
+exports.encode = encode;
+exports.decode = decode;
+
+var ignored = /[\u00ad\u034f\u180b-\u180d\u200b\u2060\ufe00-\ufe0f\ufeff]/g;
+var separators = /[\u3002\uff0e\uff61]/g;
+var nonASCII = /[^\x00-\x7f]/;
+
+function map(domain) {
+  domain = domain.replace(ignored, '').replace(separators, '.').toLowerCase();
+  return typeof domain.normalize === 'function' ? domain.normalize('NFC') : domain;
+}
+
+exports.toASCII = function toASCII(domain) {
+  var labels = map(domain).split('.');
+  for (var i = 0; i < labels.length; i++) {
+    var l = labels[i];
+    if (l.slice(0, 4) === 'xn--') {
+      var u = decode(l.slice(4));
+      if (!u
+          || !nonASCII.test(u)) {
+        throw new RangeError('Invalid label');
+      }
+    } else if (nonASCII.test(l)) {
+      labels[i] = 'xn--' + encode(l);
+    }
+  }
+  return labels.join('.');
+};
+
+exports.toUnicode = function toUnicode(domain) {
+  var labels = map(domain).split('.');
+  for (var i = 0; i < labels.length; i++) {
+    if (labels[i].slice(0, 4) === 'xn--') {
+      try {
+        labels[i] = decode(labels[i].slice(4));
+      } catch (e) {
+        // keep label as is
+      }
+    }
+  }
+  return labels.join('.');
+};
+`),
+	"internal/module.js": []byte(`//
+// otto.module :: internal/module.js
+//
+//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+exports.require = function require_(m) {
+  var Module = m.constructor;
+
+  function require(id) {
+    return m.require(id);
+  }
+
+  require.cache = Module._cache;
+  require.extensions = Module._extensions;
+
+  require.resolve = function resolve(id) {
+    return Module._resolve(id, m);
+  };
+
+  return require;
+};
+`),
+	"internal/process/task_queues.js": []byte(`//
+// otto.module :: internal/process/task_queues.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('task_queue');
+var util = require('../util');
+
+var ticks = [];
+var microtasks = [];
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function runMicrotasks() {
+  while (microtasks.length > 0) {
+    microtasks.shift()();
+  }
+}
+
+binding.setTickCallback(function processTicksAndRejections() {
+  do {
+    while (ticks.length > 0) {
+      var tock = ticks.shift();
+      if (tock.args === undefined) {
+        tock.callback();
+      } else {
+        tock.callback.apply(undefined, tock.args);
+      }
+    }
+    runMicrotasks();
+  } while (ticks.length > 0);
+});
+
+exports.nextTick = function nextTick(callback) {
+  validateFunction(callback, 'callback');
+  ticks.push({
+    callback: callback,
+    args: arguments.length > 1 ? Array.prototype.slice.call(arguments, 1) : undefined,
+  });
+};
+
+exports.queueMicrotask = function queueMicrotask(callback) {
+  validateFunction(callback, 'callback');
+  microtasks.push(callback);
+};
+`),
+	"internal/process/warning.js": []byte(`//
+// otto.module :: internal/process/warning.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('warning');
+
+var codesWarned = Object.create(null);
+
+function createWarning(message, type, code, detail) {
+  var warning = binding.create(type, message);
+  warning.name = type;
+  if (code !== undefined) {
+    warning.code = code;
+  }
+  if (detail !== undefined) {
+    warning.detail = detail;
+  }
+  return warning;
+}
+
+exports.emitWarning = function emitWarning(warning, type, code) {
+  var detail;
+  if (type !== null
+      && typeof type === 'object'
+      && !Array.isArray(type)) {
+    code = type.code;
+    if (typeof type.detail === 'string') {
+      detail = type.detail;
+    }
+    type = type.type || 'Warning';
+  } else if (typeof type === 'function') {
+    code = undefined;
+    type = 'Warning';
+  }
+  if (type === undefined) {
+    type = 'Warning';
+  } else if (typeof type !== 'string') {
+    throw new TypeError('type must be a String');
+  }
+  if (typeof code === 'function') {
+    code = undefined;
+  } else if (code !== undefined
+             && typeof code !== 'string') {
+    throw new TypeError('code must be a String');
+  }
+
+  if (typeof warning === 'string') {
+    warning = createWarning(warning, type, code, detail);
+  } else if (!(warning instanceof Error)) {
+    throw new TypeError('warning must be a String or Error');
+  }
+
+  if (warning.name === 'DeprecationWarning') {
+    if (process.noDeprecation) {
+      return;
+    } else if (process.throwDeprecation) {
+      throw warning;
+    } else if (warning.code !== undefined) {
+      if (codesWarned[warning.code]) {
+        return;
+      }
+      codesWarned[warning.code] = true;
+    }
+  }
+  process.emit('warning', warning);
+};
+
+exports.onWarning = function onWarning(warning) {
+  binding.emit(warning);
+};
+`),
+	"internal/url.js": []byte(`//
+// otto.module :: internal/url.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var idna = require('./idna');
+var inspect = require('./util/inspect').inspect;
+
+var specialSchemes = {
+  'ftp': 21,
+  'file': null,
+  'http': 80,
+  'https': 443,
+  'ws': 80,
+  'wss': 443,
+};
+
+function isSpecialScheme(scheme) {
+  return Object.prototype.hasOwnProperty.call(specialSchemes, scheme);
+}
+
+function invalidURL(input) {
+  var e = new TypeError('Invalid URL');
+  e.code = 'ERR_INVALID_URL';
+  e.input = input;
+  return e;
+}
+
+function invalidArgType(name, type, v) {
+  var e = new TypeError('The "' + name + '" argument must be of type ' + type + '. Received ' + inspect(v));
+  e.code = 'ERR_INVALID_ARG_TYPE';
+  return e;
+}
+
+exports.invalidURL = invalidURL;
+exports.invalidArgType = invalidArgType;
+
+//
+// code points
+//
+
+function toCodePoints(s) {
+  var list = [];
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charCodeAt(i);
+    if (0xd800 <= c && c <= 0xdbff && i + 1 < s.length) {
+      var d = s.charCodeAt(i + 1);
+      if (0xdc00 <= d && d <= 0xdfff) {
+        list.push(String.fromCharCode(c, d));
+        i++;
+        continue;
+      }
+    }
+    list.push(0xd800 <= c && c <= 0xdfff ? '\ufffd' : s.charAt(i));
+  }
+  return list;
+}
+
+function toUSVString(s) {
+  return toCodePoints(String(s)).join('');
+}
+
+exports.toUSVString = toUSVString;
+
+function isASCIIDigit(c) {
+  return c !== undefined
+         && c.length === 1
+         && '0' <= c && c <= '9';
+}
+
+function isASCIIHexDigit(c) {
+  return isASCIIDigit(c)
+         || (c !== undefined && c.length === 1 && (('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')));
+}
+
+function isASCIIAlpha(c) {
+  return c !== undefined
+         && c.length === 1
+         && (('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'));
+}
+
+function isASCIIAlphanumeric(c) {
+  return isASCIIAlpha(c)
+         || isASCIIDigit(c);
+}
+
+function isWindowsDriveLetter(s) {
+  return s.length === 2
+         && isASCIIAlpha(s.charAt(0))
+         && (s.charAt(1) === ':' || s.charAt(1) === '|');
+}
+
+function isNormalizedWindowsDriveLetter(s) {
+  return isWindowsDriveLetter(s)
+         && s.charAt(1) === ':';
+}
+
+function startsWithWindowsDriveLetter(cps, i) {
+  if (cps.length - i < 2
+      || !isWindowsDriveLetter(cps[i] + cps[i + 1])) {
+    return false;
+  }
+  if (cps.length - i === 2) {
+    return true;
+  }
+  var c = cps[i + 2];
+  return c === '/'
+         || c === '\\'
+         || c === '?'
+         || c === '#';
+}
+
+function isSingleDot(s) {
+  return s === '.'
+         || s.toLowerCase() === '%2e';
+}
+
+function isDoubleDot(s) {
+  switch (s.toLowerCase()) {
+  case '..':
+  case '.%2e':
+  case '%2e.':
+  case '%2e%2e':
+    return true;
+  }
+  return false;
+}
+
+//
+// percent-encoding
+//
+
+function hex(b) {
+  return '%' + (b < 0x10 ? '0' : '') + b.toString(16).toUpperCase();
+}
+
+function c0ControlSet(c) {
+  return c < 0x20
+         || c > 0x7e;
+}
+
+function fragmentSet(c) {
+  return c0ControlSet(c)
+         || c === 0x20
+         || c === 0x22
+         || c === 0x3c
+         || c === 0x3e
+         || c === 0x60;
+}
+
+function querySet(c) {
+  return c0ControlSet(c)
+         || c === 0x20
+         || c === 0x22
+         || c === 0x23
+         || c === 0x3c
+         || c === 0x3e;
+}
+
+function specialQuerySet(c) {
+  return querySet(c)
+         || c === 0x27;
+}
+
+function pathSet(c) {
+  return querySet(c)
+         || c === 0x3f
+         || c === 0x60
+         || c === 0x7b
+         || c === 0x7d;
+}
+
+function userinfoSet(c) {
+  return pathSet(c)
+         || c === 0x2f
+         || c === 0x3a
+         || c === 0x3b
+         || c === 0x3d
+         || c === 0x40
+         || (0x5b <= c && c <= 0x5e)
+         || c === 0x7c;
+}
+
+function utf8Encode(s) {
+  var b = [];
+  for (var i = 0; i < s.length; i++) {
+    var cp = s.charCodeAt(i);
+    if (0xd800 <= cp && cp <= 0xdbff && i + 1 < s.length) {
+      var d = s.charCodeAt(i + 1);
+      if (0xdc00 <= d && d <= 0xdfff) {
+        cp = ((cp - 0xd800) << 10) + (d - 0xdc00) + 0x10000;
+        i++;
+      }
+    }
+    if (0xd800 <= cp && cp <= 0xdfff) {
+      cp = 0xfffd;
+    }
+
+    if (cp < 0x80) {
+      b.push(cp);
+    } else if (cp < 0x800) {
+      b.push(0xc0 | (cp >> 6), 0x80 | (cp & 0x3f));
+    } else if (cp < 0x10000) {
+      b.push(0xe0 | (cp >> 12), 0x80 | ((cp >> 6) & 0x3f), 0x80 | (cp & 0x3f));
+    } else {
+      b.push(0xf0 | (cp >> 18), 0x80 | ((cp >> 12) & 0x3f), 0x80 | ((cp >> 6) & 0x3f), 0x80 | (cp & 0x3f));
+    }
+  }
+  return b;
+}
+
+function utf8Decode(b) {
+  var s = '';
+  for (var i = 0; i < b.length;) {
+    var c = b[i++];
+    var cp;
+    var n;
+    var lower = 0x80;
+    var upper = 0xbf;
+    if (c < 0x80) {
+      s += String.fromCharCode(c);
+      continue;
+    } else if (0xc2 <= c && c <= 0xdf) {
+      n = 1;
+      cp = c & 0x1f;
+    } else if (0xe0 <= c && c <= 0xef) {
+      n = 2;
+      cp = c & 0x0f;
+      if (c === 0xe0) {
+        lower = 0xa0;
+      } else if (c === 0xed) {
+        upper = 0x9f;
+      }
+    } else if (0xf0 <= c && c <= 0xf4) {
+      n = 3;
+      cp = c & 0x07;
+      if (c === 0xf0) {
+        lower = 0x90;
+      } else if (c === 0xf4) {
+        upper = 0x8f;
+      }
+    } else {
+      s += '\ufffd';
+      continue;
+    }
+    for (; n > 0; n--) {
+      if (i >= b.length
+          || b[i] < lower
+          || b[i] > upper) {
+        cp = -1;
+        break;
+      }
+      cp = (cp << 6) | (b[i++] & 0x3f);
+      lower = 0x80;
+      upper = 0xbf;
+    }
+    if (cp < 0) {
+      s += '\ufffd';
+    } else if (cp > 0xffff) {
+      cp -= 0x10000;
+      s += String.fromCharCode(0xd800 + (cp >> 10), 0xdc00 + (cp & 0x3ff));
+    } else {
+      s += String.fromCharCode(cp);
+    }
+  }
+  return s;
+}
+
+function percentEncode(s, set, spaceAsPlus) {
+  var out = '';
+  var b = utf8Encode(s);
+  for (var i = 0; i < b.length; i++) {
+    if (spaceAsPlus && b[i] === 0x20) {
+      out += '+';
+    } else if (set(b[i])) {
+      out += hex(b[i]);
+    } else {
+      out += String.fromCharCode(b[i]);
+    }
+  }
+  return out;
+}
+
+function percentDecode(s) {
+  var b = utf8Encode(s);
+  var out = [];
+  for (var i = 0; i < b.length; i++) {
+    if (b[i] === 0x25
+        && i + 2 < b.length
+        && isASCIIHexDigit(String.fromCharCode(b[i + 1]))
+        && isASCIIHexDigit(String.fromCharCode(b[i + 2]))) {
+      out.push(parseInt(String.fromCharCode(b[i + 1], b[i + 2]), 16));
+      i += 2;
+    } else {
+      out.push(b[i]);
+    }
+  }
+  return out;
+}
+
+exports.percentDecode = function(s) {
+  return utf8Decode(percentDecode(s));
+};
+
+//
+// host
+//
+
+var forbiddenHost = /[\x00\t\n\r #/:<>?@[\\\]^|]/;
+var forbiddenDomain = /[\x00-\x20#%/:<>?@[\\\]^|\x7f]/;
+
+function parseIPv4Number(s) {
+  if (s === '') {
+    return NaN;
+  }
+  var radix = 10;
+  if (s.length >= 2
+      && s.charAt(0) === '0'
+      && (s.charAt(1) === 'x' || s.charAt(1) === 'X')) {
+    s = s.slice(2);
+    radix = 16;
+  } else if (s.length >= 2
+             && s.charAt(0) === '0') {
+    s = s.slice(1);
+    radix = 8;
+  }
+  if (s === '') {
+    return 0;
+  }
+  var re = radix === 10 ? /^[0-9]+$/ : radix === 16 ? /^[0-9A-Fa-f]+$/ : /^[0-7]+$/;
+  return re.test(s) ? parseInt(s, radix) : NaN;
+}
+
+function endsInANumber(s) {
+  var parts = s.split('.');
+  if (parts[parts.length - 1] === '') {
+    if (parts.length === 1) {
+      return false;
+    }
+    parts.pop();
+  }
+  var last = parts[parts.length - 1];
+  return /^[0-9]+$/.test(last)
+         || !isNaN(parseIPv4Number(last));
+}
+
+function parseIPv4(s) {
+  var parts = s.split('.');
+  if (parts[parts.length - 1] === ''
+      && parts.length > 1) {
+    parts.pop();
+  }
+  if (parts.length > 4) {
+    return null;
+  }
+  var numbers = [];
+  for (var i = 0; i < parts.length; i++) {
+    var n = parseIPv4Number(parts[i]);
+    if (isNaN(n)) {
+      return null;
+    }
+    numbers.push(n);
+  }
+  for (i = 0; i < numbers.length - 1; i++) {
+    if (numbers[i] > 255) {
+      return null;
+    }
+  }
+  if (numbers[numbers.length - 1] >= Math.pow(256, 5 - numbers.length)) {
+    return null;
+  }
+  var ipv4 = numbers[numbers.length - 1];
+  for (i = 0; i < numbers.length - 1; i++) {
+    ipv4 += numbers[i] * Math.pow(256, 3 - i);
+  }
+  return ipv4;
+}
+
+function serializeIPv4(n) {
+  var list = [];
+  for (var i = 0; i < 4; i++) {
+    list.unshift(String(n % 256));
+    n = Math.floor(n / 256);
+  }
+  return list.join('.');
+}
+
+function parseIPv6(s) {
+  var address = [0, 0, 0, 0, 0, 0, 0, 0];
+  var pieceIndex = 0;
+  var compress = null;
+  var i = 0;
+  var value;
+  var length;
+  if (s.charAt(i) === ':') {
+    if (s.charAt(i + 1) !== ':') {
+      return null;
+    }
+    i += 2;
+    compress = ++pieceIndex;
+  }
+  while (i < s.length) {
+    if (pieceIndex === 8) {
+      return null;
+    }
+    if (s.charAt(i) === ':') {
+      if (compress !== null) {
+        return null;
+      }
+      i++;
+      compress = ++pieceIndex;
+      continue;
+    }
+    value = length = 0;
+    while (length < 4
+           && isASCIIHexDigit(s.charAt(i))) {
+      value = value * 0x10 + parseInt(s.charAt(i), 16);
+      i++;
+      length++;
+    }
+    if (s.charAt(i) === '.') {
+      if (length === 0) {
+        return null;
+      }
+      i -= length;
+      if (pieceIndex > 6) {
+        return null;
+      }
+      var numbersSeen = 0;
+      while (i < s.length) {
+        var ipv4Piece = null;
+        if (numbersSeen > 0) {
+          if (s.charAt(i) === '.'
+              && numbersSeen < 4) {
+            i++;
+          } else {
+            return null;
+          }
+        }
+        if (!isASCIIDigit(s.charAt(i))) {
+          return null;
+        }
+        while (isASCIIDigit(s.charAt(i))) {
+          var n = parseInt(s.charAt(i), 10);
+          if (ipv4Piece === null) {
+            ipv4Piece = n;
+          } else if (ipv4Piece === 0) {
+            return null;
+          } else {
+            ipv4Piece = ipv4Piece * 10 + n;
+          }
+          if (ipv4Piece > 255) {
+            return null;
+          }
+          i++;
+        }
+        address[pieceIndex] = address[pieceIndex] * 0x100 + ipv4Piece;
+        numbersSeen++;
+        if (numbersSeen === 2
+            || numbersSeen === 4) {
+          pieceIndex++;
+        }
+      }
+      if (numbersSeen !== 4) {
+        return null;
+      }
+      break;
+    } else if (s.charAt(i) === ':') {
+      i++;
+      if (i >= s.length) {
+        return null;
+      }
+    } else if (i < s.length) {
+      return null;
+    }
+    address[pieceIndex++] = value;
+  }
+  if (compress !== null) {
+    var swaps = pieceIndex - compress;
+    pieceIndex = 7;
+    while (pieceIndex !== 0
+           && swaps > 0) {
+      var t = address[compress + swaps - 1];
+      address[compress + swaps - 1] = address[pieceIndex];
+      address[pieceIndex] = t;
+      pieceIndex--;
+      swaps--;
+    }
+  } else if (pieceIndex !== 8) {
+    return null;
+  }
+  return address;
+}
+
+function serializeIPv6(address) {
+  var compress = null;
+  var max = 1;
+  for (var i = 0; i < 8;) {
+    if (address[i] !== 0) {
+      i++;
+      continue;
+    }
+    var j = i;
+    while (j < 8
+           && address[j] === 0) {
+      j++;
+    }
+    if (j - i > max) {
+      max = j - i;
+      compress = i;
+    }
+    i = j;
+  }
+
+  var out = '';
+  var ignore0 = false;
+  for (i = 0; i < 8; i++) {
+    if (ignore0) {
+      if (address[i] === 0) {
+        continue;
+      }
+      ignore0 = false;
+    }
+    if (compress === i) {
+      out += i === 0 ? '::' : ':';
+      ignore0 = true;
+      continue;
+    }
+    out += address[i].toString(16);
+    if (i !== 7) {
+      out += ':';
+    }
+  }
+  return out;
+}
+
+function domainToASCII(domain) {
+  try {
+    return idna.toASCII(domain);
+  } catch (e) {
+    return null;
+  }
+}
+
+function parseHost(input, isNotSpecial) {
+  if (input.charAt(0) === '[') {
+    if (input.charAt(input.length - 1) !== ']') {
+      return null;
+    }
+    var address = parseIPv6(input.slice(1, -1));
+    return address ? '[' + serializeIPv6(address) + ']' : null;
+  }
+  if (isNotSpecial) {
+    return forbiddenHost.test(input) ? null : percentEncode(input, c0ControlSet);
+  }
+
+  var ascii = domainToASCII(utf8Decode(percentDecode(input)));
+  if (!ascii
+      || forbiddenDomain.test(ascii)) {
+    return null;
+  }
+  if (endsInANumber(ascii)) {
+    var ipv4 = parseIPv4(ascii);
+    return ipv4 !== null ? serializeIPv4(ipv4) : null;
+  }
+  return ascii;
+}
+
+exports.domainToASCII = function domainToASCII(domain) {
+  var host = parseHost(toUSVString(domain), false);
+  return host !== null ? host : '';
+};
+
+exports.domainToUnicode = function domainToUnicode(domain) {
+  var host = parseHost(toUSVString(domain), false);
+  return host !== null ? idna.toUnicode(host) : '';
+};
+
+//
+// URL record
+//
+
+function Record() {
+  this.scheme = '';
+  this.username = '';
+  this.password = '';
+  this.host = null;
+  this.port = null;
+  this.path = [];
+  this.query = null;
+  this.fragment = null;
+}
+
+Record.prototype.isSpecial = function isSpecial() {
+  return isSpecialScheme(this.scheme);
+};
+
+Record.prototype.hasOpaquePath = function hasOpaquePath() {
+  return typeof this.path === 'string';
+};
+
+Record.prototype.includesCredentials = function includesCredentials() {
+  return this.username !== ''
+         || this.password !== '';
+};
+
+Record.prototype.cannotHaveUsernamePasswordPort = function cannotHaveUsernamePasswordPort() {
+  return this.host === null
+         || this.host === ''
+         || this.scheme === 'file';
+};
+
+Record.prototype.shortenPath = function shortenPath() {
+  if (this.scheme === 'file'
+      && this.path.length === 1
+      && isNormalizedWindowsDriveLetter(this.path[0])) {
+    return;
+  }
+  this.path.pop();
+};
+
+Record.prototype.serializePath = function serializePath() {
+  if (this.hasOpaquePath()) {
+    return this.path;
+  }
+  var s = '';
+  for (var i = 0; i < this.path.length; i++) {
+    s += '/' + this.path[i];
+  }
+  return s;
+};
+
+Record.prototype.serialize = function serialize(excludeFragment) {
+  var s = this.scheme + ':';
+  if (this.host !== null) {
+    s += '//';
+    if (this.includesCredentials()) {
+      s += this.username;
+      if (this.password !== '') {
+        s += ':' + this.password;
+      }
+      s += '@';
+    }
+    s += this.host;
+    if (this.port !== null) {
+      s += ':' + this.port;
+    }
+  } else if (!this.hasOpaquePath()
+             && this.path.length > 1
+             && this.path[0] === '') {
+    s += '/.';
+  }
+  s += this.serializePath();
+  if (this.query !== null) {
+    s += '?' + this.query;
+  }
+  if (!excludeFragment
+      && this.fragment !== null) {
+    s += '#' + this.fragment;
+  }
+  return s;
+};
+
+Record.prototype.origin = function origin() {
+  switch (this.scheme) {
+  case 'blob':
+    var u = parse(this.serializePath());
+    if (u
+        && (u.scheme === 'http' || u.scheme === 'https')) {
+      return u.origin();
+    }
+    return 'null';
+  case 'ftp':
+  case 'http':
+  case 'https':
+  case 'ws':
+  case 'wss':
+    return this.scheme + '://' + this.host + (this.port !== null ? ':' + this.port : '');
+  }
+  return 'null';
+};
+
+//
+// basic URL parser
+//
+
+var SCHEME_START = 1;
+var SCHEME = 2;
+var NO_SCHEME = 3;
+var SPECIAL_RELATIVE_OR_AUTHORITY = 4;
+var PATH_OR_AUTHORITY = 5;
+var RELATIVE = 6;
+var RELATIVE_SLASH = 7;
+var SPECIAL_AUTHORITY_SLASHES = 8;
+var SPECIAL_AUTHORITY_IGNORE_SLASHES = 9;
+var AUTHORITY = 10;
+var HOST = 11;
+var HOSTNAME = 12;
+var PORT = 13;
+var FILE = 14;
+var FILE_SLASH = 15;
+var FILE_HOST = 16;
+var PATH_START = 17;
+var PATH = 18;
+var OPAQUE_PATH = 19;
+var QUERY = 20;
+var FRAGMENT = 21;
+
+var FAILURE = {};
+
+function parse(input, base, url, override) {
+  if (!url) {
+    url = new Record();
+    input = input.replace(/^[\x00-\x20]+|[\x00-\x20]+$/g, '');
+  }
+  input = input.replace(/[\t\n\r]/g, '');
+
+  var state = override || SCHEME_START;
+  var buffer = '';
+  var atSignSeen = false;
+  var insideBrackets = false;
+  var passwordTokenSeen = false;
+  var cps = toCodePoints(input);
+  var i;
+  for (var p = 0; p <= cps.length; p++) {
+    var c = cps[p];
+    switch (state) {
+    case SCHEME_START:
+      if (isASCIIAlpha(c)) {
+        buffer += c.toLowerCase();
+        state = SCHEME;
+      } else if (!override) {
+        state = NO_SCHEME;
+        p--;
+      } else {
+        return FAILURE;
+      }
+      break;
+    case SCHEME:
+      if (isASCIIAlphanumeric(c)
+          || c === '+'
+          || c === '-'
+          || c === '.') {
+        buffer += c.toLowerCase();
+      } else if (c === ':') {
+        if (override) {
+          if (url.isSpecial() !== isSpecialScheme(buffer)
+              || ((url.includesCredentials() || url.port !== null) && buffer === 'file')
+              || (url.scheme === 'file' && url.host === '')) {
+            return url;
+          }
+        }
+        url.scheme = buffer;
+        if (override) {
+          if (url.port === specialSchemes[url.scheme]) {
+            url.port = null;
+          }
+          return url;
+        }
+        buffer = '';
+        if (url.scheme === 'file') {
+          state = FILE;
+        } else if (url.isSpecial()
+                   && base
+                   && base.scheme === url.scheme) {
+          state = SPECIAL_RELATIVE_OR_AUTHORITY;
+        } else if (url.isSpecial()) {
+          state = SPECIAL_AUTHORITY_SLASHES;
+        } else if (cps[p + 1] === '/') {
+          state = PATH_OR_AUTHORITY;
+          p++;
+        } else {
+          url.path = '';
+          state = OPAQUE_PATH;
+        }
+      } else if (!override) {
+        buffer = '';
+        state = NO_SCHEME;
+        p = -1;
+      } else {
+        return FAILURE;
+      }
+      break;
+    case NO_SCHEME:
+      if (!base
+          || (base.hasOpaquePath() && c !== '#')) {
+        return FAILURE;
+      } else if (base.hasOpaquePath()) {
+        url.scheme = base.scheme;
+        url.path = base.path;
+        url.query = base.query;
+        url.fragment = '';
+        state = FRAGMENT;
+      } else {
+        state = base.scheme !== 'file' ? RELATIVE : FILE;
+        p--;
+      }
+      break;
+    case SPECIAL_RELATIVE_OR_AUTHORITY:
+      if (c === '/'
+          && cps[p + 1] === '/') {
+        state = SPECIAL_AUTHORITY_IGNORE_SLASHES;
+        p++;
+      } else {
+        state = RELATIVE;
+        p--;
+      }
+      break;
+    case PATH_OR_AUTHORITY:
+      if (c === '/') {
+        state = AUTHORITY;
+      } else {
+        state = PATH;
+        p--;
+      }
+      break;
+    case RELATIVE:
+      url.scheme = base.scheme;
+      if (c === '/'
+          || (url.isSpecial() && c === '\\')) {
+        state = RELATIVE_SLASH;
+      } else {
+        url.username = base.username;
+        url.password = base.password;
+        url.host = base.host;
+        url.port = base.port;
+        url.path = base.path.slice();
+        url.query = base.query;
+        if (c === '?') {
+          url.query = '';
+          state = QUERY;
+        } else if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        } else if (c !== undefined) {
+          url.query = null;
+          url.shortenPath();
+          state = PATH;
+          p--;
+        }
+      }
+      break;
+    case RELATIVE_SLASH:
+      if (url.isSpecial()
+          && (c === '/' || c === '\\')) {
+        state = SPECIAL_AUTHORITY_IGNORE_SLASHES;
+      } else if (c === '/') {
+        state = AUTHORITY;
+      } else {
+        url.username = base.username;
+        url.password = base.password;
+        url.host = base.host;
+        url.port = base.port;
+        state = PATH;
+        p--;
+      }
+      break;
+    case SPECIAL_AUTHORITY_SLASHES:
+      if (c === '/'
+          && cps[p + 1] === '/') {
+        p++;
+      } else {
+        p--;
+      }
+      state = SPECIAL_AUTHORITY_IGNORE_SLASHES;
+      break;
+    case SPECIAL_AUTHORITY_IGNORE_SLASHES:
+      if (c !== '/'
+          && c !== '\\') {
+        state = AUTHORITY;
+        p--;
+      }
+      break;
+    case AUTHORITY:
+      if (c === '@') {
+        if (atSignSeen) {
+          buffer = '%40' + buffer;
+        }
+        atSignSeen = true;
+        var bcps = toCodePoints(buffer);
+        for (i = 0; i < bcps.length; i++) {
+          if (bcps[i] === ':'
+              && !passwordTokenSeen) {
+            passwordTokenSeen = true;
+            continue;
+          }
+          if (passwordTokenSeen) {
+            url.password += percentEncode(bcps[i], userinfoSet);
+          } else {
+            url.username += percentEncode(bcps[i], userinfoSet);
+          }
+        }
+        buffer = '';
+      } else if (c === undefined
+                 || c === '/'
+                 || c === '?'
+                 || c === '#'
+                 || (url.isSpecial() && c === '\\')) {
+        if (atSignSeen
+            && buffer === '') {
+          return FAILURE;
+        }
+        p -= toCodePoints(buffer).length + 1;
+        buffer = '';
+        state = HOST;
+      } else {
+        buffer += c;
+      }
+      break;
+    case HOST:
+    case HOSTNAME:
+      if (override
+          && url.scheme === 'file') {
+        state = FILE_HOST;
+        p--;
+      } else if (c === ':'
+                 && !insideBrackets) {
+        if (buffer === '') {
+          return FAILURE;
+        } else if (override === HOSTNAME) {
+          return url;
+        }
+        var host = parseHost(buffer, !url.isSpecial());
+        if (host === null) {
+          return FAILURE;
+        }
+        url.host = host;
+        buffer = '';
+        state = PORT;
+      } else if (c === undefined
+                 || c === '/'
+                 || c === '?'
+                 || c === '#'
+                 || (url.isSpecial() && c === '\\')) {
+        p--;
+        if (url.isSpecial()
+            && buffer === '') {
+          return FAILURE;
+        } else if (override
+                   && buffer === ''
+                   && (url.includesCredentials() || url.port !== null)) {
+          return url;
+        }
+        host = parseHost(buffer, !url.isSpecial());
+        if (host === null) {
+          return FAILURE;
+        }
+        url.host = host;
+        buffer = '';
+        state = PATH_START;
+        if (override) {
+          return url;
+        }
+      } else {
+        if (c === '[') {
+          insideBrackets = true;
+        } else if (c === ']') {
+          insideBrackets = false;
+        }
+        buffer += c;
+      }
+      break;
+    case PORT:
+      if (isASCIIDigit(c)) {
+        buffer += c;
+      } else if (c === undefined
+                 || c === '/'
+                 || c === '?'
+                 || c === '#'
+                 || (url.isSpecial() && c === '\\')
+                 || override) {
+        if (buffer !== '') {
+          var port = parseInt(buffer, 10);
+          if (port > 0xffff) {
+            return FAILURE;
+          }
+          url.port = port === specialSchemes[url.scheme] ? null : port;
+          buffer = '';
+        }
+        if (override) {
+          return url;
+        }
+        state = PATH_START;
+        p--;
+      } else {
+        return FAILURE;
+      }
+      break;
+    case FILE:
+      url.scheme = 'file';
+      url.host = '';
+      if (c === '/'
+          || c === '\\') {
+        state = FILE_SLASH;
+      } else if (base
+                 && base.scheme === 'file') {
+        url.host = base.host;
+        url.path = base.path.slice();
+        url.query = base.query;
+        if (c === '?') {
+          url.query = '';
+          state = QUERY;
+        } else if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        } else if (c !== undefined) {
+          url.query = null;
+          if (!startsWithWindowsDriveLetter(cps, p)) {
+            url.shortenPath();
+          } else {
+            url.path = [];
+          }
+          state = PATH;
+          p--;
+        }
+      } else {
+        state = PATH;
+        p--;
+      }
+      break;
+    case FILE_SLASH:
+      if (c === '/'
+          || c === '\\') {
+        state = FILE_HOST;
+      } else {
+        if (base
+            && base.scheme === 'file') {
+          url.host = base.host;
+          if (!startsWithWindowsDriveLetter(cps, p)
+              && isNormalizedWindowsDriveLetter(base.path[0] || '')) {
+            url.path.push(base.path[0]);
+          }
+        }
+        state = PATH;
+        p--;
+      }
+      break;
+    case FILE_HOST:
+      if (c === undefined
+          || c === '/'
+          || c === '\\'
+          || c === '?'
+          || c === '#') {
+        p--;
+        if (!override
+            && isWindowsDriveLetter(buffer)) {
+          state = PATH;
+        } else if (buffer === '') {
+          url.host = '';
+          if (override) {
+            return url;
+          }
+          state = PATH_START;
+        } else {
+          host = parseHost(buffer, !url.isSpecial());
+          if (host === null) {
+            return FAILURE;
+          }
+          url.host = host === 'localhost' ? '' : host;
+          if (override) {
+            return url;
+          }
+          buffer = '';
+          state = PATH_START;
+        }
+      } else {
+        buffer += c;
+      }
+      break;
+    case PATH_START:
+      if (url.isSpecial()) {
+        state = PATH;
+        if (c !== '/'
+            && c !== '\\') {
+          p--;
+        }
+      } else if (!override
+                 && c === '?') {
+        url.query = '';
+        state = QUERY;
+      } else if (!override
+                 && c === '#') {
+        url.fragment = '';
+        state = FRAGMENT;
+      } else if (c !== undefined) {
+        state = PATH;
+        if (c !== '/') {
+          p--;
+        }
+      } else if (override
+                 && url.host === null) {
+        url.path.push('');
+      }
+      break;
+    case PATH:
+      if (c === undefined
+          || c === '/'
+          || (url.isSpecial() && c === '\\')
+          || (!override && (c === '?' || c === '#'))) {
+        var slash = c === '/'
+                    || (url.isSpecial() && c === '\\');
+        if (isDoubleDot(buffer)) {
+          url.shortenPath();
+          if (!slash) {
+            url.path.push('');
+          }
+        } else if (isSingleDot(buffer)) {
+          if (!slash) {
+            url.path.push('');
+          }
+        } else {
+          if (url.scheme === 'file'
+              && url.path.length === 0
+              && isWindowsDriveLetter(buffer)) {
+            buffer = buffer.charAt(0) + ':';
+          }
+          url.path.push(buffer);
+        }
+        buffer = '';
+        if (c === '?') {
+          url.query = '';
+          state = QUERY;
+        } else if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        }
+      } else {
+        buffer += percentEncode(c, pathSet);
+      }
+      break;
+    case OPAQUE_PATH:
+      if (c === '?') {
+        url.query = '';
+        state = QUERY;
+      } else if (c === '#') {
+        url.fragment = '';
+        state = FRAGMENT;
+      } else if (c !== undefined) {
+        url.path += percentEncode(c, c0ControlSet);
+      }
+      break;
+    case QUERY:
+      if (c === undefined
+          || (!override && c === '#')) {
+        url.query += percentEncode(buffer, url.isSpecial() ? specialQuerySet : querySet);
+        buffer = '';
+        if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        }
+      } else {
+        buffer += c;
+      }
+      break;
+    case FRAGMENT:
+      if (c !== undefined) {
+        url.fragment += percentEncode(c, fragmentSet);
+      }
+      break;
+    }
+  }
+  return url;
+}
+
+//
+// application/x-www-form-urlencoded
+//
+
+function formUrlencodedSet(c) {
+  return !(isASCIIAlphanumeric(String.fromCharCode(c))
+           || c === 0x2a
+           || c === 0x2d
+           || c === 0x2e
+           || c === 0x5f);
+}
+
+function parseUrlencoded(s) {
+  var list = [];
+  s.split('&').forEach(function(seq) {
+    if (seq === '') {
+      return;
+    }
+    var i = seq.indexOf('=');
+    var name = i !== -1 ? seq.slice(0, i) : seq;
+    var value = i !== -1 ? seq.slice(i + 1) : '';
+    list.push([
+      utf8Decode(percentDecode(name.replace(/\+/g, ' '))),
+      utf8Decode(percentDecode(value.replace(/\+/g, ' '))),
+    ]);
+  });
+  return list;
+}
+
+function serializeUrlencoded(list) {
+  return list.map(function(pair) {
+    return percentEncode(pair[0], formUrlencodedSet, true) + '=' + percentEncode(pair[1], formUrlencodedSet, true);
+  }).join('&');
+}
+
+//
+// URLSearchParams
+//
+
+function URLSearchParams(init) {
+  if (!(this instanceof URLSearchParams)) {
+    throw new TypeError("Class constructor URLSearchParams cannot be invoked without 'new'");
+  }
+
+  this._list = [];
+  this._url = null;
+  if (init === undefined
+      || init === null) {
+    return;
+  } else if (init instanceof URLSearchParams) {
+    this._list = init._list.map(function(pair) {
+      return pair.slice();
+    });
+  } else if (typeof init === 'object'
+             || typeof init === 'function') {
+    if (typeof init.length === 'number'
+        && typeof init !== 'function') {
+      for (var i = 0; i < init.length; i++) {
+        var pair = init[i];
+        if ((typeof pair !== 'object' && typeof pair !== 'function')
+            || pair === null
+            || typeof pair.length !== 'number') {
+          throw invalidTuple();
+        } else if (pair.length !== 2) {
+          throw invalidTuple();
+        }
+        this._list.push([toUSVString(pair[0]), toUSVString(pair[1])]);
+      }
+    } else {
+      Object.keys(init).forEach(function(k) {
+        this._list.push([toUSVString(k), toUSVString(init[k])]);
+      }, this);
+    }
+  } else {
+    init = toUSVString(init);
+    this._list = parseUrlencoded(init.charAt(0) === '?' ? init.slice(1) : init);
+  }
+}
+
+function invalidTuple() {
+  var e = new TypeError('Each query pair must be an iterable [name, value] tuple');
+  e.code = 'ERR_INVALID_TUPLE';
+  return e;
+}
+
+function missingArgs(names) {
+  var e = new TypeError('The ' + names.map(function(n) {
+    return '"' + n + '"';
+  }).join(' and ') + ' argument' + (names.length > 1 ? 's' : '') + ' must be specified');
+  e.code = 'ERR_MISSING_ARGS';
+  return e;
+}
+
+URLSearchParams.prototype._update = function _update() {
+  if (this._url !== null) {
+    var s = serializeUrlencoded(this._list);
+    this._url.query = s !== '' ? s : null;
+  }
+};
+
+URLSearchParams.prototype.append = function append(name, value) {
+  if (arguments.length < 2) {
+    throw missingArgs(['name', 'value']);
+  }
+  this._list.push([toUSVString(name), toUSVString(value)]);
+  this._update();
+};
+
+URLSearchParams.prototype.delete = function(name, value) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  if (value !== undefined) {
+    value = toUSVString(value);
+  }
+  this._list = this._list.filter(function(pair) {
+    return !(pair[0] === name && (value === undefined || pair[1] === value));
+  });
+  this._update();
+};
+
+URLSearchParams.prototype.get = function get(name) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  for (var i = 0; i < this._list.length; i++) {
+    if (this._list[i][0] === name) {
+      return this._list[i][1];
+    }
+  }
+  return null;
+};
+
+URLSearchParams.prototype.getAll = function getAll(name) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  return this._list.filter(function(pair) {
+    return pair[0] === name;
+  }).map(function(pair) {
+    return pair[1];
+  });
+};
+
+URLSearchParams.prototype.has = function has(name, value) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  if (value !== undefined) {
+    value = toUSVString(value);
+  }
+  return this._list.some(function(pair) {
+    return pair[0] === name
+           && (value === undefined || pair[1] === value);
+  });
+};
+
+URLSearchParams.prototype.set = function set(name, value) {
+  if (arguments.length < 2) {
+    throw missingArgs(['name', 'value']);
+  }
+  name = toUSVString(name);
+  value = toUSVString(value);
+  var found = false;
+  this._list = this._list.filter(function(pair) {
+    if (pair[0] !== name) {
+      return true;
+    } else if (!found) {
+      pair[1] = value;
+      found = true;
+      return true;
+    }
+    return false;
+  });
+  if (!found) {
+    this._list.push([name, value]);
+  }
+  this._update();
+};
+
+URLSearchParams.prototype.sort = function sort() {
+  // stable sort by code units
+  var list = this._list.map(function(pair, i) {
+    return [pair, i];
+  });
+  list.sort(function(a, b) {
+    if (a[0][0] < b[0][0]) {
+      return -1;
+    } else if (a[0][0] > b[0][0]) {
+      return 1;
+    }
+    return a[1] - b[1];
+  });
+  this._list = list.map(function(e) {
+    return e[0];
+  });
+  this._update();
+};
+
+URLSearchParams.prototype.forEach = function forEach(callback, thisArg) {
+  if (typeof callback !== 'function') {
+    throw invalidArgType('callback', 'function', callback);
+  }
+  for (var i = 0; i < this._list.length; i++) {
+    callback.call(thisArg, this._list[i][1], this._list[i][0], this);
+  }
+};
+
+function Iterator(params, kind) {
+  this._params = params;
+  this._kind = kind;
+  this._index = 0;
+}
+
+Iterator.prototype.next = function next() {
+  var list = this._params._list;
+  if (this._index >= list.length) {
+    return { value: undefined, done: true };
+  }
+  var pair = list[this._index++];
+  switch (this._kind) {
+  case 'keys':
+    return { value: pair[0], done: false };
+  case 'values':
+    return { value: pair[1], done: false };
+  }
+  return { value: [pair[0], pair[1]], done: false };
+};
+
+URLSearchParams.prototype.keys = function keys() {
+  return new Iterator(this, 'keys');
+};
+
+URLSearchParams.prototype.values = function values() {
+  return new Iterator(this, 'values');
+};
+
+URLSearchParams.prototype.entries = function entries() {
+  return new Iterator(this, 'entries');
+};
+
+URLSearchParams.prototype.toString = function toString() {
+  return serializeUrlencoded(this._list);
+};
+
+Object.defineProperty(URLSearchParams.prototype, 'size', {
+  get: function() {
+    return this._list.length;
+  },
+  configurable: true,
+});
+
+URLSearchParams.prototype[inspect.custom] = function(depth, options) {
+  if (typeof depth === 'number'
+      && depth < 0) {
+    return '[URLSearchParams]';
+  }
+  if (this._list.length === 0) {
+    return 'URLSearchParams {}';
+  }
+  return 'URLSearchParams { ' + this._list.map(function(pair) {
+    return inspect(pair[0], options) + ' => ' + inspect(pair[1], options);
+  }).join(', ') + ' }';
+};
+
+if (typeof Symbol === 'function'
+    && Symbol.iterator) {
+  URLSearchParams.prototype[Symbol.iterator] = URLSearchParams.prototype.entries;
+  Iterator.prototype[Symbol.iterator] = function() {
+    return this;
+  };
+}
+
+exports.URLSearchParams = URLSearchParams;
+
+//
+// URL
+//
+
+function URL(input, base) {
+  if (!(this instanceof URL)) {
+    throw new TypeError("Class constructor URL cannot be invoked without 'new'");
+  } else if (arguments.length < 1) {
+    throw missingArgs(['url']);
+  }
+
+  input = toUSVString(input);
+  var b;
+  if (base !== undefined) {
+    b = parse(toUSVString(base));
+    if (b === FAILURE) {
+      throw invalidURL(String(base));
+    }
+  }
+  var url = parse(input, b);
+  if (url === FAILURE) {
+    throw invalidURL(input);
+  }
+  this._url = url;
+  this._searchParams = new URLSearchParams(url.query || '');
+  this._searchParams._url = url;
+}
+
+URL.canParse = function canParse(input, base) {
+  if (arguments.length < 1) {
+    throw missingArgs(['url']);
+  }
+  var b;
+  if (base !== undefined) {
+    b = parse(toUSVString(base));
+    if (b === FAILURE) {
+      return false;
+    }
+  }
+  return parse(toUSVString(input), b) !== FAILURE;
+};
+
+function reparse(self, value, state) {
+  parse(toUSVString(value), null, self._url, state);
+}
+
+Object.defineProperties(URL.prototype, {
+  href: {
+    get: function() {
+      return this._url.serialize();
+    },
+    set: function(value) {
+      value = toUSVString(value);
+      var url = parse(value);
+      if (url === FAILURE) {
+        throw invalidURL(value);
+      }
+      this._url = url;
+      this._searchParams._list = parseUrlencoded(url.query || '');
+      this._searchParams._url = url;
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  origin: {
+    get: function() {
+      return this._url.origin();
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  protocol: {
+    get: function() {
+      return this._url.scheme + ':';
+    },
+    set: function(value) {
+      reparse(this, toUSVString(value) + ':', SCHEME_START);
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  username: {
+    get: function() {
+      return this._url.username;
+    },
+    set: function(value) {
+      if (!this._url.cannotHaveUsernamePasswordPort()) {
+        this._url.username = percentEncode(toUSVString(value), userinfoSet);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  password: {
+    get: function() {
+      return this._url.password;
+    },
+    set: function(value) {
+      if (!this._url.cannotHaveUsernamePasswordPort()) {
+        this._url.password = percentEncode(toUSVString(value), userinfoSet);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  host: {
+    get: function() {
+      var url = this._url;
+      if (url.host === null) {
+        return '';
+      }
+      return url.port !== null ? url.host + ':' + url.port : url.host;
+    },
+    set: function(value) {
+      if (!this._url.hasOpaquePath()) {
+        reparse(this, value, HOST);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  hostname: {
+    get: function() {
+      return this._url.host !== null ? this._url.host : '';
+    },
+    set: function(value) {
+      if (!this._url.hasOpaquePath()) {
+        reparse(this, value, HOSTNAME);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  port: {
+    get: function() {
+      return this._url.port !== null ? String(this._url.port) : '';
+    },
+    set: function(value) {
+      if (!this._url.cannotHaveUsernamePasswordPort()) {
+        value = toUSVString(value);
+        if (value === '') {
+          this._url.port = null;
+        } else {
+          reparse(this, value, PORT);
+        }
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  pathname: {
+    get: function() {
+      return this._url.serializePath();
+    },
+    set: function(value) {
+      if (!this._url.hasOpaquePath()) {
+        this._url.path = [];
+        reparse(this, value, PATH_START);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  search: {
+    get: function() {
+      var q = this._url.query;
+      return q !== null && q !== '' ? '?' + q : '';
+    },
+    set: function(value) {
+      value = toUSVString(value);
+      if (value === '') {
+        this._url.query = null;
+        this._searchParams._list = [];
+        return;
+      }
+      if (value.charAt(0) === '?') {
+        value = value.slice(1);
+      }
+      this._url.query = '';
+      reparse(this, value, QUERY);
+      this._searchParams._list = parseUrlencoded(value);
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  searchParams: {
+    get: function() {
+      return this._searchParams;
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  hash: {
+    get: function() {
+      var f = this._url.fragment;
+      return f !== null && f !== '' ? '#' + f : '';
+    },
+    set: function(value) {
+      value = toUSVString(value);
+      if (value === '') {
+        this._url.fragment = null;
+        return;
+      }
+      if (value.charAt(0) === '#') {
+        value = value.slice(1);
+      }
+      this._url.fragment = '';
+      reparse(this, value, FRAGMENT);
+    },
+    enumerable: true,
+    configurable: true,
+  },
+});
+
+URL.prototype.toString = function toString() {
+  return this.href;
+};
+
+URL.prototype.toJSON = function toJSON() {
+  return this.href;
+};
+
+URL.prototype[inspect.custom] = function(depth, options) {
+  if (typeof depth === 'number'
+      && depth < 0) {
+    return this;
+  }
+  var o = {};
+  ['href', 'origin', 'protocol', 'username', 'password', 'host', 'hostname', 'port', 'pathname', 'search', 'searchParams', 'hash'].forEach(function(k) {
+    o[k] = this[k];
+  }, this);
+  return 'URL ' + inspect(o, options);
+};
+
+exports.URL = URL;
+
+exports.isURL = function isURL(v) {
+  return v instanceof URL;
+};
+`),
+	"internal/util/comparisons.js": []byte(`//
+// otto.module :: internal/util/comparisons.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+var propertyIsEnumerable = Object.prototype.propertyIsEnumerable;
+var toString = Object.prototype.toString;
+
+function is(a, b) {
+  if (a === b) {
+    return a !== 0 || 1 / a === 1 / b;
+  }
+  return a !== a && b !== b;
+}
+
+function isBoxed(tag) {
+  return tag === 'Number'
+         || tag === 'String'
+         || tag === 'Boolean';
+}
+
+function compare(a, b, strict, memos) {
+  if (strict ? is(a, b) : a == b || (a !== a && b !== b)) { // eslint-disable-line eqeqeq
+    return true;
+  }
+
+  if (strict) {
+    if (typeof a !== 'object'
+        || typeof b !== 'object'
+        || a === null
+        || b === null) {
+      return false;
+    } else if (Object.getPrototypeOf(a) !== Object.getPrototypeOf(b)) {
+      return false;
+    }
+  } else if (a === null
+             || typeof a !== 'object') {
+    return b === null
+           || typeof b !== 'object' ? a == b : false; // eslint-disable-line eqeqeq
+  } else if (b === null
+             || typeof b !== 'object') {
+    return false;
+  }
+
+  var tag = toString.call(a);
+  if (tag !== toString.call(b)) {
+    return false;
+  }
+  tag = tag.slice(8, -1);
+
+  if (tag === 'Array') {
+    if (a.length !== b.length) {
+      return false;
+    }
+  } else if (tag === 'Date') {
+    if (a.getTime() !== b.getTime()) {
+      return false;
+    }
+  } else if (tag === 'RegExp') {
+    if (String(a) !== String(b)
+        || a.lastIndex !== b.lastIndex) {
+      return false;
+    }
+  } else if (tag === 'Error'
+             || a instanceof Error) {
+    if (a.message !== b.message
+        || a.name !== b.name) {
+      return false;
+    }
+  } else if (isBoxed(tag)) {
+    if (!is(a.valueOf(), b.valueOf())) {
+      return false;
+    }
+  } else if (typeof a.equals === 'function'
+             && typeof a.compare === 'function'
+             && a.constructor === b.constructor
+             && a.constructor.isBuffer
+             && a.constructor.isBuffer(a)) {
+    if (!a.equals(b)) {
+      return false;
+    }
+  }
+
+  var ka = Object.keys(a);
+  var kb = Object.keys(b);
+  if (ka.length !== kb.length) {
+    return false;
+  }
+  var i;
+  for (i = 0; i < ka.length; i++) {
+    if (!(hasOwnProperty.call(b, ka[i])
+          && propertyIsEnumerable.call(b, ka[i]))) {
+      return false;
+    }
+  }
+
+  // circular
+  for (i = 0; i < memos.length; i++) {
+    if (memos[i][0] === a
+        && memos[i][1] === b) {
+      return true;
+    }
+  }
+  memos.push([a, b]);
+  try {
+    for (i = 0; i < ka.length; i++) {
+      if (!compare(a[ka[i]], b[ka[i]], strict, memos)) {
+        return false;
+      }
+    }
+  } finally {
+    memos.pop();
+  }
+  return true;
+}
+
+exports.isDeepEqual = function isDeepEqual(a, b) {
+  return compare(a, b, false, []);
+};
+
+exports.isDeepStrictEqual = function isDeepStrictEqual(a, b) {
+  return compare(a, b, true, []);
+};
+`),
+	"internal/util/inspect.js": []byte(`//
+// otto.module :: internal/util/inspect.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+var toString = Object.prototype.toString;
+
+var customInspectSymbol = typeof Symbol === 'function' ? Symbol.for('nodejs.util.inspect.custom') : 'inspect';
+
+var defaultOptions = {
+  showHidden: false,
+  depth: 2,
+  colors: false,
+  customInspect: true,
+  maxArrayLength: 100,
+  maxStringLength: 10000,
+  breakLength: 80,
+  compact: 3,
+  sorted: false,
+};
+
+var colors = {
+  bold: [1, 22],
+  italic: [3, 23],
+  underline: [4, 24],
+  inverse: [7, 27],
+  white: [37, 39],
+  grey: [90, 39],
+  black: [30, 39],
+  blue: [34, 39],
+  cyan: [36, 39],
+  green: [32, 39],
+  magenta: [35, 39],
+  red: [31, 39],
+  yellow: [33, 39],
+};
+colors.gray = colors.grey;
+
+var styles = {
+  special: 'cyan',
+  number: 'yellow',
+  bigint: 'yellow',
+  boolean: 'yellow',
+  undefined: 'grey',
+  null: 'bold',
+  string: 'green',
+  symbol: 'green',
+  date: 'magenta',
+  regexp: 'red',
+  module: 'underline',
+};
+
+var meta = {
+  '\b': '\\b',
+  '\t': '\\t',
+  '\n': '\\n',
+  '\v': '\\v',
+  '\f': '\\f',
+  '\r': '\\r',
+  '\\': '\\\\',
+};
+
+var keyRx = /^[A-Za-z_][0-9A-Za-z_]*$/;
+
+function tagOf(v) {
+  return toString.call(v).slice(8, -1);
+}
+
+function getName(fn) {
+  if (typeof fn !== 'function') {
+    return '';
+  } else if (typeof fn.name === 'string') {
+    return fn.name;
+  }
+  var m = /^\s*function\s*([^\s(]*)/.exec(Function.prototype.toString.call(fn));
+  return m ? m[1] : '';
+}
+
+function getConstructorName(obj) {
+  var proto = Object.getPrototypeOf(obj);
+  while (proto !== null) {
+    var desc = Object.getOwnPropertyDescriptor(proto, 'constructor');
+    if (desc
+        && typeof desc.value === 'function'
+        && getName(desc.value) !== '') {
+      return getName(desc.value);
+    }
+    proto = Object.getPrototypeOf(proto);
+  }
+  return null;
+}
+
+function stylize(ctx, s, type) {
+  if (ctx.colors) {
+    var style = inspect.styles[type];
+    if (style !== undefined) {
+      var color = inspect.colors[style];
+      if (color !== undefined) {
+        return '\u001b[' + color[0] + 'm' + s + '\u001b[' + color[1] + 'm';
+      }
+    }
+  }
+  return s;
+}
+
+function quote(s) {
+  var q = '\'';
+  if (s.indexOf(q) !== -1) {
+    if (s.indexOf('"') === -1) {
+      q = '"';
+    } else if (s.indexOf('` + "`" + `') === -1) {
+      q = '` + "`" + `';
+    }
+  }
+
+  var r = '';
+  for (var i = 0; i < s.length; i++) {
+    var c = s[i];
+    if (c === q) {
+      r += '\\' + c;
+    } else if (hasOwnProperty.call(meta, c)) {
+      r += meta[c];
+    } else if (c < ' ' || c === '\x7f') {
+      var h = c.charCodeAt(0).toString(16).toUpperCase();
+      r += '\\x' + (h.length < 2 ? '0' + h : h);
+    } else {
+      r += c;
+    }
+  }
+  return q + r + q;
+}
+
+function formatPrimitive(ctx, v) {
+  switch (typeof v) {
+  case 'string':
+    var trailer = '';
+    if (v.length > ctx.maxStringLength) {
+      var n = v.length - ctx.maxStringLength;
+      v = v.slice(0, ctx.maxStringLength);
+      trailer = '... ' + n + ' more character' + (n > 1 ? 's' : '');
+    }
+    return stylize(ctx, quote(v), 'string') + trailer;
+  case 'number':
+    return stylize(ctx, v === 0 && 1 / v < 0 ? '-0' : String(v), 'number');
+  case 'boolean':
+    return stylize(ctx, String(v), 'boolean');
+  case 'undefined':
+    return stylize(ctx, 'undefined', 'undefined');
+  case 'symbol':
+    return stylize(ctx, String(v), 'symbol');
+  case 'bigint':
+    return stylize(ctx, String(v) + 'n', 'bigint');
+  }
+  return stylize(ctx, 'null', 'null');
+}
+
+function formatKey(ctx, key) {
+  if (keyRx.test(key)) {
+    return key;
+  }
+  return stylize(ctx, quote(key), 'string');
+}
+
+function formatProperty(ctx, obj, key, depth, array) {
+  var desc = Object.getOwnPropertyDescriptor(obj, key) || { value: obj[key], enumerable: true };
+  var s;
+  if (desc.get !== undefined || desc.set !== undefined) {
+    if (desc.get !== undefined) {
+      s = stylize(ctx, desc.set !== undefined ? '[Getter/Setter]' : '[Getter]', 'special');
+    } else {
+      s = stylize(ctx, '[Setter]', 'special');
+    }
+  } else {
+    ctx.indentationLvl += 2;
+    s = formatValue(ctx, desc.value, depth + 1);
+    ctx.indentationLvl -= 2;
+  }
+  if (array) {
+    return s;
+  }
+
+  var name = formatKey(ctx, key);
+  if (!desc.enumerable) {
+    name = '[' + name + ']';
+  }
+  return name + ': ' + s;
+}
+
+function getKeys(ctx, obj) {
+  var keys = ctx.showHidden ? Object.getOwnPropertyNames(obj) : Object.keys(obj);
+  if (ctx.sorted) {
+    keys.sort(typeof ctx.sorted === 'function' ? ctx.sorted : undefined);
+  }
+  return keys;
+}
+
+function isIndex(key, length) {
+  var n = Number(key);
+  return String(n >>> 0) === key
+         && n < length;
+}
+
+function formatList(ctx, obj, depth, keys) {
+  var output = [];
+  var length = obj.length;
+  var max = Math.min(ctx.maxArrayLength, length);
+  var holes = 0;
+  var i;
+  for (i = 0; i < max; i++) {
+    if (!hasOwnProperty.call(obj, i)) {
+      holes++;
+      continue;
+    } else if (holes > 0) {
+      output.push(stylize(ctx, '<' + holes + ' empty item' + (holes > 1 ? 's' : '') + '>', 'undefined'));
+      holes = 0;
+    }
+    output.push(formatProperty(ctx, obj, String(i), depth, true));
+  }
+  if (holes > 0) {
+    output.push(stylize(ctx, '<' + holes + ' empty item' + (holes > 1 ? 's' : '') + '>', 'undefined'));
+  }
+  if (max < length) {
+    var n = length - max;
+    output.push('... ' + n + ' more item' + (n > 1 ? 's' : ''));
+  }
+  for (i = 0; i < keys.length; i++) {
+    if (!isIndex(keys[i], length)
+        && (keys[i] !== 'length' || ctx.showHidden)) {
+      output.push(formatProperty(ctx, obj, keys[i], depth, false));
+    }
+  }
+  return output;
+}
+
+function groupArrayElements(ctx, output, v) {
+  var totalLength = 0;
+  var maxLength = 0;
+  var outputLength = output.length;
+  if (ctx.maxArrayLength < output.length) {
+    // ignore "... n more items"
+    outputLength--;
+  }
+  var separatorSpace = 2;
+  var dataLen = new Array(outputLength);
+  var i;
+  for (i = 0; i < outputLength; i++) {
+    var len = ctx.colors ? output[i].replace(/\u001b\[\d\d?m/g, '').length : output[i].length;
+    dataLen[i] = len;
+    totalLength += len + separatorSpace;
+    if (maxLength < len) {
+      maxLength = len;
+    }
+  }
+
+  var actualMax = maxLength + separatorSpace;
+  if (actualMax * 3 + ctx.indentationLvl < ctx.breakLength
+      && (totalLength / actualMax > 5 || maxLength <= 6)) {
+    var averageBias = Math.sqrt(actualMax - totalLength / output.length);
+    var biasedMax = Math.max(actualMax - 3 - averageBias, 1);
+    var columns = Math.min(
+      Math.round(Math.sqrt(2.5 * biasedMax * outputLength) / biasedMax),
+      Math.floor((ctx.breakLength - ctx.indentationLvl) / actualMax),
+      ctx.compact * 4,
+      15
+    );
+    if (columns <= 1) {
+      return output;
+    }
+
+    var maxLineLength = [];
+    for (i = 0; i < columns; i++) {
+      var lineLength = 0;
+      for (var j = i; j < output.length; j += columns) {
+        if (dataLen[j] > lineLength) {
+          lineLength = dataLen[j];
+        }
+      }
+      maxLineLength.push(lineLength + separatorSpace);
+    }
+    var padStart = true;
+    if (v !== undefined) {
+      for (i = 0; i < output.length; i++) {
+        if (typeof v[i] !== 'number'
+            && typeof v[i] !== 'bigint') {
+          padStart = false;
+          break;
+        }
+      }
+    }
+
+    var tmp = [];
+    for (i = 0; i < outputLength; i += columns) {
+      var max = Math.min(i + columns, outputLength);
+      var s = '';
+      var k = i;
+      for (; k < max - 1; k++) {
+        s += pad(output[k] + ', ', maxLineLength[k - i] + output[k].length - dataLen[k], padStart);
+      }
+      if (padStart) {
+        s += pad(output[k], maxLineLength[k - i] + output[k].length - dataLen[k] - separatorSpace, true);
+      } else {
+        s += output[k];
+      }
+      tmp.push(s);
+    }
+    if (ctx.maxArrayLength < output.length) {
+      tmp.push(output[outputLength]);
+    }
+    output = tmp;
+  }
+  return output;
+}
+
+function pad(s, n, start) {
+  var p = n > s.length ? new Array(n - s.length + 1).join(' ') : '';
+  return start ? p + s : s + p;
+}
+
+function reduceToSingleString(ctx, output, base, braces, array, depth, v) {
+  if (ctx.compact !== true) {
+    if (typeof ctx.compact === 'number'
+        && ctx.compact >= 1) {
+      var entries = output.length;
+      if (array
+          && entries > 6) {
+        output = groupArrayElements(ctx, output, v);
+      }
+      if (ctx.currentDepth - depth < ctx.compact
+          && entries === output.length) {
+        var start = output.length + ctx.indentationLvl + braces[0].length + base.length + 10;
+        if (isBelowBreakLength(ctx, output, start, base)) {
+          var joined = output.join(', ');
+          if (joined.indexOf('\n') === -1) {
+            return (base ? base + ' ' : '') + braces[0] + ' ' + joined + ' ' + braces[1];
+          }
+        }
+      }
+    }
+    var indentation = '\n' + new Array(ctx.indentationLvl + 1).join(' ');
+    return (base ? base + ' ' : '') + braces[0] + indentation + '  ' + output.join(',' + indentation + '  ') + indentation + braces[1];
+  }
+
+  if (isBelowBreakLength(ctx, output, 0, base)) {
+    return braces[0] + (base ? ' ' + base : '') + ' ' + output.join(', ') + ' ' + braces[1];
+  }
+  var ind = new Array(ctx.indentationLvl + 1).join(' ');
+  return (base ? base + ' ' : '') + braces[0] + ' ' + output.join(',\n' + ind + '  ') + ' ' + braces[1];
+}
+
+function isBelowBreakLength(ctx, output, start, base) {
+  var total = output.length + start;
+  if (total + output.length > ctx.breakLength) {
+    return false;
+  }
+  for (var i = 0; i < output.length; i++) {
+    total += ctx.colors ? output[i].replace(/\u001b\[\d\d?m/g, '').length : output[i].length;
+    if (total > ctx.breakLength) {
+      return false;
+    }
+  }
+  return base === ''
+         || base.indexOf('\n') === -1;
+}
+
+function formatError(err) {
+  var stack = err.stack;
+  if (typeof stack === 'string'
+      && stack !== '') {
+    return stack.replace(/\n+$/, '');
+  }
+  return '[' + Error.prototype.toString.call(err) + ']';
+}
+
+function formatValue(ctx, v, depth) {
+  if (v === null
+      || (typeof v !== 'object'
+          && typeof v !== 'function')) {
+    return formatPrimitive(ctx, v);
+  }
+
+  if (ctx.customInspect) {
+    var fn = v[customInspectSymbol];
+    if (typeof fn === 'function'
+        && fn !== inspect
+        && !(v.constructor && v.constructor.prototype === v)) {
+      var opts = {};
+      for (var k in ctx) {
+        if (hasOwnProperty.call(defaultOptions, k)) {
+          opts[k] = ctx[k];
+        }
+      }
+      opts.depth = ctx.depth === null ? null : ctx.depth - depth;
+      opts.stylize = function(s, type) {
+        return stylize(ctx, s, type);
+      };
+      var r = fn.call(v, opts.depth, opts, inspect);
+      if (r !== v) {
+        return typeof r === 'string' ? r : formatValue(ctx, r, depth);
+      }
+    }
+  }
+
+  if (ctx.seen.indexOf(v) !== -1) {
+    var i = ctx.circular.indexOf(v);
+    if (i === -1) {
+      ctx.circular.push(v);
+      i = ctx.circular.length - 1;
+    }
+    return stylize(ctx, '[Circular *' + (i + 1) + ']', 'special');
+  }
+  return formatRaw(ctx, v, depth);
+}
+
+function formatRaw(ctx, v, depth) {
+  var keys = getKeys(ctx, v);
+  var tag = tagOf(v);
+  var name = getConstructorName(v);
+  var prefix = name === null ? '[' + tag + ': null prototype] ' : name !== 'Object' ? name + ' ' : '';
+  var base = '';
+  var braces = ['{', '}'];
+  var array = false;
+
+  switch (tag) {
+  case 'Array':
+    array = true;
+    braces = [(name !== 'Array' ? prefix : '') + '[', ']'];
+    if (v.length === 0
+        && keys.length === 0) {
+      return braces[0] + ']';
+    }
+    break;
+  case 'Arguments':
+    array = true;
+    braces = ['[Arguments] [', ']'];
+    if (v.length === 0) {
+      return braces[0] + ']';
+    }
+    break;
+  case 'Function':
+    var fn = getName(v);
+    base = stylize(ctx, '[Function' + (fn ? ': ' + fn : ' (anonymous)') + ']', 'special');
+    keys = keys.filter(function(k) {
+      return k !== 'prototype';
+    });
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'RegExp':
+    base = stylize(ctx, RegExp.prototype.toString.call(v), 'regexp');
+    keys = keys.filter(function(k) {
+      return k !== 'lastIndex';
+    });
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'Date':
+    base = stylize(ctx, isNaN(v.getTime()) ? 'Invalid Date' : v.toISOString(), 'date');
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'Error':
+    base = formatError(v);
+    keys = keys.filter(function(k) {
+      return k !== 'stack'
+             && k !== 'message';
+    });
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'Number':
+  case 'String':
+  case 'Boolean':
+    base = '[' + tag + ': ' + formatPrimitive(ctx, v.valueOf()) + ']';
+    if (tag === 'String') {
+      keys = keys.filter(function(k) {
+        return !isIndex(k, v.length)
+               && k !== 'length';
+      });
+    }
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  default:
+    if (keys.length === 0) {
+      return prefix + '{}';
+    }
+    braces[0] = prefix + '{';
+  }
+
+  if (ctx.depth !== null
+      && depth > ctx.depth) {
+    return stylize(ctx, '[' + (name || tag) + ']', 'special');
+  }
+
+  ctx.seen.push(v);
+  ctx.currentDepth = depth;
+  var output;
+  if (array) {
+    output = formatList(ctx, v, depth, keys);
+  } else {
+    output = keys.map(function(k) {
+      return formatProperty(ctx, v, k, depth, false);
+    });
+  }
+  ctx.seen.pop();
+
+  var i = ctx.circular.indexOf(v);
+  if (i !== -1) {
+    var ref = stylize(ctx, '<ref *' + (i + 1) + '>', 'special');
+    if (base) {
+      base = ref + ' ' + base;
+    } else {
+      braces[0] = ref + ' ' + braces[0];
+    }
+  }
+  return reduceToSingleString(ctx, output, base, braces, array, depth, v);
+}
+
+function inspect(v, opts) {
+  var ctx = {
+    seen: [],
+    circular: [],
+    indentationLvl: 0,
+    currentDepth: 0,
+  };
+  var k;
+  for (k in inspect.defaultOptions) {
+    if (hasOwnProperty.call(inspect.defaultOptions, k)) {
+      ctx[k] = inspect.defaultOptions[k];
+    }
+  }
+  if (typeof opts === 'boolean') {
+    // legacy
+    ctx.showHidden = opts;
+    if (arguments.length > 2) {
+      ctx.depth = arguments[2];
+    }
+    if (arguments.length > 3) {
+      ctx.colors = arguments[3];
+    }
+  } else if (opts !== null
+             && typeof opts === 'object') {
+    for (k in opts) {
+      if (hasOwnProperty.call(opts, k)) {
+        ctx[k] = opts[k];
+      }
+    }
+  }
+  if (ctx.depth === Infinity) {
+    ctx.depth = null;
+  }
+  return formatValue(ctx, v, 0);
+}
+
+inspect.custom = customInspectSymbol;
+inspect.defaultOptions = defaultOptions;
+inspect.colors = colors;
+inspect.styles = styles;
+
+function formatNumber(v, fn) {
+  if (typeof v === 'object'
+      && v !== null) {
+    return 'NaN';
+  }
+  v = fn(v);
+  return v === 0 && 1 / v < 0 ? '-0' : String(v);
+}
+
+function format() {
+  return formatWithOptions.apply(undefined, [undefined].concat(Array.prototype.slice.call(arguments)));
+}
+
+function formatWithOptions(opts) {
+  var args = Array.prototype.slice.call(arguments, 1);
+  var ctx = opts || {};
+  var s = '';
+  var a = 0;
+  if (typeof args[0] === 'string') {
+    var f = args[0];
+    a = 1;
+    var last = 0;
+    for (var i = 0; i < f.length - 1; i++) {
+      if (f[i] !== '%') {
+        continue;
+      }
+
+      var c = f[i + 1];
+      var r;
+      if (c === '%') {
+        s += f.slice(last, i) + '%';
+        last = i + 2;
+        i++;
+        continue;
+      } else if (a >= args.length) {
+        continue;
+      }
+      var v = args[a];
+      switch (c) {
+      case 's':
+        if (typeof v === 'string') {
+          r = v;
+        } else if (typeof v === 'number') {
+          r = formatNumber(v, Number);
+        } else if (typeof v === 'bigint') {
+          r = String(v) + 'n';
+        } else if (v !== null
+                   && typeof v === 'object'
+                   && !(v instanceof Error)
+                   && typeof v.toString === 'function'
+                   && v.toString !== Object.prototype.toString
+                   && v.toString !== Array.prototype.toString) {
+          r = String(v);
+        } else {
+          r = inspect(v, extend(ctx, { depth: 0, colors: false }));
+        }
+        break;
+      case 'd':
+        r = formatNumber(v, Number);
+        break;
+      case 'i':
+        r = formatNumber(v, parseInt);
+        break;
+      case 'f':
+        r = formatNumber(v, parseFloat);
+        break;
+      case 'j':
+        try {
+          r = JSON.stringify(v);
+        } catch (e) {
+          r = '[Circular]';
+        }
+        break;
+      case 'o':
+        r = inspect(v, extend(ctx, { showHidden: true, depth: 4 }));
+        break;
+      case 'O':
+        r = inspect(v, ctx);
+        break;
+      case 'c':
+        r = '';
+        break;
+      default:
+        continue;
+      }
+      s += f.slice(last, i) + r;
+      last = i + 2;
+      i++;
+      a++;
+    }
+    s += f.slice(last);
+  }
+  for (; a < args.length; a++) {
+    var x = args[a];
+    s += (s || a > 0 ? ' ' : '') + (typeof x === 'string' ? x : inspect(x, ctx));
+  }
+  return s;
+}
+
+function extend(dst, src) {
+  var r = {};
+  var k;
+  for (k in dst) {
+    if (hasOwnProperty.call(dst, k)) {
+      r[k] = dst[k];
+    }
+  }
+  for (k in src) {
+    if (hasOwnProperty.call(src, k)) {
+      r[k] = src[k];
+    }
+  }
+  return r;
+}
+
+exports.inspect = inspect;
+exports.format = format;
+exports.formatWithOptions = formatWithOptions;
+exports.getName = getName;
+`),
+	"internal/util.js": []byte(`//
+// otto.module :: internal/util.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var _inspect = require('./util/inspect');
+
+var inspect = _inspect.inspect;
+
+exports.normalizeEncoding = function normalizeEncoding(enc) {
+  if (enc === undefined
+      || enc === null
+      || enc === '') {
+    return 'utf8';
+  }
+  switch (String(enc).toLowerCase()) {
+  case 'utf8':
+  case 'utf-8':
+    return 'utf8';
+  case 'ucs2':
+  case 'ucs-2':
+  case 'utf16le':
+  case 'utf-16le':
+    return 'utf16le';
+  case 'latin1':
+  case 'binary':
+    return 'latin1';
+  case 'base64':
+  case 'base64url':
+  case 'ascii':
+  case 'hex':
+    return String(enc).toLowerCase();
+  }
+};
+
+exports.unknownEncoding = function unknownEncoding(enc) {
+  var e = new TypeError('Unknown encoding: ' + enc);
+  e.code = 'ERR_UNKNOWN_ENCODING';
+  return e;
+};
+
+function received(v) {
+  if (v === undefined
+      || v === null) {
+    return String(v);
+  } else if (typeof v === 'function') {
+    return _inspect.getName(v) ? 'function ' + _inspect.getName(v) : inspect(v);
+  } else if (typeof v === 'object') {
+    return _inspect.getName(v.constructor) ? 'an instance of ' + _inspect.getName(v.constructor) : inspect(v, { depth: -1 });
+  }
+  var s = inspect(v);
+  return 'type ' + typeof v + ' (' + (s.length > 28 ? s.slice(0, 25) + '...' : s) + ')';
+}
+
+exports.invalidArgType = function invalidArgType(name, type, v) {
+  var s = / argument$/.test(name) ? 'The ' + name : 'The "' + name + '" ' + (name.indexOf('.') !== -1 ? 'property' : 'argument');
+  var e = new TypeError(s + ' must be ' + type + '. Received ' + received(v));
+  e.code = 'ERR_INVALID_ARG_TYPE';
+  return e;
+};
+
+exports.invalidArgValue = function invalidArgValue(name, v) {
+  var e = new TypeError('The ' + (name.indexOf('.') !== -1 ? 'property' : 'argument') + " '" + name + "' is invalid. Received " + inspect(v));
+  e.code = 'ERR_INVALID_ARG_VALUE';
+  return e;
+};
+
+exports.outOfRange = function outOfRange(name, range, v) {
+  var s;
+  if (typeof v === 'number'
+      && Math.floor(v) === v
+      && Math.abs(v) > 0x100000000) {
+    s = String(v);
+    var i = s.length;
+    var start = s.charAt(0) === '-' ? 1 : 0;
+    var t = '';
+    for (; i >= start + 4; i -= 3) {
+      t = '_' + s.slice(i - 3, i) + t;
+    }
+    s = s.slice(0, i) + t;
+  } else {
+    s = inspect(v);
+  }
+  var e = new RangeError('The value of "' + name + '" is out of range. It must be ' + range + '. Received ' + s);
+  e.code = 'ERR_OUT_OF_RANGE';
+  return e;
+};
+
+exports.validatePort = function validatePort(port, name, describe) {
+  if ((typeof port !== 'number' && typeof port !== 'string')
+      || (typeof port === 'string' && port.trim() === '')
+      || +port !== (+port >>> 0)
+      || port > 0xffff) {
+    var e = new RangeError(name + ' should be >= 0 and < 65536. Received ' + (describe || received)(port) + '.');
+    e.code = 'ERR_SOCKET_BAD_PORT';
+    throw e;
+  }
+  return +port;
+};
+`),
+	"module.js": []byte(`//
+// otto.module :: module.js
+//
+//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var NativeModule = require('native_module');
+var _module = require('internal/module');
+var path = require('path');
+
+var vm = process.binding('vm');
+
+function update(parent, child, scan) {
+  if (parent
+      && !(scan
+           && parent.children.indexOf(child) === -1)) {
+    parent.children.push(child);
+  }
+}
+
+function Module(id, parent) {
+  this.id = id;
+  this.exports = {};
+  this.parent = parent;
+  this.filename = null;
+  this.loaded = false;
+  this.children = [];
+
+  update(parent, this, false);
+}
+
+Module._cache = Object.create(null);
+Module._pathCache = Object.create(null);
+Module._extensions = Object.create(null);
+
+Module._resolve = function _resolve(id, parent) {
+  var dir = '';
+  if (parent
+      && parent.filename) {
+    dir = path.dirname(parent.filename);
+  }
+
+  var k = id + '\x00' + dir;
+  var p = Module._pathCache[k];
+  if (!p) {
+    Module._pathCache[k] = p = vm.resolve(id, dir);
+  }
+  return p;
+};
+
+Module.prototype.require = function require(id) {
+  if (NativeModule.exists(id)
+      && !NativeModule.isInternal(id)) {
+    return NativeModule.require(id);
+  }
+
+  var n = Module._resolve(id, this);
+  var m = Module._cache[n];
+  if (m) {
+    update(this, m, true);
+  } else {
+    Module._cache[n] = m = new Module(id, this);
+    m.filename = n;
+    m.paths = [];
+    m._load();
+  }
+  return m.exports;
+};
+
+Module.prototype._compile = function _compile() {
+  var fn = vm.compile(this.filename);
+  fn.call(this.exports, this.exports, _module.require(this), this, this.filename, path.dirname(this.filename));
+};
+
+Module.prototype._load = function _load() {
+  if (this.loaded) {
+    throw new Error('already loaded');
+  }
+
+  var ext = path.extname(this.filename);
+  if (!(ext in Module._extensions)) {
+    ext = '.js';
+  }
+  Module._extensions[ext](this);
+  this.loaded = true;
+};
+
+Module._extensions['.js'] = function _extensions$js(module) {
+  module._compile();
+};
+
+Module._extensions['.json'] = function _extensions$json(module) {
+  try {
+    module.exports = JSON.parse(vm.load(module.filename));
+  } catch (err) {
+    throw new err.constructor(module.filename + ': ' + err.message);
+  }
+};
+
+module.exports = Module;
+`),
+	"net.js": []byte(`//
+// otto.module :: net.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('net');
+var EventEmitter = require('./events');
+var Duplex = require('./stream').Duplex;
+var timers = require('./timers');
+var util = require('./internal/util');
+
+function validateHost(host, name) {
+  if (host !== null
+      && host !== undefined
+      && typeof host !== 'string') {
+    throw util.invalidArgType(name, 'of type string', host);
+  }
+  return host;
+}
+
+function normalizeArgs(args) {
+  args = Array.prototype.slice.call(args);
+  var cb = typeof args[args.length - 1] === 'function' ? args.pop() : null;
+  var options;
+  if (args[0] !== null
+      && typeof args[0] === 'object') {
+    options = args[0];
+  } else {
+    options = {
+      port: args[0],
+      host: typeof args[1] === 'string' ? args[1] : undefined,
+    };
+  }
+  return [options, cb];
+}
+
+//
+// Socket
+//
+
+function Socket(options) {
+  if (!(this instanceof Socket)) {
+    return new Socket(options);
+  }
+
+  if (options === null
+      || options === undefined) {
+    options = {};
+  } else if (typeof options !== 'object') {
+    throw util.invalidArgType('options', 'of type object', options);
+  }
+
+  Duplex.call(this, {
+    allowHalfOpen: options.allowHalfOpen === true,
+    // 'close' is emitted with hadError
+    emitClose: false,
+  });
+
+  this.connecting = false;
+  this.bytesRead = 0;
+  this.bytesWritten = 0;
+  this.timeout = 0;
+  Object.defineProperties(this, {
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _connectReq: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _server: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _timer: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _unref: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+function handleGetter(side, name) {
+  return {
+    get: function() {
+      return this._handle ? this._handle[side][name] : undefined;
+    },
+    configurable: true,
+  };
+}
+
+Socket.prototype = Object.create(Duplex.prototype, {
+  constructor: {
+    value: Socket,
+    writable: true,
+    configurable: true,
+  },
+  localAddress: handleGetter('local', 'address'),
+  localFamily: handleGetter('local', 'family'),
+  localPort: handleGetter('local', 'port'),
+  remoteAddress: handleGetter('remote', 'address'),
+  remoteFamily: handleGetter('remote', 'family'),
+  remotePort: handleGetter('remote', 'port'),
+  pending: {
+    get: function() {
+      return !this._handle || this.connecting;
+    },
+    configurable: true,
+  },
+  readyState: {
+    get: function() {
+      if (this.connecting) {
+        return 'opening';
+      } else if (this.readable && this.writable) {
+        return 'open';
+      } else if (this.readable && !this.writable) {
+        return 'readOnly';
+      } else if (!this.readable && this.writable) {
+        return 'writeOnly';
+      }
+      return 'closed';
+    },
+    configurable: true,
+  },
+});
+
+Socket.prototype.connect = function connect() {
+  var args = normalizeArgs(arguments);
+  var options = args[0];
+  var cb = args[1];
+  if (options.port === undefined
+      || options.port === null) {
+    var e = new TypeError('The "options" or "port" or "path" argument must be specified');
+    e.code = 'ERR_MISSING_ARGS';
+    throw e;
+  }
+  var port = util.validatePort(options.port, 'Port');
+  var host = validateHost(options.host, 'options.host') || 'localhost';
+
+  if (cb) {
+    this.once('connect', cb);
+  }
+  this.connecting = true;
+  try {
+    this._connectReq = binding.connect(host, port, onconnect.bind(null, this));
+  } catch (err) {
+    this.connecting = false;
+    throw err;
+  }
+  return this;
+};
+
+function onconnect(self, err, handle) {
+  self._connectReq = null;
+  if (self.destroyed) {
+    if (handle) {
+      handle.close();
+    }
+    return;
+  }
+  self.connecting = false;
+  if (err) {
+    self.destroy(err);
+    return;
+  }
+  self._attach(handle);
+  self.emit('connect');
+  self.emit('ready');
+}
+
+Socket.prototype._attach = function _attach(handle) {
+  this._handle = handle;
+  if (this._unref) {
+    handle.unref();
+  }
+  this._refreshTimeout();
+};
+
+// defers fn until the socket is connected
+function afterConnect(self, fn) {
+  if (self.connecting) {
+    self.once('connect', fn);
+    return true;
+  }
+  return false;
+}
+
+Socket.prototype._read = function _read(n) {
+  var self = this;
+  if (afterConnect(this, function() {
+    self._read(n);
+  }) || !this._handle) {
+    return;
+  }
+
+  this._handle.read(n, function(err, buf) {
+    if (err) {
+      self.destroy(err);
+    } else if (buf === null) {
+      self.push(null);
+    } else {
+      self.bytesRead += buf.length;
+      self._refreshTimeout();
+      self.push(buf);
+    }
+  });
+};
+
+Socket.prototype._write = function _write(chunk, encoding, cb) {
+  this._writev([{ chunk: chunk, encoding: encoding }], cb);
+};
+
+Socket.prototype._writev = function _writev(chunks, cb) {
+  var self = this;
+  if (afterConnect(this, function() {
+    self._writev(chunks, cb);
+  })) {
+    return;
+  } else if (!this._handle) {
+    var e = new Error('This socket is closed');
+    e.code = 'ERR_SOCKET_CLOSED';
+    cb(e);
+    return;
+  }
+
+  var body = Buffer.concat(chunks.map(function(c) {
+    return Buffer.isBuffer(c.chunk) ? c.chunk : Buffer.from(c.chunk, c.encoding);
+  }));
+  this.bytesWritten += body.length;
+  this._refreshTimeout();
+  this._handle.write(body, cb);
+};
+
+Socket.prototype._final = function _final(cb) {
+  var self = this;
+  if (afterConnect(this, function() {
+    self._final(cb);
+  })) {
+    return;
+  } else if (!this._handle) {
+    cb();
+    return;
+  }
+
+  this._handle.shutdown(cb);
+};
+
+Socket.prototype._destroy = function _destroy(err, cb) {
+  this._clearTimeout();
+  if (this._connectReq) {
+    this._connectReq.abort();
+    this._connectReq = null;
+  }
+  this.connecting = false;
+  if (this._handle) {
+    this._handle.close();
+    this._handle = null;
+  }
+  cb(err);
+  process.nextTick(emitCloseNT, this, !!err);
+
+  var server = this._server;
+  if (server) {
+    this._server = null;
+    server._connections--;
+    server._emitCloseIfDrained();
+  }
+};
+
+function emitCloseNT(self, hadError) {
+  self.emit('close', hadError);
+}
+
+Socket.prototype.address = function address() {
+  return this._handle ? this._handle.local : {};
+};
+
+Socket.prototype.setTimeout = function setTimeout(msecs, cb) {
+  if (typeof msecs !== 'number') {
+    throw util.invalidArgType('msecs', 'of type number', msecs);
+  } else if (!(msecs >= 0)) {
+    throw util.outOfRange('msecs', '>= 0', msecs);
+  }
+  if (cb !== undefined) {
+    if (typeof cb !== 'function') {
+      throw util.invalidArgType('callback', 'of type function', cb);
+    }
+    if (msecs === 0) {
+      this.removeListener('timeout', cb);
+    } else {
+      this.once('timeout', cb);
+    }
+  }
+  this.timeout = msecs;
+  this._clearTimeout();
+  if (msecs > 0
+      && !this.destroyed) {
+    var self = this;
+    this._timer = timers.setTimeout(function() {
+      self._timer = null;
+      self.emit('timeout');
+    }, msecs);
+    this._timer.unref();
+  }
+  return this;
+};
+
+Socket.prototype._refreshTimeout = function _refreshTimeout() {
+  if (this._timer) {
+    this._timer.refresh();
+  }
+};
+
+Socket.prototype._clearTimeout = function _clearTimeout() {
+  if (this._timer) {
+    timers.clearTimeout(this._timer);
+    this._timer = null;
+  }
+};
+
+Socket.prototype.setNoDelay = function setNoDelay(noDelay) {
+  if (this._handle) {
+    this._handle.setNoDelay(noDelay === undefined ? true : !!noDelay);
+  }
+  return this;
+};
+
+Socket.prototype.setKeepAlive = function setKeepAlive(enable, initialDelay) {
+  if (this._handle) {
+    this._handle.setKeepAlive(!!enable, ~~initialDelay);
+  }
+  return this;
+};
+
+Socket.prototype.ref = function ref() {
+  this._unref = false;
+  if (this._handle) {
+    this._handle.ref();
+  }
+  return this;
+};
+
+Socket.prototype.unref = function unref() {
+  this._unref = true;
+  if (this._handle) {
+    this._handle.unref();
+  }
+  return this;
+};
+
+//
+// Server
+//
+
+function Server(options, connectionListener) {
+  if (!(this instanceof Server)) {
+    return new Server(options, connectionListener);
+  }
+
+  if (typeof options === 'function') {
+    connectionListener = options;
+    options = {};
+  } else if (options === null
+             || options === undefined) {
+    options = {};
+  } else if (typeof options !== 'object'
+             || Array.isArray(options)) {
+    throw util.invalidArgType('options', 'of type object', options);
+  }
+
+  EventEmitter.call(this);
+
+  this.allowHalfOpen = options.allowHalfOpen === true;
+  Object.defineProperties(this, {
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _connections: {
+      value: 0,
+      writable: true,
+      configurable: true,
+    },
+    _unref: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+  if (connectionListener) {
+    this.on('connection', connectionListener);
+  }
+}
+
+Server.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Server,
+    writable: true,
+    configurable: true,
+  },
+  listening: {
+    get: function() {
+      return this._handle !== null;
+    },
+    configurable: true,
+  },
+});
+
+Server.prototype.listen = function listen() {
+  var args = normalizeArgs(arguments);
+  var options = args[0];
+  var cb = args[1];
+  var port = options.port === undefined || options.port === null ? 0 : util.validatePort(options.port, 'options.port');
+  var host = validateHost(options.host, 'options.host');
+
+  if (this._handle) {
+    var e = new Error('Listen method has been called more than once without closing.');
+    e.code = 'ERR_SERVER_ALREADY_LISTEN';
+    throw e;
+  }
+  if (cb) {
+    this.once('listening', cb);
+  }
+
+  try {
+    this._handle = binding.listen(host, port, onconnection.bind(null, this));
+  } catch (err) {
+    process.nextTick(emitErrorNT, this, err);
+    return this;
+  }
+  if (this._unref) {
+    this._handle.unref();
+  }
+  process.nextTick(emitListeningNT, this);
+  return this;
+};
+
+function emitErrorNT(self, err) {
+  self.emit('error', err);
+}
+
+function emitListeningNT(self) {
+  if (self._handle) {
+    self.emit('listening');
+  }
+}
+
+function onconnection(server, handle) {
+  var socket = new Socket({ allowHalfOpen: server.allowHalfOpen });
+  socket._attach(handle);
+  socket._server = server;
+  server._connections++;
+  server.emit('connection', socket);
+}
+
+Server.prototype.address = function address() {
+  return this._handle ? this._handle.address : null;
+};
+
+Server.prototype.close = function close(cb) {
+  if (typeof cb === 'function') {
+    if (this._handle) {
+      this.once('close', cb);
+    } else {
+      this.once('close', function() {
+        var e = new Error('Server is not running.');
+        e.code = 'ERR_SERVER_NOT_RUNNING';
+        cb(e);
+      });
+    }
+  }
+
+  if (this._handle) {
+    this._handle.close();
+    this._handle = null;
+    this._emitCloseIfDrained();
+  } else {
+    process.nextTick(emitCloseNT, this);
+  }
+  return this;
+};
+
+// 'close' is emitted after all the connections are ended
+Server.prototype._emitCloseIfDrained = function _emitCloseIfDrained() {
+  if (!this._handle
+      && this._connections === 0) {
+    process.nextTick(emitCloseNT, this);
+  }
+};
+
+Server.prototype.getConnections = function getConnections(cb) {
+  process.nextTick(cb, null, this._connections);
+  return this;
+};
+
+Server.prototype.ref = function ref() {
+  this._unref = false;
+  if (this._handle) {
+    this._handle.ref();
+  }
+  return this;
+};
+
+Server.prototype.unref = function unref() {
+  this._unref = true;
+  if (this._handle) {
+    this._handle.unref();
+  }
+  return this;
+};
+
+//
+// API
+//
+
+function connect() {
+  var options = normalizeArgs(arguments)[0];
+  var socket = new Socket(options);
+  if (options.timeout) {
+    socket.setTimeout(options.timeout);
+  }
+  return socket.connect.apply(socket, arguments);
+}
+
+function createServer(options, connectionListener) {
+  return new Server(options, connectionListener);
+}
+
+function isIP(input) {
+  return binding.isIP(input);
+}
+
+function isIPv4(input) {
+  return isIP(input) === 4;
+}
+
+function isIPv6(input) {
+  return isIP(input) === 6;
+}
+
+exports.Server = Server;
+exports.Socket = Socket;
+exports.Stream = Socket;
+exports.connect = connect;
+exports.createConnection = connect;
+exports.createServer = createServer;
+exports.isIP = isIP;
+exports.isIPv4 = isIPv4;
+exports.isIPv6 = isIPv6;
+`),
+	"os.js": []byte(`//
+// otto.module :: os.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('os');
+
+exports.EOL = binding.EOL;
+exports.devNull = binding.devNull;
+
+exports.constants = {
+  signals: binding.signals,
+};
+
+exports.platform = function platform() {
+  return binding.platform;
+};
+
+exports.type = function type() {
+  return binding.type;
+};
+
+exports.arch = function arch() {
+  return binding.arch;
+};
+
+exports.endianness = function endianness() {
+  return binding.endianness;
+};
+
+exports.release = function release() {
+  return binding.release();
+};
+
+exports.hostname = function hostname() {
+  return binding.hostname();
+};
+
+exports.homedir = function homedir() {
+  return binding.homedir();
+};
+
+exports.tmpdir = function tmpdir() {
+  return binding.tmpdir();
+};
+
+exports.userInfo = function userInfo() {
+  var u = binding.userInfo();
+  return {
+    uid: u.uid,
+    gid: u.gid,
+    username: u.username,
+    homedir: u.homedir,
+    shell: u.shell,
+  };
+};
+
+exports.cpus = function cpus() {
+  var list = binding.cpus();
+  var r = [];
+  for (var i = 0; i < list.length; i++) {
+    var t = list[i].times;
+    r.push({
+      model: list[i].model,
+      speed: list[i].speed,
+      times: {
+        user: t.user,
+        nice: t.nice,
+        sys: t.sys,
+        idle: t.idle,
+        irq: t.irq,
+      },
+    });
+  }
+  return r;
+};
+
+exports.totalmem = function totalmem() {
+  return binding.totalmem();
+};
+
+exports.freemem = function freemem() {
+  return binding.freemem();
+};
+`),
+	"path.js": []byte(`//
+// otto.module :: path.js
+//
+//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+function assert(path) {
+  if (typeof path !== 'string') {
+    throw new TypeError('path must be a String');
+  }
+}
+
+var posix = {
+  delimiter: ':',
+  sep: '/',
+
+  _isSep: function _isSep(c) {
+    return c === '/';
+  },
+
+  _volname: function _volname() {
+    return '';
+  },
+};
+
+var win32 = {
+  delimiter: ';',
+  sep: '\\',
+
+  _isSep: function _isSep(c) {
+    return c === '\\'
+           || c === '/';
+  },
+
+  _volname: function _volname(path) {
+    if (path.length > 1) {
+      if (path[1] === ':'
+          && ('A' <= path[0] && path[0] <= 'Z'
+              || 'a' <= path[0] && path[0] <= 'z')) {
+        // drive letter
+        return path.slice(0, 2);
+      } else if (path.length > 4
+                 && win32._isSep(path[0])
+                 && win32._isSep(path[1])
+                 && !(win32._isSep(path[2])
+                      || path[2] === '.')) {
+        // UNC
+        for (var i = 3; i < path.length - 1; i++) {
+          if (win32._isSep(path[i])
+              && !win32._isSep(path[++i])) {
+            for (; i < path.length; i++) {
+              if (win32._isSep(path[i])) {
+                break;
+              }
+            }
+            return path.slice(0, i);
+          }
+        }
+      }
+    }
+    return '';
+  },
+};
+
+function _basename(os) {
+  return function basename(path, ext) {
+    assert(path);
+    if (ext !== undefined) {
+      assert(ext);
+    }
+    // volume
+    var vol = os._volname(path);
+    if (vol
+        && vol === path) {
+      return '';
+    }
+    // trim trailing separators
+    var end;
+    for (end = path.length; end > vol.length && os._isSep(path[end - 1]); end--);
+
+    for (var i = end - 1; i > vol.length; i--) {
+      if (os._isSep(path[i])) {
+        i++;
+        if (ext
+            && path.indexOf(ext, end - ext.length) !== -1) {
+          return path.slice(i, end - ext.length);
+        }
+        return path.slice(i, end);
+      }
+    }
+    return '';
+  };
+}
+
+posix.basename = _basename(posix);
+win32.basename = _basename(win32);
+
+function _dirname(os) {
+  return function dirname(path) {
+    assert(path);
+    if (!path) {
+      return '.';
+    }
+    // volume
+    var vol = os._volname(path);
+    if (vol
+        && vol === path) {
+      return vol;
+    }
+    // trim trailing separators
+    var end;
+    for (end = path.length; end > vol.length && os._isSep(path[end - 1]); end--);
+
+    for (var i = end - 1; i > vol.length; i--) {
+      if (os._isSep(path[i])) {
+        return path.slice(0, i);
+      }
+    }
+    return end < path.length ? vol + path[end] : '.';
+  };
+}
+
+posix.dirname = _dirname(posix);
+win32.dirname = _dirname(win32);
+
+function _extname(os) {
+  return function extname(path) {
+    assert(path);
+    for (var i = path.length - 1; i > 0 && !os._isSep(path[i]); i--) {
+      if (path[i] === '.'
+          && !os._isSep(path[i - 1])) {
+        return path.slice(i);
+      }
+    }
+    return '';
+  };
+}
+
+posix.extname = _extname(posix);
+win32.extname = _extname(win32);
+
+function _format(os) {
+  return function format(pathObject) {
+    if (pathObject === null
+        || typeof pathObject !== 'object') {
+      throw new TypeError('pathObject must be an Object');
+    }
+    var dir = pathObject.dir || pathObject.root;
+    var base = pathObject.base || '';
+    if (!base) {
+      if (pathObject.name) {
+        base += pathObject.name;
+      }
+      if (pathObject.ext) {
+        base += pathObject.ext;
+      }
+    }
+    if (!dir) {
+      return base;
+    }
+    return dir === pathObject.root ? dir + base : dir + os.sep + base;
+  };
+}
+
+posix.format = _format(posix);
+win32.format = _format(win32);
+
+function _isAbsolute(os) {
+  return function isAbsolute(path) {
+    assert(path);
+    return !!path
+           && os._isSep(path[os._volname(path).length]);
+  };
+}
+
+posix.isAbsolute = _isAbsolute(posix);
+win32.isAbsolute = _isAbsolute(win32);
+
+function _join(os) {
+  return function join() {
+    var path = '';
+    for (var i = 0; i < arguments.length; i++) {
+      var a = arguments[i];
+      assert(a);
+      if (a) {
+        if (path
+            && !os._isSep(a[0])) {
+          path += os.sep;
+        }
+        path += a;
+      }
+    }
+    if (!path) {
+      return '.';
+    }
+    return os.normalize(path);
+  };
+}
+
+posix.join = _join(posix);
+win32.join = _join(win32);
+
+function _normalize(os) {
+  return function normalize(path) {
+    assert(path);
+    // volume
+    var vol = os._volname(path);
+    if (vol === path) {
+      if (!vol) {
+        return '.';
+      }
       return vol.length === 2 ? vol + '.' : vol.replace(/\//g, os.sep) + os.sep;
     }
 
-    var abs = os._isSep(path[vol.length]);
-    var dot = 0;
-    var rv = '';
-    var n = path.length;
-    var i = vol.length;
-    while (i < n) {
-      if (os._isSep(path[i])) {
-        // separator
-        i++;
-      } else if (path[i] === '.'
-                 && (i + 1 === n
-                     || os._isSep(path[i + 1]))) {
-        // .
-        i++;
-      } else if (path[i] === '.'
-                 && path[i + 1] === '.'
-                 && (i + 2 === n
-                     || os._isSep(path[i + 2]))) {
-        // ..
-        i += 2;
-        if (dot < rv.length) {
-          rv = rv.slice(0, rv.lastIndexOf(os.sep));
-        } else if (!abs) {
-          if (rv) {
-            rv += os.sep;
-          }
-          rv += '..';
-          dot = rv.length;
-        }
-      } else {
-        if (abs
-            || rv) {
-          rv += os.sep;
-        }
-        var beg = i;
-        for (; i < n && !os._isSep(path[i]); i++);
-        rv += path.slice(beg, i);
+    var abs = os._isSep(path[vol.length]);
+    var dot = 0;
+    var rv = '';
+    var n = path.length;
+    var i = vol.length;
+    while (i < n) {
+      if (os._isSep(path[i])) {
+        // separator
+        i++;
+      } else if (path[i] === '.'
+                 && (i + 1 === n
+                     || os._isSep(path[i + 1]))) {
+        // .
+        i++;
+      } else if (path[i] === '.'
+                 && path[i + 1] === '.'
+                 && (i + 2 === n
+                     || os._isSep(path[i + 2]))) {
+        // ..
+        i += 2;
+        if (dot < rv.length) {
+          rv = rv.slice(0, rv.lastIndexOf(os.sep));
+        } else if (!abs) {
+          if (rv) {
+            rv += os.sep;
+          }
+          rv += '..';
+          dot = rv.length;
+        }
+      } else {
+        if (abs
+            || rv) {
+          rv += os.sep;
+        }
+        var beg = i;
+        for (; i < n && !os._isSep(path[i]); i++);
+        rv += path.slice(beg, i);
+      }
+    }
+    if (!rv) {
+      rv = abs ? os.sep : '.';
+    }
+    if (vol.length < n - 1
+        && os._isSep(path[n - 1])) {
+      rv += os.sep;
+    }
+    return vol ? vol.replace(/\//g, os.sep) + rv : rv;
+  };
+}
+
+posix.normalize = _normalize(posix);
+win32.normalize = _normalize(win32);
+
+function _parse(os) {
+  return function parse(path) {
+    assert(path);
+    // volume
+    var vol = os._volname(path);
+
+    var root;
+    var dir;
+    if (vol === path
+        || !os._isSep(path[vol.length])) {
+      // relative
+      root = dir = '';
+    } else {
+      // absolute
+      root = dir = path.slice(0, vol.length + 1);
+    }
+    // trim trailing separators
+    var end;
+    for (end = path.length; end > vol.length && os._isSep(path[end - 1]); end--);
+
+    var base;
+    var dot = end;
+    if (end === vol.length) {
+      base = vol.length;
+    } else {
+      for (base = end - 1; base > vol.length; base--) {
+        if (os._isSep(path[base - 1])) {
+          dir = path.slice(0, base - 1);
+          break;
+        } else if (path[base] === '.'
+                   && dot === end
+                   && (base > vol.length && base < end - 1)) {
+          dot = base;
+        }
+      }
+    }
+    return {
+      root: root,
+      dir: dir,
+      base: path.slice(base, end),
+      name: path.slice(base, dot),
+      ext: path.slice(dot, end),
+    };
+  };
+}
+
+posix.parse = _parse(posix);
+win32.parse = _parse(win32);
+
+posix.posix = win32.posix = posix;
+posix.win32 = win32.win32 = win32;
+
+if (process.platform === 'win32') {
+  module.exports = win32;
+} else {
+  module.exports = posix;
+}
+`),
+	"querystring.js": []byte(`//
+// otto.module :: querystring.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var url = require('./internal/url');
+
+function unescape(s, decodeSpaces) {
+  try {
+    return decodeURIComponent(s);
+  } catch (e) {
+    return url.percentDecode(decodeSpaces ? s.replace(/\+/g, ' ') : s);
+  }
+}
+
+exports.unescape = unescape;
+
+exports.escape = function escape(s) {
+  if (typeof s !== 'string') {
+    s = typeof s === 'object' ? String(s) : s + '';
+  }
+  try {
+    return encodeURIComponent(s);
+  } catch (e) {
+    var err = new URIError('URI malformed');
+    err.code = 'ERR_INVALID_URI';
+    throw err;
+  }
+};
+
+exports.parse = function parse(qs, sep, eq, options) {
+  var o = Object.create(null);
+  if (typeof qs !== 'string'
+      || qs.length === 0) {
+    return o;
+  }
+
+  sep = sep ? String(sep) : '&';
+  eq = eq ? String(eq) : '=';
+  var maxKeys = 1000;
+  if (options
+      && typeof options.maxKeys === 'number') {
+    maxKeys = options.maxKeys > 0 ? options.maxKeys : 0;
+  }
+  var decode = exports.unescape;
+  if (options
+      && typeof options.decodeURIComponent === 'function') {
+    decode = options.decodeURIComponent;
+  }
+  var custom = decode !== unescape;
+
+  var list = qs.split(sep);
+  if (maxKeys > 0
+      && list.length > maxKeys) {
+    list = list.slice(0, maxKeys);
+  }
+  list.forEach(function(s) {
+    if (s === '') {
+      return;
+    }
+    var i = s.indexOf(eq);
+    var k = decodeStr(i !== -1 ? s.slice(0, i) : s);
+    var v = decodeStr(i !== -1 ? s.slice(i + eq.length) : '');
+    if (!(k in o)) {
+      o[k] = v;
+    } else if (Array.isArray(o[k])) {
+      o[k].push(v);
+    } else {
+      o[k] = [o[k], v];
+    }
+  });
+  return o;
+
+  function decodeStr(s) {
+    s = s.replace(/\+/g, custom ? '%20' : ' ');
+    if (!custom
+        && s.indexOf('%') === -1) {
+      return s;
+    }
+    try {
+      return decode(s);
+    } catch (e) {
+      return unescape(s, true);
+    }
+  }
+};
+
+function stringifyPrimitive(v) {
+  switch (typeof v) {
+  case 'string':
+    return v;
+  case 'number':
+    return isFinite(v) ? String(v) : '';
+  case 'bigint':
+    return String(v);
+  case 'boolean':
+    return v ? 'true' : 'false';
+  }
+  return '';
+}
+
+exports.stringify = function stringify(obj, sep, eq, options) {
+  if (obj === null
+      || typeof obj !== 'object') {
+    return '';
+  }
+
+  sep = sep || '&';
+  eq = eq || '=';
+  var encode = exports.escape;
+  if (options
+      && typeof options.encodeURIComponent === 'function') {
+    encode = options.encodeURIComponent;
+  }
+
+  var list = [];
+  Object.keys(obj).forEach(function(k) {
+    var v = obj[k];
+    var ks = encode(stringifyPrimitive(k)) + eq;
+    if (Array.isArray(v)) {
+      v.forEach(function(v) {
+        list.push(ks + encode(stringifyPrimitive(v)));
+      });
+    } else {
+      list.push(ks + encode(stringifyPrimitive(v)));
+    }
+  });
+  return list.join(sep);
+};
+
+exports.decode = exports.parse;
+exports.encode = exports.stringify;
+`),
+	"readline.js": []byte(`//
+// otto.module :: readline.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var EventEmitter = require('./events');
+var StringDecoder = require('./string_decoder').StringDecoder;
+var util = require('./internal/util');
+
+var kHistorySize = 30;
+var kMincrlfDelay = 100;
+
+function useAfterClose() {
+  var e = new Error('readline was closed');
+  e.code = 'ERR_USE_AFTER_CLOSE';
+  return e;
+}
+
+function validateString(v, name) {
+  if (typeof v !== 'string') {
+    throw util.invalidArgType(name, 'of type string', v);
+  }
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+//
+// Interface
+//
+
+function Interface(input, output, completer, terminal) {
+  if (!(this instanceof Interface)) {
+    return new Interface(input, output, completer, terminal);
+  }
+
+  EventEmitter.call(this);
+
+  var historySize;
+  var prompt = '> ';
+  var crlfDelay;
+  if (input
+      && typeof input.on !== 'function') {
+    // options
+    output = input.output;
+    completer = input.completer;
+    terminal = input.terminal;
+    historySize = input.historySize;
+    if (input.prompt !== undefined) {
+      prompt = input.prompt;
+    }
+    crlfDelay = input.crlfDelay;
+    input = input.input;
+  }
+  if (input === undefined
+      || input === null) {
+    input = process.stdin;
+  }
+  if (output === undefined) {
+    output = process.stdout;
+  }
+  if (completer !== undefined) {
+    validateFunction(completer, 'completer');
+  }
+  if (historySize === undefined) {
+    historySize = kHistorySize;
+  } else if (!(typeof historySize === 'number' && historySize >= 0)) {
+    throw util.outOfRange('historySize', '>= 0', historySize);
+  }
+
+  this.input = input;
+  this.output = output;
+  this.completer = completer;
+  this.terminal = false;
+  this.historySize = historySize;
+  this.history = [];
+  this.crlfDelay = crlfDelay ? Math.max(kMincrlfDelay, crlfDelay) : kMincrlfDelay;
+  this.line = '';
+  this.cursor = 0;
+  this.closed = false;
+  this.paused = false;
+  Object.defineProperties(this, {
+    _prompt: {
+      value: prompt,
+      writable: true,
+      configurable: true,
+    },
+    _oldPrompt: {
+      value: '',
+      writable: true,
+      configurable: true,
+    },
+    _questionCallback: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _lineBuffer: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _sawReturnAt: {
+      value: 0,
+      writable: true,
+      configurable: true,
+    },
+    _decoder: {
+      value: new StringDecoder('utf8'),
+      writable: true,
+      configurable: true,
+    },
+  });
+
+  var self = this;
+  function ondata(data) {
+    self._normalWrite(data);
+  }
+  function onend() {
+    if (typeof self._lineBuffer === 'string'
+        && self._lineBuffer.length > 0) {
+      self.emit('line', self._lineBuffer);
+    }
+    self.close();
+  }
+  function onerror(err) {
+    self.emit('error', err);
+  }
+  input.on('error', onerror);
+  input.on('data', ondata);
+  input.on('end', onend);
+  this.once('close', function() {
+    input.removeListener('data', ondata);
+    input.removeListener('error', onerror);
+    input.removeListener('end', onend);
+  });
+
+  input.resume();
+}
+
+Interface.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Interface,
+    writable: true,
+    configurable: true,
+  },
+});
+
+Interface.prototype.setPrompt = function setPrompt(prompt) {
+  this._prompt = prompt;
+};
+
+Interface.prototype.getPrompt = function getPrompt() {
+  return this._prompt;
+};
+
+Interface.prototype.prompt = function prompt(preserveCursor) {
+  if (this.paused) {
+    this.resume();
+  }
+  this._writeToOutput(this._prompt);
+};
+
+Interface.prototype.question = function question(query, options, cb) {
+  cb = typeof options === 'function' ? options : cb;
+  if (typeof cb !== 'function') {
+    return;
+  }
+  if (this.closed) {
+    throw useAfterClose();
+  }
+
+  if (this._questionCallback) {
+    this.prompt();
+  } else {
+    this._oldPrompt = this._prompt;
+    this.setPrompt(query);
+    this._questionCallback = cb;
+    this.prompt();
+  }
+};
+
+Interface.prototype._writeToOutput = function _writeToOutput(s) {
+  validateString(s, 'stringToWrite');
+  if (this.output !== null
+      && this.output !== undefined) {
+    this.output.write(s);
+  }
+};
+
+Interface.prototype._onLine = function _onLine(line) {
+  if (this._questionCallback) {
+    var cb = this._questionCallback;
+    this._questionCallback = null;
+    this.setPrompt(this._oldPrompt);
+    cb(line);
+  } else {
+    this.emit('line', line);
+  }
+};
+
+Interface.prototype._normalWrite = function _normalWrite(b) {
+  if (b === undefined) {
+    return;
+  }
+  var s = typeof b === 'string' ? b : this._decoder.write(b);
+  if (this._sawReturnAt
+      && Date.now() - this._sawReturnAt <= this.crlfDelay) {
+    if (s.charAt(0) === '\n') {
+      s = s.slice(1);
+    }
+    this._sawReturnAt = 0;
+  }
+
+  // split lines on \r\n, \n and \r
+  var lines = [];
+  var start = 0;
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charAt(i);
+    if (c === '\n'
+        || c === '\r') {
+      lines.push(s.slice(start, i));
+      if (c === '\r'
+          && s.charAt(i + 1) === '\n') {
+        i++;
+      }
+      start = i + 1;
+    }
+  }
+  if (lines.length === 0) {
+    if (s) {
+      this._lineBuffer = (this._lineBuffer || '') + s;
+    }
+    return;
+  }
+
+  if (this._lineBuffer) {
+    lines[0] = this._lineBuffer + lines[0];
+  }
+  this._sawReturnAt = s.charAt(s.length - 1) === '\r' ? Date.now() : 0;
+  this._lineBuffer = s.slice(start);
+  for (i = 0; i < lines.length && !this.closed; i++) {
+    this._onLine(lines[i]);
+  }
+};
+
+Interface.prototype.write = function write(d, key) {
+  if (this.closed) {
+    throw useAfterClose();
+  }
+  if (this.paused) {
+    this.resume();
+  }
+  this._normalWrite(d);
+};
+
+Interface.prototype.pause = function pause() {
+  if (this.paused) {
+    return;
+  }
+  this.input.pause();
+  this.paused = true;
+  this.emit('pause');
+  return this;
+};
+
+Interface.prototype.resume = function resume() {
+  if (!this.paused) {
+    return;
+  }
+  this.input.resume();
+  this.paused = false;
+  this.emit('resume');
+  return this;
+};
+
+Interface.prototype.close = function close() {
+  if (this.closed) {
+    return;
+  }
+  this.pause();
+  this.closed = true;
+  this.emit('close');
+};
+
+Interface.prototype.getCursorPos = function getCursorPos() {
+  var s = this._prompt + this.line.slice(0, this.cursor);
+  return {
+    rows: 0,
+    cols: s.length,
+  };
+};
+
+// async iteration without for await...of; next(callback) calls back with
+// (err, { value, done }) for each line
+Interface.prototype.asyncIterator = function asyncIterator(options) {
+  var self = this;
+  var highWaterMark = options && options.highWaterMark !== undefined ? options.highWaterMark : 1024;
+  var lines = [];
+  var waiting = [];
+  var error = null;
+  var done = false;
+
+  function online(line) {
+    if (waiting.length) {
+      waiting.shift()(null, { value: line, done: false });
+    } else {
+      lines.push(line);
+      if (lines.length >= highWaterMark) {
+        self.pause();
+      }
+    }
+  }
+  function onclose() {
+    finish();
+  }
+  function onerror(err) {
+    error = err;
+    finish();
+  }
+  function finish() {
+    if (done) {
+      return;
+    }
+    done = true;
+    self.removeListener('line', online);
+    self.removeListener('close', onclose);
+    self.removeListener('error', onerror);
+    while (waiting.length) {
+      var cb = waiting.shift();
+      if (error) {
+        cb(error);
+        error = null;
+      } else {
+        cb(null, { value: undefined, done: true });
+      }
+    }
+  }
+  this.on('line', online);
+  this.on('close', onclose);
+  this.on('error', onerror);
+
+  return {
+    next: function next(callback) {
+      validateFunction(callback, 'callback');
+      if (lines.length) {
+        var line = lines.shift();
+        if (lines.length < highWaterMark
+            && !done
+            && self.paused
+            && !self.closed) {
+          self.resume();
+        }
+        callback(null, { value: line, done: false });
+      } else if (error) {
+        var err = error;
+        error = null;
+        callback(err);
+      } else if (done) {
+        callback(null, { value: undefined, done: true });
+      } else {
+        waiting.push(callback);
+      }
+    },
+    return: function(callback) {
+      lines = [];
+      finish();
+      self.close();
+      if (typeof callback === 'function') {
+        callback(null, { value: undefined, done: true });
+      }
+    },
+  };
+};
+
+function createInterface(input, output, completer, terminal) {
+  return new Interface(input, output, completer, terminal);
+}
+
+//
+// cursor
+//
+
+var CSI = '\x1b[';
+
+function write(stream, data, callback) {
+  if (!stream) {
+    if (typeof callback === 'function') {
+      process.nextTick(callback, null);
+    }
+    return true;
+  }
+  return stream.write(data, callback);
+}
+
+function cursorTo(stream, x, y, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  if (typeof y === 'function') {
+    callback = y;
+    y = undefined;
+  }
+  if (x !== x) {
+    throw util.invalidArgValue('x', x);
+  } else if (y !== y) {
+    throw util.invalidArgValue('y', y);
+  } else if (typeof x !== 'number'
+             && typeof y !== 'number') {
+    return write(null, '', callback);
+  }
+  var data = typeof y !== 'number' ? CSI + (x + 1) + 'G' : CSI + (y + 1) + ';' + (x + 1) + 'H';
+  return write(stream, data, callback);
+}
+
+function moveCursor(stream, dx, dy, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  var data = '';
+  if (dx < 0) {
+    data += CSI + -dx + 'D';
+  } else if (dx > 0) {
+    data += CSI + dx + 'C';
+  }
+  if (dy < 0) {
+    data += CSI + -dy + 'A';
+  } else if (dy > 0) {
+    data += CSI + dy + 'B';
+  }
+  return write(stream, data, callback);
+}
+
+function clearLine(stream, dir, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  var type = dir < 0 ? '1K' : dir > 0 ? '0K' : '2K';
+  return write(stream, CSI + type, callback);
+}
+
+function clearScreenDown(stream, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  return write(stream, CSI + '0J', callback);
+}
+
+exports.Interface = Interface;
+exports.createInterface = createInterface;
+exports.clearLine = clearLine;
+exports.clearScreenDown = clearScreenDown;
+exports.cursorTo = cursorTo;
+exports.moveCursor = moveCursor;
+`),
+	"stream.js": []byte(`//
+// otto.module :: stream.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('stream');
+var Buffer = require('./buffer').Buffer;
+var EventEmitter = require('./events');
+var StringDecoder = require('./string_decoder').StringDecoder;
+var util = require('./internal/util');
+
+//
+// errors
+//
+
+function makeError(Base, code, msg) {
+  var e = new Base(msg);
+  e.code = code;
+  return e;
+}
+
+function methodNotImplemented(name) {
+  return makeError(Error, 'ERR_METHOD_NOT_IMPLEMENTED', 'The ' + name + ' method is not implemented');
+}
+
+function streamDestroyed(name) {
+  return makeError(Error, 'ERR_STREAM_DESTROYED', 'Cannot call ' + name + ' after a stream was destroyed');
+}
+
+function streamAlreadyFinished(name) {
+  return makeError(Error, 'ERR_STREAM_ALREADY_FINISHED', 'Cannot call ' + name + ' after a stream was finished');
+}
+
+function streamNullValues() {
+  return makeError(TypeError, 'ERR_STREAM_NULL_VALUES', 'May not write null values to stream');
+}
+
+function streamPrematureClose() {
+  return makeError(Error, 'ERR_STREAM_PREMATURE_CLOSE', 'Premature close');
+}
+
+function multipleCallback() {
+  return makeError(Error, 'ERR_MULTIPLE_CALLBACK', 'Callback called multiple times');
+}
+
+function invalidChunk(v) {
+  return util.invalidArgType('chunk', 'of type string or an instance of Buffer, TypedArray, or DataView', v);
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+//
+// utilities
+//
+
+function nop() {
+}
+
+function once(fn) {
+  var called = false;
+  return function() {
+    if (!called) {
+      called = true;
+      return fn.apply(this, arguments);
+    }
+  };
+}
+
+function inherits(ctor, base) {
+  ctor.prototype = Object.create(base.prototype, {
+    constructor: {
+      value: ctor,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+function hidden(obj, name, value) {
+  Object.defineProperty(obj, name, {
+    value: value,
+    writable: true,
+    configurable: true,
+  });
+}
+
+function getters(proto, props) {
+  Object.keys(props).forEach(function(k) {
+    var p = props[k];
+    Object.defineProperty(proto, k, {
+      get: typeof p === 'function' ? p : p.get,
+      set: p.set,
+      configurable: true,
+    });
+  });
+}
+
+function getHighWaterMark(state, options, duplexKey, isDuplex) {
+  var hwm = options.highWaterMark;
+  var name = 'options.highWaterMark';
+  if ((hwm === undefined || hwm === null)
+      && isDuplex) {
+    hwm = options[duplexKey];
+    name = 'options.' + duplexKey;
+  }
+  if (hwm !== undefined
+      && hwm !== null) {
+    if (!(typeof hwm === 'number' && hwm >= 0 && hwm < Infinity)) {
+      throw util.invalidArgValue(name, hwm);
+    }
+    return Math.floor(hwm);
+  }
+  return state.objectMode ? 16 : 16 * 1024;
+}
+
+//
+// destroy
+//
+
+function checkError(err, w, r) {
+  if (err) {
+    if (w
+        && !w.errored) {
+      w.errored = err;
+    }
+    if (r
+        && !r.errored) {
+      r.errored = err;
+    }
+  }
+}
+
+function destroy(err, cb) {
+  var self = this;
+  var r = this._readableState;
+  var w = this._writableState;
+  if ((w && w.destroyed)
+      || (r && r.destroyed)) {
+    if (typeof cb === 'function') {
+      cb();
+    }
+    return this;
+  }
+
+  checkError(err, w, r);
+  if (w) {
+    w.destroyed = true;
+  }
+  if (r) {
+    r.destroyed = true;
+  }
+
+  var called = false;
+  function onDestroy(err) {
+    if (called) {
+      return;
+    }
+    called = true;
+
+    checkError(err, w, r);
+    if (w) {
+      w.closed = true;
+    }
+    if (r) {
+      r.closed = true;
+    }
+    if (typeof cb === 'function') {
+      cb(err);
+    }
+    if (err) {
+      process.nextTick(emitErrorCloseNT, self, err);
+    } else {
+      process.nextTick(emitCloseNT, self);
+    }
+  }
+
+  try {
+    this._destroy(err || null, onDestroy);
+  } catch (e) {
+    onDestroy(e);
+  }
+  return this;
+}
+
+function emitErrorCloseNT(self, err) {
+  emitErrorNT(self, err);
+  emitCloseNT(self);
+}
+
+function emitCloseNT(self) {
+  var r = self._readableState;
+  var w = self._writableState;
+  if (w) {
+    w.closeEmitted = true;
+  }
+  if (r) {
+    r.closeEmitted = true;
+  }
+  if ((w && w.emitClose)
+      || (r && r.emitClose)) {
+    self.emit('close');
+  }
+}
+
+function emitErrorNT(self, err) {
+  var r = self._readableState;
+  var w = self._writableState;
+  if ((w && w.errorEmitted)
+      || (r && r.errorEmitted)) {
+    return;
+  }
+  if (w) {
+    w.errorEmitted = true;
+  }
+  if (r) {
+    r.errorEmitted = true;
+  }
+  self.emit('error', err);
+}
+
+function errorOrDestroy(stream, err, sync) {
+  var r = stream._readableState;
+  var w = stream._writableState;
+  if ((w && w.destroyed)
+      || (r && r.destroyed)) {
+    return;
+  }
+  if ((r && r.autoDestroy)
+      || (w && w.autoDestroy)) {
+    stream.destroy(err);
+  } else if (err) {
+    checkError(err, w, r);
+    if (sync) {
+      process.nextTick(emitErrorNT, stream, err);
+    } else {
+      emitErrorNT(stream, err);
+    }
+  }
+}
+
+function isDestroyed() {
+  var r = this._readableState;
+  var w = this._writableState;
+  return !!((r && r.destroyed) || (w && w.destroyed));
+}
+
+function setDestroyed(v) {
+  if (this._readableState) {
+    this._readableState.destroyed = v;
+  }
+  if (this._writableState) {
+    this._writableState.destroyed = v;
+  }
+}
+
+//
+// Stream
+//
+
+function Stream(options) {
+  EventEmitter.call(this, options);
+}
+
+inherits(Stream, EventEmitter);
+
+//
+// Readable
+//
+
+function ReadableState(options, stream, isDuplex) {
+  this.objectMode = !!options.objectMode;
+  if (isDuplex) {
+    this.objectMode = this.objectMode || !!options.readableObjectMode;
+  }
+  this.highWaterMark = getHighWaterMark(this, options, 'readableHighWaterMark', isDuplex);
+  this.buffer = [];
+  this.length = 0;
+  this.pipes = [];
+  this.flowing = null;
+  this.ended = false;
+  this.endEmitted = false;
+  this.reading = false;
+  this.sync = true;
+  this.needReadable = false;
+  this.emittedReadable = false;
+  this.readableListening = false;
+  this.resumeScheduled = false;
+  this.paused = null;
+  this.errorEmitted = false;
+  this.emitClose = options.emitClose !== false;
+  this.autoDestroy = options.autoDestroy !== false;
+  this.destroyed = false;
+  this.errored = null;
+  this.closed = false;
+  this.closeEmitted = false;
+  this.defaultEncoding = options.defaultEncoding || 'utf8';
+  this.awaitDrain = 0;
+  this.readingMore = false;
+  this.dataEmitted = false;
+  this.decoder = null;
+  this.encoding = null;
+  if (options.encoding) {
+    this.decoder = new StringDecoder(options.encoding);
+    this.encoding = this.decoder.encoding;
+  }
+}
+
+function Readable(options) {
+  if (!(this instanceof Readable)) {
+    return new Readable(options);
+  }
+
+  options = options || {};
+  hidden(this, '_readableState', new ReadableState(options, this, this instanceof Duplex));
+  if (typeof options.read === 'function') {
+    this._read = options.read;
+  }
+  if (typeof options.destroy === 'function') {
+    this._destroy = options.destroy;
+  }
+  Stream.call(this, options);
+}
+
+inherits(Readable, Stream);
+
+Readable.ReadableState = ReadableState;
+
+Readable.prototype.destroy = destroy;
+
+Readable.prototype._destroy = function _destroy(err, cb) {
+  cb(err);
+};
+
+Readable.prototype.push = function push(chunk, encoding) {
+  return readableAddChunk(this, chunk, encoding, false);
+};
+
+Readable.prototype.unshift = function unshift(chunk, encoding) {
+  return readableAddChunk(this, chunk, encoding, true);
+};
+
+function readableAddChunk(stream, chunk, encoding, addToFront) {
+  var state = stream._readableState;
+  var err;
+  if (!state.objectMode) {
+    if (typeof chunk === 'string') {
+      encoding = encoding || state.defaultEncoding;
+      if (state.encoding !== encoding) {
+        if (addToFront
+            && state.encoding) {
+          chunk = Buffer.from(chunk, encoding).toString(state.encoding);
+        } else {
+          chunk = Buffer.from(chunk, encoding);
+          encoding = '';
+        }
+      }
+    } else if (Buffer.isBuffer(chunk)) {
+      encoding = '';
+    } else if (chunk !== null
+               && chunk !== undefined) {
+      err = invalidChunk(chunk);
+    }
+  }
+
+  if (err) {
+    errorOrDestroy(stream, err);
+  } else if (chunk === null) {
+    state.reading = false;
+    onEofChunk(stream, state);
+  } else if (state.objectMode
+             || (chunk && chunk.length > 0)) {
+    if (addToFront) {
+      if (state.endEmitted) {
+        errorOrDestroy(stream, makeError(Error, 'ERR_STREAM_UNSHIFT_AFTER_END_EVENT', 'stream.unshift() after end event'));
+      } else if (state.destroyed
+                 || state.errored) {
+        return false;
+      } else {
+        addChunk(stream, state, chunk, true);
+      }
+    } else if (state.ended) {
+      errorOrDestroy(stream, makeError(Error, 'ERR_STREAM_PUSH_AFTER_EOF', 'stream.push() after EOF'));
+    } else if (state.destroyed
+               || state.errored) {
+      return false;
+    } else {
+      state.reading = false;
+      if (state.decoder
+          && !encoding) {
+        chunk = state.decoder.write(chunk);
+        if (state.objectMode
+            || chunk.length !== 0) {
+          addChunk(stream, state, chunk, false);
+        } else {
+          maybeReadMore(stream, state);
+        }
+      } else {
+        addChunk(stream, state, chunk, false);
+      }
+    }
+  } else if (!addToFront) {
+    state.reading = false;
+    maybeReadMore(stream, state);
+  }
+  return !state.ended
+         && (state.length < state.highWaterMark || state.length === 0);
+}
+
+function addChunk(stream, state, chunk, addToFront) {
+  if (state.flowing
+      && state.length === 0
+      && !state.sync
+      && stream.listenerCount('data') > 0) {
+    state.awaitDrain = 0;
+    state.dataEmitted = true;
+    stream.emit('data', chunk);
+  } else {
+    state.length += state.objectMode ? 1 : chunk.length;
+    if (addToFront) {
+      state.buffer.unshift(chunk);
+    } else {
+      state.buffer.push(chunk);
+    }
+    if (state.needReadable) {
+      emitReadable(stream);
+    }
+  }
+  maybeReadMore(stream, state);
+}
+
+Readable.prototype.isPaused = function isPaused() {
+  var state = this._readableState;
+  return state.paused === true
+         || state.flowing === false;
+};
+
+Readable.prototype.setEncoding = function setEncoding(enc) {
+  var state = this._readableState;
+  var decoder = new StringDecoder(enc);
+  state.decoder = decoder;
+  state.encoding = decoder.encoding;
+
+  var content = '';
+  state.buffer.forEach(function(b) {
+    content += decoder.write(b);
+  });
+  state.buffer = content !== '' ? [content] : [];
+  state.length = content.length;
+  return this;
+};
+
+var MAX_HWM = 0x40000000;
+
+function computeNewHighWaterMark(n) {
+  if (n >= MAX_HWM) {
+    return MAX_HWM;
+  }
+  n--;
+  n |= n >>> 1;
+  n |= n >>> 2;
+  n |= n >>> 4;
+  n |= n >>> 8;
+  n |= n >>> 16;
+  return n + 1;
+}
+
+function howMuchToRead(n, state) {
+  if (n <= 0
+      || (state.length === 0 && state.ended)) {
+    return 0;
+  } else if (state.objectMode) {
+    return 1;
+  } else if (n !== n) {
+    // read everything, or only the head if flowing
+    if (state.flowing
+        && state.length) {
+      return state.buffer[0].length;
+    }
+    return state.length;
+  } else if (n <= state.length) {
+    return n;
+  }
+  return state.ended ? state.length : 0;
+}
+
+Readable.prototype.read = function read(n) {
+  if (n === undefined) {
+    n = NaN;
+  } else if (Math.floor(n) !== n) {
+    n = parseInt(n, 10);
+  }
+  var state = this._readableState;
+  var nOrig = n;
+
+  if (n > state.highWaterMark) {
+    state.highWaterMark = computeNewHighWaterMark(n);
+  }
+  if (n !== 0) {
+    state.emittedReadable = false;
+  }
+  if (n === 0
+      && state.needReadable
+      && ((state.highWaterMark !== 0 ? state.length >= state.highWaterMark : state.length > 0) || state.ended)) {
+    if (state.length === 0
+        && state.ended) {
+      endReadable(this);
+    } else {
+      emitReadable(this);
+    }
+    return null;
+  }
+
+  n = howMuchToRead(n, state);
+  if (n === 0
+      && state.ended) {
+    if (state.length === 0) {
+      endReadable(this);
+    }
+    return null;
+  }
+
+  var doRead = state.needReadable;
+  if (state.length === 0
+      || state.length - n < state.highWaterMark) {
+    doRead = true;
+  }
+  if (state.ended
+      || state.reading
+      || state.destroyed
+      || state.errored) {
+    doRead = false;
+  } else if (doRead) {
+    state.reading = true;
+    state.sync = true;
+    if (state.length === 0) {
+      state.needReadable = true;
+    }
+    try {
+      this._read(state.highWaterMark);
+    } catch (e) {
+      errorOrDestroy(this, e);
+    }
+    state.sync = false;
+    if (!state.reading) {
+      n = howMuchToRead(nOrig, state);
+    }
+  }
+
+  var ret = n > 0 ? fromList(n, state) : null;
+  if (ret === null) {
+    state.needReadable = state.length <= state.highWaterMark;
+    n = 0;
+  } else {
+    state.length -= n;
+    state.awaitDrain = 0;
+  }
+
+  if (state.length === 0) {
+    if (!state.ended) {
+      state.needReadable = true;
+    }
+    if (nOrig !== n
+        && state.ended) {
+      endReadable(this);
+    }
+  }
+  if (ret !== null
+      && !state.errorEmitted
+      && !state.closeEmitted) {
+    state.dataEmitted = true;
+    this.emit('data', ret);
+  }
+  return ret;
+};
+
+Readable.prototype._read = function _read(n) {
+  throw methodNotImplemented('_read()');
+};
+
+function onEofChunk(stream, state) {
+  if (state.ended) {
+    return;
+  }
+  if (state.decoder) {
+    var chunk = state.decoder.end();
+    if (chunk
+        && chunk.length) {
+      state.buffer.push(chunk);
+      state.length += state.objectMode ? 1 : chunk.length;
+    }
+  }
+  state.ended = true;
+
+  if (state.sync) {
+    emitReadable(stream);
+  } else {
+    state.needReadable = false;
+    state.emittedReadable = true;
+    emitReadable_(stream);
+  }
+}
+
+function emitReadable(stream) {
+  var state = stream._readableState;
+  state.needReadable = false;
+  if (!state.emittedReadable) {
+    state.emittedReadable = true;
+    process.nextTick(emitReadable_, stream);
+  }
+}
+
+function emitReadable_(stream) {
+  var state = stream._readableState;
+  if (!state.destroyed
+      && !state.errored
+      && (state.length || state.ended)) {
+    stream.emit('readable');
+    state.emittedReadable = false;
+  }
+  state.needReadable = !state.flowing
+                       && !state.ended
+                       && state.length <= state.highWaterMark;
+  flow(stream);
+}
+
+function maybeReadMore(stream, state) {
+  if (!state.readingMore) {
+    state.readingMore = true;
+    process.nextTick(maybeReadMore_, stream, state);
+  }
+}
+
+function maybeReadMore_(stream, state) {
+  while (!state.reading
+         && !state.ended
+         && (state.length < state.highWaterMark || (state.flowing && state.length === 0))) {
+    var len = state.length;
+    stream.read(0);
+    if (len === state.length) {
+      break;
+    }
+  }
+  state.readingMore = false;
+}
+
+function fromList(n, state) {
+  if (state.length === 0) {
+    return null;
+  }
+
+  var ret;
+  if (state.objectMode) {
+    ret = state.buffer.shift();
+  } else if (!n
+             || n >= state.length) {
+    if (state.decoder) {
+      ret = state.buffer.join('');
+    } else if (state.buffer.length === 1) {
+      ret = state.buffer[0];
+    } else {
+      ret = Buffer.concat(state.buffer, state.length);
+    }
+    state.buffer = [];
+  } else {
+    ret = consume(n, state.buffer, state.decoder);
+  }
+  return ret;
+}
+
+function consume(n, list, hasStrings) {
+  var first = list[0];
+  if (n < first.length) {
+    list[0] = first.slice(n);
+    return first.slice(0, n);
+  } else if (n === first.length) {
+    return list.shift();
+  }
+
+  var parts = [];
+  while (n > 0) {
+    var c = list[0];
+    if (n < c.length) {
+      parts.push(c.slice(0, n));
+      list[0] = c.slice(n);
+      break;
+    }
+    parts.push(list.shift());
+    n -= c.length;
+  }
+  return hasStrings ? parts.join('') : Buffer.concat(parts);
+}
+
+function endReadable(stream) {
+  var state = stream._readableState;
+  if (!state.endEmitted) {
+    state.ended = true;
+    process.nextTick(endReadableNT, state, stream);
+  }
+}
+
+function endReadableNT(state, stream) {
+  if (!state.errored
+      && !state.closeEmitted
+      && !state.endEmitted
+      && state.length === 0) {
+    state.endEmitted = true;
+    stream.emit('end');
+
+    if (stream.writable
+        && stream.allowHalfOpen === false) {
+      process.nextTick(endWritableNT, stream);
+    } else if (state.autoDestroy) {
+      var w = stream._writableState;
+      if (!w
+          || (w.autoDestroy && (w.finished || w.writable === false))) {
+        stream.destroy();
+      }
+    }
+  }
+}
+
+function endWritableNT(stream) {
+  if (stream.writable
+      && !stream.writableEnded
+      && !stream.destroyed) {
+    stream.end();
+  }
+}
+
+Readable.prototype.pipe = function pipe(dest, pipeOpts) {
+  var src = this;
+  var state = this._readableState;
+  state.pipes.push(dest);
+
+  var doEnd = (!pipeOpts || pipeOpts.end !== false)
+              && dest !== process.stdout
+              && dest !== process.stderr;
+  var endFn = doEnd ? onend : unpipe;
+  if (state.endEmitted) {
+    process.nextTick(endFn);
+  } else {
+    src.once('end', endFn);
+  }
+
+  dest.on('unpipe', onunpipe);
+  function onunpipe(readable, unpipeInfo) {
+    if (readable === src
+        && unpipeInfo
+        && unpipeInfo.hasUnpiped === false) {
+      unpipeInfo.hasUnpiped = true;
+      cleanup();
+    }
+  }
+
+  function onend() {
+    dest.end();
+  }
+
+  var ondrain = pipeOnDrain(src);
+  dest.on('drain', ondrain);
+
+  var cleanedUp = false;
+  function cleanup() {
+    dest.removeListener('close', onclose);
+    dest.removeListener('finish', onfinish);
+    dest.removeListener('drain', ondrain);
+    dest.removeListener('error', onerror);
+    dest.removeListener('unpipe', onunpipe);
+    src.removeListener('end', onend);
+    src.removeListener('end', unpipe);
+    src.removeListener('data', ondata);
+    cleanedUp = true;
+
+    if (state.awaitDrain
+        && (!dest._writableState || dest._writableState.needDrain)) {
+      ondrain();
+    }
+  }
+
+  src.on('data', ondata);
+  function ondata(chunk) {
+    if (dest.write(chunk) === false) {
+      if (!cleanedUp
+          && state.pipes.indexOf(dest) !== -1) {
+        state.awaitDrain++;
+      }
+      src.pause();
+    }
+  }
+
+  function onerror(err) {
+    unpipe();
+    dest.removeListener('error', onerror);
+    if (dest.listenerCount('error') === 0) {
+      var s = dest._writableState || dest._readableState;
+      if (s
+          && !s.errorEmitted) {
+        errorOrDestroy(dest, err);
+      } else {
+        dest.emit('error', err);
+      }
+    }
+  }
+  dest.prependListener('error', onerror);
+
+  function onclose() {
+    dest.removeListener('finish', onfinish);
+    unpipe();
+  }
+  dest.once('close', onclose);
+
+  function onfinish() {
+    dest.removeListener('close', onclose);
+    unpipe();
+  }
+  dest.once('finish', onfinish);
+
+  function unpipe() {
+    src.unpipe(dest);
+  }
+
+  dest.emit('pipe', src);
+
+  if (dest.writableNeedDrain === true) {
+    if (state.flowing) {
+      state.awaitDrain++;
+      src.pause();
+    }
+  } else if (!state.flowing) {
+    src.resume();
+  }
+  return dest;
+};
+
+function pipeOnDrain(src) {
+  return function pipeOnDrainFunctionResult() {
+    var state = src._readableState;
+    if (state.awaitDrain) {
+      state.awaitDrain--;
+    }
+    if (state.awaitDrain === 0
+        && src.listenerCount('data') > 0) {
+      src.resume();
+    }
+  };
+}
+
+Readable.prototype.unpipe = function unpipe(dest) {
+  var state = this._readableState;
+  if (state.pipes.length === 0) {
+    return this;
+  }
+
+  if (!dest) {
+    var dests = state.pipes;
+    state.pipes = [];
+    this.pause();
+    for (var i = 0; i < dests.length; i++) {
+      dests[i].emit('unpipe', this, { hasUnpiped: false });
+    }
+    return this;
+  }
+
+  var index = state.pipes.indexOf(dest);
+  if (index === -1) {
+    return this;
+  }
+  state.pipes.splice(index, 1);
+  if (state.pipes.length === 0) {
+    this.pause();
+  }
+  dest.emit('unpipe', this, { hasUnpiped: false });
+  return this;
+};
+
+Readable.prototype.on = Readable.prototype.addListener = function on(ev, fn) {
+  var res = Stream.prototype.on.call(this, ev, fn);
+  var state = this._readableState;
+
+  if (ev === 'data') {
+    state.readableListening = this.listenerCount('readable') > 0;
+    if (state.flowing !== false) {
+      this.resume();
+    }
+  } else if (ev === 'readable') {
+    if (!state.endEmitted
+        && !state.readableListening) {
+      state.readableListening = state.needReadable = true;
+      state.flowing = false;
+      state.emittedReadable = false;
+      if (state.length) {
+        emitReadable(this);
+      } else if (!state.reading) {
+        process.nextTick(nReadingNextTick, this);
+      }
+    }
+  }
+  return res;
+};
+
+Readable.prototype.off = Readable.prototype.removeListener = function removeListener(ev, fn) {
+  var res = Stream.prototype.removeListener.call(this, ev, fn);
+  if (ev === 'readable') {
+    process.nextTick(updateReadableListening, this);
+  }
+  return res;
+};
+
+Readable.prototype.removeAllListeners = function removeAllListeners(ev) {
+  var res = Stream.prototype.removeAllListeners.apply(this, arguments);
+  if (ev === 'readable'
+      || ev === undefined) {
+    process.nextTick(updateReadableListening, this);
+  }
+  return res;
+};
+
+function updateReadableListening(self) {
+  var state = self._readableState;
+  state.readableListening = self.listenerCount('readable') > 0;
+  if (state.resumeScheduled
+      && state.paused === false) {
+    state.flowing = true;
+  } else if (self.listenerCount('data') > 0) {
+    self.resume();
+  } else if (!state.readableListening) {
+    state.flowing = null;
+  }
+}
+
+function nReadingNextTick(self) {
+  self.read(0);
+}
+
+Readable.prototype.resume = function resume() {
+  var state = this._readableState;
+  if (!state.flowing) {
+    state.flowing = !state.readableListening;
+    if (!state.resumeScheduled) {
+      state.resumeScheduled = true;
+      process.nextTick(resume_, this, state);
+    }
+  }
+  state.paused = false;
+  return this;
+};
+
+function resume_(stream, state) {
+  if (!state.reading) {
+    stream.read(0);
+  }
+  state.resumeScheduled = false;
+  stream.emit('resume');
+  flow(stream);
+  if (state.flowing
+      && !state.reading) {
+    stream.read(0);
+  }
+}
+
+Readable.prototype.pause = function pause() {
+  var state = this._readableState;
+  if (state.flowing !== false) {
+    state.flowing = false;
+    this.emit('pause');
+  }
+  state.paused = true;
+  return this;
+};
+
+function flow(stream) {
+  var state = stream._readableState;
+  while (state.flowing
+         && stream.read() !== null) {
+  }
+}
+
+Readable.from = function from(iterable, opts) {
+  var read;
+  if (typeof iterable === 'string'
+      || Buffer.isBuffer(iterable)) {
+    read = function() {
+      this.push(iterable);
+      this.push(null);
+    };
+  } else if (Array.isArray(iterable)) {
+    var i = 0;
+    read = function() {
+      if (i < iterable.length) {
+        var v = iterable[i++];
+        if (v === null) {
+          this.destroy(streamNullValues());
+        } else {
+          this.push(v);
+        }
+      } else {
+        this.push(null);
+      }
+    };
+  } else {
+    throw util.invalidArgType('iterable', 'an instance of Array', iterable);
+  }
+
+  var options = {
+    objectMode: true,
+    highWaterMark: 1,
+  };
+  Object.keys(opts || {}).forEach(function(k) {
+    options[k] = opts[k];
+  });
+  options.read = read;
+  return new Readable(options);
+};
+
+getters(Readable.prototype, {
+  readable: {
+    get: function() {
+      var r = this._readableState;
+      return !!r
+             && r.readable !== false
+             && !r.destroyed
+             && !r.errorEmitted
+             && !r.endEmitted;
+    },
+    set: function(v) {
+      if (this._readableState) {
+        this._readableState.readable = !!v;
+      }
+    },
+  },
+  readableAborted: function() {
+    var r = this._readableState;
+    return !!(r.readable !== false && (r.destroyed || r.errored) && !r.endEmitted);
+  },
+  readableDidRead: function() {
+    return this._readableState.dataEmitted;
+  },
+  readableEncoding: function() {
+    return this._readableState ? this._readableState.encoding : null;
+  },
+  readableEnded: function() {
+    return this._readableState ? this._readableState.endEmitted : false;
+  },
+  readableFlowing: {
+    get: function() {
+      return this._readableState.flowing;
+    },
+    set: function(v) {
+      if (this._readableState) {
+        this._readableState.flowing = v;
+      }
+    },
+  },
+  readableHighWaterMark: function() {
+    return this._readableState.highWaterMark;
+  },
+  readableLength: function() {
+    return this._readableState ? this._readableState.length : undefined;
+  },
+  readableObjectMode: function() {
+    return this._readableState ? this._readableState.objectMode : false;
+  },
+  closed: function() {
+    return this._readableState ? this._readableState.closed : false;
+  },
+  errored: function() {
+    return this._readableState ? this._readableState.errored : null;
+  },
+  destroyed: {
+    get: isDestroyed,
+    set: setDestroyed,
+  },
+});
+
+//
+// Writable
+//
+
+function WritableState(options, stream, isDuplex) {
+  this.objectMode = !!options.objectMode;
+  if (isDuplex) {
+    this.objectMode = this.objectMode || !!options.writableObjectMode;
+  }
+  this.highWaterMark = getHighWaterMark(this, options, 'writableHighWaterMark', isDuplex);
+  this.finalCalled = false;
+  this.needDrain = false;
+  this.ending = false;
+  this.ended = false;
+  this.finished = false;
+  this.destroyed = false;
+  this.decodeStrings = options.decodeStrings !== false;
+  this.defaultEncoding = options.defaultEncoding || 'utf8';
+  this.length = 0;
+  this.writing = false;
+  this.corked = 0;
+  this.sync = true;
+  this.bufferProcessing = false;
+  this.onwrite = onwrite.bind(undefined, stream);
+  this.writecb = null;
+  this.writelen = 0;
+  this.afterWriteTickInfo = null;
+  this.buffered = [];
+  this.bufferedIndex = 0;
+  this.allBuffers = true;
+  this.allNoop = true;
+  this.pendingcb = 0;
+  this.prefinished = false;
+  this.errorEmitted = false;
+  this.emitClose = options.emitClose !== false;
+  this.autoDestroy = options.autoDestroy !== false;
+  this.errored = null;
+  this.closed = false;
+  this.closeEmitted = false;
+  this.onFinished = [];
+}
+
+WritableState.prototype.getBuffer = function getBuffer() {
+  return this.buffered.slice(this.bufferedIndex);
+};
+
+function resetBuffer(state) {
+  state.buffered = [];
+  state.bufferedIndex = 0;
+  state.allBuffers = true;
+  state.allNoop = true;
+}
+
+function Writable(options) {
+  if (!(this instanceof Writable)
+      && !(this instanceof Duplex)) {
+    return new Writable(options);
+  }
+
+  options = options || {};
+  hidden(this, '_writableState', new WritableState(options, this, this instanceof Duplex));
+  if (typeof options.write === 'function') {
+    this._write = options.write;
+  }
+  if (typeof options.writev === 'function') {
+    this._writev = options.writev;
+  }
+  if (typeof options.destroy === 'function') {
+    this._destroy = options.destroy;
+  }
+  if (typeof options.final === 'function') {
+    this._final = options.final;
+  }
+  Stream.call(this, options);
+}
+
+inherits(Writable, Stream);
+
+Writable.WritableState = WritableState;
+
+Writable.prototype.pipe = function pipe() {
+  errorOrDestroy(this, makeError(Error, 'ERR_STREAM_CANNOT_PIPE', 'Cannot pipe, not readable'));
+};
+
+Writable.prototype.write = function write(chunk, encoding, cb) {
+  return writeImpl(this, chunk, encoding, cb) === true;
+};
+
+function writeImpl(stream, chunk, encoding, cb) {
+  var state = stream._writableState;
+  if (typeof encoding === 'function') {
+    cb = encoding;
+    encoding = state.defaultEncoding;
+  } else {
+    if (!encoding) {
+      encoding = state.defaultEncoding;
+    } else if (encoding !== 'buffer'
+               && !Buffer.isEncoding(encoding)) {
+      throw util.unknownEncoding(encoding);
+    }
+    if (typeof cb !== 'function') {
+      cb = nop;
+    }
+  }
+
+  if (chunk === null) {
+    throw streamNullValues();
+  } else if (!state.objectMode) {
+    if (typeof chunk === 'string') {
+      if (state.decodeStrings) {
+        chunk = Buffer.from(chunk, encoding);
+        encoding = 'buffer';
+      }
+    } else if (Buffer.isBuffer(chunk)) {
+      encoding = 'buffer';
+    } else {
+      throw invalidChunk(chunk);
+    }
+  }
+
+  var err;
+  if (state.ending) {
+    err = makeError(Error, 'ERR_STREAM_WRITE_AFTER_END', 'write after end');
+  } else if (state.destroyed) {
+    err = streamDestroyed('write');
+  }
+  if (err) {
+    process.nextTick(cb, err);
+    errorOrDestroy(stream, err, true);
+    return err;
+  }
+  state.pendingcb++;
+  return writeOrBuffer(stream, state, chunk, encoding, cb);
+}
+
+function writeOrBuffer(stream, state, chunk, encoding, callback) {
+  var len = state.objectMode ? 1 : chunk.length;
+  state.length += len;
+  var ret = state.length < state.highWaterMark;
+  if (!ret) {
+    state.needDrain = true;
+  }
+
+  if (state.writing
+      || state.corked
+      || state.errored) {
+    state.buffered.push({
+      chunk: chunk,
+      encoding: encoding,
+      callback: callback,
+    });
+    if (state.allBuffers
+        && encoding !== 'buffer') {
+      state.allBuffers = false;
+    }
+    if (state.allNoop
+        && callback !== nop) {
+      state.allNoop = false;
+    }
+  } else {
+    state.writelen = len;
+    state.writecb = callback;
+    state.writing = true;
+    state.sync = true;
+    stream._write(chunk, encoding, state.onwrite);
+    state.sync = false;
+  }
+  return ret
+         && !state.errored
+         && !state.destroyed;
+}
+
+function doWrite(stream, state, writev, len, chunk, encoding, cb) {
+  state.writelen = len;
+  state.writecb = cb;
+  state.writing = true;
+  state.sync = true;
+  if (state.destroyed) {
+    state.onwrite(streamDestroyed('write'));
+  } else if (writev) {
+    stream._writev(chunk, state.onwrite);
+  } else {
+    stream._write(chunk, encoding, state.onwrite);
+  }
+  state.sync = false;
+}
+
+function onwriteError(stream, state, err, cb) {
+  --state.pendingcb;
+  cb(err);
+  errorBuffer(state);
+  errorOrDestroy(stream, err);
+}
+
+function onwrite(stream, err) {
+  var state = stream._writableState;
+  var sync = state.sync;
+  var cb = state.writecb;
+  if (typeof cb !== 'function') {
+    errorOrDestroy(stream, multipleCallback());
+    return;
+  }
+
+  state.writing = false;
+  state.writecb = null;
+  state.length -= state.writelen;
+  state.writelen = 0;
+
+  if (err) {
+    if (!state.errored) {
+      state.errored = err;
+    }
+    if (stream._readableState
+        && !stream._readableState.errored) {
+      stream._readableState.errored = err;
+    }
+    if (sync) {
+      process.nextTick(onwriteError, stream, state, err, cb);
+    } else {
+      onwriteError(stream, state, err, cb);
+    }
+  } else {
+    if (state.buffered.length > state.bufferedIndex) {
+      clearBuffer(stream, state);
+    }
+    if (sync) {
+      if (state.afterWriteTickInfo !== null
+          && state.afterWriteTickInfo.cb === cb) {
+        state.afterWriteTickInfo.count++;
+      } else {
+        state.afterWriteTickInfo = {
+          count: 1,
+          cb: cb,
+          stream: stream,
+          state: state,
+        };
+        process.nextTick(afterWriteTick, state.afterWriteTickInfo);
+      }
+    } else {
+      afterWrite(stream, state, 1, cb);
+    }
+  }
+}
+
+function afterWriteTick(info) {
+  info.state.afterWriteTickInfo = null;
+  afterWrite(info.stream, info.state, info.count, info.cb);
+}
+
+function afterWrite(stream, state, count, cb) {
+  if (!state.ending
+      && !stream.destroyed
+      && state.length === 0
+      && state.needDrain) {
+    state.needDrain = false;
+    stream.emit('drain');
+  }
+  while (count-- > 0) {
+    state.pendingcb--;
+    cb();
+  }
+  if (state.destroyed) {
+    errorBuffer(state);
+  }
+  finishMaybe(stream, state);
+}
+
+function errorBuffer(state) {
+  if (state.writing) {
+    return;
+  }
+
+  for (var i = state.bufferedIndex; i < state.buffered.length; i++) {
+    var b = state.buffered[i];
+    state.length -= state.objectMode ? 1 : b.chunk.length;
+    b.callback(state.errored || streamDestroyed('write'));
+  }
+  var fns = state.onFinished.splice(0);
+  for (i = 0; i < fns.length; i++) {
+    fns[i](state.errored || streamDestroyed('end'));
+  }
+  resetBuffer(state);
+}
+
+function clearBuffer(stream, state) {
+  if (state.corked
+      || state.bufferProcessing
+      || state.destroyed) {
+    return;
+  }
+
+  var buffered = state.buffered;
+  var i = state.bufferedIndex;
+  var n = buffered.length - i;
+  if (!n) {
+    return;
+  }
+
+  state.bufferProcessing = true;
+  if (n > 1
+      && stream._writev) {
+    state.pendingcb -= n - 1;
+    var callback = state.allNoop ? nop : function(err) {
+      for (var j = i; j < buffered.length; j++) {
+        buffered[j].callback(err);
+      }
+    };
+    var chunks = buffered.slice(i);
+    chunks.allBuffers = state.allBuffers;
+    doWrite(stream, state, true, state.length, chunks, '', callback);
+    resetBuffer(state);
+  } else {
+    do {
+      var b = buffered[i];
+      buffered[i++] = null;
+      doWrite(stream, state, false, state.objectMode ? 1 : b.chunk.length, b.chunk, b.encoding, b.callback);
+    } while (i < buffered.length
+             && !state.writing);
+
+    if (i === buffered.length) {
+      resetBuffer(state);
+    } else if (i > 256) {
+      buffered.splice(0, i);
+      state.bufferedIndex = 0;
+    } else {
+      state.bufferedIndex = i;
+    }
+  }
+  state.bufferProcessing = false;
+}
+
+Writable.prototype._write = function _write(chunk, encoding, cb) {
+  if (this._writev) {
+    this._writev([{ chunk: chunk, encoding: encoding }], cb);
+  } else {
+    throw methodNotImplemented('_write()');
+  }
+};
+
+Writable.prototype._writev = null;
+
+Writable.prototype.cork = function cork() {
+  this._writableState.corked++;
+};
+
+Writable.prototype.uncork = function uncork() {
+  var state = this._writableState;
+  if (state.corked) {
+    state.corked--;
+    if (!state.writing) {
+      clearBuffer(this, state);
+    }
+  }
+};
+
+Writable.prototype.setDefaultEncoding = function setDefaultEncoding(encoding) {
+  if (typeof encoding === 'string') {
+    encoding = encoding.toLowerCase();
+  }
+  if (!Buffer.isEncoding(encoding)) {
+    throw util.unknownEncoding(encoding);
+  }
+  this._writableState.defaultEncoding = encoding;
+  return this;
+};
+
+Writable.prototype.end = function end(chunk, encoding, cb) {
+  var state = this._writableState;
+  if (typeof chunk === 'function') {
+    cb = chunk;
+    chunk = null;
+    encoding = null;
+  } else if (typeof encoding === 'function') {
+    cb = encoding;
+    encoding = null;
+  }
+
+  var err;
+  if (chunk !== null
+      && chunk !== undefined) {
+    var ret = writeImpl(this, chunk, encoding);
+    if (ret instanceof Error) {
+      err = ret;
+    }
+  }
+  if (state.corked) {
+    state.corked = 1;
+    this.uncork();
+  }
+
+  if (err) {
+    // already reported
+  } else if (!state.errored
+             && !state.ending) {
+    state.ending = true;
+    finishMaybe(this, state, true);
+    state.ended = true;
+  } else if (state.finished) {
+    err = streamAlreadyFinished('end');
+  } else if (state.destroyed) {
+    err = streamDestroyed('end');
+  }
+
+  if (typeof cb === 'function') {
+    if (err
+        || state.finished) {
+      process.nextTick(cb, err);
+    } else {
+      state.onFinished.push(cb);
+    }
+  }
+  return this;
+};
+
+function needFinish(state) {
+  return state.ending
+         && !state.destroyed
+         && state.length === 0
+         && !state.errored
+         && state.buffered.length === 0
+         && !state.finished
+         && !state.writing
+         && !state.errorEmitted
+         && !state.closeEmitted;
+}
+
+function callFinal(stream, state) {
+  var called = false;
+  function onFinish(err) {
+    if (called) {
+      errorOrDestroy(stream, multipleCallback());
+      return;
+    }
+    called = true;
+
+    state.pendingcb--;
+    if (err) {
+      var fns = state.onFinished.splice(0);
+      for (var i = 0; i < fns.length; i++) {
+        fns[i](err);
+      }
+      errorOrDestroy(stream, err, state.sync);
+    } else if (needFinish(state)) {
+      state.prefinished = true;
+      stream.emit('prefinish');
+      state.pendingcb++;
+      process.nextTick(finish, stream, state);
+    }
+  }
+
+  state.sync = true;
+  state.pendingcb++;
+  try {
+    stream._final(onFinish);
+  } catch (e) {
+    onFinish(e);
+  }
+  state.sync = false;
+}
+
+function prefinish(stream, state) {
+  if (!state.prefinished
+      && !state.finalCalled) {
+    if (typeof stream._final === 'function'
+        && !state.destroyed) {
+      state.finalCalled = true;
+      callFinal(stream, state);
+    } else {
+      state.prefinished = true;
+      stream.emit('prefinish');
+    }
+  }
+}
+
+function finishMaybe(stream, state, sync) {
+  if (needFinish(state)) {
+    prefinish(stream, state);
+    if (state.pendingcb === 0) {
+      if (sync) {
+        state.pendingcb++;
+        process.nextTick(function() {
+          if (needFinish(state)) {
+            finish(stream, state);
+          } else {
+            state.pendingcb--;
+          }
+        });
+      } else if (needFinish(state)) {
+        state.pendingcb++;
+        finish(stream, state);
+      }
+    }
+  }
+}
+
+function finish(stream, state) {
+  state.pendingcb--;
+  state.finished = true;
+
+  var fns = state.onFinished.splice(0);
+  for (var i = 0; i < fns.length; i++) {
+    fns[i]();
+  }
+  stream.emit('finish');
+
+  if (state.autoDestroy) {
+    var r = stream._readableState;
+    if (!r
+        || (r.autoDestroy && (r.endEmitted || r.readable === false))) {
+      stream.destroy();
+    }
+  }
+}
+
+Writable.prototype.destroy = function(err, cb) {
+  var state = this._writableState;
+  if (!state.destroyed
+      && (state.bufferedIndex < state.buffered.length || state.onFinished.length)) {
+    process.nextTick(errorBuffer, state);
+  }
+  destroy.call(this, err, cb);
+  return this;
+};
+
+Writable.prototype._destroy = function _destroy(err, cb) {
+  cb(err);
+};
+
+getters(Writable.prototype, {
+  writable: {
+    get: function() {
+      var w = this._writableState;
+      return !!w
+             && w.writable !== false
+             && !w.destroyed
+             && !w.errored
+             && !w.ending
+             && !w.ended;
+    },
+    set: function(v) {
+      if (this._writableState) {
+        this._writableState.writable = !!v;
+      }
+    },
+  },
+  writableFinished: function() {
+    return this._writableState ? this._writableState.finished : false;
+  },
+  writableObjectMode: function() {
+    return this._writableState ? this._writableState.objectMode : false;
+  },
+  writableEnded: function() {
+    return this._writableState ? this._writableState.ending : false;
+  },
+  writableNeedDrain: function() {
+    var w = this._writableState;
+    return w ? !w.destroyed && !w.ending && w.needDrain : false;
+  },
+  writableHighWaterMark: function() {
+    return this._writableState && this._writableState.highWaterMark;
+  },
+  writableCorked: function() {
+    return this._writableState ? this._writableState.corked : 0;
+  },
+  writableLength: function() {
+    return this._writableState && this._writableState.length;
+  },
+  closed: function() {
+    return this._writableState ? this._writableState.closed : false;
+  },
+  errored: function() {
+    return this._writableState ? this._writableState.errored : null;
+  },
+  destroyed: {
+    get: isDestroyed,
+    set: setDestroyed,
+  },
+});
+
+//
+// Duplex
+//
+
+function Duplex(options) {
+  if (!(this instanceof Duplex)) {
+    return new Duplex(options);
+  }
+
+  Readable.call(this, options);
+  Writable.call(this, options);
+
+  if (options) {
+    this.allowHalfOpen = options.allowHalfOpen !== false;
+    if (options.readable === false) {
+      this._readableState.readable = false;
+      this._readableState.ended = true;
+      this._readableState.endEmitted = true;
+    }
+    if (options.writable === false) {
+      this._writableState.writable = false;
+      this._writableState.ending = true;
+      this._writableState.ended = true;
+      this._writableState.finished = true;
+    }
+  } else {
+    this.allowHalfOpen = true;
+  }
+}
+
+inherits(Duplex, Readable);
+
+Object.getOwnPropertyNames(Writable.prototype).forEach(function(k) {
+  if (!Object.prototype.hasOwnProperty.call(Duplex.prototype, k)
+      && !Object.prototype.hasOwnProperty.call(Readable.prototype, k)) {
+    Object.defineProperty(Duplex.prototype, k, Object.getOwnPropertyDescriptor(Writable.prototype, k));
+  }
+});
+
+getters(Duplex.prototype, {
+  closed: function() {
+    return this._readableState.closed
+           && this._writableState.closed;
+  },
+  errored: function() {
+    return this._readableState.errored
+           || this._writableState.errored;
+  },
+});
+
+//
+// Transform
+//
+
+function Transform(options) {
+  if (!(this instanceof Transform)) {
+    return new Transform(options);
+  }
+
+  Duplex.call(this, options);
+  this._readableState.sync = false;
+  hidden(this, '_transformCallback', null);
+
+  if (options) {
+    if (typeof options.transform === 'function') {
+      this._transform = options.transform;
+    }
+    if (typeof options.flush === 'function') {
+      this._flush = options.flush;
+    }
+  }
+  this.on('prefinish', transformPrefinish);
+}
+
+inherits(Transform, Duplex);
+
+function transformFinal(cb) {
+  var self = this;
+  if (typeof this._flush === 'function'
+      && !this.destroyed) {
+    this._flush(function(err, data) {
+      if (err) {
+        if (cb) {
+          cb(err);
+        } else {
+          self.destroy(err);
+        }
+        return;
+      }
+      if (data !== null
+          && data !== undefined) {
+        self.push(data);
+      }
+      self.push(null);
+      if (cb) {
+        cb();
+      }
+    });
+  } else {
+    this.push(null);
+    if (cb) {
+      cb();
+    }
+  }
+}
+
+function transformPrefinish() {
+  if (this._final !== transformFinal) {
+    transformFinal.call(this);
+  }
+}
+
+Transform.prototype._final = transformFinal;
+
+Transform.prototype._transform = function _transform(chunk, encoding, cb) {
+  throw methodNotImplemented('_transform()');
+};
+
+Transform.prototype._write = function _write(chunk, encoding, callback) {
+  var self = this;
+  var r = this._readableState;
+  var w = this._writableState;
+  var length = r.length;
+
+  this._transform(chunk, encoding, function(err, val) {
+    if (err) {
+      callback(err);
+      return;
+    }
+    if (val !== null
+        && val !== undefined) {
+      self.push(val);
+    }
+    if (w.ended
+        || length === r.length
+        || r.length < r.highWaterMark) {
+      callback();
+    } else {
+      self._transformCallback = callback;
+    }
+  });
+};
+
+Transform.prototype._read = function _read() {
+  if (this._transformCallback) {
+    var cb = this._transformCallback;
+    this._transformCallback = null;
+    cb();
+  }
+};
+
+//
+// PassThrough
+//
+
+function PassThrough(options) {
+  if (!(this instanceof PassThrough)) {
+    return new PassThrough(options);
+  }
+
+  Transform.call(this, options);
+}
+
+inherits(PassThrough, Transform);
+
+PassThrough.prototype._transform = function _transform(chunk, encoding, cb) {
+  cb(null, chunk);
+};
+
+//
+// finished
+//
+
+function isReadableStream(stream) {
+  return !!stream
+         && typeof stream.pipe === 'function'
+         && typeof stream.on === 'function'
+         && (!stream._writableState || (stream._readableState && stream._readableState.readable !== false))
+         && (!stream._writableState || stream._readableState);
+}
+
+function isWritableStream(stream) {
+  return !!stream
+         && typeof stream.write === 'function'
+         && typeof stream.on === 'function'
+         && (!stream._readableState || (stream._writableState && stream._writableState.writable !== false));
+}
+
+function finished(stream, options, callback) {
+  if (arguments.length === 2) {
+    callback = options;
+    options = {};
+  } else if (options === null
+             || options === undefined) {
+    options = {};
+  }
+  validateFunction(callback, 'callback');
+  callback = once(callback);
+
+  var readable = options.readable !== undefined ? options.readable : isReadableStream(stream);
+  var writable = options.writable !== undefined ? options.writable : isWritableStream(stream);
+  var r = stream._readableState;
+  var w = stream._writableState;
+
+  var s = w || r;
+  var willEmitClose = !!s
+                      && s.autoDestroy
+                      && s.emitClose
+                      && !s.closed
+                      && isReadableStream(stream) === readable
+                      && isWritableStream(stream) === writable;
+  var readableEnded = !!(r && r.endEmitted);
+  var writableFinished = !!(w && w.finished);
+
+  function onfinish() {
+    writableFinished = true;
+    if (stream.destroyed) {
+      willEmitClose = false;
+    }
+    if (willEmitClose
+        && (!stream.readable || readable)) {
+      return;
+    }
+    if (!readable
+        || readableEnded) {
+      callback.call(stream);
+    }
+  }
+
+  function onend() {
+    readableEnded = true;
+    if (stream.destroyed) {
+      willEmitClose = false;
+    }
+    if (willEmitClose
+        && (!stream.writable || writable)) {
+      return;
+    }
+    if (!writable
+        || writableFinished) {
+      callback.call(stream);
+    }
+  }
+
+  function onerror(err) {
+    callback.call(stream, err);
+  }
+
+  function onclose() {
+    var err = (w && w.errored) || (r && r.errored);
+    if (err) {
+      return callback.call(stream, err);
+    }
+    if ((readable && !readableEnded)
+        || (writable && !writableFinished)) {
+      return callback.call(stream, streamPrematureClose());
+    }
+    callback.call(stream);
+  }
+
+  stream.on('end', onend);
+  stream.on('finish', onfinish);
+  if (options.error !== false) {
+    stream.on('error', onerror);
+  }
+  stream.on('close', onclose);
+
+  if ((w && w.closeEmitted)
+      || (r && r.closeEmitted)) {
+    process.nextTick(onclose);
+  } else if ((!readable || readableEnded)
+             && (!writable || writableFinished)) {
+    process.nextTick(function() {
+      callback.call(stream);
+    });
+  }
+
+  return function cleanup() {
+    callback = nop;
+    stream.removeListener('end', onend);
+    stream.removeListener('finish', onfinish);
+    stream.removeListener('error', onerror);
+    stream.removeListener('close', onclose);
+  };
+}
+
+//
+// pipeline
+//
+
+function pipeline() {
+  var streams = Array.prototype.slice.call(arguments);
+  var callback = streams.pop();
+  validateFunction(callback, 'streams[stream.length - 1]');
+  if (streams.length === 1
+      && Array.isArray(streams[0])) {
+    streams = streams[0];
+  }
+  if (streams.length < 2) {
+    throw makeError(TypeError, 'ERR_MISSING_ARGS', 'The "streams" argument must be specified');
+  }
+
+  var error;
+  var destroys = [];
+  var finishCount = streams.length;
+
+  function finish(err) {
+    if (err
+        && (!error || error.code === 'ERR_STREAM_PREMATURE_CLOSE')) {
+      error = err;
+    }
+    var final = --finishCount === 0;
+    if (!error
+        && !final) {
+      return;
+    }
+    while (destroys.length) {
+      destroys.shift()(error);
+    }
+    if (final) {
+      process.nextTick(callback, error);
+    }
+  }
+
+  streams.forEach(function(stream, i) {
+    var reading = i < streams.length - 1;
+    var writing = i > 0;
+    var done = false;
+    finished(stream, { readable: reading, writable: writing }, function(err) {
+      done = !err;
+      finish(err);
+    });
+    destroys.push(function(err) {
+      if (!done) {
+        done = true;
+        if (typeof stream.destroy === 'function') {
+          stream.destroy(err || streamDestroyed('pipe'));
+        }
+      }
+    });
+    if (i > 0) {
+      streams[i - 1].pipe(stream);
+    }
+  });
+  return streams[streams.length - 1];
+}
+
+//
+// host streams
+//
+
+function fromReader(handle) {
+  var r = new Readable({
+    read: function(n) {
+      var self = this;
+      handle.read(n, function(err, buf) {
+        if (err) {
+          self.destroy(err);
+        } else {
+          self.push(buf);
+        }
+      });
+    },
+    destroy: function(err, cb) {
+      handle.close();
+      cb(err);
+    },
+  });
+  // a paused stream does not keep the event loop alive
+  r.on('pause', function() {
+    handle.unref();
+  });
+  r.on('resume', function() {
+    handle.ref();
+  });
+  return r;
+}
+
+function fromWriter(handle) {
+  return new Writable({
+    write: function(chunk, encoding, cb) {
+      handle.write(chunk, cb);
+    },
+    final: function(cb) {
+      handle.close(cb);
+    },
+    destroy: function(err, cb) {
+      handle.close(function() {
+        cb(err);
+      });
+    },
+  });
+}
+
+binding.setup(fromReader, fromWriter);
+
+module.exports = Stream;
+Stream.Stream = Stream;
+Stream.Readable = Readable;
+Stream.Writable = Writable;
+Stream.Duplex = Duplex;
+Stream.Transform = Transform;
+Stream.PassThrough = PassThrough;
+Stream.finished = finished;
+Stream.pipeline = pipeline;
+`),
+	"string_decoder.js": []byte(`//
+// otto.module :: string_decoder.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var Buffer = require('./buffer').Buffer;
+var util = require('./internal/util');
+
+function normalizeEncoding(enc) {
+  var s = util.normalizeEncoding(enc);
+  if (s === undefined) {
+    throw util.unknownEncoding(enc);
+  }
+  return s;
+}
+
+function StringDecoder(encoding) {
+  this.encoding = normalizeEncoding(encoding);
+  switch (this.encoding) {
+  case 'utf8':
+    this.fillLast = utf8FillLast;
+    this.text = utf8Text;
+    this.end = utf8End;
+    break;
+  case 'utf16le':
+    this.fillLast = utf16FillLast;
+    this.text = utf16Text;
+    this.end = utf16End;
+    break;
+  case 'base64':
+  case 'base64url':
+    this.text = base64Text;
+    this.end = base64End;
+    break;
+  default:
+    this.write = simpleWrite;
+    this.end = simpleEnd;
+    return;
+  }
+  this.lastNeed = 0;
+  this.lastTotal = 0;
+  this.lastChar = Buffer.alloc(4);
+}
+
+exports.StringDecoder = StringDecoder;
+
+StringDecoder.prototype.write = function write(buf) {
+  if (typeof buf === 'string') {
+    return buf;
+  } else if (!Buffer.isBuffer(buf)) {
+    buf = Buffer.from(buf);
+  }
+  if (buf.length === 0) {
+    return '';
+  }
+
+  var r;
+  var i = 0;
+  if (this.lastNeed) {
+    r = this.fillLast(buf);
+    if (r === undefined) {
+      return '';
+    }
+    i = this.lastNeed;
+    this.lastNeed = 0;
+  }
+  if (i < buf.length) {
+    return r ? r + this.text(buf, i) : this.text(buf, i);
+  }
+  return r || '';
+};
+
+StringDecoder.prototype.fillLast = function fillLast(buf) {
+  if (this.lastNeed <= buf.length) {
+    buf.copy(this.lastChar, this.lastTotal - this.lastNeed, 0, this.lastNeed);
+    return this.lastChar.toString(this.encoding, 0, this.lastTotal);
+  }
+  buf.copy(this.lastChar, this.lastTotal - this.lastNeed, 0, buf.length);
+  this.lastNeed -= buf.length;
+};
+
+// returns the number of bytes of a UTF-8 sequence starting with the byte, 0
+// for a single byte, -1 for a continuation byte, or -2 for an invalid byte
+function utf8CheckByte(c) {
+  if (c <= 0x7f) {
+    return 0;
+  } else if (c >> 5 === 0x06) {
+    return 2;
+  } else if (c >> 4 === 0x0e) {
+    return 3;
+  } else if (c >> 3 === 0x1e) {
+    return 4;
+  }
+  return c >> 6 === 0x02 ? -1 : -2;
+}
+
+function utf8CheckIncomplete(self, buf, i) {
+  var j = buf.length - 1;
+  if (j < i) {
+    return 0;
+  }
+  var n = utf8CheckByte(buf[j]);
+  if (n >= 0) {
+    if (n > 0) {
+      self.lastNeed = n - 1;
+    }
+    return n;
+  } else if (--j < i
+             || n === -2) {
+    return 0;
+  }
+  n = utf8CheckByte(buf[j]);
+  if (n >= 0) {
+    if (n > 0) {
+      self.lastNeed = n - 2;
+    }
+    return n;
+  } else if (--j < i
+             || n === -2) {
+    return 0;
+  }
+  n = utf8CheckByte(buf[j]);
+  if (n >= 0) {
+    if (n === 2) {
+      n = 0;
+    } else if (n > 0) {
+      self.lastNeed = n - 3;
+    }
+    return n;
+  }
+  return 0;
+}
+
+function utf8CheckExtraBytes(self, buf) {
+  for (var i = 0; i < self.lastNeed && i < buf.length; i++) {
+    if ((buf[i] & 0xc0) !== 0x80) {
+      self.lastNeed = i;
+      return '\ufffd';
+    }
+  }
+}
+
+function utf8FillLast(buf) {
+  var p = this.lastTotal - this.lastNeed;
+  var r = utf8CheckExtraBytes(this, buf);
+  if (r !== undefined) {
+    return r;
+  } else if (this.lastNeed <= buf.length) {
+    buf.copy(this.lastChar, p, 0, this.lastNeed);
+    return this.lastChar.toString(this.encoding, 0, this.lastTotal);
+  }
+  buf.copy(this.lastChar, p, 0, buf.length);
+  this.lastNeed -= buf.length;
+}
+
+function utf8Text(buf, i) {
+  var total = utf8CheckIncomplete(this, buf, i);
+  if (!this.lastNeed) {
+    return buf.toString('utf8', i, buf.length);
+  }
+  this.lastTotal = total;
+  var end = buf.length - (total - this.lastNeed);
+  buf.copy(this.lastChar, 0, end, buf.length);
+  return buf.toString('utf8', i, end);
+}
+
+function utf8End(buf) {
+  var r = buf && buf.length ? this.write(buf) : '';
+  if (this.lastNeed) {
+    this.lastNeed = 0;
+    return r + '\ufffd';
+  }
+  return r;
+}
+
+function utf16Text(buf, i) {
+  var end = buf.length;
+  var c;
+  if ((end - i) % 2 === 0) {
+    // keep a high surrogate for the next write
+    c = buf[end - 2] | (buf[end - 1] << 8);
+    if (0xd800 <= c && c <= 0xdbff) {
+      this.lastNeed = 2;
+      this.lastTotal = 4;
+      this.lastChar[0] = buf[end - 2];
+      this.lastChar[1] = buf[end - 1];
+      return buf.toString('utf16le', i, end - 2);
+    }
+    return buf.toString('utf16le', i, end);
+  }
+  if (end - i >= 3) {
+    // keep a high surrogate with the odd byte
+    c = buf[end - 3] | (buf[end - 2] << 8);
+    if (0xd800 <= c && c <= 0xdbff) {
+      this.lastNeed = 1;
+      this.lastTotal = 4;
+      buf.copy(this.lastChar, 0, end - 3, end);
+      return buf.toString('utf16le', i, end - 3);
+    }
+  }
+  this.lastNeed = 1;
+  this.lastTotal = 2;
+  this.lastChar[0] = buf[end - 1];
+  return buf.toString('utf16le', i, end - 1);
+}
+
+function utf16FillLast(buf) {
+  if (this.lastTotal === 2) {
+    var c = this.lastChar[0] | (buf[0] << 8);
+    if (0xd800 <= c && c <= 0xdbff) {
+      // wait for the low surrogate
+      this.lastNeed = 3;
+      this.lastTotal = 4;
+    }
+  }
+  return StringDecoder.prototype.fillLast.call(this, buf);
+}
+
+function utf16End(buf) {
+  var r = buf && buf.length ? this.write(buf) : '';
+  if (this.lastNeed) {
+    var end = this.lastTotal - this.lastNeed;
+    this.lastNeed = 0;
+    return r + this.lastChar.toString('utf16le', 0, end);
+  }
+  return r;
+}
+
+function base64Text(buf, i) {
+  var n = (buf.length - i) % 3;
+  if (n === 0) {
+    return buf.toString(this.encoding, i, buf.length);
+  }
+  this.lastNeed = 3 - n;
+  this.lastTotal = 3;
+  buf.copy(this.lastChar, 0, buf.length - n, buf.length);
+  return buf.toString(this.encoding, i, buf.length - n);
+}
+
+function base64End(buf) {
+  var r = buf && buf.length ? this.write(buf) : '';
+  if (this.lastNeed) {
+    var end = 3 - this.lastNeed;
+    this.lastNeed = 0;
+    return r + this.lastChar.toString(this.encoding, 0, end);
+  }
+  return r;
+}
+
+function simpleWrite(buf) {
+  if (typeof buf === 'string') {
+    return buf;
+  } else if (!Buffer.isBuffer(buf)) {
+    buf = Buffer.from(buf);
+  }
+  return buf.toString(this.encoding);
+}
+
+function simpleEnd(buf) {
+  return buf && buf.length ? this.write(buf) : '';
+}
+`),
+	"timers.js": []byte(`//
+// otto.module :: timers.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('timers');
+var util = require('./internal/util');
+
+var TIMEOUT_MAX = 2147483647;
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function hidden(o, k, v) {
+  Object.defineProperty(o, k, {
+    value: v,
+    writable: true,
+    configurable: true,
+  });
+}
+
+binding.setupTimers(function processTimer(timer) {
+  var id = timer._id;
+  try {
+    var args = timer._timerArgs;
+    if (args === undefined) {
+      timer._onTimeout();
+    } else {
+      timer._onTimeout.apply(timer, args);
+    }
+  } finally {
+    if (timer._repeat === null
+        && timer._id === id) {
+      timer._destroyed = true;
+    }
+  }
+}, function processImmediate(immediate) {
+  immediate._destroyed = true;
+  var argv = immediate._argv;
+  if (argv === undefined) {
+    immediate._onImmediate();
+  } else {
+    immediate._onImmediate.apply(immediate, argv);
+  }
+});
+
+//
+// Timeout
+//
+
+function Timeout(callback, after, args, isRepeat) {
+  after *= 1;
+  if (!(after >= 1 && after <= TIMEOUT_MAX)) {
+    if (after > TIMEOUT_MAX) {
+      process.emitWarning(after + ' does not fit into a 32-bit signed integer.\nTimeout duration was set to 1.', 'TimeoutOverflowWarning');
+    }
+    after = 1;
+  }
+  this._idleTimeout = after;
+  this._onTimeout = callback;
+  this._timerArgs = args;
+  this._repeat = isRepeat ? after : null;
+  this._destroyed = false;
+  hidden(this, '_refed', true);
+  hidden(this, '_id', binding.start(this, after, isRepeat, true));
+}
+
+Timeout.prototype.refresh = function refresh() {
+  if (this._idleTimeout !== -1) {
+    binding.clear(this._id);
+    this._id = binding.start(this, this._idleTimeout, this._repeat !== null, this._refed);
+    this._destroyed = false;
+  }
+  return this;
+};
+
+Timeout.prototype.ref = function ref() {
+  this._refed = true;
+  binding.ref(this._id, true);
+  return this;
+};
+
+Timeout.prototype.unref = function unref() {
+  this._refed = false;
+  binding.ref(this._id, false);
+  return this;
+};
+
+Timeout.prototype.hasRef = function hasRef() {
+  return this._refed;
+};
+
+Timeout.prototype.close = function close() {
+  clearTimeout(this);
+  return this;
+};
+
+Timeout.prototype.valueOf = function valueOf() {
+  return this._id;
+};
+
+//
+// Immediate
+//
+
+function Immediate(callback, args) {
+  this._onImmediate = callback;
+  this._argv = args;
+  this._destroyed = false;
+  hidden(this, '_refed', true);
+  hidden(this, '_id', binding.scheduleImmediate(this, true));
+}
+
+Immediate.prototype.ref = function ref() {
+  this._refed = true;
+  binding.ref(this._id, true);
+  return this;
+};
+
+Immediate.prototype.unref = function unref() {
+  this._refed = false;
+  binding.ref(this._id, false);
+  return this;
+};
+
+Immediate.prototype.hasRef = function hasRef() {
+  return this._refed;
+};
+
+//
+// API
+//
+
+function clear(timer) {
+  if (timer) {
+    if (typeof timer === 'object') {
+      if (!timer._destroyed) {
+        timer._destroyed = true;
+        binding.clear(timer._id);
+      }
+      if (timer instanceof Timeout) {
+        timer._idleTimeout = -1;
+      }
+    } else if (typeof timer === 'number'
+               || typeof timer === 'string') {
+      binding.clear(+timer);
+    }
+  }
+}
+
+function setTimeout(callback, after) {
+  validateFunction(callback, 'callback');
+  var args = arguments.length > 2 ? Array.prototype.slice.call(arguments, 2) : undefined;
+  return new Timeout(callback, after, args, false);
+}
+
+function clearTimeout(timer) {
+  clear(timer);
+}
+
+function setInterval(callback, repeat) {
+  validateFunction(callback, 'callback');
+  var args = arguments.length > 2 ? Array.prototype.slice.call(arguments, 2) : undefined;
+  return new Timeout(callback, repeat, args, true);
+}
+
+function clearInterval(timer) {
+  clear(timer);
+}
+
+function setImmediate(callback) {
+  validateFunction(callback, 'callback');
+  var args = arguments.length > 1 ? Array.prototype.slice.call(arguments, 1) : undefined;
+  return new Immediate(callback, args);
+}
+
+function clearImmediate(immediate) {
+  if (immediate
+      && !immediate._destroyed) {
+    immediate._destroyed = true;
+    binding.clear(immediate._id);
+  }
+}
+
+exports.setTimeout = setTimeout;
+exports.clearTimeout = clearTimeout;
+exports.setInterval = setInterval;
+exports.clearInterval = clearInterval;
+exports.setImmediate = setImmediate;
+exports.clearImmediate = clearImmediate;
+`),
+	"url.js": []byte(`//
+// otto.module :: url.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var path = require('./path');
+var querystring = require('./querystring');
+var idna = require('./internal/idna');
+var internal = require('./internal/url');
+
+var URL = internal.URL;
+var URLSearchParams = internal.URLSearchParams;
+
+exports.URL = URL;
+exports.URLSearchParams = URLSearchParams;
+exports.domainToASCII = internal.domainToASCII;
+exports.domainToUnicode = internal.domainToUnicode;
+
+//
+// legacy API
+//
+
+function Url() {
+  this.protocol = null;
+  this.slashes = null;
+  this.auth = null;
+  this.host = null;
+  this.port = null;
+  this.hostname = null;
+  this.hash = null;
+  this.search = null;
+  this.query = null;
+  this.pathname = null;
+  this.path = null;
+  this.href = null;
+}
+
+exports.Url = Url;
+
+var protocolPattern = /^[a-z0-9.+-]+:/i;
+var portPattern = /:[0-9]*$/;
+var hostPattern = /^\/\/[^@/]+@[^@/]+/;
+var simplePathPattern = /^(\/\/?(?:[^/?\s][^?\s]*)?)(\?[^\s]*)?$/;
+var forbiddenHostChars = /[\x00\t\n\r #%/:<>?@[\\\]^|]/;
+var forbiddenHostCharsIPv6 = /[\x00\t\n\r #%/<>?@\\^|]/;
+var hostnameMaxLen = 255;
+
+var hostlessProtocol = {
+  'javascript': true,
+  'javascript:': true,
+};
+var slashedProtocol = {
+  'http': true,
+  'http:': true,
+  'https': true,
+  'https:': true,
+  'ftp': true,
+  'ftp:': true,
+  'gopher': true,
+  'gopher:': true,
+  'file': true,
+  'file:': true,
+  'ws': true,
+  'ws:': true,
+  'wss': true,
+  'wss:': true,
+};
+var autoEscape = {
+  '\t': '%09',
+  '\n': '%0A',
+  '\r': '%0D',
+  ' ': '%20',
+  '"': '%22',
+  '\'': '%27',
+  '<': '%3C',
+  '>': '%3E',
+  '\\': '%5C',
+  '^': '%5E',
+  '` + "`" + `': '%60',
+  '{': '%7B',
+  '|': '%7C',
+  '}': '%7D',
+};
+
+function has(o, k) {
+  return k !== undefined
+         && Object.prototype.hasOwnProperty.call(o, k);
+}
+
+function isIPv6Hostname(s) {
+  return s.charAt(0) === '['
+         && s.charAt(s.length - 1) === ']';
+}
+
+Url.prototype.parse = function parse(url, parseQueryString, slashesDenoteHost) {
+  if (typeof url !== 'string') {
+    throw internal.invalidArgType('url', 'string', url);
+  }
+
+  // trim, and convert backslashes before the query string
+  var rest = url.replace(/^[\x00-\x20\u00a0\ufeff]+|[\x00-\x20\u00a0\ufeff]+$/g, '');
+  var split = rest.length;
+  ['?', '#'].forEach(function(c) {
+    var i = rest.indexOf(c);
+    if (i !== -1
+        && i < split) {
+      split = i;
+    }
+  });
+  var hasHash = rest.indexOf('#') !== -1;
+  var hasAt = rest.slice(0, split).indexOf('@') !== -1;
+  rest = rest.slice(0, split).replace(/\\/g, '/') + rest.slice(split);
+  var i;
+
+  if (!slashesDenoteHost
+      && !hasHash
+      && !hasAt) {
+    var simplePath = simplePathPattern.exec(rest);
+    if (simplePath) {
+      this.path = rest;
+      this.href = rest;
+      this.pathname = simplePath[1];
+      if (simplePath[2]) {
+        this.search = simplePath[2];
+        this.query = parseQueryString ? querystring.parse(this.search.slice(1)) : this.search.slice(1);
+      } else if (parseQueryString) {
+        this.search = null;
+        this.query = Object.create(null);
+      }
+      return this;
+    }
+  }
+
+  var proto = protocolPattern.exec(rest);
+  var lowerProto;
+  if (proto) {
+    proto = proto[0];
+    lowerProto = proto.toLowerCase();
+    this.protocol = lowerProto;
+    rest = rest.slice(proto.length);
+  }
+
+  var slashes;
+  if (slashesDenoteHost
+      || proto
+      || hostPattern.test(rest)) {
+    slashes = rest.slice(0, 2) === '//';
+    if (slashes
+        && !(proto && has(hostlessProtocol, lowerProto))) {
+      rest = rest.slice(2);
+      this.slashes = true;
+    }
+  }
+
+  if (!has(hostlessProtocol, lowerProto)
+      && (slashes || (proto && !has(slashedProtocol, proto)))) {
+    // the first instance of /, ? or # ends the host, and characters not
+    // allowed in a hostname are allowed only before the last @
+    var hostEnd = -1;
+    var atSign = -1;
+    var nonHost = -1;
+    for (i = 0; i < rest.length && hostEnd === -1; i++) {
+      switch (rest.charAt(i)) {
+      case '\t':
+      case '\n':
+      case '\r':
+      case ' ':
+      case '"':
+      case '%':
+      case '\'':
+      case ';':
+      case '<':
+      case '>':
+      case '\\':
+      case '^':
+      case '` + "`" + `':
+      case '{':
+      case '|':
+      case '}':
+        if (nonHost === -1) {
+          nonHost = i;
+        }
+        break;
+      case '#':
+      case '/':
+      case '?':
+        if (nonHost === -1) {
+          nonHost = i;
+        }
+        hostEnd = i;
+        break;
+      case '@':
+        atSign = i;
+        nonHost = -1;
+        break;
+      }
+    }
+    var start = 0;
+    if (atSign !== -1) {
+      this.auth = decodeURIComponent(rest.slice(0, atSign));
+      start = atSign + 1;
+    }
+    if (nonHost === -1) {
+      this.host = rest.slice(start);
+      rest = '';
+    } else {
+      this.host = rest.slice(start, nonHost);
+      rest = rest.slice(nonHost);
+    }
+
+    this.parseHost();
+    if (typeof this.hostname !== 'string') {
+      this.hostname = '';
+    }
+
+    var hostname = this.hostname;
+    var ipv6Hostname = isIPv6Hostname(hostname);
+    if (!ipv6Hostname) {
+      for (i = 0; i < hostname.length; i++) {
+        if ('/\\#?:'.indexOf(hostname.charAt(i)) !== -1) {
+          this.hostname = hostname.slice(0, i);
+          rest = '/' + hostname.slice(i) + rest;
+          break;
+        }
+      }
+    }
+
+    if (this.hostname.length > hostnameMaxLen) {
+      this.hostname = '';
+    } else {
+      this.hostname = this.hostname.toLowerCase();
+    }
+
+    if (this.hostname !== '') {
+      if (ipv6Hostname) {
+        if (forbiddenHostCharsIPv6.test(this.hostname)) {
+          throw internal.invalidURL(url);
+        }
+      } else {
+        try {
+          this.hostname = idna.toASCII(this.hostname);
+        } catch (e) {
+          this.hostname = '';
+        }
+        if (this.hostname === ''
+            || forbiddenHostChars.test(this.hostname)) {
+          throw internal.invalidURL(url);
+        }
+      }
+    }
+
+    this.host = (this.hostname || '') + (this.port ? ':' + this.port : '');
+
+    if (ipv6Hostname) {
+      this.hostname = this.hostname.slice(1, -1);
+      if (rest.charAt(0) !== '/') {
+        rest = '/' + rest;
+      }
+    }
+  }
+
+  if (!has(hostlessProtocol, lowerProto)) {
+    rest = rest.replace(/[\t\n\r "'<>\\^` + "`" + `{|}]/g, function(c) {
+      return autoEscape[c];
+    });
+  }
+
+  var questionIdx = -1;
+  var hashIdx = -1;
+  for (i = 0; i < rest.length; i++) {
+    var c = rest.charAt(i);
+    if (c === '#') {
+      this.hash = rest.slice(i);
+      hashIdx = i;
+      break;
+    } else if (c === '?'
+               && questionIdx === -1) {
+      questionIdx = i;
+    }
+  }
+
+  if (questionIdx !== -1) {
+    if (hashIdx === -1) {
+      this.search = rest.slice(questionIdx);
+      this.query = rest.slice(questionIdx + 1);
+    } else {
+      this.search = rest.slice(questionIdx, hashIdx);
+      this.query = rest.slice(questionIdx + 1, hashIdx);
+    }
+    if (parseQueryString) {
+      this.query = querystring.parse(this.query);
+    }
+  } else if (parseQueryString) {
+    this.search = null;
+    this.query = Object.create(null);
+  }
+
+  var firstIdx = questionIdx !== -1 && (hashIdx === -1 || questionIdx < hashIdx) ? questionIdx : hashIdx;
+  if (firstIdx === -1) {
+    if (rest.length > 0) {
+      this.pathname = rest;
+    }
+  } else if (firstIdx > 0) {
+    this.pathname = rest.slice(0, firstIdx);
+  }
+  if (has(slashedProtocol, lowerProto)
+      && this.hostname
+      && !this.pathname) {
+    this.pathname = '/';
+  }
+
+  if (this.pathname
+      || this.search) {
+    this.path = (this.pathname || '') + (this.search || '');
+  }
+
+  this.href = this.format();
+  return this;
+};
+
+Url.prototype.parseHost = function parseHost() {
+  var host = this.host;
+  var port = portPattern.exec(host);
+  if (port) {
+    port = port[0];
+    if (port !== ':') {
+      this.port = port.slice(1);
+    }
+    host = host.slice(0, host.length - port.length);
+  }
+  if (host) {
+    this.hostname = host;
+  }
+};
+
+Url.prototype.format = function format() {
+  var auth = this.auth || '';
+  if (auth) {
+    auth = encodeURIComponent(auth).replace(/%3A/gi, ':') + '@';
+  }
+
+  var protocol = this.protocol || '';
+  var pathname = this.pathname || '';
+  var hash = this.hash || '';
+  var host = '';
+  var query = '';
+
+  if (this.host) {
+    host = auth + this.host;
+  } else if (this.hostname) {
+    host = auth + (this.hostname.indexOf(':') !== -1 && !isIPv6Hostname(this.hostname) ? '[' + this.hostname + ']' : this.hostname);
+    if (this.port) {
+      host += ':' + this.port;
+    }
+  }
+
+  if (this.query !== null
+      && typeof this.query === 'object') {
+    query = querystring.stringify(this.query);
+  }
+
+  var search = this.search || (query && '?' + query) || '';
+
+  if (protocol
+      && protocol.charAt(protocol.length - 1) !== ':') {
+    protocol += ':';
+  }
+
+  pathname = pathname.replace(/[?#]/g, function(c) {
+    return c === '#' ? '%23' : '%3F';
+  });
+
+  // only the slashed protocols get the //, unless they had them to begin
+  // with
+  if (this.slashes
+      || has(slashedProtocol, protocol)) {
+    if (this.slashes
+        || host) {
+      if (pathname
+          && pathname.charAt(0) !== '/') {
+        pathname = '/' + pathname;
+      }
+      host = '//' + host;
+    } else if (protocol.slice(0, 4) === 'file') {
+      host = '//';
+    }
+  }
+
+  search = search.replace(/#/g, '%23');
+
+  if (hash
+      && hash.charAt(0) !== '#') {
+    hash = '#' + hash;
+  }
+  if (search
+      && search.charAt(0) !== '?') {
+    search = '?' + search;
+  }
+  return protocol + host + pathname + search + hash;
+};
+
+Url.prototype.resolve = function resolve(relative) {
+  return this.resolveObject(urlParse(relative, false, true)).format();
+};
+
+Url.prototype.resolveObject = function resolveObject(relative) {
+  if (typeof relative === 'string') {
+    var rel = new Url();
+    rel.parse(relative, false, true);
+    relative = rel;
+  }
+
+  var result = new Url();
+  Object.keys(this).forEach(function(k) {
+    result[k] = this[k];
+  }, this);
+
+  // hash is always overridden, even href="" will remove it
+  result.hash = relative.hash;
+
+  if (relative.href === '') {
+    result.href = result.format();
+    return result;
+  }
+
+  // hrefs like //foo/bar always cut to the protocol
+  if (relative.slashes
+      && !relative.protocol) {
+    Object.keys(relative).forEach(function(k) {
+      if (k !== 'protocol') {
+        result[k] = relative[k];
+      }
+    });
+    if (has(slashedProtocol, result.protocol)
+        && result.hostname
+        && !result.pathname) {
+      result.path = result.pathname = '/';
+    }
+    result.href = result.format();
+    return result;
+  }
+
+  var relPath;
+  if (relative.protocol
+      && relative.protocol !== result.protocol) {
+    // anything other than the slashed protocols is assumed to be absolute
+    if (!has(slashedProtocol, relative.protocol)) {
+      Object.keys(relative).forEach(function(k) {
+        result[k] = relative[k];
+      });
+      result.href = result.format();
+      return result;
+    }
+
+    result.protocol = relative.protocol;
+    if (!relative.host
+        && !/^file:?$/.test(relative.protocol)
+        && !has(hostlessProtocol, relative.protocol)) {
+      relPath = (relative.pathname || '').split('/');
+      while (relPath.length && !(relative.host = relPath.shift())) {
+        // skip empty segments
+      }
+      if (!relative.host) {
+        relative.host = '';
+      }
+      if (!relative.hostname) {
+        relative.hostname = '';
+      }
+      if (relPath[0] !== '') {
+        relPath.unshift('');
+      }
+      if (relPath.length < 2) {
+        relPath.unshift('');
+      }
+      result.pathname = relPath.join('/');
+    } else {
+      result.pathname = relative.pathname;
+    }
+    result.search = relative.search;
+    result.query = relative.query;
+    result.host = relative.host || '';
+    result.auth = relative.auth;
+    result.hostname = relative.hostname || relative.host;
+    result.port = relative.port;
+    if (result.pathname
+        || result.search) {
+      result.path = (result.pathname || '') + (result.search || '');
+    }
+    result.slashes = result.slashes || relative.slashes;
+    result.href = result.format();
+    return result;
+  }
+
+  var isSourceAbs = result.pathname && result.pathname.charAt(0) === '/';
+  var isRelAbs = relative.host || (relative.pathname && relative.pathname.charAt(0) === '/');
+  var mustEndAbs = isRelAbs || isSourceAbs || (result.host && relative.pathname);
+  var removeAllDots = mustEndAbs;
+  var srcPath = (result.pathname && result.pathname.split('/')) || [];
+  var noLeadingSlashes = result.protocol && !has(slashedProtocol, result.protocol);
+  relPath = (relative.pathname && relative.pathname.split('/')) || [];
+
+  // relative links like ../.. should be able to crawl up to the hostname for
+  // non-slashed urls
+  if (noLeadingSlashes) {
+    result.hostname = '';
+    result.port = null;
+    if (result.host) {
+      if (srcPath[0] === '') {
+        srcPath[0] = result.host;
+      } else {
+        srcPath.unshift(result.host);
+      }
+    }
+    result.host = '';
+    if (relative.protocol) {
+      relative.hostname = null;
+      relative.port = null;
+      if (relative.host) {
+        if (relPath[0] === '') {
+          relPath[0] = relative.host;
+        } else {
+          relPath.unshift(relative.host);
+        }
+      }
+      relative.host = null;
+    }
+    mustEndAbs = mustEndAbs && (relPath[0] === '' || srcPath[0] === '');
+  }
+
+  var authInHost;
+  if (isRelAbs) {
+    if (relative.host
+        || relative.host === '') {
+      if (result.host !== relative.host) {
+        result.auth = null;
+      }
+      result.host = relative.host;
+      result.port = relative.port;
+    }
+    if (relative.hostname
+        || relative.hostname === '') {
+      if (result.hostname !== relative.hostname) {
+        result.auth = null;
+      }
+      result.hostname = relative.hostname;
+    }
+    result.search = relative.search;
+    result.query = relative.query;
+    srcPath = relPath;
+  } else if (relPath.length) {
+    // throw away the existing file, and take the new path instead
+    srcPath.pop();
+    srcPath = srcPath.concat(relPath);
+    result.search = relative.search;
+    result.query = relative.query;
+  } else if (relative.search !== null
+             && relative.search !== undefined) {
+    // just pull out the search
+    if (noLeadingSlashes) {
+      result.hostname = result.host = srcPath.shift();
+      authInHost = result.host && result.host.indexOf('@') > 0 && result.host.split('@');
+      if (authInHost) {
+        result.auth = authInHost.shift();
+        result.host = result.hostname = authInHost.shift();
+      }
+    }
+    result.search = relative.search;
+    result.query = relative.query;
+    if (result.pathname !== null
+        || result.search !== null) {
+      result.path = (result.pathname || '') + (result.search || '');
+    }
+    result.href = result.format();
+    return result;
+  }
+
+  if (!srcPath.length) {
+    result.pathname = null;
+    result.path = result.search ? '/' + result.search : null;
+    result.href = result.format();
+    return result;
+  }
+
+  // if a url ends in . or .., then it must get a trailing slash
+  var last = srcPath[srcPath.length - 1];
+  var hasTrailingSlash = ((result.host || relative.host || srcPath.length > 1) && (last === '.' || last === '..')) || last === '';
+
+  // strip single dots, and resolve double dots to parent dir
+  var up = 0;
+  for (var i = srcPath.length - 1; i >= 0; i--) {
+    last = srcPath[i];
+    if (last === '.') {
+      srcPath.splice(i, 1);
+    } else if (last === '..') {
+      srcPath.splice(i, 1);
+      up++;
+    } else if (up) {
+      srcPath.splice(i, 1);
+      up--;
+    }
+  }
+
+  // restore leading ..s if the path is allowed to go above the root
+  if (!mustEndAbs
+      && !removeAllDots) {
+    for (; up > 0; up--) {
+      srcPath.unshift('..');
+    }
+  }
+
+  if (mustEndAbs
+      && srcPath[0] !== ''
+      && (!srcPath[0] || srcPath[0].charAt(0) !== '/')) {
+    srcPath.unshift('');
+  }
+
+  if (hasTrailingSlash
+      && srcPath.join('/').slice(-1) !== '/') {
+    srcPath.push('');
+  }
+
+  var isAbsolute = srcPath[0] === '' || (srcPath[0] && srcPath[0].charAt(0) === '/');
+
+  // put the host back
+  if (noLeadingSlashes) {
+    result.hostname = result.host = isAbsolute ? '' : srcPath.length ? srcPath.shift() : '';
+    authInHost = result.host && result.host.indexOf('@') > 0 ? result.host.split('@') : false;
+    if (authInHost) {
+      result.auth = authInHost.shift();
+      result.host = result.hostname = authInHost.shift();
+    }
+  }
+
+  mustEndAbs = mustEndAbs || (result.host && srcPath.length);
+
+  if (mustEndAbs
+      && !isAbsolute) {
+    srcPath.unshift('');
+  }
+
+  if (srcPath.length > 0) {
+    result.pathname = srcPath.join('/');
+  } else {
+    result.pathname = null;
+    result.path = null;
+  }
+
+  if (result.pathname !== null
+      || result.search !== null) {
+    result.path = (result.pathname || '') + (result.search || '');
+  }
+  result.auth = relative.auth || result.auth;
+  result.slashes = result.slashes || relative.slashes;
+  result.href = result.format();
+  return result;
+};
+
+function urlParse(url, parseQueryString, slashesDenoteHost) {
+  if (url instanceof Url) {
+    return url;
+  }
+  var u = new Url();
+  u.parse(url, parseQueryString, slashesDenoteHost);
+  return u;
+}
+
+exports.parse = urlParse;
+
+exports.resolve = function resolve(source, relative) {
+  return urlParse(source, false, true).resolve(relative);
+};
+
+exports.resolveObject = function resolveObject(source, relative) {
+  if (!source) {
+    return relative;
+  }
+  return urlParse(source, false, true).resolveObject(relative);
+};
+
+exports.format = function format(urlObject, options) {
+  if (typeof urlObject === 'string') {
+    urlObject = urlParse(urlObject);
+  } else if (typeof urlObject !== 'object'
+             || urlObject === null) {
+    throw internal.invalidArgType('urlObject', 'object or string', urlObject);
+  } else if (urlObject instanceof URL) {
+    var fragment = true;
+    var unicode = false;
+    var search = true;
+    var auth = true;
+    if (options) {
+      if (options.fragment !== undefined) {
+        fragment = Boolean(options.fragment);
+      }
+      if (options.unicode !== undefined) {
+        unicode = Boolean(options.unicode);
+      }
+      if (options.search !== undefined) {
+        search = Boolean(options.search);
+      }
+      if (options.auth !== undefined) {
+        auth = Boolean(options.auth);
       }
     }
-    if (!rv) {
-      rv = abs ? os.sep : '.';
+    var u = urlObject;
+    var s = u.protocol;
+    if (u.host !== ''
+        || u.protocol === 'file:') {
+      s += '//';
+      if (auth
+          && (u.username !== '' || u.password !== '')) {
+        s += u.username + (u.password !== '' ? ':' + u.password : '') + '@';
+      }
+      s += unicode ? internal.domainToUnicode(u.hostname) : u.hostname;
+      if (u.port !== '') {
+        s += ':' + u.port;
+      }
     }
-    if (vol.length < n - 1
-        && os._isSep(path[n - 1])) {
-      rv += os.sep;
+    s += u.pathname;
+    if (search) {
+      s += u.search;
     }
-    return vol ? vol.replace(/\//g, os.sep) + rv : rv;
+    if (fragment) {
+      s += u.hash;
+    }
+    return s;
+  }
+  return Url.prototype.format.call(urlObject);
+};
+
+//
+// file URL
+//
+
+function isWindows(options) {
+  if (options
+      && options.windows !== undefined) {
+    return Boolean(options.windows);
+  }
+  return process.platform === 'win32';
+}
+
+exports.fileURLToPath = function fileURLToPath(u, options) {
+  if (typeof u === 'string') {
+    u = new URL(u);
+  } else if (!(u instanceof URL)) {
+    throw internal.invalidArgType('path', 'string or an instance of URL', u);
+  }
+  if (u.protocol !== 'file:') {
+    var e = new TypeError('The URL must be of scheme file');
+    e.code = 'ERR_INVALID_URL_SCHEME';
+    throw e;
+  }
+
+  var windows = isWindows(options);
+  var pathname = u.pathname;
+  if (/%2f/i.test(pathname)
+      || (windows && /%5c/i.test(pathname))) {
+    throw invalidFileURLPath('must not include encoded ' + (windows ? '\\ or / characters' : '/ characters'));
+  }
+  if (windows) {
+    pathname = decodeURIComponent(pathname.replace(/\//g, '\\'));
+    if (u.hostname !== '') {
+      // UNC path
+      return '\\\\' + internal.domainToUnicode(u.hostname) + pathname;
+    }
+    var letter = pathname.charCodeAt(1) | 0x20;
+    if (letter < 0x61
+        || letter > 0x7a
+        || pathname.charAt(2) !== ':') {
+      throw invalidFileURLPath('must be absolute');
+    }
+    return pathname.slice(1);
+  } else if (u.hostname !== '') {
+    e = new TypeError('File URL host must be "localhost" or empty on ' + process.platform);
+    e.code = 'ERR_INVALID_FILE_URL_HOST';
+    throw e;
+  }
+  return decodeURIComponent(pathname);
+};
+
+function invalidFileURLPath(msg) {
+  var e = new TypeError('File URL path ' + msg);
+  e.code = 'ERR_INVALID_FILE_URL_PATH';
+  return e;
+}
+
+exports.pathToFileURL = function pathToFileURL(filepath, options) {
+  if (typeof filepath !== 'string') {
+    throw internal.invalidArgType('path', 'string', filepath);
+  }
+
+  var windows = isWindows(options);
+  var p = windows ? path.win32 : path.posix;
+  if (windows
+      && filepath.slice(0, 2) === '\\\\') {
+    // UNC path
+    var i = filepath.indexOf('\\', 2);
+    if (i <= 2) {
+      var e = new TypeError('The argument \'path\' must be a UNC path. Received ' + JSON.stringify(filepath));
+      e.code = 'ERR_INVALID_ARG_VALUE';
+      throw e;
+    }
+    var u = new URL('file://');
+    u.hostname = filepath.slice(2, i);
+    u.pathname = encodePathChars(filepath.slice(i).replace(/\\/g, '/'), false);
+    return u;
+  }
+
+  var resolved = p.isAbsolute(filepath) ? p.normalize(filepath) : p.join(process.cwd(), filepath);
+  var sep = filepath.charAt(filepath.length - 1);
+  if ((sep === '/' || (windows && sep === '\\'))
+      && resolved.charAt(resolved.length - 1) !== p.sep) {
+    resolved += '/';
+  }
+  if (windows) {
+    resolved = '/' + resolved.replace(/\\/g, '/');
+  }
+  return new URL('file://' + encodePathChars(resolved, !windows));
+};
+
+function encodePathChars(s, escapeBackslash) {
+  return s.replace(escapeBackslash ? /[%\\\t\n\r?#]/g : /[%\t\n\r?#]/g, function(c) {
+    return '%' + (c.charCodeAt(0) < 0x10 ? '0' : '') + c.charCodeAt(0).toString(16).toUpperCase();
+  });
+}
+
+exports.urlToHttpOptions = function urlToHttpOptions(u) {
+  var o = {
+    protocol: u.protocol,
+    hostname: u.hostname.charAt(0) === '[' ? u.hostname.slice(1, -1) : u.hostname,
+    hash: u.hash,
+    search: u.search,
+    pathname: u.pathname,
+    path: (u.pathname || '') + (u.search || ''),
+    href: u.href,
   };
+  if (u.port !== '') {
+    o.port = Number(u.port);
+  }
+  if (u.username
+      || u.password) {
+    o.auth = decodeURIComponent(u.username) + ':' + decodeURIComponent(u.password);
+  }
+  return o;
+};
+`),
+	"util.js": []byte(`//
+// otto.module :: util.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var _inspect = require('./internal/util/inspect');
+var comparisons = require('./internal/util/comparisons');
+
+var toString = Object.prototype.toString;
+
+function tagOf(v) {
+  return toString.call(v).slice(8, -1);
 }
 
-posix.normalize = _normalize(posix);
-win32.normalize = _normalize(win32);
+function isObjectLike(v) {
+  return v !== null
+         && typeof v === 'object';
+}
 
-function _parse(os) {
-  return function parse(path) {
-    assert(path);
-    // volume
-    var vol = os._volname(path);
+exports.format = _inspect.format;
+exports.formatWithOptions = _inspect.formatWithOptions;
+exports.inspect = _inspect.inspect;
+exports.isDeepStrictEqual = comparisons.isDeepStrictEqual;
 
-    var root;
-    var dir;
-    if (vol === path
-        || !os._isSep(path[vol.length])) {
-      // relative
-      root = dir = '';
-    } else {
-      // absolute
-      root = dir = path.slice(0, vol.length + 1);
+exports.inherits = function inherits(ctor, superCtor) {
+  if (typeof ctor !== 'function') {
+    throw new TypeError('ctor must be a Function');
+  } else if (typeof superCtor !== 'function') {
+    throw new TypeError('superCtor must be a Function');
+  } else if (superCtor.prototype === undefined) {
+    throw new TypeError('superCtor.prototype must be an Object');
+  }
+
+  ctor.super_ = superCtor;
+  ctor.prototype = Object.create(superCtor.prototype, {
+    constructor: {
+      value: ctor,
+      enumerable: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+};
+
+exports.deprecate = function deprecate(fn, msg, code) {
+  if (typeof fn !== 'function') {
+    throw new TypeError('fn must be a Function');
+  } else if (code !== undefined
+             && typeof code !== 'string') {
+    throw new TypeError('code must be a String');
+  }
+
+  if (process.noDeprecation === true) {
+    return fn;
+  }
+
+  var warned = false;
+
+  function deprecated() {
+    if (!warned) {
+      warned = true;
+      process.emitWarning(msg, 'DeprecationWarning', code);
     }
-    // trim trailing separators
-    var end;
-    for (end = path.length; end > vol.length && os._isSep(path[end - 1]); end--);
+    if (this instanceof deprecated) {
+      var self = Object.create(fn.prototype);
+      var r = fn.apply(self, arguments);
+      return r !== null && (typeof r === 'object' || typeof r === 'function') ? r : self;
+    }
+    return fn.apply(this, arguments);
+  }
+  deprecated.prototype = fn.prototype;
 
-    var base;
-    var dot = end;
-    if (end === vol.length) {
-      base = vol.length;
-    } else {
-      for (base = end - 1; base > vol.length; base--) {
-        if (os._isSep(path[base - 1])) {
-          dir = path.slice(0, base - 1);
-          break;
-        } else if (path[base] === '.'
-                   && dot === end
-                   && (base > vol.length && base < end - 1)) {
-          dot = base;
-        }
+  return deprecated;
+};
+
+exports.callbackify = function callbackify(original) {
+  if (typeof original !== 'function') {
+    throw new TypeError('original must be a Function');
+  }
+
+  return function callbackified() {
+    var args = Array.prototype.slice.call(arguments);
+    var cb = args.pop();
+    if (typeof cb !== 'function') {
+      throw new TypeError('the last argument must be a Function');
+    }
+
+    var self = this;
+
+    function resolve(v) {
+      process.nextTick(function() {
+        cb.call(self, null, v);
+      });
+    }
+
+    function reject(reason) {
+      if (!reason) {
+        var err = new Error('Promise was rejected with a falsy value');
+        err.code = 'ERR_FALSY_VALUE_REJECTION';
+        err.reason = reason;
+        reason = err;
       }
+      process.nextTick(function() {
+        cb.call(self, reason);
+      });
+    }
+
+    var r;
+    try {
+      r = original.apply(this, args);
+    } catch (e) {
+      reject(e);
+      return;
+    }
+    if (r !== null
+        && (typeof r === 'object' || typeof r === 'function')
+        && typeof r.then === 'function') {
+      r.then(resolve, reject);
+    } else {
+      resolve(r);
     }
-    return {
-      root: root,
-      dir: dir,
-      base: path.slice(base, end),
-      name: path.slice(base, dot),
-      ext: path.slice(dot, end),
-    };
   };
+};
+
+function getenv(k) {
+  var env = process.env;
+  return typeof env.__get__ === 'function' ? env.__get__(k) : env[k];
 }
 
-posix.parse = _parse(posix);
-win32.parse = _parse(win32);
+var debugs = Object.create(null);
+var debugEnv;
 
-posix.posix = win32.posix = posix;
-posix.win32 = win32.win32 = win32;
+exports.debuglog = function debuglog(section) {
+  if (debugEnv === undefined) {
+    debugEnv = (getenv('NODE_DEBUG') || '').split(/[\s,]+/).filter(Boolean).map(function(s) {
+      return s.toUpperCase();
+    });
+  }
 
-if (process.platform === 'win32') {
-  module.exports = win32;
-} else {
-  module.exports = posix;
+  section = section.toUpperCase();
+  if (!(section in debugs)) {
+    var enabled = debugEnv.some(function(s) {
+      if (s === '*') {
+        return true;
+      } else if (s.slice(-1) === '*') {
+        return section.slice(0, s.length - 1) === s.slice(0, -1);
+      }
+      return s === section;
+    });
+    if (enabled) {
+      debugs[section] = function debug() {
+        var s = exports.format.apply(exports, arguments);
+        process.emit('debuglog', section, s);
+        if (typeof console !== 'undefined') {
+          console.error(section + ' ' + process.pid + ': ' + s);
+        }
+      };
+      debugs[section].enabled = true;
+    } else {
+      debugs[section] = function debug() {};
+      debugs[section].enabled = false;
+    }
+  }
+  return debugs[section];
+};
+
+exports.types = {
+  isDate: function isDate(v) {
+    return isObjectLike(v) && tagOf(v) === 'Date';
+  },
+  isRegExp: function isRegExp(v) {
+    return isObjectLike(v) && tagOf(v) === 'RegExp';
+  },
+  isNativeError: function isNativeError(v) {
+    return isObjectLike(v) && tagOf(v) === 'Error';
+  },
+  isNumberObject: function isNumberObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'Number';
+  },
+  isStringObject: function isStringObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'String';
+  },
+  isBooleanObject: function isBooleanObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'Boolean';
+  },
+  isBoxedPrimitive: function isBoxedPrimitive(v) {
+    return exports.types.isNumberObject(v)
+           || exports.types.isStringObject(v)
+           || exports.types.isBooleanObject(v);
+  },
+  isArgumentsObject: function isArgumentsObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'Arguments';
+  },
+  isPromise: function isPromise(v) {
+    return isObjectLike(v) && tagOf(v) === 'Promise';
+  },
+  isMap: function isMap(v) {
+    return isObjectLike(v) && tagOf(v) === 'Map';
+  },
+  isSet: function isSet(v) {
+    return isObjectLike(v) && tagOf(v) === 'Set';
+  },
+  isGeneratorFunction: function isGeneratorFunction(v) {
+    return typeof v === 'function' && tagOf(v) === 'GeneratorFunction';
+  },
+  isAsyncFunction: function isAsyncFunction(v) {
+    return typeof v === 'function' && tagOf(v) === 'AsyncFunction';
+  },
+};
+
+// legacy
+exports.isArray = Array.isArray;
+
+exports.isBoolean = function isBoolean(v) {
+  return typeof v === 'boolean';
+};
+
+exports.isNull = function isNull(v) {
+  return v === null;
+};
+
+exports.isNullOrUndefined = function isNullOrUndefined(v) {
+  return v === null
+         || v === undefined;
+};
+
+exports.isNumber = function isNumber(v) {
+  return typeof v === 'number';
+};
+
+exports.isString = function isString(v) {
+  return typeof v === 'string';
+};
+
+exports.isUndefined = function isUndefined(v) {
+  return v === undefined;
+};
+
+exports.isRegExp = exports.types.isRegExp;
+
+exports.isObject = function isObject(v) {
+  return isObjectLike(v);
+};
+
+exports.isDate = exports.types.isDate;
+
+exports.isError = function isError(v) {
+  return exports.types.isNativeError(v)
+         || v instanceof Error;
+};
+
+exports.isFunction = function isFunction(v) {
+  return typeof v === 'function';
+};
+
+exports.isPrimitive = function isPrimitive(v) {
+  return v === null
+         || (typeof v !== 'object' && typeof v !== 'function');
+};
+`),
+	"zlib.js": []byte(`//
+// otto.module :: zlib.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('zlib');
+var Buffer = require('./buffer').Buffer;
+var kMaxLength = require('./buffer').kMaxLength;
+var util = require('./internal/util');
+
+var constants = {
+  Z_NO_FLUSH: 0,
+  Z_PARTIAL_FLUSH: 1,
+  Z_SYNC_FLUSH: 2,
+  Z_FULL_FLUSH: 3,
+  Z_FINISH: 4,
+  Z_BLOCK: 5,
+  Z_OK: 0,
+  Z_STREAM_END: 1,
+  Z_NEED_DICT: 2,
+  Z_ERRNO: -1,
+  Z_STREAM_ERROR: -2,
+  Z_DATA_ERROR: -3,
+  Z_MEM_ERROR: -4,
+  Z_BUF_ERROR: -5,
+  Z_VERSION_ERROR: -6,
+  Z_NO_COMPRESSION: 0,
+  Z_BEST_SPEED: 1,
+  Z_BEST_COMPRESSION: 9,
+  Z_DEFAULT_COMPRESSION: -1,
+  Z_FILTERED: 1,
+  Z_HUFFMAN_ONLY: 2,
+  Z_RLE: 3,
+  Z_FIXED: 4,
+  Z_DEFAULT_STRATEGY: 0,
+  ZLIB_VERNUM: 4865,
+  DEFLATE: 1,
+  INFLATE: 2,
+  GZIP: 3,
+  GUNZIP: 4,
+  DEFLATERAW: 5,
+  INFLATERAW: 6,
+  UNZIP: 7,
+  Z_MIN_WINDOWBITS: 8,
+  Z_MAX_WINDOWBITS: 15,
+  Z_DEFAULT_WINDOWBITS: 15,
+  Z_MIN_CHUNK: 64,
+  Z_MAX_CHUNK: Infinity,
+  Z_DEFAULT_CHUNK: 16384,
+  Z_MIN_MEMLEVEL: 1,
+  Z_MAX_MEMLEVEL: 9,
+  Z_DEFAULT_MEMLEVEL: 8,
+  Z_MIN_LEVEL: -1,
+  Z_MAX_LEVEL: 9,
+  Z_DEFAULT_LEVEL: -1,
+};
+
+var codes = {
+  Z_OK: 0,
+  Z_STREAM_END: 1,
+  Z_NEED_DICT: 2,
+  Z_ERRNO: -1,
+  Z_STREAM_ERROR: -2,
+  Z_DATA_ERROR: -3,
+  Z_MEM_ERROR: -4,
+  Z_BUF_ERROR: -5,
+  Z_VERSION_ERROR: -6,
+};
+Object.keys(codes).forEach(function(k) {
+  codes[codes[k]] = k;
+});
+
+exports.constants = constants;
+exports.codes = codes;
+
+function toBuffer(buffer) {
+  if (typeof buffer === 'string') {
+    return Buffer.from(buffer);
+  } else if (!Buffer.isBuffer(buffer)) {
+    throw util.invalidArgType('buffer', 'of type string or an instance of Buffer, TypedArray, DataView, or ArrayBuffer', buffer);
+  }
+  return buffer;
+}
+
+function option(opts, name, min, max, def) {
+  var v = opts[name];
+  if (v === undefined) {
+    return def;
+  } else if (typeof v !== 'number') {
+    throw util.invalidArgType('options.' + name, 'of type number', v);
+  } else if (!(v >= min && v <= max)) {
+    throw util.outOfRange('options.' + name, '>= ' + min + ' and <= ' + max, v);
+  }
+  return Math.floor(v);
+}
+
+function dictionary(opts) {
+  var dict = opts.dictionary;
+  if (dict !== undefined
+      && !Buffer.isBuffer(dict)) {
+    throw util.invalidArgType('options.dictionary', 'an instance of Buffer, TypedArray, DataView, or ArrayBuffer', dict);
+  }
+  return dict;
 }
+
+function compress(format) {
+  return function(buffer, opts) {
+    buffer = toBuffer(buffer);
+    opts = opts || {};
+    var level = option(opts, 'level', constants.Z_MIN_LEVEL, constants.Z_MAX_LEVEL, constants.Z_DEFAULT_LEVEL);
+    return binding.compress(format, buffer, level, dictionary(opts));
+  };
+}
+
+function decompress(format) {
+  return function(buffer, opts) {
+    buffer = toBuffer(buffer);
+    opts = opts || {};
+    var maxOutputLength = option(opts, 'maxOutputLength', 1, kMaxLength, kMaxLength);
+    var buf = binding.decompress(format, buffer, maxOutputLength, dictionary(opts));
+    if (!buf) {
+      var e = new RangeError('Cannot create a Buffer larger than ' + maxOutputLength + ' bytes');
+      e.code = 'ERR_BUFFER_TOO_LARGE';
+      throw e;
+    }
+    return buf;
+  };
+}
+
+exports.gzipSync = compress('gzip');
+exports.gunzipSync = decompress('gzip');
+exports.deflateSync = compress('deflate');
+exports.inflateSync = decompress('deflate');
+exports.deflateRawSync = compress('deflateRaw');
+exports.inflateRawSync = decompress('deflateRaw');
+exports.unzipSync = decompress('unzip');
 `),
 }
@@ -0,0 +1,650 @@
+//
+// otto.module :: coverage.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/robertkrimen/otto"
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/file"
+	"github.com/robertkrimen/otto/parser"
+	"github.com/robertkrimen/otto/token"
+)
+
+const (
+	covVar  = "__otto_cov__"
+	covFunc = "__otto_coverage__"
+)
+
+func WithCoverage(c *Coverage) Option {
+	return func(vm *Otto) {
+		vm.coverage = c
+	}
+}
+
+type Coverage struct {
+	Filter func(name string) bool
+
+	mu    sync.Mutex
+	files map[string]*fileCoverage
+	names []string
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{files: make(map[string]*fileCoverage)}
+}
+
+func (c *Coverage) instrument(name string, b []byte) ([]byte, error) {
+	if c.Filter != nil && !c.Filter(name) {
+		return b, nil
+	}
+
+	prog, err := parser.ParseFile(nil, name, b, 0)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := prog.Body[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	if !ok {
+		return b, nil
+	}
+	in := &instrumenter{
+		src: b,
+		off: len(wrapper[0]),
+		fc:  &fileCoverage{Path: name},
+	}
+	in.lines = append(in.lines, in.off)
+	for i := in.off; i < len(b); i++ {
+		if b[i] == '\n' {
+			in.lines = append(in.lines, i+1)
+		}
+	}
+	body := fn.Body.(*ast.BlockStatement)
+	in.insert(in.prologue(body), fmt.Sprintf("var %v = %v(%q); ", covVar, covFunc, name))
+	in.statements(body.List)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fc := in.fc
+	fc.alloc()
+	if old, ok := c.files[name]; ok && old.equal(fc) {
+		fc = old
+	} else {
+		if !ok {
+			c.names = append(c.names, name)
+		}
+		c.files[name] = fc
+	}
+	return in.apply(), nil
+}
+
+func (c *Coverage) file(name string) *fileCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.files[name]
+}
+
+func (c *Coverage) WriteJSON(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := make(map[string]*istanbulFile, len(c.files))
+	for _, n := range c.names {
+		m[n] = c.files[n].istanbul()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+func (c *Coverage) WriteLCOV(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, n := range c.names {
+		c.files[n].lcov(bw)
+	}
+	return bw.Flush()
+}
+
+func (vm *Otto) coverage_file(call otto.FunctionCall) otto.Value {
+	name, err := vm.toString("name", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	fc := vm.coverage.file(name)
+	if fc == nil {
+		return vm.throw(fmt.Errorf("cannot find coverage for '%v'", name))
+	}
+
+	o, _ := vm.Object(`({})`)
+	o.Set("s", func(call otto.FunctionCall) otto.Value {
+		i, _ := call.Argument(0).ToInteger()
+		atomic.AddInt64(&fc.s[i], 1)
+		return otto.UndefinedValue()
+	})
+	o.Set("f", func(call otto.FunctionCall) otto.Value {
+		i, _ := call.Argument(0).ToInteger()
+		atomic.AddInt64(&fc.f[i], 1)
+		return otto.UndefinedValue()
+	})
+	o.Set("b", func(call otto.FunctionCall) otto.Value {
+		i, _ := call.Argument(0).ToInteger()
+		j, _ := call.Argument(1).ToInteger()
+		atomic.AddInt64(&fc.b[i][j], 1)
+		return otto.UndefinedValue()
+	})
+	return o.Value()
+}
+
+type Location struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type fileCoverage struct {
+	Path       string
+	Statements []Location
+	Functions  []functionCoverage
+	Branches   []branchCoverage
+
+	s []int64
+	f []int64
+	b [][]int64
+}
+
+type functionCoverage struct {
+	Name string
+	Decl Location
+	Loc  Location
+}
+
+type branchCoverage struct {
+	Type      string
+	Loc       Location
+	Locations []Location
+}
+
+func (fc *fileCoverage) alloc() {
+	fc.s = make([]int64, len(fc.Statements))
+	fc.f = make([]int64, len(fc.Functions))
+	fc.b = make([][]int64, len(fc.Branches))
+	for i, br := range fc.Branches {
+		fc.b[i] = make([]int64, len(br.Locations))
+	}
+}
+
+func (fc *fileCoverage) equal(other *fileCoverage) bool {
+	return slices.Equal(fc.Statements, other.Statements) &&
+		slices.Equal(fc.Functions, other.Functions) &&
+		slices.EqualFunc(fc.Branches, other.Branches, func(a, b branchCoverage) bool {
+			return a.Type == b.Type && a.Loc == b.Loc && slices.Equal(a.Locations, b.Locations)
+		})
+}
+
+type istanbulFile struct {
+	Path         string                      `json:"path"`
+	StatementMap map[string]Location         `json:"statementMap"`
+	FnMap        map[string]istanbulFunction `json:"fnMap"`
+	BranchMap    map[string]istanbulBranch   `json:"branchMap"`
+	S            map[string]int64            `json:"s"`
+	F            map[string]int64            `json:"f"`
+	B            map[string][]int64          `json:"b"`
+}
+
+type istanbulFunction struct {
+	Name string   `json:"name"`
+	Decl Location `json:"decl"`
+	Loc  Location `json:"loc"`
+	Line int      `json:"line"`
+}
+
+type istanbulBranch struct {
+	Loc       Location   `json:"loc"`
+	Type      string     `json:"type"`
+	Locations []Location `json:"locations"`
+	Line      int        `json:"line"`
+}
+
+func (fc *fileCoverage) istanbul() *istanbulFile {
+	f := &istanbulFile{
+		Path:         fc.Path,
+		StatementMap: make(map[string]Location),
+		FnMap:        make(map[string]istanbulFunction),
+		BranchMap:    make(map[string]istanbulBranch),
+		S:            make(map[string]int64),
+		F:            make(map[string]int64),
+		B:            make(map[string][]int64),
+	}
+	for i, loc := range fc.Statements {
+		k := strconv.Itoa(i)
+		f.StatementMap[k] = loc
+		f.S[k] = atomic.LoadInt64(&fc.s[i])
+	}
+	for i, fn := range fc.Functions {
+		k := strconv.Itoa(i)
+		f.FnMap[k] = istanbulFunction{
+			Name: fn.Name,
+			Decl: fn.Decl,
+			Loc:  fn.Loc,
+			Line: fn.Decl.Start.Line,
+		}
+		f.F[k] = atomic.LoadInt64(&fc.f[i])
+	}
+	for i, br := range fc.Branches {
+		k := strconv.Itoa(i)
+		f.BranchMap[k] = istanbulBranch{
+			Loc:       br.Loc,
+			Type:      br.Type,
+			Locations: br.Locations,
+			Line:      br.Loc.Start.Line,
+		}
+		f.B[k] = make([]int64, len(br.Locations))
+		for j := range br.Locations {
+			f.B[k][j] = atomic.LoadInt64(&fc.b[i][j])
+		}
+	}
+	return f
+}
+
+func (fc *fileCoverage) lcov(w io.Writer) {
+	fmt.Fprintln(w, "TN:")
+	fmt.Fprintf(w, "SF:%v\n", fc.Path)
+	// functions
+	var hit int
+	for _, fn := range fc.Functions {
+		fmt.Fprintf(w, "FN:%v,%v\n", fn.Decl.Start.Line, fn.Name)
+	}
+	for i, fn := range fc.Functions {
+		n := atomic.LoadInt64(&fc.f[i])
+		if n > 0 {
+			hit++
+		}
+		fmt.Fprintf(w, "FNDA:%v,%v\n", n, fn.Name)
+	}
+	fmt.Fprintf(w, "FNF:%v\n", len(fc.Functions))
+	fmt.Fprintf(w, "FNH:%v\n", hit)
+	// lines
+	lines := make(map[int]int64)
+	for i, loc := range fc.Statements {
+		n := atomic.LoadInt64(&fc.s[i])
+		if v, ok := lines[loc.Start.Line]; !ok || v < n {
+			lines[loc.Start.Line] = n
+		}
+	}
+	hit = 0
+	for _, l := range slices.Sorted(maps.Keys(lines)) {
+		if lines[l] > 0 {
+			hit++
+		}
+		fmt.Fprintf(w, "DA:%v,%v\n", l, lines[l])
+	}
+	fmt.Fprintf(w, "LF:%v\n", len(lines))
+	fmt.Fprintf(w, "LH:%v\n", hit)
+	// branches
+	var n int
+	hit = 0
+	for i, br := range fc.Branches {
+		for j := range br.Locations {
+			c := atomic.LoadInt64(&fc.b[i][j])
+			if c > 0 {
+				hit++
+			}
+			n++
+			fmt.Fprintf(w, "BRDA:%v,%v,%v,%v\n", br.Loc.Start.Line, i, j, c)
+		}
+	}
+	fmt.Fprintf(w, "BRF:%v\n", n)
+	fmt.Fprintf(w, "BRH:%v\n", hit)
+	fmt.Fprintln(w, "end_of_record")
+}
+
+type instrumenter struct {
+	src   []byte
+	off   int
+	lines []int
+	edits []edit
+	fc    *fileCoverage
+}
+
+type edit struct {
+	pos int
+	s   string
+}
+
+func (in *instrumenter) apply() []byte {
+	slices.SortStableFunc(in.edits, func(a, b edit) int {
+		return a.pos - b.pos
+	})
+	var b bytes.Buffer
+	var i int
+	for _, e := range in.edits {
+		b.Write(in.src[i:e.pos])
+		b.WriteString(e.s)
+		i = e.pos
+	}
+	b.Write(in.src[i:])
+	return b.Bytes()
+}
+
+func (in *instrumenter) insert(pos int, s string) {
+	in.edits = append(in.edits, edit{pos, s})
+}
+
+func (in *instrumenter) pos(idx file.Idx) int {
+	return int(idx) - 1
+}
+
+func (in *instrumenter) position(pos int) Position {
+	i, ok := slices.BinarySearch(in.lines, pos)
+	if !ok {
+		i--
+	}
+	return Position{
+		Line:   i + 1,
+		Column: pos - in.lines[i],
+	}
+}
+
+func (in *instrumenter) location(n ast.Node) Location {
+	return Location{
+		Start: in.position(in.pos(n.Idx0())),
+		End:   in.position(in.end(n)),
+	}
+}
+
+// end returns the end of n including the trailing semicolon.
+func (in *instrumenter) end(n ast.Node) int {
+	i := in.pos(n.Idx1())
+	for j := i; j < len(in.src); j++ {
+		switch in.src[j] {
+		case ' ', '\t':
+			continue
+		case ';':
+			return j + 1
+		}
+		break
+	}
+	return i
+}
+
+// prologue returns the position after the directive prologue of the block.
+func (in *instrumenter) prologue(b *ast.BlockStatement) int {
+	pos := in.pos(b.LeftBrace) + 1
+	for _, s := range b.List {
+		if s, ok := s.(*ast.ExpressionStatement); ok {
+			if _, ok := s.Expression.(*ast.StringLiteral); ok {
+				pos = in.end(s)
+				continue
+			}
+		}
+		break
+	}
+	return pos
+}
+
+func (in *instrumenter) statements(list []ast.Statement) {
+	directive := true
+	for _, s := range list {
+		if directive {
+			if s, ok := s.(*ast.ExpressionStatement); ok {
+				if _, ok := s.Expression.(*ast.StringLiteral); ok {
+					continue
+				}
+			}
+			directive = false
+		}
+		in.statement(s, false)
+	}
+}
+
+func (in *instrumenter) block(s ast.Statement, prefix string) {
+	if b, ok := s.(*ast.BlockStatement); ok {
+		if prefix != "" {
+			in.insert(in.pos(b.LeftBrace)+1, prefix)
+		}
+		in.statements(b.List)
+		return
+	}
+	in.insert(in.pos(s.Idx0()), "{"+prefix)
+	in.statement(s, false)
+	in.insert(in.end(s), "}")
+}
+
+func (in *instrumenter) counter(n ast.Node) {
+	in.fc.Statements = append(in.fc.Statements, in.location(n))
+	in.insert(in.pos(n.Idx0()), fmt.Sprintf("%v.s(%v);", covVar, len(in.fc.Statements)-1))
+}
+
+func (in *instrumenter) branch(typ string, n ast.Node, locs ...Location) int {
+	in.fc.Branches = append(in.fc.Branches, branchCoverage{
+		Type:      typ,
+		Loc:       in.location(n),
+		Locations: locs,
+	})
+	return len(in.fc.Branches) - 1
+}
+
+func (in *instrumenter) statement(s ast.Statement, labelled bool) {
+	switch s.(type) {
+	case *ast.BlockStatement, *ast.EmptyStatement, *ast.FunctionStatement:
+	default:
+		if !labelled {
+			in.counter(s)
+		}
+	}
+
+	switch s := s.(type) {
+	case *ast.BlockStatement:
+		in.statements(s.List)
+	case *ast.CaseStatement:
+		// handled by *ast.SwitchStatement
+	case *ast.DoWhileStatement:
+		in.block(s.Body, "")
+		in.expression(s.Test)
+	case *ast.ExpressionStatement:
+		in.expression(s.Expression)
+	case *ast.ForInStatement:
+		in.expression(s.Into)
+		in.expression(s.Source)
+		in.block(s.Body, "")
+	case *ast.ForStatement:
+		in.expression(s.Initializer)
+		in.expression(s.Test)
+		in.expression(s.Update)
+		in.block(s.Body, "")
+	case *ast.FunctionStatement:
+		in.function(s.Function)
+	case *ast.IfStatement:
+		locs := []Location{in.location(s.Consequent)}
+		if s.Alternate != nil {
+			locs = append(locs, in.location(s.Alternate))
+		} else {
+			locs = append(locs, in.location(s))
+		}
+		i := in.branch("if", s, locs...)
+		in.expression(s.Test)
+		in.block(s.Consequent, fmt.Sprintf("%v.b(%v,0);", covVar, i))
+		if s.Alternate != nil {
+			in.block(s.Alternate, fmt.Sprintf("%v.b(%v,1);", covVar, i))
+		} else {
+			in.insert(in.end(s.Consequent), fmt.Sprintf(" else {%v.b(%v,1);}", covVar, i))
+		}
+	case *ast.LabelledStatement:
+		in.statement(s.Statement, true)
+	case *ast.ReturnStatement:
+		in.expression(s.Argument)
+	case *ast.SwitchStatement:
+		in.expression(s.Discriminant)
+		locs := make([]Location, len(s.Body))
+		for j, c := range s.Body {
+			locs[j].Start = in.position(in.pos(c.Case))
+			if len(c.Consequent) > 0 {
+				locs[j].End = in.position(in.end(c.Consequent[len(c.Consequent)-1]))
+			} else {
+				locs[j].End = in.position(in.colon(c))
+			}
+		}
+		i := in.branch("switch", s, locs...)
+		for j, c := range s.Body {
+			in.expression(c.Test)
+			pos := in.colon(c)
+			in.insert(pos, fmt.Sprintf("%v.b(%v,%v);", covVar, i, j))
+			in.statements(c.Consequent)
+		}
+	case *ast.ThrowStatement:
+		in.expression(s.Argument)
+	case *ast.TryStatement:
+		in.block(s.Body, "")
+		if s.Catch != nil {
+			in.block(s.Catch.Body, "")
+		}
+		if s.Finally != nil {
+			in.block(s.Finally, "")
+		}
+	case *ast.VariableStatement:
+		for _, e := range s.List {
+			in.expression(e)
+		}
+	case *ast.WhileStatement:
+		in.expression(s.Test)
+		in.block(s.Body, "")
+	case *ast.WithStatement:
+		in.expression(s.Object)
+		in.block(s.Body, "")
+	}
+}
+
+// colon returns the position after the colon of the case clause.
+func (in *instrumenter) colon(c *ast.CaseStatement) int {
+	pos := in.pos(c.Case)
+	if c.Test != nil {
+		pos = in.pos(c.Test.Idx1())
+	}
+	if i := bytes.IndexByte(in.src[pos:], ':'); i != -1 {
+		return pos + i + 1
+	}
+	return pos
+}
+
+func (in *instrumenter) function(fn *ast.FunctionLiteral) {
+	name := "(anonymous_" + strconv.Itoa(len(in.fc.Functions)) + ")"
+	decl := Location{
+		Start: in.position(in.pos(fn.Function)),
+		End:   in.position(in.pos(fn.Function) + len("function")),
+	}
+	if fn.Name != nil {
+		name = fn.Name.Name
+		decl = in.location(fn.Name)
+	}
+	in.fc.Functions = append(in.fc.Functions, functionCoverage{
+		Name: name,
+		Decl: decl,
+		Loc:  in.location(fn),
+	})
+
+	body := fn.Body.(*ast.BlockStatement)
+	in.insert(in.prologue(body), fmt.Sprintf("%v.f(%v);", covVar, len(in.fc.Functions)-1))
+	in.statements(body.List)
+}
+
+func (in *instrumenter) expression(e ast.Expression) {
+	switch e := e.(type) {
+	case *ast.ArrayLiteral:
+		for _, v := range e.Value {
+			in.expression(v)
+		}
+	case *ast.AssignExpression:
+		in.expression(e.Left)
+		in.expression(e.Right)
+	case *ast.BinaryExpression:
+		if e.Operator != token.LOGICAL_AND && e.Operator != token.LOGICAL_OR {
+			in.expression(e.Left)
+			in.expression(e.Right)
+			break
+		}
+
+		var leaves []ast.Expression
+		var walk func(ast.Expression)
+		walk = func(x ast.Expression) {
+			if x, ok := x.(*ast.BinaryExpression); ok && x.Operator == e.Operator {
+				walk(x.Left)
+				walk(x.Right)
+				return
+			}
+			leaves = append(leaves, x)
+		}
+		walk(e)
+		locs := make([]Location, len(leaves))
+		for j, x := range leaves {
+			locs[j] = in.location(x)
+		}
+		i := in.branch("binary-expr", e, locs...)
+		for j, x := range leaves {
+			in.wrap(x, i, j)
+		}
+	case *ast.BracketExpression:
+		in.expression(e.Left)
+		in.expression(e.Member)
+	case *ast.CallExpression:
+		in.expression(e.Callee)
+		for _, a := range e.ArgumentList {
+			in.expression(a)
+		}
+	case *ast.ConditionalExpression:
+		i := in.branch("cond-expr", e, in.location(e.Consequent), in.location(e.Alternate))
+		in.expression(e.Test)
+		in.wrap(e.Consequent, i, 0)
+		in.wrap(e.Alternate, i, 1)
+	case *ast.DotExpression:
+		in.expression(e.Left)
+	case *ast.FunctionLiteral:
+		in.function(e)
+	case *ast.NewExpression:
+		in.expression(e.Callee)
+		for _, a := range e.ArgumentList {
+			in.expression(a)
+		}
+	case *ast.ObjectLiteral:
+		for _, p := range e.Value {
+			in.expression(p.Value)
+		}
+	case *ast.SequenceExpression:
+		for _, x := range e.Sequence {
+			in.expression(x)
+		}
+	case *ast.UnaryExpression:
+		in.expression(e.Operand)
+	case *ast.VariableExpression:
+		in.expression(e.Initializer)
+	}
+}
+
+func (in *instrumenter) wrap(e ast.Expression, i, j int) {
+	in.insert(in.pos(e.Idx0()), fmt.Sprintf("(%v.b(%v,%v), ", covVar, i, j))
+	in.expression(e)
+	in.insert(in.pos(e.Idx1()), ")")
+}
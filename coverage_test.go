@@ -0,0 +1,217 @@
+//
+// otto.module :: coverage_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+var lcov = `TN:
+SF:%v
+FN:5,f
+FN:13,(anonymous_1)
+FNDA:%[2]v,f
+FNDA:%[3]v,(anonymous_1)
+FNF:2
+FNH:2
+DA:3,%[3]v
+DA:6,%[2]v
+DA:7,%[3]v
+DA:8,%[3]v
+DA:10,%[2]v
+DA:13,%[3]v
+DA:14,%[3]v
+DA:17,%[3]v
+DA:18,%[3]v
+DA:19,%[3]v
+DA:21,%[3]v
+DA:24,%[3]v
+DA:25,%[3]v
+DA:27,0
+DA:30,%[2]v
+DA:32,%[3]v
+LF:16
+LH:15
+BRDA:6,0,0,%[3]v
+BRDA:6,0,1,%[3]v
+BRDA:8,1,0,0
+BRDA:8,1,1,%[3]v
+BRDA:10,2,0,%[3]v
+BRDA:10,2,1,%[3]v
+BRDA:14,3,0,%[3]v
+BRDA:14,3,1,%[3]v
+BRDA:14,4,0,%[3]v
+BRDA:14,4,1,%[3]v
+BRDA:21,5,0,0
+BRDA:21,5,1,%[3]v
+BRDA:21,5,2,0
+BRF:13
+BRH:10
+end_of_record
+`
+
+func TestCoverage(t *testing.T) {
+	c := module.NewCoverage()
+	name := abs("testdata/coverage/index.js")
+
+	for i := 1; i <= 2; i++ {
+		vm, err := module.New(module.WithCoverage(c))
+		if err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		vm.Register(new(module.FileLoader))
+
+		if v, err := vm.Run(`require('./testdata/coverage/index.js');`); err != nil {
+			t.Fatal(module.Wrap(err))
+		} else if g, e := v.String(), "4"; g != e {
+			t.Errorf("expected %v, got %v", e, g)
+		}
+
+		var b bytes.Buffer
+		if err := c.WriteLCOV(&b); err != nil {
+			t.Fatal(err)
+		}
+		if g, e := b.String(), fmt.Sprintf(lcov, name, 2*i, i); g != e {
+			t.Errorf("expected %q, got %q", e, g)
+		}
+	}
+}
+
+func TestCoverage_JSON(t *testing.T) {
+	c := module.NewCoverage()
+	vm, err := module.New(module.WithCoverage(c))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Register(new(module.FileLoader))
+
+	if _, err := vm.Run(`require('./testdata/coverage/index.js');`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	var b bytes.Buffer
+	if err := c.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]struct {
+		Path         string
+		StatementMap map[string]module.Location
+		FnMap        map[string]struct {
+			Name string
+			Line int
+		}
+		BranchMap map[string]struct {
+			Type      string
+			Locations []module.Location
+		}
+		S map[string]int
+		F map[string]int
+		B map[string][]int
+	}
+	if err := json.Unmarshal(b.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	name := abs("testdata/coverage/index.js")
+	fc, ok := m[name]
+	if !ok {
+		t.Fatalf("%v not found", name)
+	}
+	if g, e := fc.Path, name; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := len(fc.S), len(fc.StatementMap); g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+	if g, e := fc.StatementMap["0"], (module.Location{
+		Start: module.Position{Line: 3, Column: 0},
+		End:   module.Position{Line: 3, Column: 10},
+	}); g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+	if g, e := fc.FnMap["0"].Name, "f"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := fc.FnMap["0"].Line, 5; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+	for k, e := range map[string]string{
+		"0": "if",
+		"2": "cond-expr",
+		"3": "binary-expr",
+		"5": "switch",
+	} {
+		if g := fc.BranchMap[k].Type; g != e {
+			t.Errorf("branchMap[%v].type = %q, expected %q", k, g, e)
+		}
+		if g, e := len(fc.B[k]), len(fc.BranchMap[k].Locations); g != e {
+			t.Errorf("len(b[%v]) = %v, expected %v", k, g, e)
+		}
+	}
+}
+
+func TestCoverage_Filter(t *testing.T) {
+	c := module.NewCoverage()
+	c.Filter = func(name string) bool {
+		return !strings.Contains(name, "node_modules")
+	}
+	vm, err := module.New(module.WithCoverage(c))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	file := new(module.FileLoader)
+	folder := &module.FolderLoader{File: file}
+	vm.Register(file)
+	vm.Register(folder)
+	vm.Register(&module.NodeModulesLoader{
+		File:   file,
+		Folder: folder,
+	})
+
+	if _, err := vm.Run(`require('./testdata/file05');`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	var b bytes.Buffer
+	if err := c.WriteLCOV(&b); err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for l := range strings.Lines(b.String()) {
+		if n, ok := strings.CutPrefix(l, "SF:"); ok {
+			files = append(files, strings.TrimSpace(n))
+		}
+	}
+	if g, e := strings.Join(files, ","), abs("testdata/file05.js"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestCoverageError(t *testing.T) {
+	vm, err := module.New(module.WithCoverage(module.NewCoverage()))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Register(new(module.FileLoader))
+
+	for _, src := range []string{
+		`require('./testdata/error01.js');`,
+		`__otto_coverage__(null);`,
+		`__otto_coverage__('_');`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", strings.Trim(src, ";"))
+		}
+	}
+}
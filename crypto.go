@@ -0,0 +1,869 @@
+//
+// otto.module :: crypto.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hkdf"
+	"crypto/hmac"
+	_ "crypto/md5"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+var hashes = map[string]crypto.Hash{
+	"md5":    crypto.MD5,
+	"sha1":   crypto.SHA1,
+	"sha224": crypto.SHA224,
+	"sha256": crypto.SHA256,
+	"sha384": crypto.SHA384,
+	"sha512": crypto.SHA512,
+}
+
+func lookupHash(name string) (crypto.Hash, bool) {
+	name = strings.TrimPrefix(strings.ToLower(name), "rsa-")
+	if strings.HasPrefix(name, "sha-") {
+		name = "sha" + name[4:]
+	}
+	h, ok := hashes[name]
+	return h, ok
+}
+
+func (vm *Otto) crypto_binding(o *otto.Object) error {
+	o.Set("getHashes", vm.crypto_getHashes)
+	o.Set("createHash", vm.crypto_createHash)
+	o.Set("createHmac", vm.crypto_createHmac)
+	o.Set("randomBytes", vm.crypto_randomBytes)
+	o.Set("randomInt", vm.crypto_randomInt)
+	o.Set("randomUUID", vm.crypto_randomUUID)
+	o.Set("timingSafeEqual", vm.crypto_timingSafeEqual)
+	o.Set("getCiphers", vm.crypto_getCiphers)
+	o.Set("getCipherInfo", vm.crypto_getCipherInfo)
+	o.Set("createCipher", vm.crypto_createCipher)
+	o.Set("pbkdf2", vm.crypto_pbkdf2)
+	o.Set("scrypt", vm.crypto_scrypt)
+	o.Set("hkdf", vm.crypto_hkdf)
+	o.Set("sign", vm.crypto_sign)
+	o.Set("verify", vm.crypto_verify)
+	return nil
+}
+
+func (vm *Otto) crypto_getHashes(call otto.FunctionCall) otto.Value {
+	list := make([]string, 0, len(hashes))
+	for k := range hashes {
+		list = append(list, k)
+	}
+	slices.Sort(list)
+	return vm.stringArray(list)
+}
+
+func (vm *Otto) crypto_createHash(call otto.FunctionCall) otto.Value {
+	alg, err := vm.toString("algorithm", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	h, ok := lookupHash(alg)
+	if !ok {
+		return otto.NullValue()
+	}
+	return vm.hash(h.New(), h.New)
+}
+
+func (vm *Otto) crypto_createHmac(call otto.FunctionCall) otto.Value {
+	alg, err := vm.toString("algorithm", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	key, err := vm.toBuffer("key", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	h, ok := lookupHash(alg)
+	if !ok {
+		return otto.NullValue()
+	}
+	return vm.hash(hmac.New(h.New, key), nil)
+}
+
+func (vm *Otto) hash(h hash.Hash, fn func() hash.Hash) otto.Value {
+	o, _ := vm.Object(`({})`)
+	o.Set("update", func(call otto.FunctionCall) otto.Value {
+		b, err := vm.toBuffer("data", call.Argument(0))
+		if err != nil {
+			return vm.throw(err)
+		}
+
+		h.Write(b)
+		return otto.UndefinedValue()
+	})
+	o.Set("digest", func(call otto.FunctionCall) otto.Value {
+		v, err := vm.NewBuffer(h.Sum(nil))
+		if err != nil {
+			return vm.throw(err)
+		}
+		return v
+	})
+	if fn != nil {
+		o.Set("copy", func(call otto.FunctionCall) otto.Value {
+			b, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				return vm.throw(err)
+			}
+			c := fn()
+			if err := c.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+				return vm.throw(err)
+			}
+			return vm.hash(c, fn)
+		})
+	}
+	return o.Value()
+}
+
+func (vm *Otto) crypto_randomBytes(call otto.FunctionCall) otto.Value {
+	n, err := call.Argument(0).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	b := make([]byte, n)
+	rand.Read(b)
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) crypto_randomInt(call otto.FunctionCall) otto.Value {
+	lo, err := call.Argument(0).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	hi, err := call.Argument(1).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(hi-lo))
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, _ := vm.ToValue(lo + n.Int64())
+	return v
+}
+
+func (vm *Otto) crypto_randomUUID(call otto.FunctionCall) otto.Value {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	v, _ := vm.ToValue(fmt.Sprintf("%x-%x-%x-%x-%x", b[:4], b[4:6], b[6:8], b[8:10], b[10:]))
+	return v
+}
+
+func (vm *Otto) crypto_timingSafeEqual(call otto.FunctionCall) otto.Value {
+	a, err := vm.toBuffer("buf1", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := vm.toBuffer("buf2", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	v, _ := vm.ToValue(subtle.ConstantTimeCompare(a, b) == 1)
+	return v
+}
+
+type cryptoError struct {
+	name string
+	code string
+	msg  string
+}
+
+func (e *cryptoError) Error() string {
+	return e.msg
+}
+
+var (
+	errBadDecrypt            = &cryptoError{"Error", "ERR_OSSL_BAD_DECRYPT", "error:1C800064:Provider routines::bad decrypt"}
+	errWrongFinalBlockLength = &cryptoError{"Error", "ERR_OSSL_WRONG_FINAL_BLOCK_LENGTH", "error:1C80006B:Provider routines::wrong final block length"}
+	errAuthenticate          = &cryptoError{"Error", "", "Unsupported state or unable to authenticate data"}
+	errUnsupportedKey        = &cryptoError{"Error", "ERR_OSSL_UNSUPPORTED", "error:1E08010C:DECODER routines::unsupported"}
+	errInvalidDigest         = &cryptoError{"Error", "ERR_OSSL_INVALID_DIGEST", "error:1C80007A:Provider routines::invalid digest"}
+	errInvalidKeyLength      = &cryptoError{"RangeError", "ERR_CRYPTO_INVALID_KEYLEN", "Invalid key length"}
+)
+
+func (vm *Otto) throwCrypto(err error) otto.Value {
+	e, ok := err.(*cryptoError)
+	if !ok {
+		return vm.throw(err)
+	}
+
+	var v otto.Value
+	switch e.name {
+	case "TypeError":
+		v = vm.MakeTypeError(e.msg)
+	case "RangeError":
+		v = vm.MakeRangeError(e.msg)
+	default:
+		v = vm.MakeCustomError(e.name, e.msg)
+	}
+	if e.code != "" {
+		v.Object().Set("code", e.code)
+	}
+	panic(v)
+}
+
+type cipherInfo struct {
+	name      string
+	mode      string
+	keyLength int
+	ivLength  int
+	blockSize int
+}
+
+var ciphers = []string{
+	"aes-128-cbc",
+	"aes-128-ctr",
+	"aes-128-gcm",
+	"aes-192-cbc",
+	"aes-192-ctr",
+	"aes-192-gcm",
+	"aes-256-cbc",
+	"aes-256-ctr",
+	"aes-256-gcm",
+}
+
+func lookupCipher(name string) (info cipherInfo, ok bool) {
+	name = strings.ToLower(name)
+	if !slices.Contains(ciphers, name) {
+		return
+	}
+	info = cipherInfo{
+		name:      name,
+		mode:      name[8:],
+		ivLength:  aes.BlockSize,
+		blockSize: 1,
+	}
+	info.keyLength, _ = strconv.Atoi(name[4:7])
+	info.keyLength /= 8
+	switch info.mode {
+	case "cbc":
+		info.blockSize = aes.BlockSize
+	case "gcm":
+		info.ivLength = 12
+	}
+	return info, true
+}
+
+func (vm *Otto) crypto_getCiphers(call otto.FunctionCall) otto.Value {
+	return vm.stringArray(ciphers)
+}
+
+func (vm *Otto) crypto_getCipherInfo(call otto.FunctionCall) otto.Value {
+	name, err := vm.toString("name", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	info, ok := lookupCipher(name)
+	if !ok {
+		return otto.UndefinedValue()
+	}
+	o, _ := vm.Object(`({})`)
+	o.Set("name", info.name)
+	o.Set("mode", info.mode)
+	o.Set("keyLength", info.keyLength)
+	o.Set("ivLength", info.ivLength)
+	o.Set("blockSize", info.blockSize)
+	return o.Value()
+}
+
+func (vm *Otto) crypto_createCipher(call otto.FunctionCall) otto.Value {
+	name, err := vm.toString("cipher", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	key, err := vm.toBuffer("key", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	iv, err := vm.toBuffer("iv", call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+	decrypt, _ := call.Argument(3).ToBoolean()
+	tagLen, err := call.Argument(4).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	info, ok := lookupCipher(name)
+	if !ok {
+		return otto.NullValue()
+	}
+	c, err := newCipherState(info, key, iv, decrypt, int(tagLen))
+	if err != nil {
+		return vm.throwCrypto(err)
+	}
+	o, _ := vm.Object(`({})`)
+	o.Set("update", func(call otto.FunctionCall) otto.Value {
+		b, err := vm.toBuffer("data", call.Argument(0))
+		if err != nil {
+			return vm.throw(err)
+		}
+
+		v, err := vm.NewBuffer(c.update(b))
+		if err != nil {
+			return vm.throw(err)
+		}
+		return v
+	})
+	o.Set("final", func(call otto.FunctionCall) otto.Value {
+		b, err := c.final()
+		if err != nil {
+			return vm.throwCrypto(err)
+		}
+		v, err := vm.NewBuffer(b)
+		if err != nil {
+			return vm.throw(err)
+		}
+		return v
+	})
+	o.Set("setAutoPadding", func(call otto.FunctionCall) otto.Value {
+		c.padding, _ = call.Argument(0).ToBoolean()
+		return otto.UndefinedValue()
+	})
+	o.Set("setAAD", func(call otto.FunctionCall) otto.Value {
+		b, err := vm.toBuffer("buffer", call.Argument(0))
+		if err != nil {
+			return vm.throw(err)
+		}
+
+		c.aad = append(c.aad, b...)
+		return otto.UndefinedValue()
+	})
+	o.Set("getAuthTag", func(call otto.FunctionCall) otto.Value {
+		v, err := vm.NewBuffer(c.tag)
+		if err != nil {
+			return vm.throw(err)
+		}
+		return v
+	})
+	o.Set("setAuthTag", func(call otto.FunctionCall) otto.Value {
+		b, err := vm.toBuffer("buffer", call.Argument(0))
+		if err != nil {
+			return vm.throw(err)
+		}
+
+		c.tag = bytes.Clone(b)
+		return otto.UndefinedValue()
+	})
+	return o.Value()
+}
+
+type cipherState struct {
+	mode    string
+	decrypt bool
+	padding bool
+	buf     []byte
+	// cbc
+	cbc cipher.BlockMode
+	// ctr, gcm
+	stream cipher.Stream
+	// gcm
+	aead   cipher.AEAD
+	iv     []byte
+	aad    []byte
+	data   []byte
+	tag    []byte
+	tagLen int
+}
+
+func newCipherState(info cipherInfo, key, iv []byte, decrypt bool, tagLen int) (*cipherState, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errInvalidKeyLength
+	}
+	c := &cipherState{
+		mode:    info.mode,
+		decrypt: decrypt,
+		padding: true,
+	}
+	switch info.mode {
+	case "cbc":
+		if decrypt {
+			c.cbc = cipher.NewCBCDecrypter(block, iv)
+		} else {
+			c.cbc = cipher.NewCBCEncrypter(block, iv)
+		}
+	case "ctr":
+		c.stream = cipher.NewCTR(block, iv)
+	case "gcm":
+		if len(iv) == 12 {
+			c.aead, err = cipher.NewGCM(block)
+		} else {
+			c.aead, err = cipher.NewGCMWithNonceSize(block, len(iv))
+		}
+		if err != nil {
+			return nil, err
+		}
+		// recover the initial counter block from the first block of the key stream
+		s := &gcmCTR{
+			block: block,
+			used:  aes.BlockSize,
+		}
+		block.Decrypt(s.ctr[:], c.aead.Seal(nil, iv, make([]byte, aes.BlockSize), nil))
+		c.stream = s
+		c.iv = bytes.Clone(iv)
+		c.tagLen = tagLen
+	}
+	return c, nil
+}
+
+func (c *cipherState) update(b []byte) []byte {
+	switch c.mode {
+	case "cbc":
+		c.buf = append(c.buf, b...)
+		n := len(c.buf) / aes.BlockSize * aes.BlockSize
+		if c.decrypt && c.padding && n > 0 && n == len(c.buf) {
+			// keep the last block for final
+			n -= aes.BlockSize
+		}
+		out := make([]byte, n)
+		c.cbc.CryptBlocks(out, c.buf[:n])
+		c.buf = append(c.buf[:0], c.buf[n:]...)
+		return out
+	case "gcm":
+		out := make([]byte, len(b))
+		c.stream.XORKeyStream(out, b)
+		if c.decrypt {
+			c.data = append(c.data, out...)
+		} else {
+			c.data = append(c.data, b...)
+		}
+		return out
+	default:
+		out := make([]byte, len(b))
+		c.stream.XORKeyStream(out, b)
+		return out
+	}
+}
+
+func (c *cipherState) final() ([]byte, error) {
+	switch c.mode {
+	case "cbc":
+		switch {
+		case !c.padding:
+			if len(c.buf) != 0 {
+				return nil, errWrongFinalBlockLength
+			}
+			return nil, nil
+		case c.decrypt:
+			if len(c.buf) != aes.BlockSize {
+				return nil, errWrongFinalBlockLength
+			}
+			out := make([]byte, aes.BlockSize)
+			c.cbc.CryptBlocks(out, c.buf)
+			n := int(out[len(out)-1])
+			if n == 0 || n > aes.BlockSize || !bytes.Equal(out[len(out)-n:], bytes.Repeat(out[len(out)-1:], n)) {
+				return nil, errBadDecrypt
+			}
+			return out[:len(out)-n], nil
+		default:
+			n := aes.BlockSize - len(c.buf)
+			c.buf = append(c.buf, bytes.Repeat([]byte{byte(n)}, n)...)
+			out := make([]byte, len(c.buf))
+			c.cbc.CryptBlocks(out, c.buf)
+			return out, nil
+		}
+	case "gcm":
+		sealed := c.aead.Seal(nil, c.iv, c.data, c.aad)
+		tag := sealed[len(c.data):]
+		if c.decrypt {
+			if len(c.tag) == 0 || subtle.ConstantTimeCompare(tag[:len(c.tag)], c.tag) != 1 {
+				return nil, errAuthenticate
+			}
+		} else {
+			c.tag = tag[:c.tagLen]
+		}
+	}
+	return nil, nil
+}
+
+type gcmCTR struct {
+	block cipher.Block
+	ctr   [aes.BlockSize]byte
+	ks    [aes.BlockSize]byte
+	used  int
+}
+
+func (s *gcmCTR) XORKeyStream(dst, src []byte) {
+	for i := range src {
+		if s.used == len(s.ks) {
+			s.block.Encrypt(s.ks[:], s.ctr[:])
+			binary.BigEndian.PutUint32(s.ctr[12:], binary.BigEndian.Uint32(s.ctr[12:])+1)
+			s.used = 0
+		}
+		dst[i] = src[i] ^ s.ks[s.used]
+		s.used++
+	}
+}
+
+func (vm *Otto) crypto_pbkdf2(call otto.FunctionCall) otto.Value {
+	password, err := vm.toBuffer("password", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	salt, err := vm.toBuffer("salt", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	iter, err := call.Argument(2).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	keyLen, err := call.Argument(3).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	digest, err := vm.toString("digest", call.Argument(4))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	h, ok := lookupHash(digest)
+	if !ok {
+		return otto.NullValue()
+	}
+	b, err := pbkdf2.Key(h.New, string(password), salt, int(iter), int(keyLen))
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) crypto_scrypt(call otto.FunctionCall) otto.Value {
+	password, err := vm.toBuffer("password", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	salt, err := vm.toBuffer("salt", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	var args [4]int64
+	for i := range args {
+		args[i], err = call.Argument(2 + i).ToInteger()
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
+
+	b, err := scrypt(password, salt, int(args[1]), int(args[2]), int(args[3]), int(args[0]))
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) crypto_hkdf(call otto.FunctionCall) otto.Value {
+	digest, err := vm.toString("digest", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	ikm, err := vm.toBuffer("ikm", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	salt, err := vm.toBuffer("salt", call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+	info, err := vm.toBuffer("info", call.Argument(3))
+	if err != nil {
+		return vm.throw(err)
+	}
+	keyLen, err := call.Argument(4).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	h, ok := lookupHash(digest)
+	if !ok {
+		return otto.NullValue()
+	} else if keyLen > 255*int64(h.Size()) {
+		return vm.throwCrypto(errInvalidKeyLength)
+	}
+	var b []byte
+	if keyLen > 0 {
+		b, err = hkdf.Key(h.New, ikm, salt, string(info), int(keyLen))
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
+	v, err := vm.NewBuffer(b)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) crypto_sign(call otto.FunctionCall) otto.Value {
+	data, err := vm.toBuffer("data", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := vm.toBuffer("key", call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	key, err := parsePrivateKey(b)
+	if err != nil {
+		return vm.throwCrypto(err)
+	}
+	opts, err := vm.signerOpts(key.Public(), call)
+	if err != nil {
+		return vm.throwCrypto(err)
+	}
+	var sig []byte
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, key, opts.digest(data))
+		if err == nil && opts.p1363 {
+			sig, err = derToP1363(sig, (key.Curve.Params().BitSize+7)/8)
+		}
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(key, data)
+	default:
+		sig, err = key.Sign(rand.Reader, opts.digest(data), opts.SignerOpts)
+	}
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, err := vm.NewBuffer(sig)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) crypto_verify(call otto.FunctionCall) otto.Value {
+	data, err := vm.toBuffer("data", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := vm.toBuffer("key", call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+	sig, err := vm.toBuffer("signature", call.Argument(3))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	key, err := parsePublicKey(b)
+	if err != nil {
+		return vm.throwCrypto(err)
+	}
+	opts, err := vm.signerOpts(key, call)
+	if err != nil {
+		return vm.throwCrypto(err)
+	}
+	var ok bool
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if pss, _ := opts.SignerOpts.(*rsa.PSSOptions); pss != nil {
+			ok = rsa.VerifyPSS(key, opts.HashFunc(), opts.digest(data), sig, pss) == nil
+		} else {
+			ok = rsa.VerifyPKCS1v15(key, opts.HashFunc(), opts.digest(data), sig) == nil
+		}
+	case *ecdsa.PublicKey:
+		if opts.p1363 {
+			sig, err = p1363ToDER(sig)
+		}
+		ok = err == nil && ecdsa.VerifyASN1(key, opts.digest(data), sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, data, sig)
+	}
+	v, _ := vm.ToValue(ok)
+	return v
+}
+
+type signerOpts struct {
+	crypto.SignerOpts
+	p1363 bool
+}
+
+func (o *signerOpts) digest(data []byte) []byte {
+	h := o.HashFunc().New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// signerOpts reads the digest, padding, saltLength and dsaEncoding arguments.
+func (vm *Otto) signerOpts(key crypto.PublicKey, call otto.FunctionCall) (*signerOpts, error) {
+	var h crypto.Hash
+	if v := call.Argument(0); v.IsString() {
+		var ok bool
+		h, ok = lookupHash(v.String())
+		if !ok {
+			return nil, &cryptoError{"TypeError", "ERR_CRYPTO_INVALID_DIGEST", "Invalid digest: " + v.String()}
+		}
+	}
+	opts := &signerOpts{SignerOpts: h}
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if h == 0 {
+			opts.SignerOpts = crypto.SHA256
+		}
+		if padding, _ := call.Argument(4).ToInteger(); padding == 6 {
+			saltLength := rsa.PSSSaltLengthAuto
+			if v := call.Argument(5); v.IsNumber() {
+				n, _ := v.ToInteger()
+				switch n {
+				case -1:
+					saltLength = rsa.PSSSaltLengthEqualsHash
+				case -2:
+				default:
+					saltLength = int(n)
+				}
+			}
+			opts.SignerOpts = &rsa.PSSOptions{
+				SaltLength: saltLength,
+				Hash:       opts.HashFunc(),
+			}
+		}
+	case *ecdsa.PublicKey:
+		if h == 0 {
+			opts.SignerOpts = crypto.SHA256
+		}
+		opts.p1363 = call.Argument(6).String() == "ieee-p1363"
+	case ed25519.PublicKey:
+		if h != 0 {
+			return nil, errInvalidDigest
+		}
+	default:
+		return nil, errUnsupportedKey
+	}
+	return opts, nil
+}
+
+func parsePrivateKey(b []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errUnsupportedKey
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, errUnsupportedKey
+	}
+	if err != nil {
+		return nil, errUnsupportedKey
+	}
+	return key.(crypto.Signer), nil
+}
+
+func parsePublicKey(b []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errUnsupportedKey
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		priv, err := parsePrivateKey(b)
+		if err != nil {
+			return nil, err
+		}
+		return priv.Public(), nil
+	}
+	if err != nil {
+		return nil, errUnsupportedKey
+	}
+	return key, nil
+}
+
+func derToP1363(der []byte, size int) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, err
+	}
+	b := make([]byte, size*2)
+	sig.R.FillBytes(b[:size])
+	sig.S.FillBytes(b[size:])
+	return b, nil
+}
+
+func p1363ToDER(b []byte) ([]byte, error) {
+	if len(b) == 0 || len(b)%2 != 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		new(big.Int).SetBytes(b[:len(b)/2]),
+		new(big.Int).SetBytes(b[len(b)/2:]),
+	})
+}
@@ -0,0 +1,303 @@
+//
+// otto.module :: crypto_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestCrypto(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var crypto = require('crypto');
+		var h = crypto.createHash('sha384').update('a');
+		var c = h.copy();
+		h.update('b');
+		var hmac = crypto.createHmac('sha512', 'key').update('the data');
+		[
+			crypto.getHashes().join() + ':' + Array.isArray(crypto.getHashes()),
+			crypto.createHash('SHA-256').digest('hex'),
+			crypto.createHash('RSA-SHA1').digest('base64'),
+			crypto.createHash('sha1').digest('base64url'),
+			crypto.createHash('md5').update('日本', 'utf8').update('616263', 'hex').digest('hex'),
+			crypto.createHash('sha224').update(Buffer.from('x')).digest().length,
+			h.digest('hex'),
+			c.digest('hex'),
+			crypto.createHmac('sha256', Buffer.from('k')).update('d').digest('hex'),
+			hmac.digest('base64'),
+			hmac.digest('hex') === '',
+			crypto.randomBytes(8).length,
+			crypto.randomInt(10) < 10,
+			crypto.randomInt(-3, -1) < -1,
+			/^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$/.test(crypto.randomUUID()),
+			crypto.timingSafeEqual(Buffer.from('ab'), Buffer.from('ab')),
+			crypto.timingSafeEqual(Buffer.from('ab'), Buffer.from('ac')),
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"md5,sha1,sha224,sha256,sha384,sha512:true",
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"2jmj7l5rSw0yVb/vlWAYkK/YBwk=",
+		"2jmj7l5rSw0yVb_vlWAYkK_YBwk",
+		"32f1686e1469492882a79482df3b0ec5",
+		"28",
+		"c7be03ba5bcaa384727076db0018e99248e1a6e8bd1b9ef58a9ec9dd4eeebb3f48b836201221175befa74ddc3d35afdd",
+		"54a59b9f22b0b80880d8427e548b7c23abd873486e1f035dce9cd697e85175033caa88e6d57bc35efae0b5afd3145f31",
+		"e7ea21c3bcb63a4da3ad78503168d36bdca0be622382ea60a108fad4e4966679",
+		"yW1jccYEZxxjKIa+a2AedHZ9hiFnPWPaaa3/YT8sg5ohebTxfU0UZz3RPS32DxULOPRU2CtvW1xa6udY0TlO3A==",
+		"true",
+		"8",
+		"true",
+		"true",
+		"true",
+		"true",
+		"false",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	src = `
+		var crypto = require('crypto');
+		var out = [];
+		crypto.randomBytes(4, function(err, buf) {
+			out.push(err + ':' + buf.length);
+		});
+		crypto.randomInt(1, 2, function(err, n) {
+			out.push(err + ':' + n);
+		});
+		out.push('sync');
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "sync,null:4,null:1"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestCrypto_Cipher(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var crypto = require('crypto');
+		var z = Buffer.alloc(16);
+		var out = [];
+		var c = crypto.createCipheriv('aes-128-cbc', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii');
+		var enc = c.update('hello world', 'utf8', 'hex') + c.final('hex');
+		out.push(enc);
+		var d = crypto.createDecipheriv('aes-128-cbc', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii');
+		out.push(d.update(enc, 'hex', 'utf8') + d.final('utf8'));
+		out.push(crypto.createCipheriv('aes-128-ctr', z, z).update('abc', 'utf8', 'hex'));
+		c = crypto.createCipheriv('aes-128-gcm', z, Buffer.alloc(12)).setAAD(Buffer.from('aad'));
+		out.push(c.update('abc', 'utf8', 'hex') + c.final('hex'), c.getAuthTag().toString('hex'));
+		c = crypto.createCipheriv('aes-128-gcm', z, Buffer.alloc(7));
+		var ct = Buffer.concat([c.update('abcdefghijklmnopqrstuvwxyz'), c.final()]);
+		out.push(ct.toString('hex'), c.getAuthTag().toString('hex'));
+		d = crypto.createDecipheriv('aes-128-gcm', z, Buffer.alloc(7));
+		d.setAuthTag(c.getAuthTag());
+		out.push(d.update(ct, null, 'utf8') + d.final('utf8'));
+		out.push(crypto.getCiphers().length + ':' + Array.isArray(crypto.getCiphers()));
+		out.push(JSON.stringify(crypto.getCipherInfo('AES-256-CBC')));
+		out.join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"1100e34ab814198c32a71764f9e50513",
+		"hello world",
+		"078b28",
+		"62eab9",
+		"58c6be800d685fed90a53fb5d57363d8",
+		"b7c6e3b0bba17ed55fccbc71032f4e7524ebb8770a06d12e2949",
+		"1b541498b7242fd1d1804b24c2e57d49",
+		"abcdefghijklmnopqrstuvwxyz",
+		"9:true",
+		`{"blockSize":16,"ivLength":16,"keyLength":32,"mode":"cbc","name":"aes-256-cbc"}`,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestCrypto_KDF(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var crypto = require('crypto');
+		[
+			crypto.pbkdf2Sync('pw', 'salt', 1000, 32, 'sha256').toString('hex'),
+			crypto.scryptSync('pw', 'salt', 32).toString('hex'),
+			crypto.scryptSync('pw', 'salt', 16, { N: 16, r: 1, p: 1 }).toString('hex'),
+			crypto.scryptSync('pw', 'salt', 16, { cost: 16, blockSize: 2, parallelization: 3 }).toString('hex'),
+			crypto.hkdfSync('sha256', 'key', 'salt', 'info', 16).toString('hex'),
+			crypto.hkdfSync('sha256', 'key', 'salt', 'info', 0).length,
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"0a38253555ce37f5c72a6b703f996814ebf241f203af146e93dcdeb031c5567e",
+		"c0b515908e61334cae6d6003c00be60e2e675878c9ac8ba282e1d70c335d3012",
+		"086be1ce38ba574b6133f2c1cfafecb7",
+		"1d7de095e073b06f2939e344605a92fd",
+		"9ca0d662557439e3b83365f2da4626d3",
+		"0",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestCrypto_Sign(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []struct {
+		name string
+		key  crypto.Signer
+	}{
+		{"rsa", rsaKey},
+		{"ec", ecKey},
+		{"ed", edKey},
+	} {
+		priv, err := x509.MarshalPKCS8PrivateKey(k.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := x509.MarshalPKIXPublicKey(k.key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		vm.Set(k.name+"Priv", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: priv})))
+		vm.Set(k.name+"Pub", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})))
+	}
+
+	src := `
+		var crypto = require('crypto');
+		var data = Buffer.from('data');
+		var out = [];
+		var sig = crypto.sign('sha256', data, rsaPriv);
+		out.push(sig.length, crypto.verify('sha256', data, rsaPub, sig), crypto.verify('sha256', Buffer.from('x'), rsaPub, sig));
+		var pss = { key: rsaPriv, padding: crypto.constants.RSA_PKCS1_PSS_PADDING, saltLength: crypto.constants.RSA_PSS_SALTLEN_DIGEST };
+		sig = crypto.sign('sha384', data, pss);
+		pss.key = rsaPub;
+		out.push(crypto.verify('sha384', data, pss, sig), crypto.verify('sha384', data, rsaPub, sig));
+		sig = crypto.createSign('sha256').update('da').update('ta').sign(ecPriv, 'hex');
+		out.push(crypto.createVerify('sha256').update('data').verify(ecPub, sig, 'hex'));
+		sig = crypto.sign(null, data, { key: ecPriv, dsaEncoding: 'ieee-p1363' });
+		out.push(sig.length, crypto.verify(null, data, { key: ecPub, dsaEncoding: 'ieee-p1363' }, sig), crypto.verify(null, data, ecPub, sig));
+		sig = crypto.sign(null, data, edPriv);
+		out.push(sig.length, crypto.verify(null, data, edPub, sig), crypto.verify(undefined, data, edPriv, sig));
+		out.join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "256,true,false,true,false,true,64,true,false,64,true,true"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestCryptoError(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`crypto.createHash('nope')`, "Error: Digest method not supported"},
+		{`crypto.createHash(1)`, `TypeError: The "algorithm" argument must be of type string. Received type number (1)`},
+		{`crypto.createHash('sha1').update(1)`, `TypeError: The "data" argument must be of type string or an instance of Buffer, TypedArray, or DataView. Received type number (1)`},
+		{`crypto.createHash('sha1').update('a', 'hex')`, `TypeError: The argument 'encoding' is invalid for data of length 1. Received 'hex'`},
+		{`var h = crypto.createHash('sha1'); h.digest(); h.digest()`, "Error: Digest already called"},
+		{`var h = crypto.createHash('sha1'); h.digest(); h.copy()`, "Error: Digest already called"},
+		{`crypto.createHmac('nope', 'key')`, "TypeError: Invalid digest: nope"},
+		{`crypto.createHmac('sha1', 1)`, `TypeError: The "key" argument must be of type string or an instance of ArrayBuffer, Buffer, TypedArray, DataView, KeyObject, or CryptoKey. Received type number (1)`},
+		{`var h = crypto.createHmac('sha1', ''); h.digest(); h.update('a')`, "Error: Digest already called"},
+		{`crypto.randomBytes('1')`, `TypeError: The "size" argument must be of type number. Received type string ('1')`},
+		{`crypto.randomBytes(-1)`, `RangeError: The value of "size" is out of range. It must be >= 0 && <= 2147483647. Received -1`},
+		{`crypto.randomBytes(1, 1)`, `TypeError: The "callback" argument must be of type function. Received type number (1)`},
+		{`crypto.randomInt(1.5)`, `TypeError: The "max" argument must be a safe integer. Received type number (1.5)`},
+		{`crypto.randomInt(5, 1)`, `RangeError: The value of "max" is out of range. It must be greater than the value of "min" (5). Received 1`},
+		{`crypto.randomInt(Math.pow(2, 48) + 1)`, `RangeError: The value of "max" is out of range. It must be <= 281474976710655. Received 281_474_976_710_657`},
+		{`crypto.timingSafeEqual('a', 'a')`, `TypeError: The "buf1" argument must be an instance of ArrayBuffer, Buffer, TypedArray, or DataView.`},
+		{`crypto.timingSafeEqual(Buffer.from('a'), Buffer.from('ab'))`, "RangeError: Input buffers must have the same byte length"},
+		{`crypto.createCipheriv('nope', '', '')`, "Error: Unknown cipher"},
+		{`crypto.createCipheriv('aes-128-cbc', 'k', 'iiiiiiiiiiiiiiii')`, "RangeError: Invalid key length"},
+		{`crypto.createCipheriv('aes-128-cbc', 'kkkkkkkkkkkkkkkk', 'i')`, "TypeError: Invalid initialization vector"},
+		{`crypto.createCipheriv('aes-128-gcm', 'kkkkkkkkkkkkkkkk', '', { authTagLength: 16 })`, "TypeError: Invalid initialization vector"},
+		{`crypto.createCipheriv('aes-128-gcm', 'kkkkkkkkkkkkkkkk', 'i', { authTagLength: 5 })`, "TypeError: Invalid authentication tag length: 5"},
+		{`var c = crypto.createCipheriv('aes-128-ctr', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii'); c.final(); c.update('a')`, "Error: Trying to add data in unsupported state"},
+		{`var c = crypto.createCipheriv('aes-128-ctr', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii'); c.final(); c.final()`, "Error: Invalid state"},
+		{`var c = crypto.createCipheriv('aes-128-ctr', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii'); c.update('a', 'utf8', 'hex'); c.final('base64')`, "Error: Cannot change encoding"},
+		{`crypto.createCipheriv('aes-128-ctr', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii').setAAD(Buffer.from('a'))`, "Error: Invalid state for operation setAAD"},
+		{`crypto.createCipheriv('aes-128-gcm', 'kkkkkkkkkkkkkkkk', 'i').getAuthTag()`, "Error: Invalid state for operation getAuthTag"},
+		{`var c = crypto.createCipheriv('aes-128-cbc', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii').setAutoPadding(false); c.update('a'); c.final()`, "Error: error:1C80006B:Provider routines::wrong final block length"},
+		{`var d = crypto.createDecipheriv('aes-128-cbc', 'kkkkkkkkkkkkkkkk', 'iiiiiiiiiiiiiiii'); d.update(Buffer.alloc(16)); d.final()`, "Error: error:1C800064:Provider routines::bad decrypt"},
+		{`var d = crypto.createDecipheriv('aes-128-gcm', 'kkkkkkkkkkkkkkkk', 'i'); d.setAuthTag(Buffer.alloc(16)); d.final()`, "Error: Unsupported state or unable to authenticate data"},
+		{`crypto.createDecipheriv('aes-128-gcm', 'kkkkkkkkkkkkkkkk', 'i').setAuthTag(Buffer.alloc(5))`, "TypeError: Invalid authentication tag length: 5"},
+		{`crypto.pbkdf2Sync('pw', 'salt', 0, 1, 'sha1')`, `RangeError: The value of "iterations" is out of range. It must be >= 1 && <= 2147483647. Received 0`},
+		{`crypto.pbkdf2Sync('pw', 'salt', 1, 1, 'nope')`, "TypeError: Invalid digest: nope"},
+		{`crypto.scryptSync('pw', 'salt', 1, { N: 3 })`, "RangeError: Invalid scrypt params"},
+		{`crypto.scryptSync('pw', 'salt', 1, { N: 16, cost: 16 })`, "Error: Invalid scrypt parameter"},
+		{`crypto.scryptSync('pw', 'salt', 1, { N: 1 << 20 })`, "RangeError: Invalid scrypt params: error:030000AC:digital envelope routines::memory limit exceeded"},
+		{`crypto.hkdfSync('nope', 'key', 'salt', 'info', 1)`, "TypeError: Invalid digest: nope"},
+		{`crypto.hkdfSync('sha256', 'key', 'salt', 'info', 255 * 32 + 1)`, "RangeError: Invalid key length"},
+		{`crypto.createSign('nope')`, "TypeError: Invalid digest"},
+		{`crypto.sign('sha256', Buffer.from('a'), 'key')`, "Error: error:1E08010C:DECODER routines::unsupported"},
+	} {
+		if _, err := vm.Run(`var crypto = require('crypto');` + tt.src); err == nil {
+			t.Errorf("%v: expected error", tt.src)
+		} else if g, e := module.Wrap(err).Error(), tt.err; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+//
+// otto.module :: encoding.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+func normalizeEncoding(enc string) (string, error) {
+	switch strings.ToLower(enc) {
+	case "", "utf8", "utf-8":
+		return "utf8", nil
+	case "latin1", "binary":
+		return "latin1", nil
+	case "ucs2", "ucs-2", "utf16le", "utf-16le":
+		return "utf16le", nil
+	case "ascii":
+		return "ascii", nil
+	case "hex":
+		return "hex", nil
+	case "base64":
+		return "base64", nil
+	case "base64url":
+		return "base64url", nil
+	}
+	return "", fmt.Errorf("unknown encoding: %v", enc)
+}
+
+func encode(s, enc string) ([]byte, error) {
+	enc, err := normalizeEncoding(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch enc {
+	case "latin1", "ascii":
+		u := utf16.Encode([]rune(s))
+		b := make([]byte, len(u))
+		for i, c := range u {
+			b[i] = byte(c)
+		}
+		return b, nil
+	case "utf16le":
+		u := utf16.Encode([]rune(s))
+		b := make([]byte, len(u)*2)
+		for i, c := range u {
+			b[i*2] = byte(c)
+			b[i*2+1] = byte(c >> 8)
+		}
+		return b, nil
+	case "hex":
+		// ignore an odd trailing character and anything after invalid characters
+		n := 0
+		for n+1 < len(s) && isHex(s[n]) && isHex(s[n+1]) {
+			n += 2
+		}
+		return hex.DecodeString(s[:n])
+	case "base64", "base64url":
+		return decodeBase64(s), nil
+	}
+	return []byte(s), nil
+}
+
+func decode(b []byte, enc string) (string, error) {
+	enc, err := normalizeEncoding(enc)
+	if err != nil {
+		return "", err
+	}
+
+	switch enc {
+	case "latin1":
+		r := make([]rune, len(b))
+		for i, c := range b {
+			r[i] = rune(c)
+		}
+		return string(r), nil
+	case "ascii":
+		r := make([]rune, len(b))
+		for i, c := range b {
+			r[i] = rune(c & 0x7f)
+		}
+		return string(r), nil
+	case "utf16le":
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			u[i] = uint16(b[i*2]) | uint16(b[i*2+1])<<8
+		}
+		return string(utf16.Decode(u)), nil
+	case "hex":
+		return hex.EncodeToString(b), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(b), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(b), nil
+	}
+	if utf8.Valid(b) {
+		return string(b), nil
+	}
+	var sb strings.Builder
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		if r == utf8.RuneError && n == 1 {
+			n = invalidUTF8(b)
+		}
+		sb.WriteRune(r)
+		b = b[n:]
+	}
+	return sb.String(), nil
+}
+
+// returns the length of the maximal subpart of an ill-formed UTF-8 sequence
+func invalidUTF8(b []byte) int {
+	var n int
+	lo, hi := byte(0x80), byte(0xbf)
+	switch c := b[0]; {
+	case 0xc2 <= c && c <= 0xdf:
+		n = 2
+	case c == 0xe0:
+		n, lo = 3, 0xa0
+	case c == 0xed:
+		n, hi = 3, 0x9f
+	case 0xe1 <= c && c <= 0xef:
+		n = 3
+	case c == 0xf0:
+		n, lo = 4, 0x90
+	case c == 0xf4:
+		n, hi = 4, 0x8f
+	case 0xf1 <= c && c <= 0xf3:
+		n = 4
+	default:
+		return 1
+	}
+	i := 1
+	for ; i < n && i < len(b); i++ {
+		if b[i] < lo || hi < b[i] {
+			break
+		}
+		lo, hi = 0x80, 0xbf
+	}
+	return i
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func decodeBase64(s string) []byte {
+	// accept both alphabets, ignore whitespace and stop at padding
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '=':
+			i = len(s)
+		case c == '-':
+			b.WriteByte('+')
+		case c == '_':
+			b.WriteByte('/')
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '+', c == '/':
+			b.WriteByte(c)
+		}
+	}
+	src := b.String()
+	if len(src)%4 == 1 {
+		src = src[:len(src)-1]
+	}
+	buf, _ := base64.RawStdEncoding.DecodeString(src)
+	return buf
+}
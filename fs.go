@@ -0,0 +1,453 @@
+//
+// otto.module :: fs.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/robertkrimen/otto"
+)
+
+var errnos = []struct {
+	errno syscall.Errno
+	code  string
+	desc  string
+}{
+	{syscall.EPERM, "EPERM", "operation not permitted"},
+	{syscall.ENOENT, "ENOENT", "no such file or directory"},
+	{syscall.EBADF, "EBADF", "bad file descriptor"},
+	{syscall.EACCES, "EACCES", "permission denied"},
+	{syscall.EBUSY, "EBUSY", "resource busy or locked"},
+	{syscall.EEXIST, "EEXIST", "file already exists"},
+	{syscall.EXDEV, "EXDEV", "cross-device link not permitted"},
+	{syscall.ENOTDIR, "ENOTDIR", "not a directory"},
+	{syscall.EISDIR, "EISDIR", "illegal operation on a directory"},
+	{syscall.EINVAL, "EINVAL", "invalid argument"},
+	{syscall.EMFILE, "EMFILE", "too many open files"},
+	{syscall.ENOSPC, "ENOSPC", "no space left on device"},
+	{syscall.EROFS, "EROFS", "read-only file system"},
+	{syscall.ELOOP, "ELOOP", "too many symbolic links encountered"},
+	{syscall.ENAMETOOLONG, "ENAMETOOLONG", "name too long"},
+	{syscall.ENOTEMPTY, "ENOTEMPTY", "directory not empty"},
+	{syscall.ENOBUFS, "ENOBUFS", "no buffer space available"},
+	{syscall.ETIMEDOUT, "ETIMEDOUT", "connection timed out"},
+}
+
+func (vm *Otto) throwErrno(op string, err error, path ...string) otto.Value {
+	i := -1
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		for j, e := range errnos {
+			if e.errno == errno {
+				i = j
+				break
+			}
+		}
+	}
+	if i == -1 {
+		for j, e := range errnos {
+			if errors.Is(err, e.errno) {
+				i = j
+				break
+			}
+		}
+		switch {
+		case i != -1:
+		case errors.Is(err, fs.ErrNotExist):
+			i = 1
+		case errors.Is(err, fs.ErrExist):
+			i = 5
+		case errors.Is(err, fs.ErrPermission):
+			i = 3
+		default:
+			return vm.throw(err)
+		}
+	}
+
+	e := errnos[i]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v: %v, %v", e.code, e.desc, op)
+	for j, p := range path {
+		if j > 0 {
+			b.WriteString(" ->")
+		}
+		fmt.Fprintf(&b, " '%v'", p)
+	}
+	v := vm.MakeCustomError("Error", b.String())
+	o := v.Object()
+	o.Set("errno", -int(e.errno))
+	o.Set("code", e.code)
+	o.Set("syscall", op)
+	if len(path) > 0 {
+		o.Set("path", path[0])
+	}
+	if len(path) > 1 {
+		o.Set("dest", path[1])
+	}
+	panic(v)
+}
+
+func (vm *Otto) fs_readFile(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	buffer := !call.Argument(1).IsDefined() || call.Argument(1).IsNull()
+	enc, err := vm.toEncoding(call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	flag := "r"
+	if call.Argument(2).IsDefined() {
+		flag, err = vm.toString("flag", call.Argument(2))
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
+
+	// open the file for writing without data to create, truncate or check
+	// it as the flag requires
+	flags := -1
+	switch flag {
+	case "r", "rs", "sr":
+	case "r+", "rs+", "sr+":
+		flags = os.O_WRONLY
+	case "w+":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	case "wx+", "xw+":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	case "a+", "as+", "sa+":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	case "ax+", "xa+":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND | os.O_EXCL
+	default:
+		return vm.throw(fmt.Errorf("invalid flag: %v", flag))
+	}
+	if flags != -1 {
+		if err := vm.fs.WriteFile(path, nil, flags, 0o666); err != nil {
+			return vm.throwErrno("open", err, path)
+		}
+	}
+	b, err := vm.fs.ReadFile(path)
+	switch {
+	case errors.Is(err, syscall.EISDIR):
+		// a directory can be opened, but cannot be read
+		return vm.throwErrno("read", err)
+	case err != nil:
+		return vm.throwErrno("open", err, path)
+	}
+	if buffer {
+		v, err := vm.NewBuffer(b)
+		if err != nil {
+			return vm.throw(err)
+		}
+		return v
+	}
+	s, err := decode(b, enc)
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, _ := vm.ToValue(s)
+	return v
+}
+
+func (vm *Otto) fs_writeFile(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	data := call.Argument(1)
+	if !vm.isBuffer(data) && !data.IsString() {
+		return vm.throw(fmt.Errorf("data must be a String or Buffer"))
+	}
+	enc, err := vm.toEncoding(call.Argument(2))
+	if err != nil {
+		return vm.throw(err)
+	}
+	flag, err := vm.toString("flag", call.Argument(3))
+	if err != nil {
+		return vm.throw(err)
+	}
+	mode, err := vm.toMode(call.Argument(4), 0o666)
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	var flags int
+	switch flag {
+	case "w":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	case "wx", "xw":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	case "a":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	case "ax", "xa":
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND | os.O_EXCL
+	default:
+		return vm.throw(fmt.Errorf("invalid flag: %v", flag))
+	}
+	b, ok := vm.bufferOf(data)
+	if !ok {
+		s, _ := data.ToString()
+		b, err = encode(s, enc)
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
+
+	if err := vm.fs.WriteFile(path, b, flags, mode); err != nil {
+		return vm.throwErrno("open", err, path)
+	}
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) fs_exists(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return otto.FalseValue()
+	}
+
+	_, err = vm.fs.Stat(path)
+	v, _ := vm.ToValue(err == nil)
+	return v
+}
+
+func (vm *Otto) fs_stat(call otto.FunctionCall) otto.Value {
+	return vm.stat(call, "stat", vm.fs.Stat)
+}
+
+func (vm *Otto) fs_lstat(call otto.FunctionCall) otto.Value {
+	return vm.stat(call, "lstat", vm.fs.Lstat)
+}
+
+func (vm *Otto) stat(call otto.FunctionCall, op string, stat func(string) (fs.FileInfo, error)) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	fi, err := stat(path)
+	if err != nil {
+		return vm.throwErrno(op, err, path)
+	}
+	o, _ := vm.Object(`({})`)
+	for k, v := range statOf(fi) {
+		o.Set(k, v)
+	}
+	return o.Value()
+}
+
+func statOf(fi fs.FileInfo) map[string]any {
+	mtime := float64(fi.ModTime().UnixNano()) / 1e6
+	st := map[string]any{
+		"dev":         0,
+		"ino":         0,
+		"mode":        modeOf(fi.Mode()),
+		"nlink":       1,
+		"uid":         0,
+		"gid":         0,
+		"rdev":        0,
+		"size":        fi.Size(),
+		"blksize":     4096,
+		"blocks":      (fi.Size() + 511) / 512,
+		"atimeMs":     mtime,
+		"mtimeMs":     mtime,
+		"ctimeMs":     mtime,
+		"birthtimeMs": mtime,
+	}
+	sysStat(fi, st)
+	return st
+}
+
+func modeOf(m fs.FileMode) int {
+	mode := int(m.Perm())
+	switch m.Type() {
+	case 0:
+		mode |= 0o100000
+	case fs.ModeDir:
+		mode |= 0o40000
+	case fs.ModeSymlink:
+		mode |= 0o120000
+	case fs.ModeNamedPipe:
+		mode |= 0o10000
+	case fs.ModeSocket:
+		mode |= 0o140000
+	case fs.ModeDevice | fs.ModeCharDevice:
+		mode |= 0o20000
+	case fs.ModeDevice:
+		mode |= 0o60000
+	}
+	if m&fs.ModeSetuid != 0 {
+		mode |= 0o4000
+	}
+	if m&fs.ModeSetgid != 0 {
+		mode |= 0o2000
+	}
+	if m&fs.ModeSticky != 0 {
+		mode |= 0o1000
+	}
+	return mode
+}
+
+func (vm *Otto) fs_readdir(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	list, err := vm.fs.ReadDir(path)
+	if err != nil {
+		return vm.throwErrno("scandir", err, path)
+	}
+	ents := make([]any, len(list))
+	for i, de := range list {
+		ents[i] = []any{de.Name(), modeOf(de.Type())}
+	}
+	v, _ := vm.ToValue(ents)
+	return v
+}
+
+func (vm *Otto) fs_mkdir(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	recursive, _ := call.Argument(1).ToBoolean()
+	mode, err := vm.toMode(call.Argument(2), 0o777)
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	if !recursive {
+		if err := vm.fs.Mkdir(path, mode); err != nil {
+			return vm.throwErrno("mkdir", err, path)
+		}
+		return otto.UndefinedValue()
+	}
+	// find the first directory to be created
+	first := ""
+	for p := filepath.Clean(path); ; {
+		fi, err := vm.fs.Stat(p)
+		if err == nil {
+			if !fi.IsDir() {
+				return vm.throwErrno("mkdir", syscall.ENOTDIR, path)
+			}
+			break
+		}
+		first = p
+		if d := filepath.Dir(p); d != p {
+			p = d
+		} else {
+			break
+		}
+	}
+	if err := MkdirAll(vm.fs, path, mode); err != nil {
+		return vm.throwErrno("mkdir", err, path)
+	}
+	if first == "" {
+		return otto.UndefinedValue()
+	}
+	if abs, err := vm.fs.Abs(first); err == nil {
+		first = abs
+	}
+	v, _ := vm.ToValue(first)
+	return v
+}
+
+func (vm *Otto) fs_rm(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	recursive, _ := call.Argument(1).ToBoolean()
+	force, _ := call.Argument(2).ToBoolean()
+
+	fi, err := vm.fs.Lstat(path)
+	switch {
+	case err != nil:
+		if force && errors.Is(err, fs.ErrNotExist) {
+			return otto.UndefinedValue()
+		}
+		return vm.throwErrno("lstat", err, path)
+	case fi.IsDir():
+		if !recursive {
+			return vm.throwErrno("rm", syscall.EISDIR, path)
+		}
+		err = RemoveAll(vm.fs, path)
+	default:
+		err = vm.fs.Remove(path)
+	}
+	if err != nil {
+		return vm.throwErrno("rm", err, path)
+	}
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) fs_rename(call otto.FunctionCall) otto.Value {
+	oldpath, err := vm.toString("oldPath", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	newpath, err := vm.toString("newPath", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	if err := vm.fs.Rename(oldpath, newpath); err != nil {
+		return vm.throwErrno("rename", err, oldpath, newpath)
+	}
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) fs_realpath(call otto.FunctionCall) otto.Value {
+	path, err := vm.toString("path", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	p, err := vm.fs.Abs(path)
+	if err == nil {
+		p, err = vm.fs.EvalSymlinks(p)
+	}
+	if err != nil {
+		return vm.throwErrno("realpath", err, path)
+	}
+	v, _ := vm.ToValue(p)
+	return v
+}
+
+func (vm *Otto) toEncoding(v otto.Value) (string, error) {
+	if !v.IsDefined() || v.IsNull() {
+		return "utf8", nil
+	}
+	enc, err := vm.toString("encoding", v)
+	if err != nil {
+		return "", err
+	}
+	return normalizeEncoding(enc)
+}
+
+func (vm *Otto) toMode(v otto.Value, mode fs.FileMode) (fs.FileMode, error) {
+	if !v.IsDefined() {
+		return mode, nil
+	} else if !v.IsNumber() {
+		return 0, fmt.Errorf("mode must be a Number")
+	}
+	i, err := v.ToInteger()
+	if err != nil {
+		return 0, err
+	}
+	return fs.FileMode(i) & fs.ModePerm, nil
+}
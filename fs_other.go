@@ -0,0 +1,16 @@
+//
+// otto.module :: fs_other.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+//go:build !unix
+
+package module
+
+import "io/fs"
+
+func sysStat(fs.FileInfo, map[string]any) {
+}
@@ -0,0 +1,224 @@
+//
+// otto.module :: fs_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestFS(t *testing.T) {
+	dir := t.TempDir()
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("dir", filepath.ToSlash(dir))
+
+	src := `
+		var fs = require('fs');
+		var r = [];
+		r.push(fs.realpathSync(fs.mkdirSync(dir + '/a/b', { recursive: true })) === fs.realpathSync(dir + '/a'));
+		r.push(fs.mkdirSync(dir + '/a/b', { recursive: true }) === undefined);
+		fs.writeFileSync(dir + '/a/file', 'ab');
+		fs.appendFileSync(dir + '/a/file', 'c');
+		r.push(fs.readFileSync(dir + '/a/file', 'utf8'));
+		r.push(fs.readFileSync(dir + '/a/file', { encoding: 'hex' }));
+		fs.writeFileSync(dir + '/a/file', '6465', 'hex');
+		r.push(fs.readFileSync(dir + '/a/file', 'latin1'));
+		fs.appendFileSync(dir + '/a/file', Buffer.from([0x66]));
+		r.push(Buffer.isBuffer(fs.readFileSync(dir + '/a/file')), fs.readFileSync(dir + '/a/file').toString());
+		r.push(fs.existsSync(dir + '/a/file'), fs.existsSync(dir + '/_'), fs.existsSync());
+		var st = fs.statSync(dir + '/a/file');
+		r.push(st instanceof fs.Stats, st.isFile(), st.isDirectory(), st.size, st.mtime instanceof Date);
+		r.push(fs.statSync(dir + '/_', { throwIfNoEntry: false }));
+		r.push(fs.readdirSync(dir + '/a').join('|'));
+		r.push(fs.readdirSync(dir + '/a', { withFileTypes: true }).map(function(d) {
+			return d.name + ':' + d.isDirectory();
+		}).join('|'));
+		fs.renameSync(dir + '/a/file', dir + '/a/b/file');
+		r.push(fs.readdirSync(dir + '/a/b').join('|'));
+		fs.rmSync(dir + '/a/b/file');
+		fs.rmSync(dir + '/a', { recursive: true });
+		fs.rmSync(dir + '/a', { force: true });
+		r.push(fs.existsSync(dir + '/a'));
+		r.join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "true,true,abc,616263,de,true,def,true,false,false,true,true,false,3,true,,b|file,b:true|file:false,file,false"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestFS_Options(t *testing.T) {
+	dir := t.TempDir()
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("dir", filepath.ToSlash(dir))
+
+	src := `
+		var fs = require('fs');
+		var r = [];
+		r.push(JSON.stringify(fs.readFileSync(dir + '/file', { encoding: 'utf8', flag: 'a+' })));
+		fs.writeFileSync(dir + '/file', 'ab');
+		r.push(fs.readFileSync(dir + '/file', { encoding: 'utf8', flag: 'r+' }));
+		r.push(Buffer.isBuffer(fs.readFileSync(dir + '/file', 'buffer')));
+		try {
+			fs.readFileSync(dir + '/file', { flag: 'wx+' });
+		} catch (e) {
+			r.push(e.code);
+		}
+		r.push(JSON.stringify(fs.readFileSync(dir + '/file', { encoding: 'utf8', flag: 'w+' })));
+		var list = fs.readdirSync(dir, 'buffer');
+		r.push(Buffer.isBuffer(list[0]), list[0].toString());
+		r.push(fs.readdirSync(dir, { encoding: 'hex' }).join('|'));
+		r.push(Buffer.isBuffer(fs.readdirSync(dir, { encoding: 'buffer', withFileTypes: true })[0].name));
+		r.join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), `"",ab,true,EEXIST,"",true,file,66696c65,true`; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, tt := range []struct {
+		src, msg string
+	}{
+		{`fs.readFileSync(dir + '/file', { flag: 'w' });`, `The argument 'flags' is invalid. Received 'w'`},
+		{`fs.readFileSync(dir + '/file', 'utf7');`, `The argument 'encoding' is invalid. Received 'utf7'`},
+		{`fs.readdirSync(dir, 'utf7');`, `The argument 'encoding' is invalid. Received 'utf7'`},
+	} {
+		src := `
+			try {
+				` + tt.src + `
+			} catch (e) {
+				[e.name, e.code, e.message].join();
+			}
+		`
+		if v, err := vm.Run(src); err != nil {
+			t.Error(module.Wrap(err))
+		} else if g, e := v.String(), "TypeError,ERR_INVALID_ARG_VALUE,"+tt.msg; g != e {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+}
+
+var fsErrorTests = []struct {
+	src     string
+	code    string
+	syscall string
+	msg     string
+}{
+	{
+		src:     `fs.readFileSync(dir + '/_');`,
+		code:    "ENOENT",
+		syscall: "open",
+		msg:     "ENOENT: no such file or directory, open '%v/_'",
+	},
+	{
+		src:     `fs.readFileSync(dir);`,
+		code:    "EISDIR",
+		syscall: "read",
+		msg:     "EISDIR: illegal operation on a directory, read",
+	},
+	{
+		src:     `fs.writeFileSync(dir + '/file', '', { flag: 'wx' });`,
+		code:    "EEXIST",
+		syscall: "open",
+		msg:     "EEXIST: file already exists, open '%v/file'",
+	},
+	{
+		src:     `fs.mkdirSync(dir);`,
+		code:    "EEXIST",
+		syscall: "mkdir",
+		msg:     "EEXIST: file already exists, mkdir '%v'",
+	},
+	{
+		src:     `fs.statSync(dir + '/_');`,
+		code:    "ENOENT",
+		syscall: "stat",
+		msg:     "ENOENT: no such file or directory, stat '%v/_'",
+	},
+	{
+		src:     `fs.readdirSync(dir + '/file');`,
+		code:    "ENOTDIR",
+		syscall: "scandir",
+		msg:     "ENOTDIR: not a directory, scandir '%v/file'",
+	},
+	{
+		src:     `fs.rmSync(dir);`,
+		code:    "EISDIR",
+		syscall: "rm",
+		msg:     "EISDIR: illegal operation on a directory, rm '%v'",
+	},
+	{
+		src:     `fs.rmSync(dir + '/_');`,
+		code:    "ENOENT",
+		syscall: "lstat",
+		msg:     "ENOENT: no such file or directory, lstat '%v/_'",
+	},
+	{
+		src:     `fs.renameSync(dir + '/_', dir + '/file');`,
+		code:    "ENOENT",
+		syscall: "rename",
+		msg:     "ENOENT: no such file or directory, rename '%[1]v/_' -> '%[1]v/file'",
+	},
+}
+
+func TestFSError(t *testing.T) {
+	dir := filepath.ToSlash(t.TempDir())
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("dir", dir)
+	if _, err := vm.Run(`var fs = require('fs');`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range fsErrorTests {
+		if tt.code == "ENOTDIR" && os.PathSeparator == '\\' {
+			continue
+		}
+		src := fmt.Sprintf(`try { %v } catch (e) { [e instanceof Error, e.code, e.syscall, typeof e.errno, e.message].join('\n'); }`, tt.src)
+		msg := tt.msg
+		if strings.Contains(msg, "%") {
+			msg = fmt.Sprintf(msg, dir)
+		}
+		if v, err := vm.Run(src); err != nil {
+			t.Error(module.Wrap(err))
+		} else if g, e := v.String(), strings.Join([]string{"true", tt.code, tt.syscall, "number", msg}, "\n"); g != e {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+
+	for _, src := range []string{
+		`fs.readFileSync();`,
+		`fs.readFileSync(dir + '/file', 'utf7');`,
+		`fs.writeFileSync(dir + '/file', '', { flag: 'r' });`,
+		`fs.mkdirSync(dir + '/_', { mode: '0777' });`,
+		`fs.renameSync(dir);`,
+		`fs.readFileSync(dir + '/file', 0);`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", strings.Trim(src, ";"))
+		}
+	}
+}
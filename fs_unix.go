@@ -0,0 +1,31 @@
+//
+// otto.module :: fs_unix.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+//go:build unix
+
+package module
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+func sysStat(fi fs.FileInfo, st map[string]any) {
+	s, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	st["dev"] = int64(s.Dev)
+	st["ino"] = int64(s.Ino)
+	st["nlink"] = int64(s.Nlink)
+	st["uid"] = int64(s.Uid)
+	st["gid"] = int64(s.Gid)
+	st["rdev"] = int64(s.Rdev)
+	st["blksize"] = int64(s.Blksize)
+	st["blocks"] = int64(s.Blocks)
+}
@@ -0,0 +1,660 @@
+//
+// otto.module :: fsys.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type FS interface {
+	Abs(name string) (string, error)
+	EvalSymlinks(name string) (string, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, flag int, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Mkdir(name string, perm fs.FileMode) error
+	Remove(name string) error
+	Rename(oldpath, newpath string) error
+}
+
+func WithFS(fsys FS) Option {
+	return func(vm *Otto) {
+		vm.fs = fsys
+	}
+}
+
+func WriteFile(fsys FS, name string, data []byte, perm fs.FileMode) error {
+	return fsys.WriteFile(name, data, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+}
+
+func MkdirAll(fsys FS, name string, perm fs.FileMode) error {
+	switch fi, err := fsys.Stat(name); {
+	case err == nil:
+		if !fi.IsDir() {
+			return &fs.PathError{
+				Op:   "mkdir",
+				Path: name,
+				Err:  syscall.ENOTDIR,
+			}
+		}
+		return nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	if dir := filepath.Dir(name); dir != name {
+		if err := MkdirAll(fsys, dir, perm); err != nil {
+			return err
+		}
+	}
+	if err := fsys.Mkdir(name, perm); err != nil {
+		// lost a race
+		if fi, err1 := fsys.Lstat(name); err1 == nil && fi.IsDir() {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func RemoveAll(fsys FS, name string) error {
+	fi, err := fsys.Lstat(name)
+	switch {
+	case err != nil:
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	case fi.IsDir():
+		list, err := fsys.ReadDir(name)
+		if err != nil {
+			return err
+		}
+		for _, de := range list {
+			if err := RemoveAll(fsys, filepath.Join(name, de.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return fsys.Remove(name)
+}
+
+type OSFS struct{}
+
+func (OSFS) Abs(name string) (string, error) {
+	return filepath.Abs(name)
+}
+
+func (OSFS) EvalSymlinks(name string) (string, error) {
+	return filepath.EvalSymlinks(name)
+}
+
+func (OSFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (OSFS) WriteFile(name string, data []byte, flag int, perm fs.FileMode) error {
+	f, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return err
+	}
+	return writeAndClose(f, data)
+}
+
+func (OSFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (OSFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (OSFS) Mkdir(name string, perm fs.FileMode) error {
+	return os.Mkdir(name, perm)
+}
+
+func (OSFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (OSFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+func writeAndClose(w io.WriteCloser, data []byte) error {
+	_, err := w.Write(data)
+	if err1 := w.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+type DirFS struct {
+	dir  string
+	root *os.Root
+}
+
+func NewDirFS(dir string) (*DirFS, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &DirFS{
+		dir:  dir,
+		root: root,
+	}, nil
+}
+
+func (d *DirFS) Close() error {
+	return d.root.Close()
+}
+
+func (d *DirFS) rel(name string) string {
+	if p := vpath(name); p != "/" {
+		return filepath.FromSlash(p[1:])
+	}
+	return "."
+}
+
+func (d *DirFS) error(op, name string, err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		return &fs.PathError{
+			Op:   op,
+			Path: name,
+			Err:  pe.Err,
+		}
+	}
+	return err
+}
+
+func (d *DirFS) Abs(name string) (string, error) {
+	return vpath(name), nil
+}
+
+func (d *DirFS) EvalSymlinks(name string) (string, error) {
+	p, err := d.realpath(name)
+	if err != nil {
+		return "", d.error("realpath", name, err)
+	}
+	return p, nil
+}
+
+func (d *DirFS) realpath(name string) (string, error) {
+	p, err := filepath.EvalSymlinks(filepath.Join(d.dir, d.rel(name)))
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(d.dir, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &fs.PathError{
+			Op:   "realpath",
+			Path: name,
+			Err:  syscall.EACCES,
+		}
+	}
+	return vpath(rel), nil
+}
+
+func (d *DirFS) ReadFile(name string) ([]byte, error) {
+	f, err := d.root.Open(d.rel(name))
+	if err != nil {
+		return nil, d.error("open", name, err)
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, d.error("read", name, err)
+	}
+	return b, nil
+}
+
+func (d *DirFS) WriteFile(name string, data []byte, flag int, perm fs.FileMode) error {
+	f, err := d.root.OpenFile(d.rel(name), flag, perm)
+	if err != nil {
+		return d.error("open", name, err)
+	}
+	return d.error("write", name, writeAndClose(f, data))
+}
+
+func (d *DirFS) Stat(name string) (fs.FileInfo, error) {
+	fi, err := d.root.Stat(d.rel(name))
+	if err != nil {
+		return nil, d.error("stat", name, err)
+	}
+	return fi, nil
+}
+
+func (d *DirFS) Lstat(name string) (fs.FileInfo, error) {
+	fi, err := d.root.Lstat(d.rel(name))
+	if err != nil {
+		return nil, d.error("lstat", name, err)
+	}
+	return fi, nil
+}
+
+func (d *DirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f, err := d.root.Open(d.rel(name))
+	if err != nil {
+		return nil, d.error("open", name, err)
+	}
+	defer f.Close()
+
+	list, err := f.ReadDir(-1)
+	if err != nil {
+		return nil, d.error("readdir", name, err)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list, nil
+}
+
+func (d *DirFS) Mkdir(name string, perm fs.FileMode) error {
+	return d.error("mkdir", name, d.root.Mkdir(d.rel(name), perm))
+}
+
+func (d *DirFS) Remove(name string) error {
+	return d.error("remove", name, d.root.Remove(d.rel(name)))
+}
+
+func (d *DirFS) Rename(oldpath, newpath string) error {
+	lerr := func(err error) error {
+		return &os.LinkError{
+			Op:  "rename",
+			Old: oldpath,
+			New: newpath,
+			Err: underlyingError(err),
+		}
+	}
+	// resolve parent directories within the tree, and rename the last
+	// elements without following them
+	var paths [2]string
+	for i, name := range []string{oldpath, newpath} {
+		p := vpath(name)
+		if p == "/" {
+			return lerr(syscall.EBUSY)
+		}
+		dir, err := d.realpath(path.Dir(p))
+		if err != nil {
+			return lerr(err)
+		}
+		paths[i] = filepath.Join(d.dir, d.rel(dir), path.Base(p))
+	}
+	if err := os.Rename(paths[0], paths[1]); err != nil {
+		return lerr(err)
+	}
+	return nil
+}
+
+type MemFS struct {
+	mu   sync.RWMutex
+	root *memFile
+}
+
+func NewMemFS() *MemFS {
+	return &MemFS{
+		root: &memFile{
+			name:    "/",
+			mode:    fs.ModeDir | 0o777,
+			modTime: time.Now(),
+			files:   make(map[string]*memFile),
+		},
+	}
+}
+
+func (m *MemFS) lookup(op, name string) (*memFile, error) {
+	f := m.root
+	p := vpath(name)
+	if p == "/" {
+		return f, nil
+	}
+	for _, s := range strings.Split(p[1:], "/") {
+		if !f.IsDir() {
+			return nil, &fs.PathError{
+				Op:   op,
+				Path: name,
+				Err:  syscall.ENOTDIR,
+			}
+		}
+		c, ok := f.files[s]
+		if !ok {
+			return nil, &fs.PathError{
+				Op:   op,
+				Path: name,
+				Err:  syscall.ENOENT,
+			}
+		}
+		f = c
+	}
+	return f, nil
+}
+
+func (m *MemFS) parent(op, name string) (*memFile, string, error) {
+	p := vpath(name)
+	if p == "/" {
+		return nil, "", &fs.PathError{
+			Op:   op,
+			Path: name,
+			Err:  syscall.EBUSY,
+		}
+	}
+	dir, err := m.lookup(op, path.Dir(p))
+	if err != nil {
+		return nil, "", err
+	} else if !dir.IsDir() {
+		return nil, "", &fs.PathError{
+			Op:   op,
+			Path: name,
+			Err:  syscall.ENOTDIR,
+		}
+	}
+	return dir, path.Base(p), nil
+}
+
+func (m *MemFS) Abs(name string) (string, error) {
+	return vpath(name), nil
+}
+
+func (m *MemFS) EvalSymlinks(name string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, err := m.lookup("realpath", name); err != nil {
+		return "", err
+	}
+	return vpath(name), nil
+}
+
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, err := m.lookup("open", name)
+	switch {
+	case err != nil:
+		return nil, err
+	case f.IsDir():
+		return nil, &fs.PathError{
+			Op:   "read",
+			Path: name,
+			Err:  syscall.EISDIR,
+		}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, flag int, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir, base, err := m.parent("open", name)
+	if err != nil {
+		return err
+	}
+	f, ok := dir.files[base]
+	switch {
+	case !ok:
+		if flag&os.O_CREATE == 0 {
+			return &fs.PathError{
+				Op:   "open",
+				Path: name,
+				Err:  syscall.ENOENT,
+			}
+		}
+		f = &memFile{
+			name: base,
+			mode: perm & fs.ModePerm,
+		}
+		dir.files[base] = f
+		dir.modTime = time.Now()
+	case flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL:
+		return &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  syscall.EEXIST,
+		}
+	case f.IsDir():
+		return &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  syscall.EISDIR,
+		}
+	}
+	switch {
+	case flag&os.O_APPEND != 0:
+		f.data = append(f.data, data...)
+	case flag&os.O_TRUNC != 0:
+		f.data = append([]byte(nil), data...)
+	default:
+		if len(data) > len(f.data) {
+			f.data = append(f.data[:0:0], data...)
+		} else {
+			f.data = append(append([]byte(nil), data...), f.data[len(data):]...)
+		}
+	}
+	f.modTime = time.Now()
+	return nil
+}
+
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	return m.stat("stat", name)
+}
+
+func (m *MemFS) Lstat(name string) (fs.FileInfo, error) {
+	return m.stat("lstat", name)
+}
+
+func (m *MemFS) stat(op, name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, err := m.lookup(op, name)
+	if err != nil {
+		return nil, err
+	}
+	return f.info(), nil
+}
+
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, err := m.lookup("open", name)
+	switch {
+	case err != nil:
+		return nil, err
+	case !f.IsDir():
+		return nil, &fs.PathError{
+			Op:   "readdir",
+			Path: name,
+			Err:  syscall.ENOTDIR,
+		}
+	}
+	list := make([]fs.DirEntry, 0, len(f.files))
+	for _, c := range f.files {
+		list = append(list, fs.FileInfoToDirEntry(c.info()))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list, nil
+}
+
+func (m *MemFS) Mkdir(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir, base, err := m.parent("mkdir", name)
+	if err != nil {
+		if vpath(name) == "/" {
+			err.(*fs.PathError).Err = syscall.EEXIST
+		}
+		return err
+	} else if _, ok := dir.files[base]; ok {
+		return &fs.PathError{
+			Op:   "mkdir",
+			Path: name,
+			Err:  syscall.EEXIST,
+		}
+	}
+	now := time.Now()
+	dir.files[base] = &memFile{
+		name:    base,
+		mode:    fs.ModeDir | perm&fs.ModePerm,
+		modTime: now,
+		files:   make(map[string]*memFile),
+	}
+	dir.modTime = now
+	return nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir, base, err := m.parent("remove", name)
+	if err != nil {
+		return err
+	}
+	f, ok := dir.files[base]
+	switch {
+	case !ok:
+		return &fs.PathError{
+			Op:   "remove",
+			Path: name,
+			Err:  syscall.ENOENT,
+		}
+	case f.IsDir() && len(f.files) > 0:
+		return &fs.PathError{
+			Op:   "remove",
+			Path: name,
+			Err:  syscall.ENOTEMPTY,
+		}
+	}
+	delete(dir.files, base)
+	dir.modTime = time.Now()
+	return nil
+}
+
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lerr := func(err error) error {
+		return &os.LinkError{
+			Op:  "rename",
+			Old: oldpath,
+			New: newpath,
+			Err: underlyingError(err),
+		}
+	}
+	odir, obase, err := m.parent("rename", oldpath)
+	if err != nil {
+		return lerr(err)
+	}
+	f, ok := odir.files[obase]
+	if !ok {
+		return lerr(syscall.ENOENT)
+	}
+	ndir, nbase, err := m.parent("rename", newpath)
+	if err != nil {
+		return lerr(err)
+	}
+	if op, np := vpath(oldpath), vpath(newpath); op == np {
+		return nil
+	} else if f.IsDir() && strings.HasPrefix(np, op+"/") {
+		return lerr(syscall.EINVAL)
+	}
+	if t, ok := ndir.files[nbase]; ok {
+		switch {
+		case t.IsDir() && !f.IsDir():
+			return lerr(syscall.EISDIR)
+		case !t.IsDir() && f.IsDir():
+			return lerr(syscall.ENOTDIR)
+		case t.IsDir() && len(t.files) > 0:
+			return lerr(syscall.ENOTEMPTY)
+		}
+	}
+	delete(odir.files, obase)
+	f.name = nbase
+	ndir.files[nbase] = f
+	now := time.Now()
+	odir.modTime = now
+	ndir.modTime = now
+	return nil
+}
+
+type memFile struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	data    []byte
+	files   map[string]*memFile
+}
+
+func (f *memFile) info() fs.FileInfo {
+	return &memFileInfo{
+		name:    f.name,
+		size:    int64(len(f.data)),
+		mode:    f.mode,
+		modTime: f.modTime,
+	}
+}
+
+func (f *memFile) IsDir() bool { return f.mode.IsDir() }
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi *memFileInfo) Name() string       { return fi.name }
+func (fi *memFileInfo) Size() int64        { return fi.size }
+func (fi *memFileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *memFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *memFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *memFileInfo) Sys() any           { return nil }
+
+func underlyingError(err error) error {
+	switch e := err.(type) {
+	case *fs.PathError:
+		return e.Err
+	case *os.LinkError:
+		return e.Err
+	}
+	return err
+}
+
+func vpath(name string) string {
+	return path.Join("/", filepath.ToSlash(name))
+}
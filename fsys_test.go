@@ -0,0 +1,230 @@
+//
+// otto.module :: fsys_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestMemFS(t *testing.T) {
+	fsys := module.NewMemFS()
+	testFS(t, fsys, "/")
+
+	for _, tt := range []struct {
+		fn    func() error
+		errno syscall.Errno
+	}{
+		{func() error { return fsys.Mkdir("/", 0o777) }, syscall.EEXIST},
+		{func() error { return fsys.Remove("/") }, syscall.EBUSY},
+		{func() error { return fsys.Rename("/a", "/a/b") }, syscall.EINVAL},
+	} {
+		if err := module.MkdirAll(fsys, "/a", 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := tt.fn(); !errors.Is(err, tt.errno) {
+			t.Errorf("expected %v, got %v", tt.errno, err)
+		}
+	}
+}
+
+func TestDirFS(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	if err := os.Mkdir(root, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret"), []byte("secret"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := module.NewDirFS(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	testFS(t, fsys, "/")
+
+	// confined
+	if b, err := fsys.ReadFile("../secret"); err == nil {
+		t.Errorf("expected error, got %q", b)
+	}
+	if err := module.WriteFile(fsys, "../../file", []byte("file"), 0o666); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "file")); err != nil {
+		t.Error(err)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink(filepath.Join(dir, "secret"), filepath.Join(root, "link")); err != nil {
+			t.Fatal(err)
+		}
+		if b, err := fsys.ReadFile("/link"); err == nil {
+			t.Errorf("expected error, got %q", b)
+		}
+		if p, err := fsys.EvalSymlinks("/link"); err == nil {
+			t.Errorf("expected error, got %q", p)
+		}
+		if err := os.Symlink(dir, filepath.Join(root, "dir")); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.Rename("/dir/secret", "/secret"); err == nil {
+			t.Error("expected error")
+		}
+	}
+}
+
+func TestOSFS(t *testing.T) {
+	testFS(t, module.OSFS{}, t.TempDir())
+}
+
+func testFS(t *testing.T, fsys module.FS, root string) {
+	t.Helper()
+
+	join := func(elem ...string) string {
+		return filepath.Join(append([]string{root}, elem...)...)
+	}
+	if err := module.MkdirAll(fsys, join("a", "b"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := module.MkdirAll(fsys, join("a", "b"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := module.WriteFile(fsys, join("a", "file"), []byte("ab"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.WriteFile(join("a", "file"), []byte("c"), os.O_WRONLY|os.O_APPEND, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := fsys.ReadFile(join("a", "file")); err != nil {
+		t.Error(err)
+	} else if g, e := string(b), "abc"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if fi, err := fsys.Stat(join("a", "file")); err != nil {
+		t.Error(err)
+	} else if fi.IsDir() || fi.Size() != 3 || fi.Name() != "file" {
+		t.Errorf("unexpected FileInfo: %v %v %v", fi.Name(), fi.IsDir(), fi.Size())
+	}
+	if fi, err := fsys.Lstat(join("a", "b")); err != nil {
+		t.Error(err)
+	} else if !fi.IsDir() {
+		t.Errorf("%v is not a directory", fi.Name())
+	}
+	if list, err := fsys.ReadDir(join("a")); err != nil {
+		t.Error(err)
+	} else {
+		var names []string
+		for _, de := range list {
+			names = append(names, de.Name())
+		}
+		if g, e := strings.Join(names, ","), "b,file"; g != e {
+			t.Errorf("expected %q, got %q", e, g)
+		}
+	}
+	if err := fsys.Rename(join("a", "file"), join("a", "b", "file")); err != nil {
+		t.Error(err)
+	}
+	if p, err := fsys.EvalSymlinks(join("a", "b", "file")); err != nil {
+		t.Error(err)
+	} else if g, e := filepath.Base(p), "file"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if p, err := fsys.Abs(join("a", ".", "b")); err != nil {
+		t.Error(err)
+	} else if !strings.HasSuffix(filepath.ToSlash(p), "/a/b") {
+		t.Errorf("unexpected path: %v", p)
+	}
+
+	for _, tt := range []struct {
+		fn    func() error
+		errno syscall.Errno
+	}{
+		{func() error { _, err := fsys.ReadFile(join("_")); return err }, syscall.ENOENT},
+		{func() error { _, err := fsys.Stat(join("_")); return err }, syscall.ENOENT},
+		{func() error { _, err := fsys.ReadDir(join("_")); return err }, syscall.ENOENT},
+		{func() error { return fsys.WriteFile(join("_", "file"), nil, os.O_WRONLY|os.O_CREATE, 0o666) }, syscall.ENOENT},
+		{func() error {
+			return fsys.WriteFile(join("a", "b", "file"), nil, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+		}, syscall.EEXIST},
+		{func() error { return fsys.Mkdir(join("a"), 0o777) }, syscall.EEXIST},
+		{func() error { return fsys.Remove(join("a")) }, syscall.ENOTEMPTY},
+		{func() error { return fsys.Remove(join("_")) }, syscall.ENOENT},
+		{func() error { return fsys.Rename(join("_"), join("a")) }, syscall.ENOENT},
+	} {
+		if err := tt.fn(); !errors.Is(err, tt.errno) {
+			if runtime.GOOS == "windows" && tt.errno == syscall.ENOTEMPTY {
+				continue
+			}
+			t.Errorf("expected %v, got %v", tt.errno, err)
+		}
+	}
+
+	if err := module.RemoveAll(fsys, join("a")); err != nil {
+		t.Error(err)
+	}
+	if _, err := fsys.Stat(join("a")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+	if err := module.RemoveAll(fsys, join("a")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithFS(t *testing.T) {
+	fsys := module.NewMemFS()
+	for _, name := range []string{"/lib", "/node_modules/pkg"} {
+		if err := module.MkdirAll(fsys, name, 0o777); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, data := range map[string]string{
+		"/lib/a.js":                  `module.exports = require('pkg') + require('./b').b;`,
+		"/lib/b.json":                `{"b": "b"}`,
+		"/node_modules/pkg/index.js": `module.exports = 'a';`,
+	} {
+		if err := module.WriteFile(fsys, name, []byte(data), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vm, err := module.New(module.WithFS(fsys))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	file := new(module.FileLoader)
+	folder := &module.FolderLoader{File: file}
+	vm.Register(file)
+	vm.Register(folder)
+	vm.Register(&module.NodeModulesLoader{
+		File:   file,
+		Folder: folder,
+	})
+
+	src := `
+		var fs = require('fs');
+		fs.writeFileSync('/lib/c.txt', 'c');
+		[require('/lib/a'), fs.readFileSync('/lib/c.txt', 'utf8'), fs.readdirSync('/lib').join('|'), fs.realpathSync('lib/../lib/a.js')].join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "ab,c,a.js|b.json|c.txt,/lib/a.js"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if _, err := os.Stat("/lib/c.txt"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,321 @@
+//
+// otto.module :: handler.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/robertkrimen/otto"
+)
+
+func WithHandlerErrorFunc(fn func(*HandlerError)) Option {
+	return func(vm *Otto) {
+		vm.handlerErrorFunc = fn
+	}
+}
+
+type HandlerError struct {
+	Method  string
+	URL     string
+	Name    string
+	Message string
+	Code    string
+	Stack   string
+	Err     error
+}
+
+func (e *HandlerError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %v: ", e.Method, e.URL)
+	if e.Code != "" {
+		fmt.Fprintf(&b, "[%v] ", e.Code)
+	}
+	if e.Name != "" {
+		fmt.Fprintf(&b, "%v: ", e.Name)
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Handler returns an http.Handler which calls the function exported by the
+// module id on the event loop, so RunLoop must be running to serve requests.
+// RunLoop does not return until the Handler is closed.
+func (vm *Otto) Handler(id string) (*Handler, error) {
+	fn, err := vm.Call("require", nil, id)
+	if err != nil {
+		return nil, err
+	} else if !fn.IsFunction() {
+		return nil, fmt.Errorf("%v: module.exports must be a Function", id)
+	}
+	call, err := vm.Otto.Run(`(function(fn, req) {
+		try {
+			return { value: fn(req) };
+		} catch (e) {
+			return { error: e instanceof Error ? e : new Error(String(e)) };
+		}
+	})`)
+	if err != nil {
+		return nil, err
+	}
+	// requests are dispatched to the event loop, so keep it alive
+	vm.Ref()
+	return &Handler{
+		vm:   vm,
+		fn:   fn,
+		call: call,
+	}, nil
+}
+
+type Handler struct {
+	vm     *Otto
+	fn     otto.Value
+	call   otto.Value
+	closed atomic.Bool
+}
+
+// Close releases the event loop. The requests after Close are responded with
+// 503 Service Unavailable.
+func (h *Handler) Close() error {
+	if h.closed.CompareAndSwap(false, true) {
+		h.vm.Unref()
+	}
+	return nil
+}
+
+type handlerResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.closed.Load() {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	type result struct {
+		resp *handlerResponse
+		err  *HandlerError
+	}
+	ch := make(chan result, 1)
+	h.vm.Ref()
+	h.vm.Enqueue(func() error {
+		h.vm.Unref()
+		if r.Context().Err() != nil {
+			// the client has gone away
+			return nil
+		}
+		resp, err := h.serve(r, body)
+		ch <- result{resp, err}
+		return nil
+	})
+	var res result
+	select {
+	case res = <-ch:
+	case <-r.Context().Done():
+		return
+	}
+	if res.err != nil {
+		h.error(w, r, res.err)
+		return
+	}
+	for k, v := range res.resp.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(res.resp.body)))
+	w.WriteHeader(res.resp.status)
+	w.Write(res.resp.body)
+}
+
+func (h *Handler) serve(r *http.Request, body []byte) (resp *handlerResponse, he *HandlerError) {
+	defer func() {
+		if e := recover(); e != nil {
+			he = &HandlerError{
+				Name:    "panic",
+				Message: fmt.Sprint(e),
+				Stack:   string(debug.Stack()),
+			}
+		}
+	}()
+
+	req, err := h.request(r, body)
+	if err != nil {
+		return nil, &HandlerError{Err: err}
+	}
+	v, err := h.vm.MakeCallback(h.call, otto.UndefinedValue(), h.fn, req)
+	if err != nil {
+		return nil, &HandlerError{Err: err}
+	}
+	if e, _ := v.Object().Get("error"); e.IsObject() {
+		return nil, h.vm.handlerError(e.Object())
+	}
+	v, _ = v.Object().Get("value")
+	resp, err = h.response(v)
+	if err != nil {
+		return nil, &HandlerError{Err: err}
+	}
+	return resp, nil
+}
+
+func (h *Handler) request(r *http.Request, body []byte) (otto.Value, error) {
+	vm := h.vm
+	o, _ := vm.Object(`({})`)
+	o.Set("method", r.Method)
+	o.Set("url", r.RequestURI)
+	o.Set("path", r.URL.Path)
+	query, _ := vm.Object(`({})`)
+	for k, v := range r.URL.Query() {
+		if len(v) == 1 {
+			query.Set(k, v[0])
+		} else {
+			query.Set(k, v)
+		}
+	}
+	o.Set("query", query)
+	headers, _ := vm.Object(`({})`)
+	headers.Set("host", r.Host)
+	for k, v := range r.Header {
+		k = strings.ToLower(k)
+		if k == "cookie" {
+			headers.Set(k, strings.Join(v, "; "))
+		} else {
+			headers.Set(k, strings.Join(v, ", "))
+		}
+	}
+	o.Set("headers", headers)
+	b, err := vm.NewBuffer(body)
+	if err != nil {
+		return otto.UndefinedValue(), err
+	}
+	o.Set("body", b)
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		o.Set("remoteAddress", host)
+	}
+	return o.Value(), nil
+}
+
+func (h *Handler) response(v otto.Value) (*handlerResponse, error) {
+	if !v.IsObject() {
+		return nil, errors.New("handler must return an Object")
+	}
+	o := v.Object()
+
+	resp := &handlerResponse{
+		status: http.StatusOK,
+		header: make(http.Header),
+	}
+	if v, _ := o.Get("status"); v.IsDefined() {
+		n, err := v.ToInteger()
+		if err != nil || !v.IsNumber() || n < 100 || 999 < n {
+			return nil, fmt.Errorf("invalid status code: %v", v)
+		}
+		resp.status = int(n)
+	}
+	if v, _ := o.Get("headers"); v.IsObject() {
+		headers := v.Object()
+		for _, k := range headers.Keys() {
+			v, _ := headers.Get(k)
+			if v.Class() == "Array" {
+				a := v.Object()
+				for _, i := range a.Keys() {
+					e, _ := a.Get(i)
+					resp.header.Add(k, e.String())
+				}
+			} else {
+				resp.header.Set(k, v.String())
+			}
+		}
+	}
+	switch v, _ := o.Get("body"); {
+	case !v.IsDefined() || v.IsNull():
+	case v.IsString():
+		resp.body = []byte(v.String())
+	case v.IsObject():
+		if b, ok := h.vm.bufferOf(v); ok {
+			resp.body = b
+			break
+		}
+		s, err := h.vm.Call("JSON.stringify", nil, v)
+		if err != nil {
+			return nil, err
+		}
+		resp.body = []byte(s.String())
+		if resp.header.Get("Content-Type") == "" {
+			resp.header.Set("Content-Type", "application/json")
+		}
+	default:
+		resp.body = []byte(v.String())
+	}
+	return resp, nil
+}
+
+func (h *Handler) error(w http.ResponseWriter, r *http.Request, e *HandlerError) {
+	e.Method = r.Method
+	e.URL = r.RequestURI
+	if e.Err != nil && e.Message == "" {
+		e.Message = Wrap(e.Err).Error()
+	}
+	for k := range w.Header() {
+		delete(w.Header(), k)
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+	switch {
+	case h.vm.handlerErrorFunc != nil:
+		h.vm.handlerErrorFunc(e)
+	case h.vm.logger != nil:
+		h.vm.logger.LogAttrs(context.Background(), slog.LevelError, e.Message,
+			slog.String("method", e.Method),
+			slog.String("url", e.URL),
+			slog.String("name", e.Name),
+			slog.String("code", e.Code),
+			slog.String("stack", e.Stack),
+		)
+	default:
+		fmt.Fprintln(h.vm.stderr, e)
+	}
+}
+
+func (vm *Otto) handlerError(o *otto.Object) *HandlerError {
+	e := new(HandlerError)
+	for _, p := range []struct {
+		name string
+		dst  *string
+	}{
+		{"name", &e.Name},
+		{"message", &e.Message},
+		{"code", &e.Code},
+		{"stack", &e.Stack},
+	} {
+		if v, _ := o.Get(p.name); v.IsDefined() && !v.IsNull() {
+			*p.dst = v.String()
+		}
+	}
+	return e
+}
@@ -0,0 +1,245 @@
+//
+// otto.module :: handler_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestHandler(t *testing.T) {
+	vm, err := module.New(module.WithHandlerErrorFunc(func(e *module.HandlerError) {
+		t.Error(e)
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Register(new(module.FileLoader))
+
+	h, err := vm.Handler("./testdata/handler")
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- vm.RunLoop(ctx)
+	}()
+	defer func() {
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Error(module.Wrap(err))
+		}
+	}()
+	for _, tt := range []struct {
+		method, target, body string
+		status               int
+		header               http.Header
+		out                  string
+	}{
+		{
+			method: "GET",
+			target: "/text?q=1",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type": {"text/plain"},
+				"Set-Cookie":   {"a=1", "b=2"},
+			},
+			out: "GET /text?q=1 1 custom",
+		},
+		{
+			method: "POST",
+			target: "/buffer",
+			body:   "body",
+			status: http.StatusCreated,
+			out:    "<body>",
+		},
+		{
+			method: "GET",
+			target: "/json?q=1&q=2",
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			out: `{"host":"example.com","query":{"q":["1","2"]}}`,
+		},
+		{
+			method: "GET",
+			target: "/timer",
+			status: http.StatusOK,
+			out:    "true",
+		},
+	} {
+		if tt.target == "/timer" {
+			time.Sleep(10 * time.Millisecond)
+		}
+		r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		r.Header.Set("X-Custom", "custom")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		resp := w.Result()
+		if g, e := resp.StatusCode, tt.status; g != e {
+			t.Errorf("%v %v: expected %v, got %v", tt.method, tt.target, e, g)
+		}
+		for k, e := range tt.header {
+			if g := resp.Header.Values(k); strings.Join(g, ", ") != strings.Join(e, ", ") {
+				t.Errorf("%v %v: %v: expected %q, got %q", tt.method, tt.target, k, e, g)
+			}
+		}
+		b, _ := io.ReadAll(resp.Body)
+		if g, e := string(b), tt.out; g != e {
+			t.Errorf("%v %v: expected %q, got %q", tt.method, tt.target, e, g)
+		}
+		if g, e := resp.Header.Get("Content-Length"), strconv.Itoa(len(tt.out)); g != e {
+			t.Errorf("%v %v: expected Content-Length %v, got %v", tt.method, tt.target, e, g)
+		}
+	}
+}
+
+func TestHandlerClose(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Register(new(module.FileLoader))
+
+	h, err := vm.Handler("./testdata/handler_count")
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	// canceled before the event loop runs
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+
+	done := make(chan error)
+	go func() {
+		done <- vm.RunLoop(context.Background())
+	}()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if g, e := w.Body.String(), "1"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	h.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(module.Wrap(err))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RunLoop did not return")
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if g, e := w.Code, http.StatusServiceUnavailable; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	var he *module.HandlerError
+	vm, err := module.New(module.WithHandlerErrorFunc(func(e *module.HandlerError) {
+		he = e
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Register(new(module.FileLoader))
+
+	h, err := vm.Handler("./testdata/handler")
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if _, err := vm.Handler("./testdata/handler_error"); err == nil {
+		t.Error("expected error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- vm.RunLoop(ctx)
+	}()
+	defer func() {
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Error(module.Wrap(err))
+		}
+	}()
+	for _, tt := range []struct {
+		target string
+		err    module.HandlerError
+	}{
+		{
+			target: "/throw",
+			err: module.HandlerError{
+				Name:    "TypeError",
+				Message: "invalid value",
+				Code:    "ERR_INVALID_ARG_VALUE",
+			},
+		},
+		{
+			target: "/string",
+			err: module.HandlerError{
+				Name:    "Error",
+				Message: "oops",
+			},
+		},
+		{
+			target: "/status",
+			err: module.HandlerError{
+				Message: "invalid status code: 42",
+			},
+		},
+		{
+			target: "/",
+			err: module.HandlerError{
+				Message: "handler must return an Object",
+			},
+		},
+	} {
+		he = nil
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+
+		if g, e := w.Code, http.StatusInternalServerError; g != e {
+			t.Errorf("%v: expected %v, got %v", tt.target, e, g)
+		}
+		switch {
+		case he == nil:
+			t.Errorf("%v: expected error", tt.target)
+		case he.Method != "GET" || he.URL != tt.target:
+			t.Errorf("%v: unexpected request: %v %v", tt.target, he.Method, he.URL)
+		case he.Name != tt.err.Name || he.Message != tt.err.Message || he.Code != tt.err.Code:
+			t.Errorf("%v: expected %q, got %q", tt.target, &tt.err, he)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/cyclic", nil))
+	if g, e := w.Code, http.StatusInternalServerError; g != e {
+		t.Errorf("/cyclic: expected %v, got %v", e, g)
+	}
+	if g := w.Header().Get("X-Custom"); g != "" {
+		t.Errorf("/cyclic: unexpected header: X-Custom: %v", g)
+	}
+	if he == nil || !strings.HasPrefix(he.Message, "TypeError: Converting circular structure to JSON") {
+		t.Errorf("/cyclic: unexpected error: %v", he)
+	}
+}
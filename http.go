@@ -0,0 +1,441 @@
+//
+// otto.module :: http.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/robertkrimen/otto"
+)
+
+var defaultTransport http.RoundTripper
+
+func init() {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DisableCompression = true
+	defaultTransport = t
+}
+
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(vm *Otto) {
+		vm.transport = rt
+	}
+}
+
+// netTransport returns the default transport which dials through the
+// NetPolicy. Proxies are not used since the policy would check the address of
+// the proxy instead of the host.
+func (vm *Otto) netTransport() http.RoundTripper {
+	t := defaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = vm.dial
+	return t
+}
+
+func (vm *Otto) http_binding(o *otto.Object) error {
+	o.Set("request", vm.http_request)
+	o.Set("listen", vm.http_listen)
+	return nil
+}
+
+func (vm *Otto) http_request(call otto.FunctionCall) otto.Value {
+	opts := call.Argument(0).Object()
+	if opts == nil {
+		return vm.throw(errors.New("options must be an Object"))
+	}
+	onresponse := call.Argument(1)
+
+	v, _ := opts.Get("method")
+	method := v.String()
+	v, _ = opts.Get("url")
+	url := v.String()
+	var chunked bool
+	if v, _ := opts.Get("chunked"); v.IsBoolean() {
+		chunked, _ = v.ToBoolean()
+	}
+	var body io.Reader
+	var pw *io.PipeWriter
+	if chunked {
+		var pr *io.PipeReader
+		pr, pw = io.Pipe()
+		body = pr
+	} else if v, _ := opts.Get("body"); v.IsObject() {
+		b, err := vm.toBuffer("body", v)
+		if err != nil {
+			return vm.throw(err)
+		}
+		body = bytes.NewReader(append([]byte(nil), b...))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		cancel()
+		return vm.throw(err)
+	}
+	if v, _ := opts.Get("headers"); v.IsObject() {
+		o := v.Object()
+		for _, k := range o.Keys() {
+			p, _ := o.Get(k)
+			if !p.IsObject() {
+				continue
+			}
+			n, _ := p.Object().Get("0")
+			s, _ := p.Object().Get("1")
+			switch k := n.String(); {
+			case strings.EqualFold(k, "Host"):
+				req.Host = s.String()
+			case strings.EqualFold(k, "Content-Length") && pw != nil:
+				if n, err := strconv.ParseInt(s.String(), 10, 64); err == nil {
+					req.ContentLength = n
+				}
+			default:
+				req.Header.Add(k, s.String())
+			}
+		}
+	}
+	if _, ok := req.Header["User-Agent"]; !ok {
+		// suppress the default User-Agent of net/http
+		req.Header.Set("User-Agent", "")
+	}
+	rt := vm.transport
+	if rt == nil {
+		rt = defaultTransport
+	}
+
+	vm.Ref()
+	go func() {
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			cancel()
+			if pw != nil {
+				pw.CloseWithError(err)
+			}
+		}
+		vm.Enqueue(func() error {
+			vm.Unref()
+			if err != nil {
+				_, err = onresponse.Call(otto.UndefinedValue(), vm.httpError(err, false))
+				return err
+			}
+			_, err := onresponse.Call(otto.UndefinedValue(), otto.NullValue(), vm.newResponse(resp, cancel))
+			return err
+		})
+	}()
+
+	var o *otto.Object
+	if pw != nil {
+		o = vm.newWriterHandle(pw, pw, false, nil)
+	} else {
+		o, _ = vm.Object(`({})`)
+	}
+	o.Set("abort", func(call otto.FunctionCall) otto.Value {
+		cancel()
+		if pw != nil {
+			pw.CloseWithError(context.Canceled)
+		}
+		return otto.UndefinedValue()
+	})
+	return o.Value()
+}
+
+func (vm *Otto) newResponse(resp *http.Response, cancel context.CancelFunc) otto.Value {
+	o, _ := vm.Object(`({})`)
+	o.Set("statusCode", resp.StatusCode)
+	o.Set("statusMessage", strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode)+" "))
+	o.Set("httpVersionMajor", resp.ProtoMajor)
+	o.Set("httpVersionMinor", resp.ProtoMinor)
+	// net/http removes Transfer-Encoding from the header
+	if len(resp.TransferEncoding) > 0 {
+		resp.Header["Transfer-Encoding"] = []string{strings.Join(resp.TransferEncoding, ", ")}
+	}
+	o.Set("rawHeaders", rawHeaders(resp.Header))
+	o.Set("body", vm.newReaderHandle(resp.Body, &responseBody{resp.Body, cancel}, func(err error) otto.Value {
+		return vm.httpError(err, true)
+	}))
+	return o.Value()
+}
+
+func rawHeaders(h http.Header) []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var raw []string
+	for _, k := range keys {
+		for _, v := range h[k] {
+			raw = append(raw, k, v)
+		}
+	}
+	return raw
+}
+
+type responseBody struct {
+	io.Closer
+	cancel context.CancelFunc
+}
+
+func (b *responseBody) Close() error {
+	err := b.Closer.Close()
+	b.cancel()
+	return err
+}
+
+func (vm *Otto) httpError(err error, body bool) otto.Value {
+	if v, ok := vm.netError(err); ok {
+		return v
+	} else if v, ok := vm.accessDenied(err, "Net"); ok {
+		return v
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		msg := "socket hang up"
+		if body {
+			msg = "aborted"
+		}
+		v := vm.MakeCustomError("Error", msg)
+		v.Object().Set("code", "ECONNRESET")
+		return v
+	}
+	return vm.MakeCustomError("Error", err.Error())
+}
+
+func (vm *Otto) http_listen(call otto.FunctionCall) otto.Value {
+	var host string
+	if v := call.Argument(0); v.IsDefined() && !v.IsNull() {
+		var err error
+		if host, err = vm.toString("host", v); err != nil {
+			return vm.throw(err)
+		}
+	}
+	port, err := call.Argument(1).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	onrequest := call.Argument(2)
+
+	l, err := vm.listen(host, port)
+	if err != nil {
+		return vm.throwNet(err)
+	}
+	s := &httpServer{
+		vm:        vm,
+		onrequest: onrequest,
+		refed:     true,
+	}
+	s.srv = &http.Server{Handler: s}
+	vm.Ref()
+	go s.srv.Serve(l)
+
+	o, _ := vm.Object(`({})`)
+	o.Set("address", vm.addressOf(l.Addr()))
+	o.Set("close", s.close)
+	o.Set("closeAll", s.closeAll)
+	o.Set("ref", s.ref)
+	o.Set("unref", s.unref)
+	return o.Value()
+}
+
+// httpServer serializes the requests onto the event loop, and each handler
+// waits until the response is ended by the script.
+type httpServer struct {
+	vm        *Otto
+	srv       *http.Server
+	onrequest otto.Value
+	refed     bool
+	closed    bool
+}
+
+func (s *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vm := s.vm
+	resp := &httpResponse{
+		vm:   vm,
+		w:    w,
+		done: make(chan struct{}),
+	}
+	// streaming bodies require reading the request while writing the response
+	http.NewResponseController(w).EnableFullDuplex()
+	var res *otto.Object
+	vm.Ref()
+	vm.Enqueue(func() error {
+		res = vm.newWriterHandle(resp, resp, false, nil)
+		res.Set("writeHead", resp.writeHead)
+		_, err := s.onrequest.Call(otto.UndefinedValue(), vm.newIncomingMessage(r), res)
+		return err
+	})
+	select {
+	case <-resp.done:
+	case <-r.Context().Done():
+		vm.Enqueue(func() error {
+			if v, _ := res.Get("onabort"); v.IsFunction() {
+				_, err := v.Call(res.Value())
+				return err
+			}
+			return nil
+		})
+	}
+	resp.mu.Lock()
+	resp.finished = true
+	resp.mu.Unlock()
+	vm.Enqueue(func() error {
+		vm.Unref()
+		return nil
+	})
+}
+
+func (s *httpServer) close(call otto.FunctionCall) otto.Value {
+	cb := call.Argument(0)
+	if s.closed {
+		return otto.UndefinedValue()
+	}
+	s.closed = true
+	if s.refed {
+		s.vm.Unref()
+	}
+
+	s.vm.Ref()
+	go func() {
+		s.srv.Shutdown(context.Background())
+		s.vm.Enqueue(func() error {
+			s.vm.Unref()
+			_, err := cb.Call(otto.UndefinedValue())
+			return err
+		})
+	}()
+	return otto.UndefinedValue()
+}
+
+func (s *httpServer) closeAll(call otto.FunctionCall) otto.Value {
+	s.srv.Close()
+	return otto.UndefinedValue()
+}
+
+func (s *httpServer) ref(call otto.FunctionCall) otto.Value {
+	if !s.refed {
+		s.refed = true
+		if !s.closed {
+			s.vm.Ref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (s *httpServer) unref(call otto.FunctionCall) otto.Value {
+	if s.refed {
+		s.refed = false
+		if !s.closed {
+			s.vm.Unref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) newIncomingMessage(r *http.Request) otto.Value {
+	o, _ := vm.Object(`({})`)
+	o.Set("method", r.Method)
+	o.Set("url", r.RequestURI)
+	o.Set("httpVersionMajor", r.ProtoMajor)
+	o.Set("httpVersionMinor", r.ProtoMinor)
+	// net/http removes Host and Transfer-Encoding from the header
+	h := r.Header.Clone()
+	if len(r.TransferEncoding) > 0 {
+		h["Transfer-Encoding"] = []string{strings.Join(r.TransferEncoding, ", ")}
+	}
+	o.Set("rawHeaders", append([]string{"Host", r.Host}, rawHeaders(h)...))
+	if host, port, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		o.Set("remoteAddress", host)
+		if n, err := strconv.Atoi(port); err == nil {
+			o.Set("remotePort", n)
+		}
+	}
+	if a, ok := r.Context().Value(http.LocalAddrContextKey).(*net.TCPAddr); ok {
+		o.Set("localAddress", a.IP.String())
+		o.Set("localPort", a.Port)
+	}
+	o.Set("body", vm.newReaderHandle(r.Body, nil, func(err error) otto.Value {
+		return vm.httpError(err, true)
+	}))
+	return o.Value()
+}
+
+// httpResponse guards the http.ResponseWriter which must not be used after
+// the handler returns.
+type httpResponse struct {
+	vm       *Otto
+	w        http.ResponseWriter
+	done     chan struct{}
+	once     sync.Once
+	mu       sync.Mutex
+	finished bool
+}
+
+func (r *httpResponse) writeHead(call otto.FunctionCall) otto.Value {
+	status, err := call.Argument(0).ToInteger()
+	if err != nil {
+		return r.vm.throw(err)
+	}
+	sendDate, _ := call.Argument(2).ToBoolean()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.finished {
+		return otto.UndefinedValue()
+	}
+	h := r.w.Header()
+	if v := call.Argument(1); v.IsObject() {
+		o := v.Object()
+		for _, k := range o.Keys() {
+			p, _ := o.Get(k)
+			if !p.IsObject() {
+				continue
+			}
+			n, _ := p.Object().Get("0")
+			s, _ := p.Object().Get("1")
+			h.Add(n.String(), s.String())
+		}
+	}
+	// suppress the defaults of net/http
+	if _, ok := h["Content-Type"]; !ok {
+		h["Content-Type"] = nil
+	}
+	if !sendDate {
+		h["Date"] = nil
+	}
+	r.w.WriteHeader(int(status))
+	return otto.UndefinedValue()
+}
+
+func (r *httpResponse) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.finished {
+		return 0, net.ErrClosed
+	}
+	n, err := r.w.Write(b)
+	if err == nil {
+		err = http.NewResponseController(r.w).Flush()
+	}
+	return n, err
+}
+
+func (r *httpResponse) Close() error {
+	r.once.Do(func() {
+		close(r.done)
+	})
+	return nil
+}
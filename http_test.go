@@ -0,0 +1,557 @@
+//
+// otto.module :: http_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestHTTP(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.Header().Add("X-Dup", "1")
+		w.Header().Add("X-Dup", "2")
+		fmt.Fprintf(w, "%v %v %v %q %v %v", r.Method, r.URL, r.Host, b, r.ContentLength, r.TransferEncoding)
+		fmt.Fprintf(w, " %q %q", r.Header.Get("X-Custom"), r.Header.Get("User-Agent"))
+	})
+	mux.HandleFunc("/chunked", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte("bc"))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	})
+	mux.HandleFunc("/partial", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "10")
+		w.Write([]byte("abc"))
+		w.(http.Flusher).Flush()
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("url", srv.URL)
+
+	src := `
+		var http = require('http');
+		var out = [];
+		function events(name, obj, events) {
+			events.forEach(function(e) {
+				obj.on(e, function(err) {
+					out.push(name + ' ' + e + (err instanceof Error ? ' ' + err.code + ' ' + err.message : ''));
+				});
+			});
+		}
+
+		var req = http.request(url + '/echo', { method: 'post', headers: { 'X-Custom': 'v' } }, function(res) {
+			out.push(res.statusCode + ' ' + res.statusMessage + ' ' + res.httpVersion);
+			out.push(JSON.stringify(res.headers['set-cookie']) + ' ' + res.headers['x-dup']);
+			events('res', res, ['end', 'close']);
+			var b = '';
+			res.setEncoding('utf8');
+			res.on('data', function(c) { b += c; });
+			res.on('end', function() { out.push(b); });
+		});
+		events('req', req, ['finish', 'response', 'close']);
+		req.write('hello, ');
+		req.end('world');
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	host := strings.TrimPrefix(srv.URL, "http://")
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"req finish",
+		"200 OK 1.1",
+		`["a=1","b=2"] 1, 2`,
+		"req response",
+		"res end",
+		`POST /echo ` + host + ` "hello, world" -1 [chunked] "v" ""`,
+		"req close",
+		"res close",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	src = `
+		var out = [];
+		var u = require('url').parse(url);
+		http.get({ hostname: u.hostname, port: u.port, path: '/echo?q', headers: { 'User-Agent': 'otto' } }, function(res) {
+			var b = '';
+			res.on('data', function(c) { b += c; });
+			res.on('end', function() { out.push('get ' + b); });
+		});
+		var buf = http.request(url + '/echo', { method: 'PUT' }, function(res) {
+			var b = '';
+			res.on('data', function(c) { b += c; });
+			res.on('end', function() { out.push('put ' + b); });
+		});
+		buf.end(Buffer.from('data'));
+		http.get(url + '/chunked', function(res) {
+			var b = '';
+			res.on('data', function(c) { b += c; });
+			res.on('end', function() { out.push('chunked ' + b + ' ' + res.headers['transfer-encoding']); });
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.sort().join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"chunked abc chunked",
+		`get GET /echo?q ` + host + ` "" 0 [] "" "otto"`,
+		`put PUT /echo ` + host + ` "data" 4 [] "" ""`,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, tt := range []struct {
+		src string
+		out []string
+	}{
+		{
+			src: `
+				var req = http.get(url + '/slow');
+				events('req', req, ['abort', 'error', 'close']);
+				setTimeout(function() {
+					req.abort();
+					out.push('aborted ' + req.aborted + ' ' + req.destroyed);
+				}, 1);
+			`,
+			out: []string{
+				"aborted true true",
+				"req abort",
+				"req error ECONNRESET socket hang up",
+				"req close",
+			},
+		},
+		{
+			src: `
+				var req = http.get(url + '/slow', { timeout: 10 });
+				events('req', req, ['timeout', 'error', 'close']);
+				req.on('timeout', function() { req.destroy(); });
+			`,
+			out: []string{
+				"req timeout",
+				"req error ECONNRESET socket hang up",
+				"req close",
+			},
+		},
+		{
+			src: `
+				var req = http.get(url + '/partial', function(res) {
+					events('res', res, ['aborted', 'error', 'close']);
+					res.on('data', function(c) { out.push('res data ' + c); });
+				});
+			`,
+			out: []string{
+				"res data abc",
+				"res aborted",
+				"res error ECONNRESET aborted",
+				"res close",
+			},
+		},
+		{
+			src: `
+				var req = http.get('http://127.0.0.1:1/');
+				events('req', req, ['error', 'close']);
+			`,
+			out: []string{
+				"req error ECONNREFUSED connect ECONNREFUSED 127.0.0.1:1",
+				"req close",
+			},
+		},
+	} {
+		if _, err := vm.Run(`var out = [];` + tt.src); err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		if err := vm.RunLoop(context.Background()); err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		if v, err := vm.Run(`out.join('\n')`); err != nil {
+			t.Fatal(module.Wrap(err))
+		} else if g, e := v.String(), strings.Join(tt.out, "\n"); g != e {
+			t.Errorf("expected %q, got %q", e, g)
+		}
+	}
+}
+
+func TestHTTPS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%v %v", r.Method, r.TLS != nil)
+	}))
+	defer srv.Close()
+
+	vm, err := module.New(module.WithRoundTripper(srv.Client().Transport))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("url", srv.URL)
+
+	src := `
+		var https = require('https');
+		var out = [];
+		https.get(url, function(res) {
+			res.on('data', function(c) { out.push(c.toString()); });
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "GET true"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestHTTPRoundTripper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	vm, err := module.New(module.WithRoundTripper(egressPolicy{srv.Listener.Addr().String()}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("url", srv.URL)
+
+	src := `
+		var http = require('http');
+		var out = [];
+		http.get(url, function(res) {
+			res.on('data', function(c) { out.push(c.toString()); });
+		});
+		http.get('http://example.com/').on('error', function(e) {
+			out.push(e.message);
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.sort().join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "egress not allowed: example.com:80\nok"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestHTTPNetPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	vm, err := module.New(module.WithNetPolicy(&module.AddressPolicy{
+		Dial: []string{srv.Listener.Addr().String(), "localhost"},
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("url", srv.URL)
+
+	src := `
+		var http = require('http');
+		var out = [];
+		function onerror(e) {
+			out.push([e.message.replace(/: .*/, ''), e.code, e.permission].join());
+		}
+		http.get(url, function(res) {
+			res.on('data', function(c) { out.push(c.toString()); });
+		});
+		http.get('http://example.com/').on('error', onerror);
+		http.get('http://localhost:1/').on('error', onerror);
+		require('net').connect(1, 'localhost').on('error', onerror);
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.sort().join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"connect not allowed,ERR_ACCESS_DENIED,Net",
+		"connect not allowed,ERR_ACCESS_DENIED,Net",
+		"dial not allowed,ERR_ACCESS_DENIED,Net",
+		"ok",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+type egressPolicy struct {
+	host string
+}
+
+func (p egressPolicy) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != p.host {
+		return nil, errors.New("egress not allowed: " + req.URL.Host)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHTTPError(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`http.get('https://localhost/')`, `TypeError: Protocol "https:" not supported. Expected "http:"`},
+		{`require('https').get('http://localhost/')`, `TypeError: Protocol "http:" not supported. Expected "https:"`},
+		{`http.request({ method: 'BAD METHOD' })`, `TypeError: Method must be a valid HTTP token ["BAD METHOD"]`},
+		{`http.request({ method: 1 })`, `TypeError: The "options.method" property must be of type string. Received type number (1)`},
+		{`http.request({ path: '/a b' })`, "TypeError: Request path contains unescaped characters"},
+		{`http.request({ headers: { 'a b': 'c' } })`, `TypeError: Header name must be a valid HTTP token ["a b"]`},
+		{`http.request({ headers: { a: undefined } })`, `TypeError: Invalid value "undefined" for header "a"`},
+		{`http.request({ headers: { a: '\n' } })`, `TypeError: Invalid character in header content ["a"]`},
+		{`http.request({ timeout: -1 })`, `RangeError: The value of "timeout" is out of range. It must be >= 0. Received -1`},
+		{`var req = http.request({}); req.flushHeaders(); req.setHeader('a', 'b')`, "Error: Cannot set headers after they are sent to the client"},
+	} {
+		_, err := vm.Run(`var http = require('http');` + tt.src)
+		if err == nil {
+			t.Errorf("%v: expected error", tt.src)
+		} else if g, e := module.Wrap(err).Error(), tt.err; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+}
+
+func TestHTTPServer(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var http = require('http');
+		var out = [];
+		var server = http.createServer(function(req, res) {
+			switch (req.url) {
+			case '/hello':
+				res.setHeader('Content-Type', 'text/plain');
+				res.end('hello ' + req.method + ' ' + req.headers['x-a']);
+				break;
+			case '/echo':
+				req.pipe(res);
+				break;
+			case '/stream':
+				res.writeHead(202, { 'X-A': ['1', '2'] });
+				res.write('a');
+				setTimeout(function() { res.end('b'); }, 1);
+				break;
+			case '/hang':
+				req.on('aborted', function() { out.push('aborted'); });
+				res.on('close', function() { out.push('close ' + res.writableFinished); });
+				res.write('x');
+				break;
+			case '/close':
+				res.end();
+				server.close();
+				break;
+			}
+		});
+		server.on('listening', function() { out.push('listening'); });
+		server.on('close', function() { out.push('close'); });
+		server.listen(0, '127.0.0.1');
+		server.address().port;
+	`
+	v, err := vm.Run(src)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	url := fmt.Sprintf("http://127.0.0.1:%v", v)
+	done := make(chan error)
+	go func() {
+		done <- vm.RunLoop(context.Background())
+	}()
+
+	req, _ := http.NewRequest("GET", url+"/hello", nil)
+	req.Header.Set("X-A", "v")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if g, e := string(b), "hello GET v"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := resp.Header.Get("Content-Type"), "text/plain"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := resp.ContentLength, int64(11); g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+
+	resp, err = http.Head(url + "/hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if g, e := string(b), ""; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	resp, err = http.Post(url+"/echo", "text/plain", strings.NewReader(strings.Repeat("data", 10000)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if g, e := string(b), strings.Repeat("data", 10000); g != e {
+		t.Errorf("expected %v bytes, got %v bytes", len(e), len(g))
+	}
+	if g, e := resp.Header.Get("Content-Type"), ""; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	resp, err = http.Get(url + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if g, e := resp.StatusCode, 202; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+	if g, e := string(b), "ab"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := fmt.Sprint(resp.TransferEncoding, resp.Header["X-A"]), "[chunked] [1 2]"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ = http.NewRequestWithContext(ctx, "GET", url+"/hang", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = make([]byte, 1)
+	if _, err := io.ReadFull(resp.Body, b); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	resp.Body.Close()
+
+	resp, err = http.Get(url + "/close")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := <-done; err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "listening\naborted\nclose false\nclose"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestHTTPServerError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("addr", l.Addr().String())
+
+	src := `
+		var http = require('http');
+		var out = [];
+		var server = http.createServer();
+		server.on('error', function(e) {
+			out.push([e.code, e.errno < 0, e.syscall, e.address + ':' + e.port === addr, e.message === 'listen EADDRINUSE: address already in use ' + addr].join());
+			server.close(function(e) { out.push(e.code); });
+		});
+		server.listen(+addr.split(':')[1], '127.0.0.1');
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "EADDRINUSE,true,listen,true,true\nERR_SERVER_NOT_RUNNING"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`http.createServer().listen(65536)`, "RangeError: options.port should be >= 0 and < 65536. Received 65536."},
+		{`http.createServer().listen(-1)`, "RangeError: options.port should be >= 0 and < 65536. Received -1."},
+		{`var s = http.createServer().listen(0); try { s.listen(0); } finally { s.close(); }`, "Error: Listen method has been called more than once without closing."},
+		{`new http.ServerResponse({}, {}).writeHead(99)`, "RangeError: Invalid status code: 99"},
+		{`new http.ServerResponse({}, { writeHead: function() {} }).writeHead(200).writeHead(200)`, "Error: Cannot write headers after they are sent to the client"},
+	} {
+		_, err := vm.Run(`var http = require('http');` + tt.src)
+		if err == nil {
+			t.Errorf("%v: expected error", tt.src)
+		} else if g, e := module.Wrap(err).Error(), tt.err; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+}
@@ -0,0 +1,502 @@
+//
+// otto.module :: assert.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var _inspect = require('./internal/util/inspect');
+var comparisons = require('./internal/util/comparisons');
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+var inspect = _inspect.inspect;
+
+var headers = {
+  deepStrictEqual: 'Expected values to be strictly deep-equal:',
+  strictEqual: 'Expected values to be strictly equal:',
+  deepEqual: 'Expected values to be loosely deep-equal:',
+  notDeepStrictEqual: 'Expected "actual" not to be strictly deep-equal to:',
+  notStrictEqual: 'Expected "actual" to be strictly unequal to:',
+  notDeepEqual: 'Expected "actual" not to be loosely deep-equal to:',
+};
+
+var operators = {
+  equal: '==',
+  notEqual: '!=',
+};
+
+function inspectValue(v) {
+  return inspect(v, {
+    depth: 1000,
+    customInspect: false,
+    compact: false,
+    sorted: true,
+    breakLength: Infinity,
+  });
+}
+
+function diff(a, b) {
+  // longest common subsequence
+  var n = a.length;
+  var m = b.length;
+  var lcs = [];
+  var i;
+  var j;
+  for (i = 0; i <= n; i++) {
+    lcs.push(new Array(m + 1));
+    lcs[i][m] = 0;
+  }
+  for (j = 0; j <= m; j++) {
+    lcs[n][j] = 0;
+  }
+  for (i = n - 1; i >= 0; i--) {
+    for (j = m - 1; j >= 0; j--) {
+      lcs[i][j] = a[i] === b[j] ? lcs[i + 1][j + 1] + 1 : Math.max(lcs[i + 1][j], lcs[i][j + 1]);
+    }
+  }
+
+  var lines = [];
+  i = 0;
+  j = 0;
+  while (i < n || j < m) {
+    if (i < n
+        && j < m
+        && a[i] === b[j]) {
+      lines.push('  ' + a[i++]);
+      j++;
+    } else if (j >= m
+               || (i < n && lcs[i + 1][j] >= lcs[i][j + 1])) {
+      lines.push('+ ' + a[i++]);
+    } else {
+      lines.push('- ' + b[j++]);
+    }
+  }
+  return lines;
+}
+
+function createMessage(actual, expected, operator) {
+  var a = inspectValue(actual);
+  var b = inspectValue(expected);
+  if (hasOwnProperty.call(operators, operator)) {
+    return a + ' ' + operators[operator] + ' ' + b;
+  } else if (operator.slice(0, 3) === 'not') {
+    if (a.indexOf('\n') === -1) {
+      return headers[operator] + ' ' + a;
+    }
+    return headers[operator] + '\n\n' + a + '\n';
+  }
+
+  var header = headers[operator] || 'Expected values to be equal:';
+  var al = a.split('\n');
+  var bl = b.split('\n');
+  if (al.length === 1
+      && bl.length === 1
+      && a !== b) {
+    return header + '\n\n' + a + ' !== ' + b + '\n';
+  }
+  return header + '\n+ actual - expected\n\n' + diff(al, bl).join('\n') + '\n';
+}
+
+function AssertionError(options) {
+  if (options === null
+      || typeof options !== 'object') {
+    throw new TypeError('options must be an Object');
+  }
+
+  var message = options.message;
+  if (message !== undefined) {
+    this.message = String(message);
+    this.generatedMessage = false;
+  } else {
+    this.message = createMessage(options.actual, options.expected, options.operator);
+    this.generatedMessage = true;
+  }
+  this.code = 'ERR_ASSERTION';
+  this.actual = options.actual;
+  this.expected = options.expected;
+  this.operator = options.operator;
+
+  var stack = new Error(this.message).stack;
+  if (typeof stack === 'string') {
+    var i = stack.indexOf('\n    at ');
+    this.stack = this.name + ' [' + this.code + ']: ' + this.message + (i !== -1 ? stack.slice(i) : '');
+  }
+}
+
+AssertionError.prototype = Object.create(Error.prototype, {
+  constructor: {
+    value: AssertionError,
+    enumerable: false,
+    writable: true,
+    configurable: true,
+  },
+});
+AssertionError.prototype.name = 'AssertionError';
+
+AssertionError.prototype.toString = function toString() {
+  return this.name + ' [' + this.code + ']: ' + this.message;
+};
+
+function innerFail(obj) {
+  if (obj.message instanceof Error) {
+    throw obj.message;
+  }
+  throw new AssertionError(obj);
+}
+
+function innerOk(args, name) {
+  if (args.length === 0) {
+    innerFail({
+      message: 'No value argument passed to `assert.ok()`',
+      actual: undefined,
+      expected: true,
+      operator: '==',
+    });
+  } else if (!args[0]) {
+    innerFail({
+      message: args[1] !== undefined ? args[1] : 'The expression evaluated to a falsy value:\n\n  ' + name + '(' + inspectValue(args[0]) + ')\n',
+      actual: args[0],
+      expected: true,
+      operator: '==',
+    });
+  }
+}
+
+function assert() {
+  innerOk(arguments, 'assert');
+}
+
+assert.AssertionError = AssertionError;
+
+assert.ok = function ok() {
+  innerOk(arguments, 'assert.ok');
+};
+
+assert.fail = function fail(message) {
+  if (message instanceof Error) {
+    throw message;
+  }
+  throw new AssertionError({
+    message: message !== undefined ? message : 'Failed',
+    operator: 'fail',
+  });
+};
+
+function is(a, b) {
+  if (a === b) {
+    return a !== 0 || 1 / a === 1 / b;
+  }
+  return a !== a && b !== b;
+}
+
+function checkArgs(args) {
+  if (args.length < 2) {
+    throw new TypeError('"actual" and "expected" arguments must be specified');
+  }
+}
+
+assert.equal = function equal(actual, expected, message) {
+  checkArgs(arguments);
+  if (!(actual == expected || (actual !== actual && expected !== expected))) { // eslint-disable-line eqeqeq
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'equal',
+    });
+  }
+};
+
+assert.notEqual = function notEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (actual == expected || (actual !== actual && expected !== expected)) { // eslint-disable-line eqeqeq
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notEqual',
+    });
+  }
+};
+
+assert.strictEqual = function strictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (!is(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'strictEqual',
+    });
+  }
+};
+
+assert.notStrictEqual = function notStrictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (is(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notStrictEqual',
+    });
+  }
+};
+
+assert.deepEqual = function deepEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (!comparisons.isDeepEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'deepEqual',
+    });
+  }
+};
+
+assert.notDeepEqual = function notDeepEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (comparisons.isDeepEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notDeepEqual',
+    });
+  }
+};
+
+assert.deepStrictEqual = function deepStrictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (!comparisons.isDeepStrictEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'deepStrictEqual',
+    });
+  }
+};
+
+assert.notDeepStrictEqual = function notDeepStrictEqual(actual, expected, message) {
+  checkArgs(arguments);
+  if (comparisons.isDeepStrictEqual(actual, expected)) {
+    innerFail({
+      message: message,
+      actual: actual,
+      expected: expected,
+      operator: 'notDeepStrictEqual',
+    });
+  }
+};
+
+var NO_EXCEPTION = {};
+
+function getActual(fn) {
+  if (typeof fn !== 'function') {
+    throw new TypeError('fn must be a Function');
+  }
+  try {
+    fn();
+  } catch (e) {
+    return e;
+  }
+  return NO_EXCEPTION;
+}
+
+function isRegExp(v) {
+  return Object.prototype.toString.call(v) === '[object RegExp]';
+}
+
+function Comparison() {}
+
+function expectedException(actual, expected, message, operator) {
+  if (typeof expected === 'function') {
+    if (expected.prototype !== undefined
+        && actual instanceof expected) {
+      return;
+    } else if (Error.prototype.isPrototypeOf(expected.prototype)
+               || expected === Error) {
+      innerFail({
+        message: message !== undefined ? message : 'The error is expected to be an instance of "' + _inspect.getName(expected) + '". Received "' + (actual !== null && typeof actual === 'object' && actual.name ? actual.name : inspectValue(actual)) + '"' + (actual !== null && typeof actual === 'object' && actual.message !== undefined ? '\n\nError message:\n\n' + actual.message : ''),
+        actual: actual,
+        expected: expected,
+        operator: operator,
+      });
+    } else if (expected.call({}, actual) !== true) {
+      innerFail({
+        message: message !== undefined ? message : 'The "' + (_inspect.getName(expected) || 'validate') + '" validation function is expected to return "true". Received ' + inspectValue(actual),
+        actual: actual,
+        expected: expected,
+        operator: operator,
+      });
+    }
+  } else if (isRegExp(expected)) {
+    if (!expected.test(String(actual))) {
+      innerFail({
+        message: message !== undefined ? message : 'The input did not match the regular expression ' + String(expected) + '. Input:\n\n' + inspectValue(String(actual)) + '\n',
+        actual: actual,
+        expected: expected,
+        operator: operator,
+      });
+    }
+  } else if (expected !== null
+             && typeof expected === 'object') {
+    if (actual === null
+        || typeof actual !== 'object') {
+      innerFail({
+        message: message,
+        actual: actual,
+        expected: expected,
+        operator: 'deepStrictEqual',
+      });
+    }
+    var keys = Object.keys(expected);
+    if (expected instanceof Error) {
+      keys.push('name', 'message');
+    }
+    for (var i = 0; i < keys.length; i++) {
+      var k = keys[i];
+      var v = expected[k];
+      if (typeof actual[k] === 'string'
+          && isRegExp(v)
+          && v.test(actual[k])) {
+        continue;
+      } else if (!(k in actual)
+                 || !comparisons.isDeepStrictEqual(actual[k], v)) {
+        if (message === undefined) {
+          var a = new Comparison();
+          var b = new Comparison();
+          keys.forEach(function(k) {
+            if (k in actual) {
+              a[k] = actual[k];
+            }
+            b[k] = expected[k];
+          });
+          message = createMessage(a, b, 'deepStrictEqual');
+        }
+        innerFail({
+          message: message,
+          actual: actual,
+          expected: expected,
+          operator: operator,
+        });
+      }
+    }
+  } else {
+    throw new TypeError('expected must be a Function, RegExp or Object');
+  }
+}
+
+assert.throws = function throws(fn, expected, message) {
+  var actual = getActual(fn);
+  if (typeof expected === 'string') {
+    message = expected;
+    expected = undefined;
+  }
+  if (actual === NO_EXCEPTION) {
+    var details = '';
+    if (expected !== undefined
+        && _inspect.getName(expected)) {
+      details += ' (' + _inspect.getName(expected) + ')';
+    }
+    details += message !== undefined ? ': ' + message : '.';
+    innerFail({
+      message: 'Missing expected exception' + details,
+      actual: undefined,
+      expected: expected,
+      operator: 'throws',
+    });
+  } else if (expected !== undefined) {
+    expectedException(actual, expected, message, 'throws');
+  }
+};
+
+assert.doesNotThrow = function doesNotThrow(fn, expected, message) {
+  var actual = getActual(fn);
+  if (typeof expected === 'string') {
+    message = expected;
+    expected = undefined;
+  }
+  if (actual === NO_EXCEPTION) {
+    return;
+  } else if (expected === undefined
+             || (typeof expected === 'function' && expected.prototype !== undefined && actual instanceof expected)
+             || (isRegExp(expected) && expected.test(String(actual)))) {
+    var details = message !== undefined ? ': ' + message : '.';
+    innerFail({
+      message: 'Got unwanted exception' + details + '\nActual message: "' + (actual && actual.message) + '"',
+      actual: actual,
+      expected: expected,
+      operator: 'doesNotThrow',
+    });
+  }
+  throw actual;
+};
+
+function match(s, re, message, operator) {
+  if (!isRegExp(re)) {
+    throw new TypeError('regexp must be a RegExp');
+  }
+  var ok = operator === 'match';
+  if (typeof s !== 'string'
+      || re.test(s) !== ok) {
+    innerFail({
+      message: message !== undefined ? message : (typeof s !== 'string' ? 'The "string" argument must be of type string. Received type ' + typeof s + ' (' + inspectValue(s) + ')' : (ok ? 'The input did not match the regular expression ' : 'The input was expected to not match the regular expression ') + String(re) + '. Input:\n\n' + inspectValue(s) + '\n'),
+      actual: s,
+      expected: re,
+      operator: operator,
+    });
+  }
+}
+
+assert.match = function match_(s, re, message) {
+  match(s, re, message, 'match');
+};
+
+assert.doesNotMatch = function doesNotMatch(s, re, message) {
+  match(s, re, message, 'doesNotMatch');
+};
+
+assert.ifError = function ifError(err) {
+  if (err !== null
+      && err !== undefined) {
+    var message = 'ifError got unwanted exception: ';
+    if (err !== null
+        && typeof err === 'object'
+        && typeof err.message === 'string') {
+      message += err.message.length === 0 && err.constructor ? _inspect.getName(err.constructor) : err.message;
+    } else {
+      message += inspectValue(err);
+    }
+    var e = new AssertionError({
+      actual: err,
+      expected: null,
+      operator: 'ifError',
+      message: message,
+    });
+    e.generatedMessage = true;
+    throw e;
+  }
+};
+
+function strict() {
+  innerOk(arguments, 'assert');
+}
+
+strict.AssertionError = AssertionError;
+Object.keys(assert).forEach(function(k) {
+  strict[k] = assert[k];
+});
+strict.equal = assert.strictEqual;
+strict.notEqual = assert.notStrictEqual;
+strict.deepEqual = assert.deepStrictEqual;
+strict.notDeepEqual = assert.notDeepStrictEqual;
+strict.strict = strict;
+assert.strict = strict;
+
+module.exports = assert;
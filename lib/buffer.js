@@ -0,0 +1,697 @@
+//
+// otto.module :: buffer.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('buffer');
+var _inspect = require('./internal/util/inspect');
+var util = require('./internal/util');
+
+var inspect = _inspect.inspect;
+var invalidArgType = util.invalidArgType;
+var invalidArgValue = util.invalidArgValue;
+var outOfRange = util.outOfRange;
+
+var kMaxLength = 0x100000000;
+var kStringMaxLength = 0x1fffffe8;
+
+exports.kMaxLength = kMaxLength;
+exports.kStringMaxLength = kStringMaxLength;
+exports.constants = {
+  MAX_LENGTH: kMaxLength,
+  MAX_STRING_LENGTH: kStringMaxLength,
+};
+exports.INSPECT_MAX_BYTES = 50;
+
+//
+// errors
+//
+
+function outOfBounds() {
+  var e = new RangeError('Attempt to access memory outside buffer bounds');
+  e.code = 'ERR_BUFFER_OUT_OF_BOUNDS';
+  return e;
+}
+
+function validateNumber(v, name) {
+  if (typeof v !== 'number') {
+    throw invalidArgType(name, 'of type number', v);
+  }
+}
+
+function validateOffset(v, name, min, max) {
+  if (min === undefined) {
+    min = 0;
+  }
+  if (max === undefined) {
+    max = kMaxLength;
+  }
+  validateNumber(v, name);
+  if (Math.floor(v) !== v) {
+    throw outOfRange(name, 'an integer', v);
+  } else if (v < min
+             || v > max) {
+    throw outOfRange(name, '>= ' + min + ' && <= ' + max, v);
+  }
+}
+
+function validateBuffer(v, name) {
+  if (!isBuffer(v)) {
+    throw invalidArgType(name, 'an instance of Buffer or Uint8Array', v);
+  }
+}
+
+function boundsError(v, length, name) {
+  if (Math.floor(v) !== v) {
+    validateNumber(v, name || 'offset');
+    throw outOfRange(name || 'offset', 'an integer', v);
+  } else if (length < 0) {
+    throw outOfBounds();
+  }
+  throw outOfRange(name || 'offset', '>= ' + (name ? 1 : 0) + ' and <= ' + length, v);
+}
+
+function normalizeEncoding(enc) {
+  var s = util.normalizeEncoding(enc);
+  if (s === undefined) {
+    throw util.unknownEncoding(enc);
+  }
+  return s;
+}
+
+function trunc(n) {
+  n = +n;
+  return n < 0 ? Math.ceil(n) : Math.floor(n);
+}
+
+//
+// Buffer
+//
+
+function isBuffer(b) {
+  return b instanceof Buffer;
+}
+
+function Buffer(arg, encodingOrOffset, length) {
+  process.emitWarning('Buffer() is deprecated due to security and usability issues. Please use the Buffer.alloc(), Buffer.allocUnsafe(), or Buffer.from() methods instead.', 'DeprecationWarning', 'DEP0005');
+  if (typeof arg === 'number') {
+    if (typeof encodingOrOffset === 'string') {
+      throw invalidArgType('string', 'of type string', arg);
+    }
+    return Buffer.alloc(arg);
+  }
+  return Buffer.from(arg, encodingOrOffset, length);
+}
+
+exports.Buffer = Buffer;
+
+function createBuffer(length) {
+  return Object.create(Buffer.prototype, {
+    length: { value: length },
+  });
+}
+
+binding.setup(createBuffer, isBuffer);
+
+Buffer.poolSize = 8192;
+
+Buffer.isBuffer = isBuffer;
+
+Buffer.isEncoding = function isEncoding(enc) {
+  return typeof enc === 'string'
+         && enc.length !== 0
+         && util.normalizeEncoding(enc) !== undefined;
+};
+
+function validateSize(size) {
+  validateNumber(size, 'size');
+  if (!(size >= 0 && size <= kMaxLength)) {
+    throw outOfRange('size', '>= 0 && <= ' + kMaxLength, size);
+  }
+}
+
+Buffer.alloc = function alloc(size, fill, encoding) {
+  validateSize(size);
+  var b = binding.alloc(size);
+  if (fill !== undefined
+      && fill !== 0
+      && size > 0) {
+    return b.fill(fill, encoding);
+  }
+  return b;
+};
+
+Buffer.allocUnsafe = function allocUnsafe(size) {
+  validateSize(size);
+  return binding.alloc(size);
+};
+
+Buffer.allocUnsafeSlow = Buffer.allocUnsafe;
+
+Buffer.from = function from(value, encodingOrOffset, length) {
+  if (typeof value === 'string') {
+    if (typeof encodingOrOffset !== 'string'
+        || encodingOrOffset.length === 0) {
+      return binding.from(value, 'utf8');
+    }
+    return binding.from(value, normalizeEncoding(encodingOrOffset));
+  } else if (value !== null
+             && typeof value === 'object') {
+    if (isBuffer(value)) {
+      return binding.from(value);
+    }
+    var v = typeof value.valueOf === 'function' ? value.valueOf() : value;
+    if (v !== null
+        && v !== undefined
+        && v !== value
+        && (typeof v === 'string' || typeof v === 'object')) {
+      return Buffer.from(v, encodingOrOffset, length);
+    } else if (value.length !== undefined) {
+      return typeof value.length === 'number' ? binding.from(value) : binding.alloc(0);
+    } else if (value.type === 'Buffer'
+               && Array.isArray(value.data)) {
+      return binding.from(value.data);
+    }
+  }
+  throw invalidArgType('first argument', 'of type string or an instance of Buffer, ArrayBuffer, or Array or an Array-like Object', value);
+};
+
+Buffer.of = function of() {
+  return binding.from(arguments);
+};
+
+Buffer.byteLength = function byteLength(string, encoding) {
+  if (typeof string !== 'string') {
+    if (isBuffer(string)) {
+      return string.length;
+    }
+    throw invalidArgType('string', 'of type string or an instance of Buffer or ArrayBuffer', string);
+  }
+  var n = string.length;
+  switch (util.normalizeEncoding(encoding)) {
+  case 'latin1':
+  case 'ascii':
+    return n;
+  case 'utf16le':
+    return n * 2;
+  case 'hex':
+    return n >>> 1;
+  case 'base64':
+  case 'base64url':
+    if (string.charAt(n - 1) === '=') {
+      n--;
+    }
+    if (n > 1
+        && string.charAt(n - 1) === '=') {
+      n--;
+    }
+    return (n * 3) >>> 2;
+  }
+  return binding.byteLength(string, 'utf8');
+};
+
+Buffer.compare = function compare(buf1, buf2) {
+  validateBuffer(buf1, 'buf1');
+  validateBuffer(buf2, 'buf2');
+  return binding.compare(buf1, buf2);
+};
+
+Buffer.concat = function concat(list, length) {
+  if (!Array.isArray(list)) {
+    throw invalidArgType('list', 'an instance of Array', list);
+  } else if (list.length === 0) {
+    return binding.alloc(0);
+  }
+
+  var i;
+  if (length === undefined) {
+    length = 0;
+    for (i = 0; i < list.length; i++) {
+      if (list[i].length) {
+        length += list[i].length;
+      }
+    }
+  } else {
+    validateOffset(length, 'length');
+  }
+
+  var b = binding.alloc(length);
+  var pos = 0;
+  for (i = 0; i < list.length; i++) {
+    if (!isBuffer(list[i])) {
+      throw invalidArgType('list[' + i + ']', 'an instance of Buffer or Uint8Array', list[i]);
+    }
+    pos += binding.copy(list[i], b, pos);
+  }
+  return b;
+};
+
+Buffer.prototype.toString = function toString(encoding, start, end) {
+  if (arguments.length === 0) {
+    return binding.toString(this, 'utf8');
+  }
+
+  var len = this.length;
+  if (start <= 0) {
+    start = 0;
+  } else if (start >= len) {
+    return '';
+  } else {
+    start = trunc(start) || 0;
+  }
+  if (end === undefined
+      || end > len) {
+    end = len;
+  } else {
+    end = trunc(end) || 0;
+  }
+  if (end <= start) {
+    return '';
+  }
+  return binding.toString(this, encoding === undefined ? 'utf8' : normalizeEncoding(encoding), start, end);
+};
+
+Buffer.prototype.toLocaleString = Buffer.prototype.toString;
+
+Buffer.prototype.toJSON = function toJSON() {
+  var data = new Array(this.length);
+  for (var i = 0; i < data.length; i++) {
+    data[i] = this[i] & 0xff;
+  }
+  return {
+    type: 'Buffer',
+    data: data,
+  };
+};
+
+Buffer.prototype[inspect.custom] = function(depth, options) {
+  var max = exports.INSPECT_MAX_BYTES;
+  var s = binding.toString(this, 'hex', 0, Math.min(max, this.length)).replace(/(.{2})/g, '$1 ').replace(/ $/, '');
+  var n = this.length - max;
+  if (n > 0) {
+    s += ' ... ' + n + ' more byte' + (n > 1 ? 's' : '');
+  }
+  return '<' + this.constructor.name + ' ' + s + '>';
+};
+
+Buffer.prototype.equals = function equals(otherBuffer) {
+  validateBuffer(otherBuffer, 'otherBuffer');
+  return this === otherBuffer
+         || binding.compare(this, otherBuffer) === 0;
+};
+
+Buffer.prototype.compare = function compare(target, targetStart, targetEnd, sourceStart, sourceEnd) {
+  validateBuffer(target, 'target');
+  if (targetStart === undefined) {
+    targetStart = 0;
+  } else {
+    validateOffset(targetStart, 'targetStart');
+  }
+  if (targetEnd === undefined) {
+    targetEnd = target.length;
+  } else {
+    validateOffset(targetEnd, 'targetEnd', 0, target.length);
+  }
+  if (sourceStart === undefined) {
+    sourceStart = 0;
+  } else {
+    validateOffset(sourceStart, 'sourceStart');
+  }
+  if (sourceEnd === undefined) {
+    sourceEnd = this.length;
+  } else {
+    validateOffset(sourceEnd, 'sourceEnd', 0, this.length);
+  }
+
+  if (sourceStart >= sourceEnd) {
+    return targetStart >= targetEnd ? 0 : -1;
+  } else if (targetStart >= targetEnd) {
+    return 1;
+  }
+  return binding.compare(this, target, sourceStart, sourceEnd, targetStart, targetEnd);
+};
+
+function indexOf(buf, v, byteOffset, encoding, forward) {
+  if (typeof byteOffset === 'string') {
+    encoding = byteOffset;
+    byteOffset = undefined;
+  } else if (byteOffset > 0x7fffffff) {
+    byteOffset = 0x7fffffff;
+  } else if (byteOffset < -0x80000000) {
+    byteOffset = -0x80000000;
+  }
+  byteOffset = +byteOffset;
+  if (byteOffset !== byteOffset) {
+    byteOffset = forward ? 0 : buf.length;
+  }
+
+  if (typeof v === 'number') {
+    return binding.indexOf(buf, (v >>> 0) & 0xff, byteOffset, 'latin1', forward);
+  }
+  var enc = encoding === undefined ? 'utf8' : util.normalizeEncoding(encoding);
+  if (typeof v === 'string') {
+    if (enc === undefined) {
+      throw util.unknownEncoding(encoding);
+    }
+    return binding.indexOf(buf, v, byteOffset, enc, forward);
+  } else if (isBuffer(v)) {
+    return binding.indexOf(buf, v, byteOffset, 'utf8', forward);
+  }
+  throw invalidArgType('value', 'one of type number or string or an instance of Buffer or Uint8Array', v);
+}
+
+Buffer.prototype.indexOf = function(value, byteOffset, encoding) {
+  return indexOf(this, value, byteOffset, encoding, true);
+};
+
+Buffer.prototype.lastIndexOf = function(value, byteOffset, encoding) {
+  return indexOf(this, value, byteOffset, encoding, false);
+};
+
+Buffer.prototype.includes = function includes(value, byteOffset, encoding) {
+  return this.indexOf(value, byteOffset, encoding) !== -1;
+};
+
+Buffer.prototype.fill = function fill(value, offset, end, encoding) {
+  var enc;
+  if (typeof value === 'string') {
+    if (offset === undefined
+        || typeof offset === 'string') {
+      encoding = offset;
+      offset = 0;
+      end = this.length;
+    } else if (typeof end === 'string') {
+      encoding = end;
+      end = this.length;
+    }
+    enc = util.normalizeEncoding(encoding);
+    if (enc === undefined) {
+      if (typeof encoding !== 'string') {
+        throw invalidArgType('encoding', 'of type string', encoding);
+      }
+      throw util.unknownEncoding(encoding);
+    } else if (value.length === 0) {
+      value = 0;
+    }
+  }
+
+  if (offset === undefined) {
+    offset = 0;
+    end = this.length;
+  } else {
+    validateOffset(offset, 'offset');
+    if (end === undefined) {
+      end = this.length;
+    } else {
+      validateOffset(end, 'end', 0, this.length);
+    }
+    if (offset >= end) {
+      return this;
+    }
+  }
+
+  if (typeof value === 'number') {
+    value &= 0xff;
+  } else if (typeof value !== 'string'
+             && !isBuffer(value)) {
+    value = (value >>> 0) & 0xff;
+  }
+  if (binding.fill(this, value, offset, end, enc) < 0) {
+    throw invalidArgValue('value', value);
+  }
+  return this;
+};
+
+Buffer.prototype.write = function write(string, offset, length, encoding) {
+  if (typeof string !== 'string') {
+    var e = new TypeError('argument must be a string');
+    e.code = 'ERR_INVALID_ARG_TYPE';
+    throw e;
+  }
+
+  if (offset === undefined) {
+    return binding.write(this, string, 0, this.length, 'utf8');
+  } else if (length === undefined
+             && typeof offset === 'string') {
+    encoding = offset;
+    length = this.length;
+    offset = 0;
+  } else {
+    validateOffset(offset, 'offset', 0, this.length);
+    var remaining = this.length - offset;
+    if (length === undefined) {
+      length = remaining;
+    } else if (typeof length === 'string') {
+      encoding = length;
+      length = remaining;
+    } else {
+      validateOffset(length, 'length', 0, this.length);
+      if (length > remaining) {
+        length = remaining;
+      }
+    }
+  }
+  return binding.write(this, string, offset, length, encoding ? normalizeEncoding(encoding) : 'utf8');
+};
+
+function toInteger(n, defaultValue) {
+  n = +n;
+  if (n === n
+      && n >= -9007199254740991
+      && n <= 9007199254740991) {
+    return n % 1 === 0 ? n : Math.floor(n);
+  }
+  return defaultValue;
+}
+
+Buffer.prototype.copy = function copy(target, targetStart, sourceStart, sourceEnd) {
+  validateBuffer(this, 'source');
+  validateBuffer(target, 'target');
+  if (targetStart === undefined) {
+    targetStart = 0;
+  } else {
+    targetStart = toInteger(targetStart, 0);
+    if (targetStart < 0) {
+      throw outOfRange('targetStart', '>= 0', targetStart);
+    }
+  }
+  if (sourceStart === undefined) {
+    sourceStart = 0;
+  } else {
+    sourceStart = toInteger(sourceStart, 0);
+    if (sourceStart < 0
+        || sourceStart > this.length) {
+      throw outOfRange('sourceStart', '>= 0 && <= ' + this.length, sourceStart);
+    }
+  }
+  if (sourceEnd === undefined) {
+    sourceEnd = this.length;
+  } else {
+    sourceEnd = toInteger(sourceEnd, 0);
+    if (sourceEnd < 0) {
+      throw outOfRange('sourceEnd', '>= 0', sourceEnd);
+    }
+  }
+
+  if (targetStart >= target.length
+      || sourceStart >= sourceEnd) {
+    return 0;
+  }
+  return binding.copy(this, target, targetStart, sourceStart, sourceEnd);
+};
+
+function adjustOffset(offset, length) {
+  offset = trunc(offset);
+  if (offset === 0
+      || offset !== offset) {
+    return 0;
+  } else if (offset < 0) {
+    offset += length;
+    return offset > 0 ? offset : 0;
+  }
+  return offset < length ? offset : length;
+}
+
+Buffer.prototype.subarray = function subarray(start, end) {
+  var len = this.length;
+  start = adjustOffset(start, len);
+  end = end !== undefined ? adjustOffset(end, len) : len;
+  return binding.slice(this, start, end > start ? end : start);
+};
+
+Buffer.prototype.slice = Buffer.prototype.subarray;
+
+function swap(b, n) {
+  if (b.length % n !== 0) {
+    var e = new RangeError('Buffer size must be a multiple of ' + n * 8 + '-bits');
+    e.code = 'ERR_INVALID_BUFFER_SIZE';
+    throw e;
+  }
+  for (var i = 0; i < b.length; i += n) {
+    for (var j = 0; j < n / 2; j++) {
+      var c = b[i + j];
+      b[i + j] = b[i + n - 1 - j];
+      b[i + n - 1 - j] = c;
+    }
+  }
+  return b;
+}
+
+Buffer.prototype.swap16 = function swap16() {
+  return swap(this, 2);
+};
+
+Buffer.prototype.swap32 = function swap32() {
+  return swap(this, 4);
+};
+
+Buffer.prototype.swap64 = function swap64() {
+  return swap(this, 8);
+};
+
+//
+// read/write
+//
+
+function readInt(b, offset, n, le, signed) {
+  validateNumber(offset, 'offset');
+  if (b[offset] === undefined
+      || b[offset + n - 1] === undefined) {
+    boundsError(offset, b.length - n);
+  }
+
+  var v = 0;
+  for (var i = 0; i < n; i++) {
+    v += (b[offset + (le ? i : n - 1 - i)] & 0xff) * Math.pow(2, 8 * i);
+  }
+  if (signed
+      && v >= Math.pow(2, 8 * n - 1)) {
+    v -= Math.pow(2, 8 * n);
+  }
+  return v;
+}
+
+function writeInt(b, value, offset, n, le, signed) {
+  value = +value;
+  var min = signed ? -Math.pow(2, 8 * n - 1) : 0;
+  var max = signed ? Math.pow(2, 8 * n - 1) - 1 : Math.pow(2, 8 * n) - 1;
+  if (n === 1) {
+    validateNumber(offset, 'offset');
+  }
+  if (value > max
+      || value < min) {
+    var range;
+    if (n > 4) {
+      range = signed ? '>= -(2 ** ' + (8 * n - 1) + ') and < 2 ** ' + (8 * n - 1) : '>= 0 and < 2 ** ' + 8 * n;
+    } else {
+      range = '>= ' + min + ' and <= ' + max;
+    }
+    throw outOfRange('value', range, value);
+  }
+  validateNumber(offset, 'offset');
+  if (b[offset] === undefined
+      || b[offset + n - 1] === undefined) {
+    boundsError(offset, b.length - n);
+  }
+
+  value = value === value ? trunc(value) : 0;
+  if (value < 0) {
+    value += Math.pow(2, 8 * n);
+  }
+  for (var i = 0; i < n; i++) {
+    b[offset + (le ? i : n - 1 - i)] = Math.floor(value / Math.pow(2, 8 * i)) % 256;
+  }
+  return offset + n;
+}
+
+function validateByteLength(offset, byteLength) {
+  if (offset === undefined) {
+    throw invalidArgType('offset', 'of type number', offset);
+  } else if (typeof byteLength !== 'number'
+             || !(byteLength >= 1 && byteLength <= 6 && byteLength % 1 === 0)) {
+    boundsError(byteLength, 6, 'byteLength');
+  }
+}
+
+[
+  ['UInt8', 1, false],
+  ['UInt16', 2, false],
+  ['UInt32', 4, false],
+  ['Int8', 1, true],
+  ['Int16', 2, true],
+  ['Int32', 4, true],
+].forEach(function(a) {
+  var name = a[0];
+  var n = a[1];
+  var signed = a[2];
+  [n === 1 ? [''] : ['LE', true], n === 1 ? null : ['BE', false]].forEach(function(o) {
+    if (o) {
+      var le = n === 1 || o[1];
+      Buffer.prototype['read' + name + o[0]] = function(offset) {
+        return readInt(this, offset === undefined ? 0 : offset, n, le, signed);
+      };
+      Buffer.prototype['write' + name + o[0]] = function(value, offset) {
+        return writeInt(this, value, offset === undefined ? 0 : offset, n, le, signed);
+      };
+    }
+  });
+});
+
+['LE', 'BE'].forEach(function(o) {
+  var le = o === 'LE';
+  Buffer.prototype['readUInt' + o] = function(offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return readInt(this, offset, byteLength, le, false);
+  };
+  Buffer.prototype['readInt' + o] = function(offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return readInt(this, offset, byteLength, le, true);
+  };
+  Buffer.prototype['writeUInt' + o] = function(value, offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return writeInt(this, value, offset, byteLength, le, false);
+  };
+  Buffer.prototype['writeInt' + o] = function(value, offset, byteLength) {
+    validateByteLength(offset, byteLength);
+    return writeInt(this, value, offset, byteLength, le, true);
+  };
+
+  [['Float', 4], ['Double', 8]].forEach(function(a) {
+    var n = a[1];
+    Buffer.prototype['read' + a[0] + o] = function(offset) {
+      if (offset === undefined) {
+        offset = 0;
+      }
+      validateNumber(offset, 'offset');
+      if (this[offset] === undefined
+          || this[offset + n - 1] === undefined) {
+        boundsError(offset, this.length - n);
+      }
+      return binding.readFloat(this, offset, n, le);
+    };
+    Buffer.prototype['write' + a[0] + o] = function(value, offset) {
+      if (offset === undefined) {
+        offset = 0;
+      }
+      value = +value;
+      validateNumber(offset, 'offset');
+      if (this[offset] === undefined
+          || this[offset + n - 1] === undefined) {
+        boundsError(offset, this.length - n);
+      }
+      binding.writeFloat(this, value, offset, n, le);
+      return offset + n;
+    };
+  });
+});
+
+Object.getOwnPropertyNames(Buffer.prototype).forEach(function(k) {
+  if (/^(read|write)UInt/.test(k)) {
+    Buffer.prototype[k.replace('UInt', 'Uint')] = Buffer.prototype[k];
+  }
+});
@@ -0,0 +1,474 @@
+//
+// otto.module :: child_process.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('child_process');
+var EventEmitter = require('./events');
+var util = require('./internal/util');
+
+var MAX_BUFFER = 1024 * 1024;
+
+//
+// errors
+//
+
+function errnoException(ret, syscall, file, args) {
+  var e = new Error(syscall + ' ' + file + ' ' + ret.error);
+  e.errno = ret.errno;
+  e.code = ret.error;
+  e.syscall = syscall + ' ' + file;
+  e.path = file;
+  e.spawnargs = args.slice(1);
+  return e;
+}
+
+function validateString(v, name) {
+  if (typeof v !== 'string') {
+    throw util.invalidArgType(name, 'of type string', v);
+  }
+}
+
+function validateObject(v, name) {
+  if (v === null
+      || typeof v !== 'object'
+      || Array.isArray(v)) {
+    throw util.invalidArgType(name, 'of type object', v);
+  }
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function validateTimeout(v) {
+  if (v !== undefined
+      && !(typeof v === 'number' && Math.floor(v) === v && v >= 0)) {
+    throw util.outOfRange('timeout', 'an unsigned integer', v);
+  }
+}
+
+function validateMaxBuffer(v) {
+  if (v !== undefined
+      && !(typeof v === 'number' && v >= 0)) {
+    throw util.outOfRange('options.maxBuffer', 'a positive number', v);
+  }
+}
+
+function assign(dst, src) {
+  Object.keys(src).forEach(function(k) {
+    dst[k] = src[k];
+  });
+  return dst;
+}
+
+function decode(buf, enc) {
+  if (buf
+      && enc
+      && enc !== 'buffer') {
+    return buf.toString(util.normalizeEncoding(enc) || 'utf8');
+  }
+  return buf;
+}
+
+//
+// arguments
+//
+
+function normalizeSpawnArguments(file, args, options) {
+  validateString(file, 'file');
+  if (file.length === 0) {
+    var e = new TypeError("The argument 'file' cannot be empty. Received ''");
+    e.code = 'ERR_INVALID_ARG_VALUE';
+    throw e;
+  }
+  if (Array.isArray(args)) {
+    args = args.slice();
+  } else if (args === undefined
+             || args === null) {
+    args = [];
+  } else if (typeof args !== 'object') {
+    throw util.invalidArgType('args', 'an instance of Array', args);
+  } else {
+    options = args;
+    args = [];
+  }
+  if (options === undefined) {
+    options = {};
+  } else {
+    validateObject(options, 'options');
+  }
+  options = assign({}, options || {});
+
+  if (options.cwd !== undefined
+      && options.cwd !== null) {
+    validateString(options.cwd, 'options.cwd');
+  }
+  validateTimeout(options.timeout);
+  validateMaxBuffer(options.maxBuffer);
+
+  var command;
+  if (options.shell) {
+    command = [file].concat(args).join(' ');
+    if (process.platform === 'win32') {
+      file = typeof options.shell === 'string' ? options.shell : process.env.ComSpec || 'cmd.exe';
+      args = ['/d', '/s', '/c', '"' + command + '"'];
+    } else {
+      file = typeof options.shell === 'string' ? options.shell : '/bin/sh';
+      args = ['-c', command];
+    }
+  }
+
+  var env;
+  if (options.env) {
+    env = Object.keys(options.env).filter(function(k) {
+      return options.env[k] !== undefined;
+    }).map(function(k) {
+      return k + '=' + options.env[k];
+    });
+  }
+
+  if (typeof options.stdio === 'string') {
+    options.stdio = [options.stdio, options.stdio, options.stdio];
+  }
+  if (Array.isArray(options.stdio)) {
+    options.stdio = options.stdio.map(function(s) {
+      if (s === null
+          || s === undefined) {
+        return 'pipe';
+      } else if (s === 'pipe'
+                 || s === 'inherit'
+                 || s === 'ignore') {
+        return s;
+      } else if (s === process.stdout
+                 || s === process.stderr
+                 || s === 1
+                 || s === 2) {
+        return 'inherit';
+      }
+      throw util.invalidArgValue('stdio', s);
+    });
+  }
+
+  return {
+    file: file,
+    args: [file].concat(args),
+    options: options,
+    binding: {
+      cwd: options.cwd,
+      env: env,
+      input: options.input === undefined ? undefined : Buffer.from(options.input),
+      timeout: options.timeout,
+      maxBuffer: options.maxBuffer,
+      killSignal: options.killSignal,
+      stdio: options.stdio,
+      shell: command,
+    },
+  };
+}
+
+function normalizeExecArgs(command, options, callback) {
+  validateString(command, 'command');
+  if (typeof options === 'function') {
+    callback = options;
+    options = undefined;
+  }
+  options = assign({}, options || {});
+  options.shell = typeof options.shell === 'string' ? options.shell : true;
+  return {
+    file: command,
+    options: options,
+    callback: callback,
+  };
+}
+
+//
+// sync
+//
+
+function spawnSync(file, args, options) {
+  return spawnSyncImpl(normalizeSpawnArguments(file, args, options));
+}
+
+function spawnSyncImpl(opts) {
+  if (opts.options.maxBuffer === undefined) {
+    opts.binding.maxBuffer = MAX_BUFFER;
+  }
+  var ret = binding.spawnSync(opts.file, opts.args.slice(1), opts.binding);
+
+  var result = {
+    status: ret.status,
+    signal: ret.signal,
+    output: null,
+    pid: ret.pid,
+    stdout: null,
+    stderr: null,
+  };
+  if (ret.pid !== 0) {
+    result.stdout = decode(ret.stdout, opts.options.encoding);
+    result.stderr = decode(ret.stderr, opts.options.encoding);
+    result.output = [null, result.stdout, result.stderr];
+  }
+  if (ret.error) {
+    result = assign({ error: errnoException(ret, 'spawnSync', opts.file, opts.args) }, result);
+  }
+  return result;
+}
+
+function checkExecSyncError(ret, args, cmd) {
+  var e;
+  if (ret.error) {
+    e = ret.error;
+    assign(e, ret);
+  } else if (ret.status !== 0) {
+    var msg = 'Command failed: ' + (cmd || args.join(' '));
+    if (ret.stderr
+        && ret.stderr.length > 0) {
+      msg += '\n' + ret.stderr.toString();
+    }
+    e = assign(new Error(msg), ret);
+  }
+  return e;
+}
+
+function execSyncImpl(opts, cmd) {
+  if (opts.options.stdio === undefined) {
+    // stderr is written to the parent
+    opts.binding.stdio = ['pipe', 'pipe', 'inherit'];
+  }
+  var ret = spawnSyncImpl(opts);
+
+  var e = checkExecSyncError(ret, opts.args, cmd);
+  if (e) {
+    throw e;
+  }
+  return ret.stdout;
+}
+
+function execFileSync(file, args, options) {
+  return execSyncImpl(normalizeSpawnArguments(file, args, options));
+}
+
+function execSync(command, options) {
+  var opts = normalizeExecArgs(command, options);
+  return execSyncImpl(normalizeSpawnArguments(opts.file, opts.options), command);
+}
+
+exports.spawnSync = spawnSync;
+exports.execFileSync = execFileSync;
+exports.execSync = execSync;
+
+//
+// ChildProcess
+//
+
+function ChildProcess() {
+  EventEmitter.call(this);
+
+  this.connected = false;
+  this.signalCode = null;
+  this.exitCode = null;
+  this.killed = false;
+  this.spawnfile = null;
+  this.spawnargs = [];
+  this.pid = undefined;
+  this.stdin = null;
+  this.stdout = null;
+  this.stderr = null;
+  this.stdio = [null, null, null];
+  Object.defineProperty(this, '_handle', {
+    value: null,
+    writable: true,
+    configurable: true,
+  });
+}
+
+ChildProcess.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: ChildProcess,
+    writable: true,
+    configurable: true,
+  },
+});
+
+ChildProcess.prototype.kill = function kill(sig) {
+  if (!this._handle) {
+    return false;
+  }
+  if (this._handle.kill(sig)) {
+    this.killed = true;
+    return true;
+  }
+  return false;
+};
+
+exports.ChildProcess = ChildProcess;
+
+function flushStdio(child) {
+  // unconsumed output would otherwise keep the child from closing
+  for (var i = 1; i < child.stdio.length; i++) {
+    var stream = child.stdio[i];
+    if (stream
+        && stream.readable
+        && !stream._readableState.readableListening
+        && stream.readableFlowing === null) {
+      stream.resume();
+    }
+  }
+}
+
+function spawn(file, args, options) {
+  var opts = normalizeSpawnArguments(file, args, options);
+  opts.binding.stream = true;
+
+  var child = new ChildProcess();
+  child.spawnfile = opts.file;
+  child.spawnargs = opts.args;
+
+  var closes = 1;
+  function maybeClose() {
+    if (--closes === 0) {
+      child.emit('close', child.exitCode, child.signalCode);
+    }
+  }
+
+  var handle = binding.spawn(opts.file, opts.args.slice(1), opts.binding, function onexit(ret) {
+    child._handle = null;
+    if (ret.error === 'ETIMEDOUT') {
+      child.killed = true;
+    }
+    child.exitCode = ret.status;
+    child.signalCode = ret.signal;
+    child.emit('exit', ret.status, ret.signal);
+    process.nextTick(flushStdio, child);
+    maybeClose();
+  });
+  if (handle.error) {
+    var e = errnoException(handle, 'spawn', opts.file, opts.args);
+    child.exitCode = handle.errno;
+    process.nextTick(function() {
+      child.emit('error', e);
+      maybeClose();
+    });
+    return child;
+  }
+
+  child.pid = handle.pid;
+  child._handle = handle;
+  child.stdin = handle.stdin;
+  child.stdout = handle.stdout;
+  child.stderr = handle.stderr;
+  child.stdio = [child.stdin, child.stdout, child.stderr];
+  [child.stdout, child.stderr].forEach(function(stream) {
+    if (stream) {
+      closes++;
+      stream.on('close', maybeClose);
+    }
+  });
+  return child;
+}
+
+//
+// async
+//
+
+function execFile(file, args, options, callback) {
+  if (typeof args === 'function') {
+    callback = args;
+    args = undefined;
+    options = undefined;
+  } else if (typeof options === 'function') {
+    callback = options;
+    options = undefined;
+  }
+  if (!Array.isArray(args)
+      && args !== null
+      && typeof args === 'object') {
+    options = args;
+    args = undefined;
+  }
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  options = assign({
+    encoding: 'utf8',
+    timeout: 0,
+    maxBuffer: MAX_BUFFER,
+    killSignal: 'SIGTERM',
+  }, options || {});
+
+  var opts = normalizeSpawnArguments(file, args, options);
+  opts.binding.stdio = ['ignore', 'pipe', 'pipe'];
+  var cmd = [file].concat(args || []).join(' ');
+
+  var child = new ChildProcess();
+  child.spawnfile = opts.file;
+  child.spawnargs = opts.args;
+
+  function done(e, stdout, stderr) {
+    if (callback) {
+      callback(e, stdout, stderr);
+    }
+  }
+
+  var handle = binding.spawn(opts.file, opts.args.slice(1), opts.binding, function onexit(ret) {
+    child._handle = null;
+    var stdout = decode(ret.stdout, options.encoding);
+    var stderr = decode(ret.stderr, options.encoding);
+    var e = null;
+    switch (ret.error) {
+    case 'ETIMEDOUT':
+      child.killed = true;
+      break;
+    case 'ENOBUFS':
+      e = new RangeError('stdout maxBuffer length exceeded');
+      e.code = 'ERR_CHILD_PROCESS_STDIO_MAXBUFFER';
+      child.killed = true;
+      break;
+    }
+    child.exitCode = ret.status;
+    child.signalCode = ret.signal;
+    if (!e
+        && (ret.status !== 0 || ret.signal)) {
+      e = new Error('Command failed: ' + cmd + '\n' + (ret.stderr ? ret.stderr.toString() : ''));
+      e.code = ret.status;
+      e.killed = child.killed;
+      e.signal = ret.signal;
+      e.cmd = cmd;
+    }
+    child.emit('exit', ret.status, ret.signal);
+    child.emit('close', ret.status, ret.signal);
+    done(e, stdout, stderr);
+  });
+  if (handle.error) {
+    var e = errnoException(handle, 'spawn', opts.file, opts.args);
+    e.cmd = cmd;
+    process.nextTick(function() {
+      if (!callback
+          || child.listenerCount('error') > 0) {
+        child.emit('error', e);
+      }
+      done(e, decode(Buffer.alloc(0), options.encoding), decode(Buffer.alloc(0), options.encoding));
+    });
+  } else {
+    child.pid = handle.pid;
+    child._handle = handle;
+  }
+  return child;
+}
+
+function exec(command, options, callback) {
+  var opts = normalizeExecArgs(command, options, callback);
+  return execFile(opts.file, opts.options, opts.callback);
+}
+
+exports.spawn = spawn;
+exports.execFile = execFile;
+exports.exec = exec;
@@ -0,0 +1,400 @@
+//
+// otto.module :: console.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('console');
+var _inspect = require('./internal/util/inspect');
+var util = require('./internal/util');
+
+var inspect = _inspect.inspect;
+var formatWithOptions = _inspect.formatWithOptions;
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+
+var stderrMethods = {
+  warn: true,
+  error: true,
+  trace: true,
+  assert: true,
+};
+
+function writableStream(name) {
+  var e = new TypeError('Console expects a writable stream instance for ' + name);
+  e.code = 'ERR_CONSOLE_WRITABLE_STREAM';
+  return e;
+}
+
+function hidden(o, k, v) {
+  Object.defineProperty(o, k, {
+    value: v,
+    writable: true,
+    configurable: true,
+  });
+}
+
+function repeat(s, n) {
+  return new Array(n + 1).join(s);
+}
+
+function stringWidth(s) {
+  var n = 0;
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charCodeAt(i);
+    if (c >= 0xd800 && c <= 0xdbff) {
+      i++;
+      n += 2;
+    } else if ((c >= 0x1100 && c <= 0x115f)
+               || (c >= 0x2e80 && c <= 0xa4cf && c !== 0x303f)
+               || (c >= 0xac00 && c <= 0xd7a3)
+               || (c >= 0xf900 && c <= 0xfaff)
+               || (c >= 0xfe30 && c <= 0xfe4f)
+               || (c >= 0xff00 && c <= 0xff60)
+               || (c >= 0xffe0 && c <= 0xffe6)) {
+      n += 2;
+    } else if (c >= 0x20
+               && !(c >= 0x7f && c <= 0x9f)
+               && !(c >= 0x300 && c <= 0x36f)
+               && c !== 0x200b) {
+      n++;
+    }
+  }
+  return n;
+}
+
+function formatTime(ms) {
+  var hours = 0;
+  var minutes = 0;
+  var seconds = 0;
+  if (ms >= 1000) {
+    if (ms >= 60000) {
+      if (ms >= 3600000) {
+        hours = Math.floor(ms / 3600000);
+        ms %= 3600000;
+      }
+      minutes = Math.floor(ms / 60000);
+      ms %= 60000;
+    }
+    seconds = ms / 1000;
+  }
+  if (hours !== 0
+      || minutes !== 0) {
+    var a = seconds.toFixed(3).split('.');
+    var pad = function(n) {
+      return ('0' + n).slice(-2);
+    };
+    return (hours !== 0 ? hours + ':' + pad(minutes) : minutes) + ':' + pad(a[0]) + '.' + a[1] + ' (' + (hours !== 0 ? 'h:m' : '') + 'm:ss.mmm)';
+  } else if (seconds !== 0) {
+    return seconds.toFixed(3) + 's';
+  }
+  return Number(ms.toFixed(3)) + 'ms';
+}
+
+function renderRow(row, widths) {
+  var out = '│ ';
+  for (var i = 0; i < row.length; i++) {
+    out += row[i] + repeat(' ', widths[i] - stringWidth(row[i]));
+    if (i !== row.length - 1) {
+      out += ' │ ';
+    }
+  }
+  return out + ' │';
+}
+
+function renderTable(head, columns) {
+  var rows = [];
+  var widths = head.map(stringWidth);
+  var longest = Math.max.apply(Math, columns.map(function(a) {
+    return a.length;
+  }));
+  for (var i = 0; i < head.length; i++) {
+    for (var j = 0; j < longest; j++) {
+      if (rows[j] === undefined) {
+        rows[j] = [];
+      }
+      var v = rows[j][i] = hasOwnProperty.call(columns[i], j) ? columns[i][j] : '';
+      widths[i] = Math.max(widths[i], stringWidth(v));
+    }
+  }
+  var divider = widths.map(function(n) {
+    return repeat('─', n + 2);
+  });
+  var s = '┌' + divider.join('┬') + '┐\n'
+        + renderRow(head, widths) + '\n'
+        + '├' + divider.join('┼') + '┤\n';
+  rows.forEach(function(row) {
+    s += renderRow(row, widths) + '\n';
+  });
+  return s + '└' + divider.join('┴') + '┘';
+}
+
+//
+// Console
+//
+
+function Console(options) {
+  if (!(this instanceof Console)) {
+    var self = Object.create(Console.prototype);
+    Console.apply(self, arguments);
+    return self;
+  }
+
+  if (!options
+      || typeof options.write === 'function') {
+    options = {
+      stdout: options,
+      stderr: arguments[1],
+      ignoreErrors: arguments[2],
+    };
+  }
+  var stdout = options.stdout;
+  var stderr = options.stderr || stdout;
+  if (!stdout
+      || typeof stdout.write !== 'function') {
+    throw writableStream('stdout');
+  } else if (typeof stderr.write !== 'function') {
+    throw writableStream('stderr');
+  }
+  var groupIndentation = options.groupIndentation === undefined ? 2 : options.groupIndentation;
+  if (typeof groupIndentation !== 'number') {
+    throw util.invalidArgType('groupIndentation', 'of type number', groupIndentation);
+  } else if (Math.floor(groupIndentation) !== groupIndentation
+             || groupIndentation < 0
+             || groupIndentation > 1000) {
+    throw util.outOfRange('groupIndentation', '>= 0 && <= 1000', groupIndentation);
+  }
+
+  hidden(this, '_stdout', stdout);
+  hidden(this, '_stderr', stderr);
+  hidden(this, '_ignoreErrors', options.ignoreErrors !== false);
+  hidden(this, '_inspectOptions', options.inspectOptions);
+  init(this, groupIndentation);
+}
+
+function init(console, groupIndentation) {
+  hidden(console, '_groupIndent', '');
+  hidden(console, '_groupIndentation', groupIndentation);
+  hidden(console, '_counts', Object.create(null));
+  hidden(console, '_times', Object.create(null));
+  // bind methods
+  for (var k in Console.prototype) {
+    if (typeof Console.prototype[k] !== 'function') {
+      continue;
+    } else if (k.charAt(0) === '_') {
+      if (!(console instanceof Console)) {
+        hidden(console, k, Console.prototype[k]);
+      }
+    } else {
+      console[k] = Console.prototype[k].bind(console);
+    }
+  }
+}
+
+Console.prototype._write = function _write(method, s) {
+  if (this._groupIndent) {
+    s = this._groupIndent + s.replace(/\n/g, '\n' + this._groupIndent);
+  }
+  var stream = stderrMethods[method] ? this._stderr : this._stdout;
+  if (!this._ignoreErrors) {
+    stream.write(s + '\n');
+    return;
+  }
+  try {
+    stream.write(s + '\n');
+  } catch (e) {
+    // ignore
+  }
+};
+
+Console.prototype._format = function _format(args) {
+  return formatWithOptions.apply(undefined, [this._inspectOptions].concat(Array.prototype.slice.call(args)));
+};
+
+Console.prototype.log = function log() {
+  this._write('log', this._format(arguments));
+};
+
+Console.prototype.info = function info() {
+  this._write('info', this._format(arguments));
+};
+
+Console.prototype.debug = function debug() {
+  this._write('debug', this._format(arguments));
+};
+
+Console.prototype.warn = function warn() {
+  this._write('warn', this._format(arguments));
+};
+
+Console.prototype.error = function error() {
+  this._write('error', this._format(arguments));
+};
+
+Console.prototype.dirxml = Console.prototype.log;
+
+Console.prototype.dir = function dir(obj, options) {
+  var opts = {};
+  var k;
+  for (k in this._inspectOptions) {
+    if (hasOwnProperty.call(this._inspectOptions, k)) {
+      opts[k] = this._inspectOptions[k];
+    }
+  }
+  opts.customInspect = false;
+  for (k in options) {
+    if (hasOwnProperty.call(options, k)) {
+      opts[k] = options[k];
+    }
+  }
+  this._write('dir', inspect(obj, opts));
+};
+
+Console.prototype.table = function table(data, properties) {
+  if (properties !== undefined
+      && !Array.isArray(properties)) {
+    throw util.invalidArgType('properties', 'an instance of Array', properties);
+  }
+  if (data === null
+      || typeof data !== 'object') {
+    this._write('table', this._format([data]));
+    return;
+  }
+
+  var fmt = function(v) {
+    var depth = v !== null && typeof v === 'object' && !Array.isArray(v) && Object.keys(v).length > 2 ? -1 : 0;
+    return inspect(v, {
+      depth: depth,
+      maxArrayLength: 3,
+      breakLength: Infinity,
+    });
+  };
+  var map = Object.create(null);
+  var keys = [];
+  var primitives = false;
+  var values = [];
+  var index = Object.keys(data);
+  index.forEach(function(k, i) {
+    var item = data[k];
+    var primitive = item === null || (typeof item !== 'function' && typeof item !== 'object');
+    if (properties === undefined
+        && primitive) {
+      primitives = true;
+      values[i] = fmt(item);
+    } else {
+      (properties || Object.keys(item)).forEach(function(key) {
+        if (!map[key]) {
+          map[key] = [];
+          keys.push(key);
+        }
+        map[key][i] = primitive || !hasOwnProperty.call(item, key) ? '' : fmt(item[key]);
+      });
+    }
+  });
+  var columns = keys.map(function(k) {
+    return map[k];
+  });
+  if (primitives) {
+    keys.push('Values');
+    columns.push(values);
+  }
+  this._write('table', renderTable(['(index)'].concat(keys), [index].concat(columns)));
+};
+
+Console.prototype.time = function time(label) {
+  label = label === undefined ? 'default' : String(label);
+  if (hasOwnProperty.call(this._times, label)) {
+    process.emitWarning("Label '" + label + "' already exists for console.time()");
+    return;
+  }
+  this._times[label] = binding.now();
+};
+
+Console.prototype.timeEnd = function timeEnd(label) {
+  label = label === undefined ? 'default' : String(label);
+  if (this._timeLog('timeEnd', label, [])) {
+    delete this._times[label];
+  }
+};
+
+Console.prototype.timeLog = function timeLog(label) {
+  label = label === undefined ? 'default' : String(label);
+  this._timeLog('timeLog', label, Array.prototype.slice.call(arguments, 1));
+};
+
+Console.prototype._timeLog = function _timeLog(method, label, data) {
+  if (!hasOwnProperty.call(this._times, label)) {
+    process.emitWarning("No such label '" + label + "' for console." + method + '()');
+    return false;
+  }
+  var s = label + ': ' + formatTime(binding.now() - this._times[label]);
+  this._write(method, this._format([s].concat(data)));
+  return true;
+};
+
+Console.prototype.count = function count(label) {
+  label = label === undefined ? 'default' : String(label);
+  var n = this._counts[label] = (this._counts[label] || 0) + 1;
+  this._write('count', label + ': ' + n);
+};
+
+Console.prototype.countReset = function countReset(label) {
+  label = label === undefined ? 'default' : String(label);
+  if (!hasOwnProperty.call(this._counts, label)) {
+    process.emitWarning("Count for '" + label + "' does not exist");
+    return;
+  }
+  delete this._counts[label];
+};
+
+Console.prototype.assert = function assert(expression) {
+  if (!expression) {
+    var args = Array.prototype.slice.call(arguments, 1);
+    args[0] = 'Assertion failed' + (args.length === 0 ? '' : ': ' + args[0]);
+    this._write('assert', this._format(args));
+  }
+};
+
+Console.prototype.group = function group() {
+  if (arguments.length > 0) {
+    this._write('group', this._format(arguments));
+  }
+  this._groupIndent += repeat(' ', this._groupIndentation);
+};
+
+Console.prototype.groupCollapsed = Console.prototype.group;
+
+Console.prototype.groupEnd = function groupEnd() {
+  this._groupIndent = this._groupIndent.slice(0, this._groupIndent.length - this._groupIndentation);
+};
+
+Console.prototype.trace = function trace() {
+  var s = 'Trace';
+  if (arguments.length > 0) {
+    s += ': ' + this._format(arguments);
+  }
+  var stack = String(new Error().stack).split('\n').slice(2).filter(Boolean);
+  if (stack.length > 0) {
+    s += '\n' + stack.join('\n');
+  }
+  this._write('trace', s);
+};
+
+//
+// global console
+//
+
+var globalConsole = {};
+init(globalConsole, 2);
+
+hidden(globalConsole, '_write', function _write(method, s) {
+  binding.write(method, s, this._groupIndent);
+});
+
+globalConsole.Console = Console;
+
+module.exports = globalConsole;
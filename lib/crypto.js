@@ -0,0 +1,670 @@
+//
+// otto.module :: crypto.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('crypto');
+var Buffer = require('./buffer').Buffer;
+var StringDecoder = require('./string_decoder').StringDecoder;
+var util = require('./internal/util');
+
+var RAND_MAX = 0xffffffffffff;
+var MAX_SAFE_INTEGER = 9007199254740991;
+
+//
+// errors
+//
+
+function hashFinalized() {
+  var e = new Error('Digest already called');
+  e.code = 'ERR_CRYPTO_HASH_FINALIZED';
+  return e;
+}
+
+function invalidDigest(name) {
+  var e = new TypeError('Invalid digest: ' + name);
+  e.code = 'ERR_CRYPTO_INVALID_DIGEST';
+  return e;
+}
+
+function invalidState(op) {
+  var e = new Error('Invalid state for operation ' + op);
+  e.code = 'ERR_CRYPTO_INVALID_STATE';
+  return e;
+}
+
+function invalidAuthTag(n) {
+  var e = new TypeError('Invalid authentication tag length: ' + n);
+  e.code = 'ERR_CRYPTO_INVALID_AUTH_TAG';
+  return e;
+}
+
+function validateString(v, name) {
+  if (typeof v !== 'string') {
+    throw util.invalidArgType(name, 'of type string', v);
+  }
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function validateInt32(v, name, min, max) {
+  if (typeof v !== 'number') {
+    throw util.invalidArgType(name, 'of type number', v);
+  } else if (Math.floor(v) !== v) {
+    throw util.outOfRange(name, 'an integer', v);
+  } else if (!(v >= min && v <= max)) {
+    throw util.outOfRange(name, '>= ' + min + ' && <= ' + max, v);
+  }
+}
+
+function validateSafeInteger(v, name) {
+  if (typeof v !== 'number'
+      || Math.floor(v) !== v
+      || Math.abs(v) > MAX_SAFE_INTEGER) {
+    throw util.invalidArgType(name, 'a safe integer', v);
+  }
+}
+
+function hidden(o, k, v) {
+  Object.defineProperty(o, k, {
+    value: v,
+    writable: true,
+    configurable: true,
+  });
+}
+
+function toBuffer(data, enc, name, type) {
+  if (typeof data === 'string') {
+    enc = util.normalizeEncoding(enc) || 'utf8';
+    if (enc === 'hex'
+        && data.length % 2 !== 0) {
+      var e = new TypeError("The argument 'encoding' is invalid for data of length " + data.length + '. Received ' + "'hex'");
+      e.code = 'ERR_INVALID_ARG_VALUE';
+      throw e;
+    }
+    return Buffer.from(data, enc);
+  } else if (!Buffer.isBuffer(data)) {
+    throw util.invalidArgType(name, type, data);
+  }
+  return data;
+}
+
+var KEY_TYPE = 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, DataView, KeyObject, or CryptoKey';
+var DATA_TYPE = 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, or DataView';
+
+function encode(buf, enc) {
+  if (enc === undefined
+      || enc === 'buffer') {
+    return buf;
+  }
+  return buf.toString(util.normalizeEncoding(enc) || 'utf8');
+}
+
+//
+// Hash
+//
+
+function Hash(algorithm, options) {
+  if (!(this instanceof Hash)) {
+    return new Hash(algorithm, options);
+  }
+
+  var handle;
+  if (algorithm instanceof Hash) {
+    handle = algorithm._handle.copy();
+  } else {
+    validateString(algorithm, 'algorithm');
+    handle = binding.createHash(algorithm);
+    if (!handle) {
+      throw new Error('Digest method not supported');
+    }
+  }
+  hidden(this, '_handle', handle);
+  hidden(this, '_finalized', false);
+}
+
+Hash.prototype.copy = function copy() {
+  if (this._finalized) {
+    throw hashFinalized();
+  }
+  return new Hash(this);
+};
+
+Hash.prototype.update = function update(data, inputEncoding) {
+  if (this._finalized) {
+    throw hashFinalized();
+  }
+  this._handle.update(toBuffer(data, inputEncoding, 'data', 'of type string or an instance of Buffer, TypedArray, or DataView'));
+  return this;
+};
+
+Hash.prototype.digest = function digest(outputEncoding) {
+  if (this._finalized) {
+    throw hashFinalized();
+  }
+  this._finalized = true;
+  return encode(this._handle.digest(), outputEncoding);
+};
+
+//
+// Hmac
+//
+
+function Hmac(hmac, key, options) {
+  if (!(this instanceof Hmac)) {
+    return new Hmac(hmac, key, options);
+  }
+
+  validateString(hmac, 'hmac');
+  key = toBuffer(key, options && options.encoding, 'key', KEY_TYPE);
+  var handle = binding.createHmac(hmac, key);
+  if (!handle) {
+    throw invalidDigest(hmac);
+  }
+  hidden(this, '_handle', handle);
+  hidden(this, '_finalized', false);
+}
+
+Hmac.prototype.update = Hash.prototype.update;
+
+Hmac.prototype.digest = function digest(outputEncoding) {
+  if (this._finalized) {
+    return encode(Buffer.alloc(0), outputEncoding);
+  }
+  this._finalized = true;
+  return encode(this._handle.digest(), outputEncoding);
+};
+
+exports.Hash = Hash;
+exports.Hmac = Hmac;
+
+exports.createHash = function createHash(algorithm, options) {
+  return new Hash(algorithm, options);
+};
+
+exports.createHmac = function createHmac(hmac, key, options) {
+  return new Hmac(hmac, key, options);
+};
+
+exports.getHashes = function getHashes() {
+  return binding.getHashes();
+};
+
+//
+// Cipheriv, Decipheriv
+//
+
+function Cipher(cipher, key, iv, options, decrypt) {
+  validateString(cipher, 'cipher');
+  key = toBuffer(key, options && options.encoding, 'key', KEY_TYPE);
+  if (iv !== null) {
+    iv = toBuffer(iv, options && options.encoding, 'iv', 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, or DataView');
+  }
+
+  var info = binding.getCipherInfo(cipher);
+  if (!info) {
+    var e = new Error('Unknown cipher');
+    e.code = 'ERR_CRYPTO_UNKNOWN_CIPHER';
+    throw e;
+  } else if (key.length !== info.keyLength) {
+    e = new RangeError('Invalid key length');
+    e.code = 'ERR_CRYPTO_INVALID_KEYLEN';
+    throw e;
+  } else if (iv === null
+             || (info.mode === 'gcm' ? iv.length === 0 : iv.length !== info.ivLength)) {
+    e = new TypeError('Invalid initialization vector');
+    e.code = 'ERR_CRYPTO_INVALID_IV';
+    throw e;
+  }
+  var authTagLength = options && options.authTagLength;
+  if (info.mode !== 'gcm'
+      || authTagLength === undefined) {
+    authTagLength = 16;
+  } else if (!(authTagLength === 4
+               || authTagLength === 8
+               || (authTagLength >= 12 && authTagLength <= 16 && Math.floor(authTagLength) === authTagLength))) {
+    throw invalidAuthTag(authTagLength);
+  }
+  hidden(this, '_handle', binding.createCipher(cipher, key, iv, decrypt, authTagLength));
+  hidden(this, '_mode', info.mode);
+  hidden(this, '_authTagLength', options && options.authTagLength);
+  hidden(this, '_decoder', null);
+  hidden(this, '_state', 0);
+}
+
+Cipher.prototype.update = function update(data, inputEncoding, outputEncoding) {
+  if (this._state === 2) {
+    throw new Error('Trying to add data in unsupported state');
+  }
+  this._state = 1;
+  return this._decode(this._handle.update(toBuffer(data, inputEncoding, 'data', DATA_TYPE)), outputEncoding, false);
+};
+
+Cipher.prototype.final = function final(outputEncoding) {
+  if (this._state === 2) {
+    var e = new Error('Invalid state');
+    e.code = 'ERR_CRYPTO_INVALID_STATE';
+    throw e;
+  }
+  this._state = 2;
+  return this._decode(this._handle.final(), outputEncoding, true);
+};
+
+Cipher.prototype.setAutoPadding = function setAutoPadding(autoPadding) {
+  if (this._state === 2) {
+    throw invalidState('setAutoPadding');
+  }
+  this._handle.setAutoPadding(autoPadding !== false);
+  return this;
+};
+
+Cipher.prototype.setAAD = function setAAD(buffer, options) {
+  if (this._mode !== 'gcm'
+      || this._state !== 0) {
+    throw invalidState('setAAD');
+  }
+  this._handle.setAAD(toBuffer(buffer, options && options.encoding, 'buffer', 'an instance of ArrayBuffer, Buffer, TypedArray, or DataView'));
+  return this;
+};
+
+Cipher.prototype._decode = function _decode(buf, enc, end) {
+  if (enc === undefined
+      || enc === 'buffer') {
+    return buf;
+  }
+  enc = util.normalizeEncoding(enc) || 'utf8';
+  if (!this._decoder) {
+    this._decoder = new StringDecoder(enc);
+  } else if (this._decoder.encoding !== enc) {
+    throw new Error('Cannot change encoding');
+  }
+  return end ? this._decoder.end(buf) : this._decoder.write(buf);
+};
+
+function Cipheriv(cipher, key, iv, options) {
+  if (!(this instanceof Cipheriv)) {
+    return new Cipheriv(cipher, key, iv, options);
+  }
+  Cipher.call(this, cipher, key, iv, options, false);
+}
+
+Cipheriv.prototype = Object.create(Cipher.prototype, {
+  constructor: {
+    value: Cipheriv,
+    writable: true,
+    configurable: true,
+  },
+});
+
+Cipheriv.prototype.getAuthTag = function getAuthTag() {
+  if (this._mode !== 'gcm'
+      || this._state !== 2) {
+    throw invalidState('getAuthTag');
+  }
+  return this._handle.getAuthTag();
+};
+
+function Decipheriv(cipher, key, iv, options) {
+  if (!(this instanceof Decipheriv)) {
+    return new Decipheriv(cipher, key, iv, options);
+  }
+  Cipher.call(this, cipher, key, iv, options, true);
+  hidden(this, '_authTag', false);
+}
+
+Decipheriv.prototype = Object.create(Cipher.prototype, {
+  constructor: {
+    value: Decipheriv,
+    writable: true,
+    configurable: true,
+  },
+});
+
+Decipheriv.prototype.setAuthTag = function setAuthTag(tagbuf, encoding) {
+  tagbuf = toBuffer(tagbuf, encoding, 'buffer', 'an instance of Buffer, TypedArray, or DataView');
+  if (this._mode !== 'gcm'
+      || this._authTag) {
+    throw invalidState('setAuthTag');
+  } else if (this._state === 2) {
+    throw new Error('Unsupported state or unable to authenticate data');
+  }
+  var n = tagbuf.length;
+  if (!(n === 4 || n === 8 || (n >= 12 && n <= 16))
+      || (this._authTagLength !== undefined && n !== this._authTagLength)) {
+    throw invalidAuthTag(n);
+  }
+  this._authTag = true;
+  this._handle.setAuthTag(tagbuf);
+  return this;
+};
+
+exports.Cipheriv = Cipheriv;
+exports.Decipheriv = Decipheriv;
+
+exports.createCipheriv = function createCipheriv(cipher, key, iv, options) {
+  return new Cipheriv(cipher, key, iv, options);
+};
+
+exports.createDecipheriv = function createDecipheriv(cipher, key, iv, options) {
+  return new Decipheriv(cipher, key, iv, options);
+};
+
+exports.getCiphers = function getCiphers() {
+  return binding.getCiphers();
+};
+
+exports.getCipherInfo = function getCipherInfo(nameOrNid, options) {
+  validateString(nameOrNid, 'nameOrNid');
+  return binding.getCipherInfo(nameOrNid);
+};
+
+//
+// key derivation
+//
+
+exports.pbkdf2Sync = function pbkdf2Sync(password, salt, iterations, keylen, digest) {
+  password = toBuffer(password, undefined, 'password', DATA_TYPE);
+  salt = toBuffer(salt, undefined, 'salt', DATA_TYPE);
+  validateInt32(iterations, 'iterations', 1, 0x7fffffff);
+  validateInt32(keylen, 'keylen', 0, 0x7fffffff);
+  validateString(digest, 'digest');
+
+  var buf = binding.pbkdf2(password, salt, iterations, keylen, digest);
+  if (!buf) {
+    throw invalidDigest(digest);
+  }
+  return buf;
+};
+
+exports.scryptSync = function scryptSync(password, salt, keylen, options) {
+  password = toBuffer(password, undefined, 'password', DATA_TYPE);
+  salt = toBuffer(salt, undefined, 'salt', DATA_TYPE);
+  validateInt32(keylen, 'keylen', 0, 0x7fffffff);
+
+  var N = 16384;
+  var r = 8;
+  var p = 1;
+  var maxmem = 32 << 20;
+  if (options) {
+    [['N', 'cost'], ['r', 'blockSize'], ['p', 'parallelization']].forEach(function(k) {
+      var v = options[k[0]];
+      if (options[k[1]] !== undefined) {
+        if (v !== undefined) {
+          var e = new Error('Invalid scrypt parameter');
+          e.code = 'ERR_CRYPTO_SCRYPT_INVALID_PARAMETER';
+          throw e;
+        }
+        v = options[k[1]];
+      }
+      if (v !== undefined) {
+        validateInt32(v, k[1], 0, 0x7fffffff);
+        switch (k[0]) {
+        case 'N':
+          N = v;
+          break;
+        case 'r':
+          r = v;
+          break;
+        case 'p':
+          p = v;
+          break;
+        }
+      }
+    });
+    if (options.maxmem !== undefined) {
+      validateSafeInteger(options.maxmem, 'maxmem');
+      maxmem = options.maxmem;
+    }
+  }
+  var msg;
+  if (N < 2
+      || (N & (N - 1)) !== 0
+      || r < 1
+      || p < 1) {
+    msg = 'Invalid scrypt params';
+  } else if (128 * r * (N + 2) + 128 * r * p > maxmem) {
+    msg = 'Invalid scrypt params: error:030000AC:digital envelope routines::memory limit exceeded';
+  }
+  if (msg) {
+    var e = new RangeError(msg);
+    e.code = 'ERR_CRYPTO_INVALID_SCRYPT_PARAMS';
+    throw e;
+  }
+  return binding.scrypt(password, salt, keylen, N, r, p);
+};
+
+exports.hkdfSync = function hkdfSync(digest, ikm, salt, info, keylen) {
+  validateString(digest, 'digest');
+  ikm = toBuffer(ikm, undefined, 'ikm', KEY_TYPE);
+  salt = toBuffer(salt, undefined, 'salt', DATA_TYPE);
+  info = toBuffer(info, undefined, 'info', DATA_TYPE);
+  validateInt32(keylen, 'length', 0, 0x7fffffff);
+  if (info.length > 1024) {
+    throw util.outOfRange('info', 'must not contain more than 1024 bytes', info.length);
+  }
+
+  var buf = binding.hkdf(digest, ikm, salt, info, keylen);
+  if (!buf) {
+    throw invalidDigest(digest);
+  }
+  return buf;
+};
+
+//
+// Sign, Verify
+//
+
+function signArgs(algorithm, data, key) {
+  var options = {};
+  if (key !== null
+      && typeof key === 'object'
+      && !Buffer.isBuffer(key)) {
+    options = key;
+    key = key.key;
+  }
+  key = toBuffer(key, options.encoding, 'key', KEY_TYPE);
+  if (options.dsaEncoding !== undefined
+      && options.dsaEncoding !== 'der'
+      && options.dsaEncoding !== 'ieee-p1363') {
+    var e = new TypeError("The property 'options.dsaEncoding' is invalid. Received '" + options.dsaEncoding + "'");
+    e.code = 'ERR_INVALID_ARG_VALUE';
+    throw e;
+  }
+  return [
+    algorithm === undefined ? null : algorithm,
+    data,
+    key,
+    undefined,
+    options.padding,
+    options.saltLength,
+    options.dsaEncoding,
+  ];
+}
+
+exports.sign = function sign(algorithm, data, key, callback) {
+  if (algorithm !== null
+      && algorithm !== undefined) {
+    validateString(algorithm, 'algorithm');
+  }
+  data = toBuffer(data, undefined, 'data', 'an instance of Buffer, TypedArray, or DataView');
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+
+  var sig = binding.sign.apply(binding, signArgs(algorithm, data, key));
+  if (callback === undefined) {
+    return sig;
+  }
+  process.nextTick(callback, null, sig);
+};
+
+exports.verify = function verify(algorithm, data, key, signature, callback) {
+  if (algorithm !== null
+      && algorithm !== undefined) {
+    validateString(algorithm, 'algorithm');
+  }
+  data = toBuffer(data, undefined, 'data', 'an instance of Buffer, TypedArray, or DataView');
+  signature = toBuffer(signature, undefined, 'signature', 'an instance of ArrayBuffer, Buffer, TypedArray, or DataView');
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+
+  var args = signArgs(algorithm, data, key);
+  args[3] = signature;
+  var ok = binding.verify.apply(binding, args);
+  if (callback === undefined) {
+    return ok;
+  }
+  process.nextTick(callback, null, ok);
+};
+
+function Sign(algorithm, options) {
+  if (!(this instanceof Sign)) {
+    return new Sign(algorithm, options);
+  }
+  initSign(this, algorithm);
+}
+
+function initSign(self, algorithm) {
+  validateString(algorithm, 'algorithm');
+  var handle = binding.createHash(algorithm);
+  if (!handle) {
+    var e = new TypeError('Invalid digest');
+    e.code = 'ERR_CRYPTO_INVALID_DIGEST';
+    throw e;
+  }
+  hidden(self, '_algorithm', algorithm);
+  hidden(self, '_data', []);
+  hidden(self, '_finalized', false);
+}
+
+Sign.prototype.update = function update(data, inputEncoding) {
+  this._data.push(toBuffer(data, inputEncoding, 'data', 'of type string or an instance of Buffer, TypedArray, or DataView'));
+  return this;
+};
+
+Sign.prototype._final = function _final() {
+  if (this._finalized) {
+    var e = new Error('Not initialised');
+    e.code = 'ERR_CRYPTO_INVALID_STATE';
+    throw e;
+  }
+  this._finalized = true;
+  return Buffer.concat(this._data);
+};
+
+Sign.prototype.sign = function sign(privateKey, outputEncoding) {
+  var data = this._final();
+  return encode(binding.sign.apply(binding, signArgs(this._algorithm, data, privateKey)), outputEncoding);
+};
+
+function Verify(algorithm, options) {
+  if (!(this instanceof Verify)) {
+    return new Verify(algorithm, options);
+  }
+  initSign(this, algorithm);
+}
+
+Verify.prototype.update = Sign.prototype.update;
+Verify.prototype._final = Sign.prototype._final;
+
+Verify.prototype.verify = function verify(object, signature, signatureEncoding) {
+  signature = toBuffer(signature, signatureEncoding, 'signature', 'of type string or an instance of ArrayBuffer, Buffer, TypedArray, or DataView');
+  var args = signArgs(this._algorithm, this._final(), object);
+  args[3] = signature;
+  return binding.verify.apply(binding, args);
+};
+
+exports.Sign = Sign;
+exports.Verify = Verify;
+
+exports.createSign = function createSign(algorithm, options) {
+  return new Sign(algorithm, options);
+};
+
+exports.createVerify = function createVerify(algorithm, options) {
+  return new Verify(algorithm, options);
+};
+
+exports.constants = {
+  RSA_PKCS1_PADDING: 1,
+  RSA_PKCS1_PSS_PADDING: 6,
+  RSA_PSS_SALTLEN_DIGEST: -1,
+  RSA_PSS_SALTLEN_MAX_SIGN: -2,
+  RSA_PSS_SALTLEN_AUTO: -2,
+};
+
+//
+// random
+//
+
+exports.randomBytes = function randomBytes(size, callback) {
+  if (typeof size !== 'number') {
+    throw util.invalidArgType('size', 'of type number', size);
+  } else if (!(size >= 0 && size <= 0x7fffffff)) {
+    throw util.outOfRange('size', '>= 0 && <= 2147483647', size);
+  }
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+
+  var buf = binding.randomBytes(size);
+  if (callback === undefined) {
+    return buf;
+  }
+  process.nextTick(callback, null, buf);
+};
+
+exports.randomInt = function randomInt(min, max, callback) {
+  var minNotSpecified = typeof max === 'undefined' || typeof max === 'function';
+  if (minNotSpecified) {
+    callback = max;
+    max = min;
+    min = 0;
+  }
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  validateSafeInteger(min, 'min');
+  validateSafeInteger(max, 'max');
+  if (max <= min) {
+    throw util.outOfRange('max', 'greater than the value of "min" (' + min + ')', max);
+  } else if (!(max - min <= RAND_MAX)) {
+    throw util.outOfRange('max' + (minNotSpecified ? '' : ' - min'), '<= ' + RAND_MAX, max - min);
+  }
+
+  var n = binding.randomInt(min, max);
+  if (callback === undefined) {
+    return n;
+  }
+  process.nextTick(callback, null, n);
+};
+
+exports.randomUUID = function randomUUID() {
+  return binding.randomUUID();
+};
+
+exports.timingSafeEqual = function timingSafeEqual(buf1, buf2) {
+  [buf1, buf2].forEach(function(v, i) {
+    if (!Buffer.isBuffer(v)) {
+      var e = new TypeError('The "buf' + (i + 1) + '" argument must be an instance of ArrayBuffer, Buffer, TypedArray, or DataView.');
+      e.code = 'ERR_INVALID_ARG_TYPE';
+      throw e;
+    }
+  });
+  if (buf1.length !== buf2.length) {
+    var e = new RangeError('Input buffers must have the same byte length');
+    e.code = 'ERR_CRYPTO_TIMING_SAFE_EQUAL_LENGTH';
+    throw e;
+  }
+  return binding.timingSafeEqual(buf1, buf2);
+};
@@ -0,0 +1,263 @@
+//
+// otto.module :: events.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var defaultMaxListeners = 10;
+
+function assertListener(listener) {
+  if (typeof listener !== 'function') {
+    throw new TypeError('listener must be a Function');
+  }
+}
+
+function assertMaxListeners(n) {
+  if (typeof n !== 'number'
+      || n < 0
+      || n !== n) {
+    throw new RangeError('n must be a non-negative Number');
+  }
+}
+
+function EventEmitter() {
+  EventEmitter.init.call(this);
+}
+
+Object.defineProperty(EventEmitter, 'defaultMaxListeners', {
+  enumerable: true,
+
+  get: function get() {
+    return defaultMaxListeners;
+  },
+
+  set: function set(n) {
+    assertMaxListeners(n);
+    defaultMaxListeners = n;
+  },
+});
+
+EventEmitter.init = function init() {
+  if (!this._events
+      || this._events === Object.getPrototypeOf(this)._events) {
+    this._events = Object.create(null);
+    this._eventsCount = 0;
+  }
+  this._maxListeners = this._maxListeners || undefined;
+};
+
+EventEmitter.listenerCount = function listenerCount(emitter, type) {
+  return emitter.listenerCount(type);
+};
+
+EventEmitter.prototype._events = undefined;
+EventEmitter.prototype._eventsCount = 0;
+EventEmitter.prototype._maxListeners = undefined;
+
+EventEmitter.prototype.setMaxListeners = function setMaxListeners(n) {
+  assertMaxListeners(n);
+  this._maxListeners = n;
+  return this;
+};
+
+EventEmitter.prototype.getMaxListeners = function getMaxListeners() {
+  return this._maxListeners === undefined ? defaultMaxListeners : this._maxListeners;
+};
+
+EventEmitter.prototype.emit = function emit(type) {
+  var events = this._events;
+  var list = events !== undefined ? events[type] : undefined;
+  if (list === undefined) {
+    if (type === 'error') {
+      var er = arguments[1];
+      if (er instanceof Error) {
+        throw er;
+      }
+      var err = new Error('Unhandled error. (' + String(er) + ')');
+      err.code = 'ERR_UNHANDLED_ERROR';
+      err.context = er;
+      throw err;
+    }
+    return false;
+  }
+
+  var args = Array.prototype.slice.call(arguments, 1);
+  list = list.slice();
+  for (var i = 0; i < list.length; i++) {
+    list[i].apply(this, args);
+  }
+  return true;
+};
+
+function addListener(target, type, listener, prepend) {
+  assertListener(listener);
+
+  var events = target._events;
+  if (events === undefined) {
+    EventEmitter.init.call(target);
+    events = target._events;
+  } else if (events.newListener !== undefined) {
+    target.emit('newListener', type, listener.listener ? listener.listener : listener);
+    events = target._events;
+  }
+
+  var list = events[type];
+  if (list === undefined) {
+    list = events[type] = [];
+    target._eventsCount++;
+  }
+  if (prepend) {
+    list.unshift(listener);
+  } else {
+    list.push(listener);
+  }
+
+  var m = target.getMaxListeners();
+  if (m > 0
+      && list.length > m
+      && !list.warned) {
+    list.warned = true;
+    process.emitWarning('Possible EventEmitter memory leak detected. ' + list.length + ' ' + String(type) + ' listeners added. Use emitter.setMaxListeners() to increase limit', 'MaxListenersExceededWarning');
+  }
+  return target;
+}
+
+EventEmitter.prototype.on = EventEmitter.prototype.addListener = function addListener_(type, listener) {
+  return addListener(this, type, listener, false);
+};
+
+EventEmitter.prototype.prependListener = function prependListener(type, listener) {
+  return addListener(this, type, listener, true);
+};
+
+function onceWrapper(target, type, listener) {
+  var fired = false;
+
+  function wrapper() {
+    if (!fired) {
+      target.removeListener(type, wrapper);
+      fired = true;
+      return listener.apply(target, arguments);
+    }
+    return undefined;
+  }
+  wrapper.listener = listener;
+
+  return wrapper;
+}
+
+EventEmitter.prototype.once = function once(type, listener) {
+  assertListener(listener);
+  return this.on(type, onceWrapper(this, type, listener));
+};
+
+EventEmitter.prototype.prependOnceListener = function prependOnceListener(type, listener) {
+  assertListener(listener);
+  return this.prependListener(type, onceWrapper(this, type, listener));
+};
+
+EventEmitter.prototype.off = EventEmitter.prototype.removeListener = function removeListener(type, listener) {
+  assertListener(listener);
+
+  var events = this._events;
+  var list = events !== undefined ? events[type] : undefined;
+  if (list === undefined) {
+    return this;
+  }
+
+  for (var i = list.length - 1; i >= 0; i--) {
+    if (list[i] === listener
+        || list[i].listener === listener) {
+      listener = list[i].listener || list[i];
+      list.splice(i, 1);
+      if (list.length === 0) {
+        delete events[type];
+        this._eventsCount--;
+      }
+      if (events.removeListener !== undefined) {
+        this.emit('removeListener', type, listener);
+      }
+      break;
+    }
+  }
+  return this;
+};
+
+EventEmitter.prototype.removeAllListeners = function removeAllListeners(type) {
+  var events = this._events;
+  if (events === undefined) {
+    return this;
+  }
+
+  var k;
+  if (events.removeListener === undefined) {
+    if (arguments.length === 0) {
+      this._events = Object.create(null);
+      this._eventsCount = 0;
+    } else if (events[type] !== undefined) {
+      delete events[type];
+      this._eventsCount--;
+    }
+  } else if (arguments.length === 0) {
+    for (k in events) {
+      if (k !== 'removeListener') {
+        this.removeAllListeners(k);
+      }
+    }
+    this.removeAllListeners('removeListener');
+    this._events = Object.create(null);
+    this._eventsCount = 0;
+  } else {
+    var list = events[type];
+    if (list !== undefined) {
+      for (var i = list.length - 1; i >= 0; i--) {
+        this.removeListener(type, list[i]);
+      }
+    }
+  }
+  return this;
+};
+
+EventEmitter.prototype.listeners = function listeners(type) {
+  var list = this._events !== undefined ? this._events[type] : undefined;
+  if (list === undefined) {
+    return [];
+  }
+  return list.map(function(fn) {
+    return fn.listener || fn;
+  });
+};
+
+EventEmitter.prototype.rawListeners = function rawListeners(type) {
+  var list = this._events !== undefined ? this._events[type] : undefined;
+  return list !== undefined ? list.slice() : [];
+};
+
+EventEmitter.prototype.listenerCount = function listenerCount(type, listener) {
+  var list = this._events !== undefined ? this._events[type] : undefined;
+  if (list === undefined) {
+    return 0;
+  } else if (listener === undefined) {
+    return list.length;
+  }
+  return list.filter(function(fn) {
+    return fn === listener
+           || fn.listener === listener;
+  }).length;
+};
+
+EventEmitter.prototype.eventNames = function eventNames() {
+  return this._eventsCount > 0 ? Object.keys(this._events) : [];
+};
+
+EventEmitter.getEventListeners = function getEventListeners(emitter, type) {
+  return emitter.listeners(type);
+};
+
+EventEmitter.EventEmitter = EventEmitter;
+
+module.exports = EventEmitter;
@@ -0,0 +1,204 @@
+//
+// otto.module :: fs.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('fs');
+var Buffer = require('./buffer').Buffer;
+var util = require('./internal/util');
+
+var constants = {
+  F_OK: 0,
+  R_OK: 4,
+  W_OK: 2,
+  X_OK: 1,
+  S_IFMT: 0xf000,
+  S_IFREG: 0x8000,
+  S_IFDIR: 0x4000,
+  S_IFCHR: 0x2000,
+  S_IFBLK: 0x6000,
+  S_IFIFO: 0x1000,
+  S_IFLNK: 0xa000,
+  S_IFSOCK: 0xc000,
+};
+
+exports.constants = constants;
+
+function assertPath(p, name) {
+  if (typeof p !== 'string') {
+    throw new TypeError((name || 'path') + ' must be a String');
+  }
+  return p;
+}
+
+function getOptions(options, defaults) {
+  if (options === undefined
+      || options === null) {
+    options = {};
+  } else if (typeof options === 'string') {
+    options = { encoding: options };
+  } else if (typeof options !== 'object') {
+    throw new TypeError('options must be a String or Object');
+  }
+
+  var o = {};
+  Object.keys(defaults).forEach(function(k) {
+    o[k] = options[k] !== undefined ? options[k] : defaults[k];
+  });
+  return o;
+}
+
+function modeChecker(type) {
+  return function() {
+    return (this.mode & constants.S_IFMT) === type;
+  };
+}
+
+function Stats(st) {
+  this.dev = st.dev;
+  this.mode = st.mode;
+  this.nlink = st.nlink;
+  this.uid = st.uid;
+  this.gid = st.gid;
+  this.rdev = st.rdev;
+  this.blksize = st.blksize;
+  this.ino = st.ino;
+  this.size = st.size;
+  this.blocks = st.blocks;
+  this.atimeMs = st.atimeMs;
+  this.mtimeMs = st.mtimeMs;
+  this.ctimeMs = st.ctimeMs;
+  this.birthtimeMs = st.birthtimeMs;
+  this.atime = new Date(st.atimeMs);
+  this.mtime = new Date(st.mtimeMs);
+  this.ctime = new Date(st.ctimeMs);
+  this.birthtime = new Date(st.birthtimeMs);
+}
+
+Stats.prototype.isFile = modeChecker(constants.S_IFREG);
+Stats.prototype.isDirectory = modeChecker(constants.S_IFDIR);
+Stats.prototype.isSymbolicLink = modeChecker(constants.S_IFLNK);
+Stats.prototype.isFIFO = modeChecker(constants.S_IFIFO);
+Stats.prototype.isSocket = modeChecker(constants.S_IFSOCK);
+Stats.prototype.isCharacterDevice = modeChecker(constants.S_IFCHR);
+Stats.prototype.isBlockDevice = modeChecker(constants.S_IFBLK);
+
+exports.Stats = Stats;
+
+function Dirent(name, mode, parentPath) {
+  this.name = name;
+  this.parentPath = parentPath;
+  this.path = parentPath;
+  this.mode = mode;
+}
+
+['isFile', 'isDirectory', 'isSymbolicLink', 'isFIFO', 'isSocket', 'isCharacterDevice', 'isBlockDevice'].forEach(function(k) {
+  Dirent.prototype[k] = Stats.prototype[k];
+});
+
+exports.Dirent = Dirent;
+
+exports.existsSync = function existsSync(p) {
+  return typeof p === 'string'
+         && binding.exists(p);
+};
+
+var readFlags = ['r', 'rs', 'sr', 'r+', 'rs+', 'sr+', 'w+', 'wx+', 'xw+', 'a+', 'as+', 'sa+', 'ax+', 'xa+'];
+
+function assertEncoding(enc) {
+  if (enc
+      && enc !== 'buffer'
+      && !Buffer.isEncoding(enc)) {
+    throw util.invalidArgValue('encoding', enc);
+  }
+  return enc === 'buffer' ? null : enc;
+}
+
+exports.readFileSync = function readFileSync(p, options) {
+  options = getOptions(options, { encoding: null, flag: 'r' });
+  if (readFlags.indexOf(options.flag) === -1) {
+    throw util.invalidArgValue('flags', options.flag);
+  }
+  return binding.readFile(assertPath(p), assertEncoding(options.encoding), options.flag);
+};
+
+function writeFile(p, data, options, flag) {
+  options = getOptions(options, { encoding: 'utf8', mode: 438, flag: flag });
+  if (typeof data !== 'string'
+      && !Buffer.isBuffer(data)) {
+    data = String(data);
+  }
+  binding.writeFile(assertPath(p), data, options.encoding, options.flag, options.mode);
+}
+
+exports.writeFileSync = function writeFileSync(p, data, options) {
+  writeFile(p, data, options, 'w');
+};
+
+exports.appendFileSync = function appendFileSync(p, data, options) {
+  writeFile(p, data, options, 'a');
+};
+
+function stat(fn, p, options) {
+  options = getOptions(options, { throwIfNoEntry: true });
+  try {
+    return new Stats(fn(assertPath(p)));
+  } catch (e) {
+    if (!options.throwIfNoEntry
+        && e.code === 'ENOENT') {
+      return undefined;
+    }
+    throw e;
+  }
+}
+
+exports.statSync = function statSync(p, options) {
+  return stat(binding.stat, p, options);
+};
+
+exports.lstatSync = function lstatSync(p, options) {
+  return stat(binding.lstat, p, options);
+};
+
+exports.readdirSync = function readdirSync(p, options) {
+  options = getOptions(options, { encoding: 'utf8', withFileTypes: false });
+  var enc = assertEncoding(options.encoding);
+  var list = binding.readdir(assertPath(p));
+  var ents = [];
+  for (var i = 0; i < list.length; i++) {
+    var name = list[i][0];
+    if (enc === null) {
+      name = Buffer.from(name);
+    } else if (util.normalizeEncoding(enc) !== 'utf8') {
+      name = Buffer.from(name).toString(enc);
+    }
+    ents.push(options.withFileTypes ? new Dirent(name, list[i][1], p) : name);
+  }
+  return ents;
+};
+
+exports.mkdirSync = function mkdirSync(p, options) {
+  if (typeof options === 'number') {
+    options = { mode: options };
+  }
+  options = getOptions(options, { recursive: false, mode: 511 });
+  return binding.mkdir(assertPath(p), Boolean(options.recursive), options.mode);
+};
+
+exports.rmSync = function rmSync(p, options) {
+  options = getOptions(options, { recursive: false, force: false });
+  binding.rm(assertPath(p), Boolean(options.recursive), Boolean(options.force));
+};
+
+exports.renameSync = function renameSync(oldPath, newPath) {
+  binding.rename(assertPath(oldPath, 'oldPath'), assertPath(newPath, 'newPath'));
+};
+
+exports.realpathSync = function realpathSync(p) {
+  return binding.realpath(assertPath(p));
+};
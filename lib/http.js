@@ -0,0 +1,784 @@
+//
+// otto.module :: http.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('http');
+var EventEmitter = require('./events');
+var timers = require('./timers');
+var url = require('./url');
+var common = require('./internal/http');
+var util = require('./internal/util');
+var inspect = require('./internal/util/inspect').inspect;
+
+var IncomingMessage = common.IncomingMessage;
+var OutgoingMessage = common.OutgoingMessage;
+
+function assign(dst, src) {
+  Object.keys(src).forEach(function(k) {
+    dst[k] = src[k];
+  });
+  return dst;
+}
+
+function validateHost(host, name) {
+  if (host !== null
+      && host !== undefined
+      && typeof host !== 'string') {
+    throw util.invalidArgType('options.' + name, 'of type string or one of undefined or null', host);
+  }
+  return host;
+}
+
+function validateTimeout(v, name) {
+  if (typeof v !== 'number') {
+    throw util.invalidArgType(name, 'of type number', v);
+  } else if (!(v >= 0)) {
+    throw util.outOfRange(name, '>= 0', v);
+  }
+}
+
+//
+// Agent
+//
+
+function Agent(options) {
+  if (!(this instanceof Agent)) {
+    return new Agent(options);
+  }
+
+  EventEmitter.call(this);
+
+  this.options = assign({}, options || {});
+  this.defaultPort = 80;
+  this.protocol = 'http:';
+  this.keepAlive = Boolean(this.options.keepAlive);
+  this.maxSockets = this.options.maxSockets || Agent.defaultMaxSockets;
+  this.maxFreeSockets = this.options.maxFreeSockets || 256;
+  this.maxTotalSockets = this.options.maxTotalSockets || Infinity;
+}
+
+Agent.defaultMaxSockets = Infinity;
+
+Agent.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Agent,
+    writable: true,
+    configurable: true,
+  },
+});
+
+// connections are managed by the http.RoundTripper of the host
+Agent.prototype.destroy = function destroy() {};
+
+var globalAgent = new Agent();
+
+//
+// ClientRequest
+//
+
+function ClientRequest(input, options, cb) {
+  if (!(this instanceof ClientRequest)) {
+    return new ClientRequest(input, options, cb);
+  }
+
+  OutgoingMessage.call(this, { autoDestroy: false });
+
+  if (typeof input === 'string') {
+    input = url.urlToHttpOptions(new url.URL(input));
+  } else if (input instanceof url.URL) {
+    input = url.urlToHttpOptions(input);
+  } else {
+    cb = options;
+    options = input;
+    input = null;
+  }
+  if (typeof options === 'function') {
+    cb = options;
+    options = input || {};
+  } else {
+    options = assign(input || {}, options || {});
+  }
+
+  var agent = options.agent;
+  var defaultAgent = options._defaultAgent || globalAgent;
+  if (agent === false) {
+    agent = new defaultAgent.constructor();
+  } else if (agent === null
+             || agent === undefined) {
+    agent = defaultAgent;
+  } else if (!(agent instanceof Agent)) {
+    throw util.invalidArgType('options.agent', 'of type Agent-like Object, undefined, or false', agent);
+  }
+  this.agent = agent;
+
+  var protocol = options.protocol || defaultAgent.protocol;
+  var expectedProtocol = agent.protocol || defaultAgent.protocol;
+  if (protocol !== expectedProtocol) {
+    var e = new TypeError('Protocol "' + protocol + '" not supported. Expected "' + expectedProtocol + '"');
+    e.code = 'ERR_INVALID_PROTOCOL';
+    throw e;
+  }
+
+  var defaultPort = options.defaultPort || agent.defaultPort;
+  var port = options.port || defaultPort || 80;
+  var host = validateHost(options.hostname, 'hostname') || validateHost(options.host, 'host') || 'localhost';
+  var setHost = options.setHost === undefined || Boolean(options.setHost);
+
+  if (options.timeout !== undefined) {
+    validateTimeout(options.timeout, 'timeout');
+  }
+
+  if (options.path) {
+    var path = String(options.path);
+    if (/[^\u0021-\u00ff]/.test(path)) {
+      e = new TypeError('Request path contains unescaped characters');
+      e.code = 'ERR_UNESCAPED_CHARACTERS';
+      throw e;
+    }
+  }
+
+  var method = options.method;
+  if (method !== null
+      && method !== undefined
+      && typeof method !== 'string') {
+    throw util.invalidArgType('options.method', 'of type string', method);
+  }
+  if (method) {
+    if (!common.checkIsHttpToken(method)) {
+      throw common.invalidHttpToken('Method', method);
+    }
+    method = method.toUpperCase();
+  } else {
+    method = 'GET';
+  }
+
+  this.method = method;
+  this.path = options.path || '/';
+  this.host = host;
+  this.protocol = protocol;
+  this.aborted = false;
+  this.res = null;
+  this.reusedSocket = false;
+  this.timeout = options.timeout;
+  Object.defineProperties(this, {
+    _port: {
+      value: port,
+      writable: true,
+      configurable: true,
+    },
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _fixedLength: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+    _timer: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+  });
+  if (cb) {
+    this.once('response', cb);
+  }
+
+  var headers = options.headers;
+  if (Array.isArray(headers)) {
+    for (var i = 0; i + 1 < headers.length; i += 2) {
+      this.appendHeader(headers[i], headers[i + 1]);
+    }
+  } else if (headers) {
+    for (var k in headers) {
+      this.setHeader(k, headers[k]);
+    }
+  }
+  if (host
+      && setHost
+      && !this.getHeader('host')) {
+    var hostHeader = host;
+    // wrap IPv6 addresses in brackets
+    if (hostHeader.indexOf(':') !== hostHeader.lastIndexOf(':')
+        && hostHeader.charAt(0) !== '[') {
+      hostHeader = '[' + hostHeader + ']';
+    }
+    if (port
+        && +port !== defaultPort) {
+      hostHeader += ':' + port;
+    }
+    this.setHeader('Host', hostHeader);
+  }
+  if (options.auth
+      && !this.getHeader('authorization')) {
+    this.setHeader('Authorization', 'Basic ' + Buffer.from(options.auth).toString('base64'));
+  }
+
+  if (options.timeout !== undefined) {
+    this.setTimeout(options.timeout);
+  }
+}
+
+ClientRequest.prototype = Object.create(OutgoingMessage.prototype, {
+  constructor: {
+    value: ClientRequest,
+    writable: true,
+    configurable: true,
+  },
+});
+
+ClientRequest.prototype._send = function _send(body, chunked) {
+  var host = this.host;
+  if (host.indexOf(':') !== -1) {
+    host = '[' + host + ']';
+  }
+  this._header = true;
+  this.chunkedEncoding = chunked;
+  this._refreshTimeout();
+  this._handle = binding.request({
+    method: this.method,
+    url: this.protocol + '//' + host + ':' + this._port + this.path,
+    headers: this._headerPairs(),
+    body: body,
+    chunked: chunked,
+  }, onresponse.bind(null, this));
+};
+
+function onresponse(req, err, r) {
+  if (req.destroyed) {
+    return;
+  } else if (err) {
+    req.destroy(err);
+    return;
+  }
+
+  var res = new IncomingMessage(r.body);
+  res.statusCode = r.statusCode;
+  res.statusMessage = r.statusMessage;
+  res.httpVersionMajor = r.httpVersionMajor;
+  res.httpVersionMinor = r.httpVersionMinor;
+  res.httpVersion = r.httpVersionMajor + '.' + r.httpVersionMinor;
+  res._addHeaderLines(r.rawHeaders);
+  res.req = req;
+  req.res = res;
+  res.on('activity', function() {
+    req._refreshTimeout();
+  });
+  res.on('end', function() {
+    req.destroy();
+  });
+  res.on('close', function() {
+    if (res.aborted) {
+      req.destroy();
+    }
+  });
+  req._refreshTimeout();
+  if (!req.emit('response', res)) {
+    res._dump();
+  }
+}
+
+ClientRequest.prototype.end = function end(chunk, encoding, cb) {
+  if (!this._header) {
+    // the whole body is known, and is sent with Content-Length
+    this._fixedLength = true;
+  }
+  return OutgoingMessage.prototype.end.call(this, chunk, encoding, cb);
+};
+
+ClientRequest.prototype._write = function _write(chunk, encoding, cb) {
+  this._writev([{ chunk: chunk, encoding: encoding }], cb);
+};
+
+ClientRequest.prototype._writev = function _writev(chunks, cb) {
+  var body = Buffer.concat(chunks.map(function(c) {
+    return c.chunk;
+  }));
+  if (!this._header) {
+    if (this._fixedLength) {
+      this._send(body, false);
+      cb();
+      return;
+    }
+    this._send(undefined, true);
+  } else if (!this.chunkedEncoding) {
+    var e = new Error('write after end');
+    e.code = 'ERR_STREAM_WRITE_AFTER_END';
+    cb(e);
+    return;
+  }
+  this._handle.write(body, cb);
+};
+
+ClientRequest.prototype._final = function _final(cb) {
+  if (!this._header) {
+    this._send(undefined, false);
+    cb();
+  } else if (this.chunkedEncoding) {
+    this._handle.close(cb);
+  } else {
+    cb();
+  }
+};
+
+ClientRequest.prototype._destroy = function _destroy(err, cb) {
+  this._clearTimeout();
+  if (this._handle) {
+    this._handle.abort();
+  }
+  if (this.res) {
+    if (!this.res.complete) {
+      this.res.destroy(common.connResetException('aborted'));
+    }
+  } else if (!err) {
+    err = common.connResetException('socket hang up');
+  }
+  cb(err);
+};
+
+ClientRequest.prototype.flushHeaders = function flushHeaders() {
+  if (!this._header
+      && !this.destroyed) {
+    this._send(undefined, true);
+  }
+};
+
+ClientRequest.prototype.abort = function abort() {
+  if (this.aborted) {
+    return;
+  }
+  this.aborted = true;
+  process.nextTick(emitAbortNT, this);
+  this.destroy();
+};
+
+function emitAbortNT(self) {
+  self.emit('abort');
+}
+
+ClientRequest.prototype.setTimeout = function setTimeout(msecs, cb) {
+  validateTimeout(msecs, 'msecs');
+  if (cb !== undefined) {
+    if (typeof cb !== 'function') {
+      throw util.invalidArgType('callback', 'of type function', cb);
+    }
+    this.once('timeout', cb);
+  }
+  this.timeout = msecs;
+  this._clearTimeout();
+  if (msecs > 0
+      && !this.destroyed) {
+    var self = this;
+    this._timer = timers.setTimeout(function() {
+      self._timer = null;
+      self.emit('timeout');
+    }, msecs);
+    this._timer.unref();
+  }
+  return this;
+};
+
+ClientRequest.prototype._refreshTimeout = function _refreshTimeout() {
+  if (this._timer) {
+    this._timer.refresh();
+  }
+};
+
+ClientRequest.prototype._clearTimeout = function _clearTimeout() {
+  if (this._timer) {
+    timers.clearTimeout(this._timer);
+    this._timer = null;
+  }
+};
+
+ClientRequest.prototype.setNoDelay = function setNoDelay() {};
+
+ClientRequest.prototype.setSocketKeepAlive = function setSocketKeepAlive() {};
+
+//
+// ServerResponse
+//
+
+function ServerResponse(req, handle) {
+  if (!(this instanceof ServerResponse)) {
+    return new ServerResponse(req, handle);
+  }
+
+  OutgoingMessage.call(this);
+
+  this.req = req;
+  this.statusCode = 200;
+  this.statusMessage = undefined;
+  this.sendDate = true;
+  Object.defineProperties(this, {
+    _handle: {
+      value: handle,
+      writable: true,
+      configurable: true,
+    },
+    _hasBody: {
+      value: req.method !== 'HEAD',
+      writable: true,
+      configurable: true,
+    },
+    _fixedLength: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+ServerResponse.prototype = Object.create(OutgoingMessage.prototype, {
+  constructor: {
+    value: ServerResponse,
+    writable: true,
+    configurable: true,
+  },
+});
+
+ServerResponse.prototype.writeHead = function writeHead(statusCode, reason, obj) {
+  if (this._header) {
+    throw common.headersSent('write');
+  }
+  var originalStatusCode = statusCode;
+  statusCode |= 0;
+  if (statusCode < 100
+      || statusCode > 999) {
+    var e = new RangeError('Invalid status code: ' + originalStatusCode);
+    e.code = 'ERR_HTTP_INVALID_STATUS_CODE';
+    throw e;
+  }
+
+  if (typeof reason === 'string') {
+    // net/http always sends the reason phrase for the status code
+    this.statusMessage = reason;
+  } else {
+    this.statusMessage = this.statusMessage || common.STATUS_CODES[statusCode] || 'unknown';
+    obj = reason;
+  }
+  this.statusCode = statusCode;
+  if (Array.isArray(obj)) {
+    for (var i = 0; i + 1 < obj.length; i += 2) {
+      this.appendHeader(obj[i], obj[i + 1]);
+    }
+  } else if (obj) {
+    for (var k in obj) {
+      if (k) {
+        this.setHeader(k, obj[k]);
+      }
+    }
+  }
+  if (statusCode === 204
+      || statusCode === 304
+      || statusCode < 200) {
+    this._hasBody = false;
+  }
+
+  this._header = true;
+  this._handle.writeHead(statusCode, this._headerPairs(), this.sendDate);
+  return this;
+};
+
+ServerResponse.prototype._implicitHeader = function _implicitHeader() {
+  this.writeHead(this.statusCode);
+};
+
+ServerResponse.prototype.end = function end(chunk, encoding, cb) {
+  if (!this._header) {
+    // the whole body is known, and is sent with Content-Length
+    this._fixedLength = true;
+  }
+  return OutgoingMessage.prototype.end.call(this, chunk, encoding, cb);
+};
+
+ServerResponse.prototype._write = function _write(chunk, encoding, cb) {
+  this._writev([{ chunk: chunk, encoding: encoding }], cb);
+};
+
+ServerResponse.prototype._writev = function _writev(chunks, cb) {
+  var body = Buffer.concat(chunks.map(function(c) {
+    return c.chunk;
+  }));
+  if (!this._header) {
+    if (this._fixedLength
+        && this._hasBody
+        && !this.hasHeader('content-length')
+        && !this.hasHeader('transfer-encoding')) {
+      this.setHeader('Content-Length', body.length);
+    }
+    this._implicitHeader();
+  }
+  if (!this._hasBody
+      || body.length === 0) {
+    cb();
+    return;
+  }
+  this._handle.write(body, cb);
+};
+
+ServerResponse.prototype._final = function _final(cb) {
+  if (!this._header) {
+    this._implicitHeader();
+  }
+  this._handle.close(cb);
+};
+
+ServerResponse.prototype._destroy = function _destroy(err, cb) {
+  this._handle.close(function() {
+    cb(err);
+  });
+};
+
+ServerResponse.prototype.flushHeaders = function flushHeaders() {
+  if (!this._header) {
+    this._implicitHeader();
+  }
+};
+
+ServerResponse.prototype.writeContinue = function writeContinue(cb) {
+  if (typeof cb === 'function') {
+    process.nextTick(cb);
+  }
+};
+
+ServerResponse.prototype.setTimeout = function setTimeout(msecs, cb) {
+  if (typeof cb === 'function') {
+    this.once('timeout', cb);
+  }
+  return this;
+};
+
+//
+// Server
+//
+
+function Server(options, requestListener) {
+  if (!(this instanceof Server)) {
+    return new Server(options, requestListener);
+  }
+
+  if (typeof options === 'function') {
+    requestListener = options;
+    options = {};
+  } else if (options === null
+             || options === undefined) {
+    options = {};
+  } else if (typeof options !== 'object'
+             || Array.isArray(options)) {
+    throw util.invalidArgType('options', 'of type object', options);
+  }
+
+  EventEmitter.call(this);
+
+  this.timeout = 0;
+  this.keepAliveTimeout = 5000;
+  this.headersTimeout = 60000;
+  this.requestTimeout = 300000;
+  this.maxHeadersCount = null;
+  this.maxRequestsPerSocket = 0;
+  Object.defineProperties(this, {
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _unref: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+  if (requestListener) {
+    this.on('request', requestListener);
+  }
+}
+
+Server.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Server,
+    writable: true,
+    configurable: true,
+  },
+  listening: {
+    get: function() {
+      return this._handle !== null;
+    },
+    configurable: true,
+  },
+});
+
+Server.prototype.listen = function listen() {
+  var args = Array.prototype.slice.call(arguments);
+  var cb = typeof args[args.length - 1] === 'function' ? args.pop() : null;
+  var options;
+  if (args[0] !== null
+      && typeof args[0] === 'object') {
+    options = args[0];
+  } else {
+    options = {
+      port: args[0],
+      host: typeof args[1] === 'string' ? args[1] : undefined,
+    };
+  }
+  var port = options.port === undefined || options.port === null ? 0 : util.validatePort(options.port, 'options.port', inspect);
+
+  if (this._handle) {
+    var e = new Error('Listen method has been called more than once without closing.');
+    e.code = 'ERR_SERVER_ALREADY_LISTEN';
+    throw e;
+  }
+  if (cb) {
+    this.once('listening', cb);
+  }
+
+  try {
+    this._handle = binding.listen(options.host, port, onrequest.bind(null, this));
+  } catch (err) {
+    process.nextTick(emitErrorNT, this, err);
+    return this;
+  }
+  if (this._unref) {
+    this._handle.unref();
+  }
+  process.nextTick(emitListeningNT, this);
+  return this;
+};
+
+function emitErrorNT(self, err) {
+  self.emit('error', err);
+}
+
+function emitListeningNT(self) {
+  if (self._handle) {
+    self.emit('listening');
+  }
+}
+
+function onrequest(server, r, handle) {
+  var req = new IncomingMessage(r.body);
+  req.method = r.method;
+  req.url = r.url;
+  req.httpVersionMajor = r.httpVersionMajor;
+  req.httpVersionMinor = r.httpVersionMinor;
+  req.httpVersion = r.httpVersionMajor + '.' + r.httpVersionMinor;
+  req._addHeaderLines(r.rawHeaders);
+  req.socket = {
+    remoteAddress: r.remoteAddress,
+    remotePort: r.remotePort,
+    localAddress: r.localAddress,
+    localPort: r.localPort,
+  };
+  var res = new ServerResponse(req, handle);
+  handle.onabort = function() {
+    if (!req.complete) {
+      req.destroy(common.connResetException('aborted'));
+    }
+    res.destroy();
+  };
+  server.emit('request', req, res);
+}
+
+Server.prototype.address = function address() {
+  return this._handle ? this._handle.address : null;
+};
+
+Server.prototype.close = function close(cb) {
+  if (typeof cb === 'function') {
+    if (this._handle) {
+      this.once('close', cb);
+    } else {
+      this.once('close', function() {
+        var e = new Error('Server is not running.');
+        e.code = 'ERR_SERVER_NOT_RUNNING';
+        cb(e);
+      });
+    }
+  }
+
+  var self = this;
+  if (this._handle) {
+    this._handle.close(function() {
+      self.emit('close');
+    });
+    this._handle = null;
+  } else {
+    process.nextTick(function() {
+      self.emit('close');
+    });
+  }
+  return this;
+};
+
+Server.prototype.closeAllConnections = function closeAllConnections() {
+  if (this._handle) {
+    this._handle.closeAll();
+  }
+};
+
+Server.prototype.closeIdleConnections = function closeIdleConnections() {};
+
+Server.prototype.setTimeout = function setTimeout(msecs, cb) {
+  this.timeout = msecs;
+  if (typeof cb === 'function') {
+    this.on('timeout', cb);
+  }
+  return this;
+};
+
+Server.prototype.ref = function ref() {
+  this._unref = false;
+  if (this._handle) {
+    this._handle.ref();
+  }
+  return this;
+};
+
+Server.prototype.unref = function unref() {
+  this._unref = true;
+  if (this._handle) {
+    this._handle.unref();
+  }
+  return this;
+};
+
+//
+// API
+//
+
+function request(input, options, cb) {
+  return new ClientRequest(input, options, cb);
+}
+
+function get(input, options, cb) {
+  var req = request(input, options, cb);
+  req.end();
+  return req;
+}
+
+function createServer(options, requestListener) {
+  return new Server(options, requestListener);
+}
+
+exports.METHODS = common.METHODS.slice();
+exports.STATUS_CODES = common.STATUS_CODES;
+exports.Agent = Agent;
+exports.ClientRequest = ClientRequest;
+exports.IncomingMessage = IncomingMessage;
+exports.OutgoingMessage = OutgoingMessage;
+exports.Server = Server;
+exports.ServerResponse = ServerResponse;
+exports.globalAgent = globalAgent;
+exports.request = request;
+exports.get = get;
+exports.createServer = createServer;
+exports.validateHeaderName = common.validateHeaderName;
+exports.validateHeaderValue = common.validateHeaderValue;
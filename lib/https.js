@@ -0,0 +1,63 @@
+//
+// otto.module :: https.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var http = require('./http');
+var url = require('./url');
+
+function Agent(options) {
+  if (!(this instanceof Agent)) {
+    return new Agent(options);
+  }
+
+  http.Agent.call(this, options);
+
+  this.defaultPort = 443;
+  this.protocol = 'https:';
+}
+
+Agent.prototype = Object.create(http.Agent.prototype, {
+  constructor: {
+    value: Agent,
+    writable: true,
+    configurable: true,
+  },
+});
+
+var globalAgent = new Agent();
+
+function request(input, options, cb) {
+  var opts = {};
+  var args = Array.prototype.slice.call(arguments);
+  if (typeof args[0] === 'string') {
+    opts = url.urlToHttpOptions(new url.URL(args.shift()));
+  } else if (args[0] instanceof url.URL) {
+    opts = url.urlToHttpOptions(args.shift());
+  }
+  if (args[0]
+      && typeof args[0] !== 'function') {
+    var o = args.shift();
+    for (var k in o) {
+      opts[k] = o[k];
+    }
+  }
+  opts._defaultAgent = exports.globalAgent;
+  return new http.ClientRequest(opts, args[0]);
+}
+
+function get(input, options, cb) {
+  var req = request.apply(null, arguments);
+  req.end();
+  return req;
+}
+
+exports.Agent = Agent;
+exports.globalAgent = globalAgent;
+exports.request = request;
+exports.get = get;
@@ -1,7 +1,7 @@
 //
 // otto.module :: internal/bootstrap.go
 //
-//   Copyright (c) 2017-2020 Akinori Hattori <hattya@gmail.com>
+//   Copyright (c) 2017-2026 Akinori Hattori <hattya@gmail.com>
 //
 //   SPDX-License-Identifier: MIT
 //
@@ -43,15 +43,61 @@
     return m.exports;
   };
 
+  NativeModule.resolve = function resolve(id, parent) {
+    if (id.slice(0, 2) !== './'
+        && id.slice(0, 3) !== '../') {
+      return id;
+    }
+
+    var list = parent.id.split('/').slice(0, -1);
+    id.split('/').forEach(function(s) {
+      if (s === '..') {
+        list.pop();
+      } else if (s !== '.') {
+        list.push(s);
+      }
+    });
+    return list.join('/');
+  };
+
   NativeModule.prototype.compile = function compile() {
+    var self = this;
     var fn = vm.compile(this.filename);
-    fn(this.exports, NativeModule.require, this, this.filename);
+    fn(this.exports, function require(id) {
+      return NativeModule.require(NativeModule.resolve(id, self));
+    }, this, this.filename);
 
     this.loaded = true;
   };
 
   var g = (0, eval)('this');
-  g.process = process;
+  var EventEmitter = NativeModule.require('events');
+  g.process = process = setupProcess(process);
+
+  setupStdio(process);
+
+  var warning = NativeModule.require('internal/process/warning');
+  process.emitWarning = warning.emitWarning;
+  process.on('warning', warning.onWarning);
+
+  var taskQueues = NativeModule.require('internal/process/task_queues');
+  process.nextTick = taskQueues.nextTick;
+  g.queueMicrotask = taskQueues.queueMicrotask;
+
+  g.Buffer = NativeModule.require('buffer').Buffer;
+  g.console = NativeModule.require('console');
+
+  var timers = NativeModule.require('timers');
+  g.setTimeout = timers.setTimeout;
+  g.clearTimeout = timers.clearTimeout;
+  g.setInterval = timers.setInterval;
+  g.clearInterval = timers.clearInterval;
+  g.setImmediate = timers.setImmediate;
+  g.clearImmediate = timers.clearImmediate;
+
+  var url = NativeModule.require('internal/url');
+  g.URL = url.URL;
+  g.URLSearchParams = url.URLSearchParams;
 
   var Module = NativeModule.require('module');
   var _module = NativeModule.require('internal/module');
@@ -59,4 +105,40 @@
   var m = new Module('<otto>');
   g.module = m;
   g.require = _module.require(m);
+
+  function setupProcess(o) {
+    function process() {
+      EventEmitter.call(this);
+    }
+    process.prototype = Object.create(EventEmitter.prototype, {
+      constructor: {
+        value: process,
+        writable: true,
+        configurable: true,
+      },
+    });
+
+    var p = new process();
+    Object.keys(o).forEach(function(k) {
+      p[k] = o[k];
+    });
+    return p;
+  }
+
+  function setupStdio(p) {
+    ['stdin', 'stdout', 'stderr'].forEach(function(name) {
+      var stream;
+      Object.defineProperty(p, name, {
+        get: function() {
+          if (!stream) {
+            NativeModule.require('stream');
+            stream = p.binding('stream')[name]();
+          }
+          return stream;
+        },
+        enumerable: true,
+        configurable: true,
+      });
+    });
+  }
 });
@@ -0,0 +1,436 @@
+//
+// otto.module :: internal/http.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var Readable = require('../stream').Readable;
+var Writable = require('../stream').Writable;
+var util = require('./util');
+
+exports.METHODS = [
+  'ACL',
+  'BIND',
+  'CHECKOUT',
+  'CONNECT',
+  'COPY',
+  'DELETE',
+  'GET',
+  'HEAD',
+  'LINK',
+  'LOCK',
+  'M-SEARCH',
+  'MERGE',
+  'MKACTIVITY',
+  'MKCALENDAR',
+  'MKCOL',
+  'MOVE',
+  'NOTIFY',
+  'OPTIONS',
+  'PATCH',
+  'POST',
+  'PROPFIND',
+  'PROPPATCH',
+  'PURGE',
+  'PUT',
+  'QUERY',
+  'REBIND',
+  'REPORT',
+  'SEARCH',
+  'SOURCE',
+  'SUBSCRIBE',
+  'TRACE',
+  'UNBIND',
+  'UNLINK',
+  'UNLOCK',
+  'UNSUBSCRIBE',
+];
+
+exports.STATUS_CODES = {
+  100: 'Continue',
+  101: 'Switching Protocols',
+  102: 'Processing',
+  103: 'Early Hints',
+  200: 'OK',
+  201: 'Created',
+  202: 'Accepted',
+  203: 'Non-Authoritative Information',
+  204: 'No Content',
+  205: 'Reset Content',
+  206: 'Partial Content',
+  207: 'Multi-Status',
+  208: 'Already Reported',
+  226: 'IM Used',
+  300: 'Multiple Choices',
+  301: 'Moved Permanently',
+  302: 'Found',
+  303: 'See Other',
+  304: 'Not Modified',
+  305: 'Use Proxy',
+  307: 'Temporary Redirect',
+  308: 'Permanent Redirect',
+  400: 'Bad Request',
+  401: 'Unauthorized',
+  402: 'Payment Required',
+  403: 'Forbidden',
+  404: 'Not Found',
+  405: 'Method Not Allowed',
+  406: 'Not Acceptable',
+  407: 'Proxy Authentication Required',
+  408: 'Request Timeout',
+  409: 'Conflict',
+  410: 'Gone',
+  411: 'Length Required',
+  412: 'Precondition Failed',
+  413: 'Payload Too Large',
+  414: 'URI Too Long',
+  415: 'Unsupported Media Type',
+  416: 'Range Not Satisfiable',
+  417: 'Expectation Failed',
+  418: 'I\'m a Teapot',
+  421: 'Misdirected Request',
+  422: 'Unprocessable Entity',
+  423: 'Locked',
+  424: 'Failed Dependency',
+  425: 'Too Early',
+  426: 'Upgrade Required',
+  428: 'Precondition Required',
+  429: 'Too Many Requests',
+  431: 'Request Header Fields Too Large',
+  451: 'Unavailable For Legal Reasons',
+  500: 'Internal Server Error',
+  501: 'Not Implemented',
+  502: 'Bad Gateway',
+  503: 'Service Unavailable',
+  504: 'Gateway Timeout',
+  505: 'HTTP Version Not Supported',
+  506: 'Variant Also Negotiates',
+  507: 'Insufficient Storage',
+  508: 'Loop Detected',
+  509: 'Bandwidth Limit Exceeded',
+  510: 'Not Extended',
+  511: 'Network Authentication Required',
+};
+
+//
+// errors
+//
+
+function invalidHttpToken(name, field) {
+  var e = new TypeError(name + ' must be a valid HTTP token ["' + field + '"]');
+  e.code = 'ERR_INVALID_HTTP_TOKEN';
+  return e;
+}
+
+function headersSent(action) {
+  var e = new Error('Cannot ' + action + ' headers after they are sent to the client');
+  e.code = 'ERR_HTTP_HEADERS_SENT';
+  return e;
+}
+
+function connResetException(msg) {
+  var e = new Error(msg);
+  e.code = 'ECONNRESET';
+  return e;
+}
+
+exports.invalidHttpToken = invalidHttpToken;
+exports.headersSent = headersSent;
+exports.connResetException = connResetException;
+
+//
+// validators
+//
+
+var tokenRegExp = /^[\^_`a-zA-Z\-0-9!#$%&'*+.|~]+$/;
+var headerCharRegExp = /[^\t\x20-\x7e\x80-\xff]/;
+
+function checkIsHttpToken(s) {
+  return tokenRegExp.test(s);
+}
+
+function checkInvalidHeaderChar(s) {
+  return headerCharRegExp.test(s);
+}
+
+function validateHeaderName(name) {
+  if (typeof name !== 'string'
+      || !name
+      || !checkIsHttpToken(name)) {
+    throw invalidHttpToken('Header name', name);
+  }
+}
+
+function validateHeaderValue(name, value) {
+  if (value === undefined) {
+    var e = new TypeError('Invalid value "' + value + '" for header "' + name + '"');
+    e.code = 'ERR_HTTP_INVALID_HEADER_VALUE';
+    throw e;
+  }
+  if (checkInvalidHeaderChar(String(value))) {
+    e = new TypeError('Invalid character in header content ["' + name + '"]');
+    e.code = 'ERR_INVALID_CHAR';
+    throw e;
+  }
+}
+
+exports.checkIsHttpToken = checkIsHttpToken;
+exports.checkInvalidHeaderChar = checkInvalidHeaderChar;
+exports.validateHeaderName = validateHeaderName;
+exports.validateHeaderValue = validateHeaderValue;
+
+//
+// IncomingMessage
+//
+
+// fields for which duplicates are discarded
+var singleFields = [
+  'age',
+  'authorization',
+  'content-length',
+  'content-type',
+  'etag',
+  'expires',
+  'from',
+  'host',
+  'if-modified-since',
+  'if-unmodified-since',
+  'last-modified',
+  'location',
+  'max-forwards',
+  'proxy-authorization',
+  'referer',
+  'retry-after',
+  'server',
+  'user-agent',
+];
+
+function IncomingMessage(handle) {
+  if (!(this instanceof IncomingMessage)) {
+    return new IncomingMessage(handle);
+  }
+
+  Readable.call(this);
+
+  this.aborted = false;
+  this.complete = false;
+  this.httpVersionMajor = null;
+  this.httpVersionMinor = null;
+  this.httpVersion = null;
+  this.headers = {};
+  this.rawHeaders = [];
+  this.trailers = {};
+  this.rawTrailers = [];
+  this.method = null;
+  this.url = '';
+  this.statusCode = null;
+  this.statusMessage = null;
+  this.socket = null;
+  Object.defineProperty(this, '_handle', {
+    value: handle,
+    writable: true,
+    configurable: true,
+  });
+}
+
+IncomingMessage.prototype = Object.create(Readable.prototype, {
+  constructor: {
+    value: IncomingMessage,
+    writable: true,
+    configurable: true,
+  },
+});
+
+IncomingMessage.prototype._addHeaderLines = function _addHeaderLines(raw) {
+  for (var i = 0; i + 1 < raw.length; i += 2) {
+    this.rawHeaders.push(raw[i], raw[i + 1]);
+    this._addHeaderLine(raw[i], raw[i + 1], this.headers);
+  }
+};
+
+IncomingMessage.prototype._addHeaderLine = function _addHeaderLine(field, value, dest) {
+  field = field.toLowerCase();
+  if (field === 'set-cookie') {
+    if (dest[field] === undefined) {
+      dest[field] = [value];
+    } else {
+      dest[field].push(value);
+    }
+  } else if (dest[field] === undefined) {
+    dest[field] = value;
+  } else if (singleFields.indexOf(field) === -1) {
+    dest[field] += (field === 'cookie' ? '; ' : ', ') + value;
+  }
+};
+
+IncomingMessage.prototype._read = function _read(n) {
+  var self = this;
+  this._handle.read(n, function(err, buf) {
+    if (err) {
+      self.destroy(err);
+    } else if (buf === null) {
+      self.complete = true;
+      self.push(null);
+    } else {
+      self.emit('activity');
+      self.push(buf);
+    }
+  });
+};
+
+IncomingMessage.prototype._destroy = function _destroy(err, cb) {
+  if (!this.readableEnded
+      || !this.complete) {
+    this.aborted = true;
+    this.emit('aborted');
+  }
+  this._handle.close();
+  // an error is emitted only if there are listeners
+  if (this.listenerCount('error') === 0) {
+    err = null;
+  }
+  process.nextTick(cb, err);
+};
+
+IncomingMessage.prototype._dump = function _dump() {
+  this.resume();
+};
+
+IncomingMessage.prototype.setTimeout = function setTimeout(msecs, cb) {
+  if (this.req) {
+    this.req.setTimeout(msecs, cb);
+  }
+  return this;
+};
+
+exports.IncomingMessage = IncomingMessage;
+
+//
+// OutgoingMessage
+//
+
+function OutgoingMessage(options) {
+  if (!(this instanceof OutgoingMessage)) {
+    return new OutgoingMessage(options);
+  }
+
+  Writable.call(this, options);
+
+  this.chunkedEncoding = false;
+  this.sendDate = false;
+  this.socket = null;
+  Object.defineProperties(this, {
+    _headers: {
+      value: {},
+      writable: true,
+      configurable: true,
+    },
+    _header: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+OutgoingMessage.prototype = Object.create(Writable.prototype, {
+  constructor: {
+    value: OutgoingMessage,
+    writable: true,
+    configurable: true,
+  },
+  headersSent: {
+    get: function() {
+      return this._header;
+    },
+    configurable: true,
+  },
+});
+
+OutgoingMessage.prototype.setHeader = function setHeader(name, value) {
+  if (this._header) {
+    throw headersSent('set');
+  }
+  validateHeaderName(name);
+  validateHeaderValue(name, value);
+  this._headers[name.toLowerCase()] = [name, value];
+  return this;
+};
+
+OutgoingMessage.prototype.appendHeader = function appendHeader(name, value) {
+  if (this._header) {
+    throw headersSent('append');
+  }
+  validateHeaderName(name);
+  validateHeaderValue(name, value);
+  var k = name.toLowerCase();
+  var e = this._headers[k];
+  if (e === undefined) {
+    this._headers[k] = [name, value];
+  } else {
+    e[1] = [].concat(e[1], value);
+  }
+  return this;
+};
+
+OutgoingMessage.prototype.getHeader = function getHeader(name) {
+  if (typeof name !== 'string') {
+    throw util.invalidArgType('name', 'of type string', name);
+  }
+  var e = this._headers[name.toLowerCase()];
+  return e && e[1];
+};
+
+OutgoingMessage.prototype.getHeaders = function getHeaders() {
+  var headers = {};
+  for (var k in this._headers) {
+    headers[k] = this._headers[k][1];
+  }
+  return headers;
+};
+
+OutgoingMessage.prototype.getHeaderNames = function getHeaderNames() {
+  return Object.keys(this._headers);
+};
+
+OutgoingMessage.prototype.getRawHeaderNames = function getRawHeaderNames() {
+  var headers = this._headers;
+  return Object.keys(headers).map(function(k) {
+    return headers[k][0];
+  });
+};
+
+OutgoingMessage.prototype.hasHeader = function hasHeader(name) {
+  if (typeof name !== 'string') {
+    throw util.invalidArgType('name', 'of type string', name);
+  }
+  return this._headers[name.toLowerCase()] !== undefined;
+};
+
+OutgoingMessage.prototype.removeHeader = function removeHeader(name) {
+  if (typeof name !== 'string') {
+    throw util.invalidArgType('name', 'of type string', name);
+  }
+  if (this._header) {
+    throw headersSent('remove');
+  }
+  delete this._headers[name.toLowerCase()];
+};
+
+// returns the headers as a list of [name, value] pairs
+OutgoingMessage.prototype._headerPairs = function _headerPairs() {
+  var pairs = [];
+  for (var k in this._headers) {
+    var e = this._headers[k];
+    [].concat(e[1]).forEach(function(v) {
+      pairs.push([e[0], String(v)]);
+    });
+  }
+  return pairs;
+};
+
+exports.OutgoingMessage = OutgoingMessage;
@@ -0,0 +1,226 @@
+//
+// otto.module :: internal/idna.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var base = 36;
+var tMin = 1;
+var tMax = 26;
+var skew = 38;
+var damp = 700;
+var initialBias = 72;
+var initialN = 0x80;
+var maxInt = 0x7fffffff;
+
+function adapt(delta, numPoints, firstTime) {
+  var k = 0;
+  delta = firstTime ? Math.floor(delta / damp) : delta >> 1;
+  delta += Math.floor(delta / numPoints);
+  for (; delta > ((base - tMin) * tMax) >> 1; k += base) {
+    delta = Math.floor(delta / (base - tMin));
+  }
+  return Math.floor(k + (base - tMin + 1) * delta / (delta + skew));
+}
+
+function threshold(k, bias) {
+  if (k <= bias) {
+    return tMin;
+  } else if (k >= bias + tMax) {
+    return tMax;
+  }
+  return k - bias;
+}
+
+function encodeDigit(d) {
+  return String.fromCharCode(d < 26 ? d + 0x61 : d + 0x16);
+}
+
+function decodeDigit(c) {
+  if (0x30 <= c && c <= 0x39) {
+    return c - 0x16;
+  } else if (0x41 <= c && c <= 0x5a) {
+    return c - 0x41;
+  } else if (0x61 <= c && c <= 0x7a) {
+    return c - 0x61;
+  }
+  return base;
+}
+
+function ucs2decode(s) {
+  var list = [];
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charCodeAt(i);
+    if (0xd800 <= c && c <= 0xdbff && i + 1 < s.length) {
+      var d = s.charCodeAt(i + 1);
+      if (0xdc00 <= d && d <= 0xdfff) {
+        list.push(((c - 0xd800) << 10) + (d - 0xdc00) + 0x10000);
+        i++;
+        continue;
+      }
+    }
+    list.push(c);
+  }
+  return list;
+}
+
+function ucs2encode(list) {
+  var s = '';
+  for (var i = 0; i < list.length; i++) {
+    var cp = list[i];
+    if (cp > 0xffff) {
+      cp -= 0x10000;
+      s += String.fromCharCode(0xd800 + (cp >> 10), 0xdc00 + (cp & 0x3ff));
+    } else {
+      s += String.fromCharCode(cp);
+    }
+  }
+  return s;
+}
+
+function encode(s) {
+  var input = ucs2decode(s);
+  var output = '';
+  var i;
+  for (i = 0; i < input.length; i++) {
+    if (input[i] < 0x80) {
+      output += String.fromCharCode(input[i]);
+    }
+  }
+
+  var n = initialN;
+  var delta = 0;
+  var bias = initialBias;
+  var b = output.length;
+  var h = b;
+  if (b) {
+    output += '-';
+  }
+  while (h < input.length) {
+    var m = maxInt;
+    for (i = 0; i < input.length; i++) {
+      if (input[i] >= n && input[i] < m) {
+        m = input[i];
+      }
+    }
+    if (m - n > Math.floor((maxInt - delta) / (h + 1))) {
+      throw new RangeError('Overflow: input needs wider integers to process');
+    }
+    delta += (m - n) * (h + 1);
+    n = m;
+    for (i = 0; i < input.length; i++) {
+      if (input[i] < n) {
+        delta++;
+      } else if (input[i] === n) {
+        var q = delta;
+        for (var k = base; ; k += base) {
+          var t = threshold(k, bias);
+          if (q < t) {
+            break;
+          }
+          output += encodeDigit(t + (q - t) % (base - t));
+          q = Math.floor((q - t) / (base - t));
+        }
+        output += encodeDigit(q);
+        bias = adapt(delta, h + 1, h === b);
+        delta = 0;
+        h++;
+      }
+    }
+    delta++;
+    n++;
+  }
+  return output;
+}
+
+function decode(s) {
+  var output = [];
+  var basic = s.lastIndexOf('-');
+  if (basic < 0) {
+    basic = 0;
+  }
+  for (var j = 0; j < basic; j++) {
+    if (s.charCodeAt(j) >= 0x80) {
+      throw new RangeError('Illegal input >= 0x80 (not a basic code point)');
+    }
+    output.push(s.charCodeAt(j));
+  }
+
+  var n = initialN;
+  var bias = initialBias;
+  var i = 0;
+  for (var index = basic > 0 ? basic + 1 : 0; index < s.length;) {
+    var oldi = i;
+    for (var w = 1, k = base; ; k += base) {
+      if (index >= s.length) {
+        throw new RangeError('Invalid input');
+      }
+      var digit = decodeDigit(s.charCodeAt(index++));
+      if (digit >= base || digit > Math.floor((maxInt - i) / w)) {
+        throw new RangeError('Invalid input');
+      }
+      i += digit * w;
+      var t = threshold(k, bias);
+      if (digit < t) {
+        break;
+      }
+      w *= base - t;
+    }
+    var out = output.length + 1;
+    bias = adapt(i - oldi, out, oldi === 0);
+    if (Math.floor(i / out) > maxInt - n) {
+      throw new RangeError('Overflow: input needs wider integers to process');
+    }
+    n += Math.floor(i / out);
+    i %= out;
+    output.splice(i++, 0, n);
+  }
+  return ucs2encode(output);
+}
+
+exports.encode = encode;
+exports.decode = decode;
+
+var ignored = /[\u00ad\u034f\u180b-\u180d\u200b\u2060\ufe00-\ufe0f\ufeff]/g;
+var separators = /[\u3002\uff0e\uff61]/g;
+var nonASCII = /[^\x00-\x7f]/;
+
+function map(domain) {
+  domain = domain.replace(ignored, '').replace(separators, '.').toLowerCase();
+  return typeof domain.normalize === 'function' ? domain.normalize('NFC') : domain;
+}
+
+exports.toASCII = function toASCII(domain) {
+  var labels = map(domain).split('.');
+  for (var i = 0; i < labels.length; i++) {
+    var l = labels[i];
+    if (l.slice(0, 4) === 'xn--') {
+      var u = decode(l.slice(4));
+      if (!u
+          || !nonASCII.test(u)) {
+        throw new RangeError('Invalid label');
+      }
+    } else if (nonASCII.test(l)) {
+      labels[i] = 'xn--' + encode(l);
+    }
+  }
+  return labels.join('.');
+};
+
+exports.toUnicode = function toUnicode(domain) {
+  var labels = map(domain).split('.');
+  for (var i = 0; i < labels.length; i++) {
+    if (labels[i].slice(0, 4) === 'xn--') {
+      try {
+        labels[i] = decode(labels[i].slice(4));
+      } catch (e) {
+        // keep label as is
+      }
+    }
+  }
+  return labels.join('.');
+};
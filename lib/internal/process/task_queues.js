@@ -0,0 +1,54 @@
+//
+// otto.module :: internal/process/task_queues.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('task_queue');
+var util = require('../util');
+
+var ticks = [];
+var microtasks = [];
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function runMicrotasks() {
+  while (microtasks.length > 0) {
+    microtasks.shift()();
+  }
+}
+
+binding.setTickCallback(function processTicksAndRejections() {
+  do {
+    while (ticks.length > 0) {
+      var tock = ticks.shift();
+      if (tock.args === undefined) {
+        tock.callback();
+      } else {
+        tock.callback.apply(undefined, tock.args);
+      }
+    }
+    runMicrotasks();
+  } while (ticks.length > 0);
+});
+
+exports.nextTick = function nextTick(callback) {
+  validateFunction(callback, 'callback');
+  ticks.push({
+    callback: callback,
+    args: arguments.length > 1 ? Array.prototype.slice.call(arguments, 1) : undefined,
+  });
+};
+
+exports.queueMicrotask = function queueMicrotask(callback) {
+  validateFunction(callback, 'callback');
+  microtasks.push(callback);
+};
@@ -0,0 +1,76 @@
+//
+// otto.module :: internal/process/warning.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('warning');
+
+var codesWarned = Object.create(null);
+
+function createWarning(message, type, code, detail) {
+  var warning = binding.create(type, message);
+  warning.name = type;
+  if (code !== undefined) {
+    warning.code = code;
+  }
+  if (detail !== undefined) {
+    warning.detail = detail;
+  }
+  return warning;
+}
+
+exports.emitWarning = function emitWarning(warning, type, code) {
+  var detail;
+  if (type !== null
+      && typeof type === 'object'
+      && !Array.isArray(type)) {
+    code = type.code;
+    if (typeof type.detail === 'string') {
+      detail = type.detail;
+    }
+    type = type.type || 'Warning';
+  } else if (typeof type === 'function') {
+    code = undefined;
+    type = 'Warning';
+  }
+  if (type === undefined) {
+    type = 'Warning';
+  } else if (typeof type !== 'string') {
+    throw new TypeError('type must be a String');
+  }
+  if (typeof code === 'function') {
+    code = undefined;
+  } else if (code !== undefined
+             && typeof code !== 'string') {
+    throw new TypeError('code must be a String');
+  }
+
+  if (typeof warning === 'string') {
+    warning = createWarning(warning, type, code, detail);
+  } else if (!(warning instanceof Error)) {
+    throw new TypeError('warning must be a String or Error');
+  }
+
+  if (warning.name === 'DeprecationWarning') {
+    if (process.noDeprecation) {
+      return;
+    } else if (process.throwDeprecation) {
+      throw warning;
+    } else if (warning.code !== undefined) {
+      if (codesWarned[warning.code]) {
+        return;
+      }
+      codesWarned[warning.code] = true;
+    }
+  }
+  process.emit('warning', warning);
+};
+
+exports.onWarning = function onWarning(warning) {
+  binding.emit(warning);
+};
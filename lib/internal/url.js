@@ -0,0 +1,1687 @@
+//
+// otto.module :: internal/url.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var idna = require('./idna');
+var inspect = require('./util/inspect').inspect;
+
+var specialSchemes = {
+  'ftp': 21,
+  'file': null,
+  'http': 80,
+  'https': 443,
+  'ws': 80,
+  'wss': 443,
+};
+
+function isSpecialScheme(scheme) {
+  return Object.prototype.hasOwnProperty.call(specialSchemes, scheme);
+}
+
+function invalidURL(input) {
+  var e = new TypeError('Invalid URL');
+  e.code = 'ERR_INVALID_URL';
+  e.input = input;
+  return e;
+}
+
+function invalidArgType(name, type, v) {
+  var e = new TypeError('The "' + name + '" argument must be of type ' + type + '. Received ' + inspect(v));
+  e.code = 'ERR_INVALID_ARG_TYPE';
+  return e;
+}
+
+exports.invalidURL = invalidURL;
+exports.invalidArgType = invalidArgType;
+
+//
+// code points
+//
+
+function toCodePoints(s) {
+  var list = [];
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charCodeAt(i);
+    if (0xd800 <= c && c <= 0xdbff && i + 1 < s.length) {
+      var d = s.charCodeAt(i + 1);
+      if (0xdc00 <= d && d <= 0xdfff) {
+        list.push(String.fromCharCode(c, d));
+        i++;
+        continue;
+      }
+    }
+    list.push(0xd800 <= c && c <= 0xdfff ? '\ufffd' : s.charAt(i));
+  }
+  return list;
+}
+
+function toUSVString(s) {
+  return toCodePoints(String(s)).join('');
+}
+
+exports.toUSVString = toUSVString;
+
+function isASCIIDigit(c) {
+  return c !== undefined
+         && c.length === 1
+         && '0' <= c && c <= '9';
+}
+
+function isASCIIHexDigit(c) {
+  return isASCIIDigit(c)
+         || (c !== undefined && c.length === 1 && (('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')));
+}
+
+function isASCIIAlpha(c) {
+  return c !== undefined
+         && c.length === 1
+         && (('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'));
+}
+
+function isASCIIAlphanumeric(c) {
+  return isASCIIAlpha(c)
+         || isASCIIDigit(c);
+}
+
+function isWindowsDriveLetter(s) {
+  return s.length === 2
+         && isASCIIAlpha(s.charAt(0))
+         && (s.charAt(1) === ':' || s.charAt(1) === '|');
+}
+
+function isNormalizedWindowsDriveLetter(s) {
+  return isWindowsDriveLetter(s)
+         && s.charAt(1) === ':';
+}
+
+function startsWithWindowsDriveLetter(cps, i) {
+  if (cps.length - i < 2
+      || !isWindowsDriveLetter(cps[i] + cps[i + 1])) {
+    return false;
+  }
+  if (cps.length - i === 2) {
+    return true;
+  }
+  var c = cps[i + 2];
+  return c === '/'
+         || c === '\\'
+         || c === '?'
+         || c === '#';
+}
+
+function isSingleDot(s) {
+  return s === '.'
+         || s.toLowerCase() === '%2e';
+}
+
+function isDoubleDot(s) {
+  switch (s.toLowerCase()) {
+  case '..':
+  case '.%2e':
+  case '%2e.':
+  case '%2e%2e':
+    return true;
+  }
+  return false;
+}
+
+//
+// percent-encoding
+//
+
+function hex(b) {
+  return '%' + (b < 0x10 ? '0' : '') + b.toString(16).toUpperCase();
+}
+
+function c0ControlSet(c) {
+  return c < 0x20
+         || c > 0x7e;
+}
+
+function fragmentSet(c) {
+  return c0ControlSet(c)
+         || c === 0x20
+         || c === 0x22
+         || c === 0x3c
+         || c === 0x3e
+         || c === 0x60;
+}
+
+function querySet(c) {
+  return c0ControlSet(c)
+         || c === 0x20
+         || c === 0x22
+         || c === 0x23
+         || c === 0x3c
+         || c === 0x3e;
+}
+
+function specialQuerySet(c) {
+  return querySet(c)
+         || c === 0x27;
+}
+
+function pathSet(c) {
+  return querySet(c)
+         || c === 0x3f
+         || c === 0x60
+         || c === 0x7b
+         || c === 0x7d;
+}
+
+function userinfoSet(c) {
+  return pathSet(c)
+         || c === 0x2f
+         || c === 0x3a
+         || c === 0x3b
+         || c === 0x3d
+         || c === 0x40
+         || (0x5b <= c && c <= 0x5e)
+         || c === 0x7c;
+}
+
+function utf8Encode(s) {
+  var b = [];
+  for (var i = 0; i < s.length; i++) {
+    var cp = s.charCodeAt(i);
+    if (0xd800 <= cp && cp <= 0xdbff && i + 1 < s.length) {
+      var d = s.charCodeAt(i + 1);
+      if (0xdc00 <= d && d <= 0xdfff) {
+        cp = ((cp - 0xd800) << 10) + (d - 0xdc00) + 0x10000;
+        i++;
+      }
+    }
+    if (0xd800 <= cp && cp <= 0xdfff) {
+      cp = 0xfffd;
+    }
+
+    if (cp < 0x80) {
+      b.push(cp);
+    } else if (cp < 0x800) {
+      b.push(0xc0 | (cp >> 6), 0x80 | (cp & 0x3f));
+    } else if (cp < 0x10000) {
+      b.push(0xe0 | (cp >> 12), 0x80 | ((cp >> 6) & 0x3f), 0x80 | (cp & 0x3f));
+    } else {
+      b.push(0xf0 | (cp >> 18), 0x80 | ((cp >> 12) & 0x3f), 0x80 | ((cp >> 6) & 0x3f), 0x80 | (cp & 0x3f));
+    }
+  }
+  return b;
+}
+
+function utf8Decode(b) {
+  var s = '';
+  for (var i = 0; i < b.length;) {
+    var c = b[i++];
+    var cp;
+    var n;
+    var lower = 0x80;
+    var upper = 0xbf;
+    if (c < 0x80) {
+      s += String.fromCharCode(c);
+      continue;
+    } else if (0xc2 <= c && c <= 0xdf) {
+      n = 1;
+      cp = c & 0x1f;
+    } else if (0xe0 <= c && c <= 0xef) {
+      n = 2;
+      cp = c & 0x0f;
+      if (c === 0xe0) {
+        lower = 0xa0;
+      } else if (c === 0xed) {
+        upper = 0x9f;
+      }
+    } else if (0xf0 <= c && c <= 0xf4) {
+      n = 3;
+      cp = c & 0x07;
+      if (c === 0xf0) {
+        lower = 0x90;
+      } else if (c === 0xf4) {
+        upper = 0x8f;
+      }
+    } else {
+      s += '\ufffd';
+      continue;
+    }
+    for (; n > 0; n--) {
+      if (i >= b.length
+          || b[i] < lower
+          || b[i] > upper) {
+        cp = -1;
+        break;
+      }
+      cp = (cp << 6) | (b[i++] & 0x3f);
+      lower = 0x80;
+      upper = 0xbf;
+    }
+    if (cp < 0) {
+      s += '\ufffd';
+    } else if (cp > 0xffff) {
+      cp -= 0x10000;
+      s += String.fromCharCode(0xd800 + (cp >> 10), 0xdc00 + (cp & 0x3ff));
+    } else {
+      s += String.fromCharCode(cp);
+    }
+  }
+  return s;
+}
+
+function percentEncode(s, set, spaceAsPlus) {
+  var out = '';
+  var b = utf8Encode(s);
+  for (var i = 0; i < b.length; i++) {
+    if (spaceAsPlus && b[i] === 0x20) {
+      out += '+';
+    } else if (set(b[i])) {
+      out += hex(b[i]);
+    } else {
+      out += String.fromCharCode(b[i]);
+    }
+  }
+  return out;
+}
+
+function percentDecode(s) {
+  var b = utf8Encode(s);
+  var out = [];
+  for (var i = 0; i < b.length; i++) {
+    if (b[i] === 0x25
+        && i + 2 < b.length
+        && isASCIIHexDigit(String.fromCharCode(b[i + 1]))
+        && isASCIIHexDigit(String.fromCharCode(b[i + 2]))) {
+      out.push(parseInt(String.fromCharCode(b[i + 1], b[i + 2]), 16));
+      i += 2;
+    } else {
+      out.push(b[i]);
+    }
+  }
+  return out;
+}
+
+exports.percentDecode = function(s) {
+  return utf8Decode(percentDecode(s));
+};
+
+//
+// host
+//
+
+var forbiddenHost = /[\x00\t\n\r #/:<>?@[\\\]^|]/;
+var forbiddenDomain = /[\x00-\x20#%/:<>?@[\\\]^|\x7f]/;
+
+function parseIPv4Number(s) {
+  if (s === '') {
+    return NaN;
+  }
+  var radix = 10;
+  if (s.length >= 2
+      && s.charAt(0) === '0'
+      && (s.charAt(1) === 'x' || s.charAt(1) === 'X')) {
+    s = s.slice(2);
+    radix = 16;
+  } else if (s.length >= 2
+             && s.charAt(0) === '0') {
+    s = s.slice(1);
+    radix = 8;
+  }
+  if (s === '') {
+    return 0;
+  }
+  var re = radix === 10 ? /^[0-9]+$/ : radix === 16 ? /^[0-9A-Fa-f]+$/ : /^[0-7]+$/;
+  return re.test(s) ? parseInt(s, radix) : NaN;
+}
+
+function endsInANumber(s) {
+  var parts = s.split('.');
+  if (parts[parts.length - 1] === '') {
+    if (parts.length === 1) {
+      return false;
+    }
+    parts.pop();
+  }
+  var last = parts[parts.length - 1];
+  return /^[0-9]+$/.test(last)
+         || !isNaN(parseIPv4Number(last));
+}
+
+function parseIPv4(s) {
+  var parts = s.split('.');
+  if (parts[parts.length - 1] === ''
+      && parts.length > 1) {
+    parts.pop();
+  }
+  if (parts.length > 4) {
+    return null;
+  }
+  var numbers = [];
+  for (var i = 0; i < parts.length; i++) {
+    var n = parseIPv4Number(parts[i]);
+    if (isNaN(n)) {
+      return null;
+    }
+    numbers.push(n);
+  }
+  for (i = 0; i < numbers.length - 1; i++) {
+    if (numbers[i] > 255) {
+      return null;
+    }
+  }
+  if (numbers[numbers.length - 1] >= Math.pow(256, 5 - numbers.length)) {
+    return null;
+  }
+  var ipv4 = numbers[numbers.length - 1];
+  for (i = 0; i < numbers.length - 1; i++) {
+    ipv4 += numbers[i] * Math.pow(256, 3 - i);
+  }
+  return ipv4;
+}
+
+function serializeIPv4(n) {
+  var list = [];
+  for (var i = 0; i < 4; i++) {
+    list.unshift(String(n % 256));
+    n = Math.floor(n / 256);
+  }
+  return list.join('.');
+}
+
+function parseIPv6(s) {
+  var address = [0, 0, 0, 0, 0, 0, 0, 0];
+  var pieceIndex = 0;
+  var compress = null;
+  var i = 0;
+  var value;
+  var length;
+  if (s.charAt(i) === ':') {
+    if (s.charAt(i + 1) !== ':') {
+      return null;
+    }
+    i += 2;
+    compress = ++pieceIndex;
+  }
+  while (i < s.length) {
+    if (pieceIndex === 8) {
+      return null;
+    }
+    if (s.charAt(i) === ':') {
+      if (compress !== null) {
+        return null;
+      }
+      i++;
+      compress = ++pieceIndex;
+      continue;
+    }
+    value = length = 0;
+    while (length < 4
+           && isASCIIHexDigit(s.charAt(i))) {
+      value = value * 0x10 + parseInt(s.charAt(i), 16);
+      i++;
+      length++;
+    }
+    if (s.charAt(i) === '.') {
+      if (length === 0) {
+        return null;
+      }
+      i -= length;
+      if (pieceIndex > 6) {
+        return null;
+      }
+      var numbersSeen = 0;
+      while (i < s.length) {
+        var ipv4Piece = null;
+        if (numbersSeen > 0) {
+          if (s.charAt(i) === '.'
+              && numbersSeen < 4) {
+            i++;
+          } else {
+            return null;
+          }
+        }
+        if (!isASCIIDigit(s.charAt(i))) {
+          return null;
+        }
+        while (isASCIIDigit(s.charAt(i))) {
+          var n = parseInt(s.charAt(i), 10);
+          if (ipv4Piece === null) {
+            ipv4Piece = n;
+          } else if (ipv4Piece === 0) {
+            return null;
+          } else {
+            ipv4Piece = ipv4Piece * 10 + n;
+          }
+          if (ipv4Piece > 255) {
+            return null;
+          }
+          i++;
+        }
+        address[pieceIndex] = address[pieceIndex] * 0x100 + ipv4Piece;
+        numbersSeen++;
+        if (numbersSeen === 2
+            || numbersSeen === 4) {
+          pieceIndex++;
+        }
+      }
+      if (numbersSeen !== 4) {
+        return null;
+      }
+      break;
+    } else if (s.charAt(i) === ':') {
+      i++;
+      if (i >= s.length) {
+        return null;
+      }
+    } else if (i < s.length) {
+      return null;
+    }
+    address[pieceIndex++] = value;
+  }
+  if (compress !== null) {
+    var swaps = pieceIndex - compress;
+    pieceIndex = 7;
+    while (pieceIndex !== 0
+           && swaps > 0) {
+      var t = address[compress + swaps - 1];
+      address[compress + swaps - 1] = address[pieceIndex];
+      address[pieceIndex] = t;
+      pieceIndex--;
+      swaps--;
+    }
+  } else if (pieceIndex !== 8) {
+    return null;
+  }
+  return address;
+}
+
+function serializeIPv6(address) {
+  var compress = null;
+  var max = 1;
+  for (var i = 0; i < 8;) {
+    if (address[i] !== 0) {
+      i++;
+      continue;
+    }
+    var j = i;
+    while (j < 8
+           && address[j] === 0) {
+      j++;
+    }
+    if (j - i > max) {
+      max = j - i;
+      compress = i;
+    }
+    i = j;
+  }
+
+  var out = '';
+  var ignore0 = false;
+  for (i = 0; i < 8; i++) {
+    if (ignore0) {
+      if (address[i] === 0) {
+        continue;
+      }
+      ignore0 = false;
+    }
+    if (compress === i) {
+      out += i === 0 ? '::' : ':';
+      ignore0 = true;
+      continue;
+    }
+    out += address[i].toString(16);
+    if (i !== 7) {
+      out += ':';
+    }
+  }
+  return out;
+}
+
+function domainToASCII(domain) {
+  try {
+    return idna.toASCII(domain);
+  } catch (e) {
+    return null;
+  }
+}
+
+function parseHost(input, isNotSpecial) {
+  if (input.charAt(0) === '[') {
+    if (input.charAt(input.length - 1) !== ']') {
+      return null;
+    }
+    var address = parseIPv6(input.slice(1, -1));
+    return address ? '[' + serializeIPv6(address) + ']' : null;
+  }
+  if (isNotSpecial) {
+    return forbiddenHost.test(input) ? null : percentEncode(input, c0ControlSet);
+  }
+
+  var ascii = domainToASCII(utf8Decode(percentDecode(input)));
+  if (!ascii
+      || forbiddenDomain.test(ascii)) {
+    return null;
+  }
+  if (endsInANumber(ascii)) {
+    var ipv4 = parseIPv4(ascii);
+    return ipv4 !== null ? serializeIPv4(ipv4) : null;
+  }
+  return ascii;
+}
+
+exports.domainToASCII = function domainToASCII(domain) {
+  var host = parseHost(toUSVString(domain), false);
+  return host !== null ? host : '';
+};
+
+exports.domainToUnicode = function domainToUnicode(domain) {
+  var host = parseHost(toUSVString(domain), false);
+  return host !== null ? idna.toUnicode(host) : '';
+};
+
+//
+// URL record
+//
+
+function Record() {
+  this.scheme = '';
+  this.username = '';
+  this.password = '';
+  this.host = null;
+  this.port = null;
+  this.path = [];
+  this.query = null;
+  this.fragment = null;
+}
+
+Record.prototype.isSpecial = function isSpecial() {
+  return isSpecialScheme(this.scheme);
+};
+
+Record.prototype.hasOpaquePath = function hasOpaquePath() {
+  return typeof this.path === 'string';
+};
+
+Record.prototype.includesCredentials = function includesCredentials() {
+  return this.username !== ''
+         || this.password !== '';
+};
+
+Record.prototype.cannotHaveUsernamePasswordPort = function cannotHaveUsernamePasswordPort() {
+  return this.host === null
+         || this.host === ''
+         || this.scheme === 'file';
+};
+
+Record.prototype.shortenPath = function shortenPath() {
+  if (this.scheme === 'file'
+      && this.path.length === 1
+      && isNormalizedWindowsDriveLetter(this.path[0])) {
+    return;
+  }
+  this.path.pop();
+};
+
+Record.prototype.serializePath = function serializePath() {
+  if (this.hasOpaquePath()) {
+    return this.path;
+  }
+  var s = '';
+  for (var i = 0; i < this.path.length; i++) {
+    s += '/' + this.path[i];
+  }
+  return s;
+};
+
+Record.prototype.serialize = function serialize(excludeFragment) {
+  var s = this.scheme + ':';
+  if (this.host !== null) {
+    s += '//';
+    if (this.includesCredentials()) {
+      s += this.username;
+      if (this.password !== '') {
+        s += ':' + this.password;
+      }
+      s += '@';
+    }
+    s += this.host;
+    if (this.port !== null) {
+      s += ':' + this.port;
+    }
+  } else if (!this.hasOpaquePath()
+             && this.path.length > 1
+             && this.path[0] === '') {
+    s += '/.';
+  }
+  s += this.serializePath();
+  if (this.query !== null) {
+    s += '?' + this.query;
+  }
+  if (!excludeFragment
+      && this.fragment !== null) {
+    s += '#' + this.fragment;
+  }
+  return s;
+};
+
+Record.prototype.origin = function origin() {
+  switch (this.scheme) {
+  case 'blob':
+    var u = parse(this.serializePath());
+    if (u
+        && (u.scheme === 'http' || u.scheme === 'https')) {
+      return u.origin();
+    }
+    return 'null';
+  case 'ftp':
+  case 'http':
+  case 'https':
+  case 'ws':
+  case 'wss':
+    return this.scheme + '://' + this.host + (this.port !== null ? ':' + this.port : '');
+  }
+  return 'null';
+};
+
+//
+// basic URL parser
+//
+
+var SCHEME_START = 1;
+var SCHEME = 2;
+var NO_SCHEME = 3;
+var SPECIAL_RELATIVE_OR_AUTHORITY = 4;
+var PATH_OR_AUTHORITY = 5;
+var RELATIVE = 6;
+var RELATIVE_SLASH = 7;
+var SPECIAL_AUTHORITY_SLASHES = 8;
+var SPECIAL_AUTHORITY_IGNORE_SLASHES = 9;
+var AUTHORITY = 10;
+var HOST = 11;
+var HOSTNAME = 12;
+var PORT = 13;
+var FILE = 14;
+var FILE_SLASH = 15;
+var FILE_HOST = 16;
+var PATH_START = 17;
+var PATH = 18;
+var OPAQUE_PATH = 19;
+var QUERY = 20;
+var FRAGMENT = 21;
+
+var FAILURE = {};
+
+function parse(input, base, url, override) {
+  if (!url) {
+    url = new Record();
+    input = input.replace(/^[\x00-\x20]+|[\x00-\x20]+$/g, '');
+  }
+  input = input.replace(/[\t\n\r]/g, '');
+
+  var state = override || SCHEME_START;
+  var buffer = '';
+  var atSignSeen = false;
+  var insideBrackets = false;
+  var passwordTokenSeen = false;
+  var cps = toCodePoints(input);
+  var i;
+  for (var p = 0; p <= cps.length; p++) {
+    var c = cps[p];
+    switch (state) {
+    case SCHEME_START:
+      if (isASCIIAlpha(c)) {
+        buffer += c.toLowerCase();
+        state = SCHEME;
+      } else if (!override) {
+        state = NO_SCHEME;
+        p--;
+      } else {
+        return FAILURE;
+      }
+      break;
+    case SCHEME:
+      if (isASCIIAlphanumeric(c)
+          || c === '+'
+          || c === '-'
+          || c === '.') {
+        buffer += c.toLowerCase();
+      } else if (c === ':') {
+        if (override) {
+          if (url.isSpecial() !== isSpecialScheme(buffer)
+              || ((url.includesCredentials() || url.port !== null) && buffer === 'file')
+              || (url.scheme === 'file' && url.host === '')) {
+            return url;
+          }
+        }
+        url.scheme = buffer;
+        if (override) {
+          if (url.port === specialSchemes[url.scheme]) {
+            url.port = null;
+          }
+          return url;
+        }
+        buffer = '';
+        if (url.scheme === 'file') {
+          state = FILE;
+        } else if (url.isSpecial()
+                   && base
+                   && base.scheme === url.scheme) {
+          state = SPECIAL_RELATIVE_OR_AUTHORITY;
+        } else if (url.isSpecial()) {
+          state = SPECIAL_AUTHORITY_SLASHES;
+        } else if (cps[p + 1] === '/') {
+          state = PATH_OR_AUTHORITY;
+          p++;
+        } else {
+          url.path = '';
+          state = OPAQUE_PATH;
+        }
+      } else if (!override) {
+        buffer = '';
+        state = NO_SCHEME;
+        p = -1;
+      } else {
+        return FAILURE;
+      }
+      break;
+    case NO_SCHEME:
+      if (!base
+          || (base.hasOpaquePath() && c !== '#')) {
+        return FAILURE;
+      } else if (base.hasOpaquePath()) {
+        url.scheme = base.scheme;
+        url.path = base.path;
+        url.query = base.query;
+        url.fragment = '';
+        state = FRAGMENT;
+      } else {
+        state = base.scheme !== 'file' ? RELATIVE : FILE;
+        p--;
+      }
+      break;
+    case SPECIAL_RELATIVE_OR_AUTHORITY:
+      if (c === '/'
+          && cps[p + 1] === '/') {
+        state = SPECIAL_AUTHORITY_IGNORE_SLASHES;
+        p++;
+      } else {
+        state = RELATIVE;
+        p--;
+      }
+      break;
+    case PATH_OR_AUTHORITY:
+      if (c === '/') {
+        state = AUTHORITY;
+      } else {
+        state = PATH;
+        p--;
+      }
+      break;
+    case RELATIVE:
+      url.scheme = base.scheme;
+      if (c === '/'
+          || (url.isSpecial() && c === '\\')) {
+        state = RELATIVE_SLASH;
+      } else {
+        url.username = base.username;
+        url.password = base.password;
+        url.host = base.host;
+        url.port = base.port;
+        url.path = base.path.slice();
+        url.query = base.query;
+        if (c === '?') {
+          url.query = '';
+          state = QUERY;
+        } else if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        } else if (c !== undefined) {
+          url.query = null;
+          url.shortenPath();
+          state = PATH;
+          p--;
+        }
+      }
+      break;
+    case RELATIVE_SLASH:
+      if (url.isSpecial()
+          && (c === '/' || c === '\\')) {
+        state = SPECIAL_AUTHORITY_IGNORE_SLASHES;
+      } else if (c === '/') {
+        state = AUTHORITY;
+      } else {
+        url.username = base.username;
+        url.password = base.password;
+        url.host = base.host;
+        url.port = base.port;
+        state = PATH;
+        p--;
+      }
+      break;
+    case SPECIAL_AUTHORITY_SLASHES:
+      if (c === '/'
+          && cps[p + 1] === '/') {
+        p++;
+      } else {
+        p--;
+      }
+      state = SPECIAL_AUTHORITY_IGNORE_SLASHES;
+      break;
+    case SPECIAL_AUTHORITY_IGNORE_SLASHES:
+      if (c !== '/'
+          && c !== '\\') {
+        state = AUTHORITY;
+        p--;
+      }
+      break;
+    case AUTHORITY:
+      if (c === '@') {
+        if (atSignSeen) {
+          buffer = '%40' + buffer;
+        }
+        atSignSeen = true;
+        var bcps = toCodePoints(buffer);
+        for (i = 0; i < bcps.length; i++) {
+          if (bcps[i] === ':'
+              && !passwordTokenSeen) {
+            passwordTokenSeen = true;
+            continue;
+          }
+          if (passwordTokenSeen) {
+            url.password += percentEncode(bcps[i], userinfoSet);
+          } else {
+            url.username += percentEncode(bcps[i], userinfoSet);
+          }
+        }
+        buffer = '';
+      } else if (c === undefined
+                 || c === '/'
+                 || c === '?'
+                 || c === '#'
+                 || (url.isSpecial() && c === '\\')) {
+        if (atSignSeen
+            && buffer === '') {
+          return FAILURE;
+        }
+        p -= toCodePoints(buffer).length + 1;
+        buffer = '';
+        state = HOST;
+      } else {
+        buffer += c;
+      }
+      break;
+    case HOST:
+    case HOSTNAME:
+      if (override
+          && url.scheme === 'file') {
+        state = FILE_HOST;
+        p--;
+      } else if (c === ':'
+                 && !insideBrackets) {
+        if (buffer === '') {
+          return FAILURE;
+        } else if (override === HOSTNAME) {
+          return url;
+        }
+        var host = parseHost(buffer, !url.isSpecial());
+        if (host === null) {
+          return FAILURE;
+        }
+        url.host = host;
+        buffer = '';
+        state = PORT;
+      } else if (c === undefined
+                 || c === '/'
+                 || c === '?'
+                 || c === '#'
+                 || (url.isSpecial() && c === '\\')) {
+        p--;
+        if (url.isSpecial()
+            && buffer === '') {
+          return FAILURE;
+        } else if (override
+                   && buffer === ''
+                   && (url.includesCredentials() || url.port !== null)) {
+          return url;
+        }
+        host = parseHost(buffer, !url.isSpecial());
+        if (host === null) {
+          return FAILURE;
+        }
+        url.host = host;
+        buffer = '';
+        state = PATH_START;
+        if (override) {
+          return url;
+        }
+      } else {
+        if (c === '[') {
+          insideBrackets = true;
+        } else if (c === ']') {
+          insideBrackets = false;
+        }
+        buffer += c;
+      }
+      break;
+    case PORT:
+      if (isASCIIDigit(c)) {
+        buffer += c;
+      } else if (c === undefined
+                 || c === '/'
+                 || c === '?'
+                 || c === '#'
+                 || (url.isSpecial() && c === '\\')
+                 || override) {
+        if (buffer !== '') {
+          var port = parseInt(buffer, 10);
+          if (port > 0xffff) {
+            return FAILURE;
+          }
+          url.port = port === specialSchemes[url.scheme] ? null : port;
+          buffer = '';
+        }
+        if (override) {
+          return url;
+        }
+        state = PATH_START;
+        p--;
+      } else {
+        return FAILURE;
+      }
+      break;
+    case FILE:
+      url.scheme = 'file';
+      url.host = '';
+      if (c === '/'
+          || c === '\\') {
+        state = FILE_SLASH;
+      } else if (base
+                 && base.scheme === 'file') {
+        url.host = base.host;
+        url.path = base.path.slice();
+        url.query = base.query;
+        if (c === '?') {
+          url.query = '';
+          state = QUERY;
+        } else if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        } else if (c !== undefined) {
+          url.query = null;
+          if (!startsWithWindowsDriveLetter(cps, p)) {
+            url.shortenPath();
+          } else {
+            url.path = [];
+          }
+          state = PATH;
+          p--;
+        }
+      } else {
+        state = PATH;
+        p--;
+      }
+      break;
+    case FILE_SLASH:
+      if (c === '/'
+          || c === '\\') {
+        state = FILE_HOST;
+      } else {
+        if (base
+            && base.scheme === 'file') {
+          url.host = base.host;
+          if (!startsWithWindowsDriveLetter(cps, p)
+              && isNormalizedWindowsDriveLetter(base.path[0] || '')) {
+            url.path.push(base.path[0]);
+          }
+        }
+        state = PATH;
+        p--;
+      }
+      break;
+    case FILE_HOST:
+      if (c === undefined
+          || c === '/'
+          || c === '\\'
+          || c === '?'
+          || c === '#') {
+        p--;
+        if (!override
+            && isWindowsDriveLetter(buffer)) {
+          state = PATH;
+        } else if (buffer === '') {
+          url.host = '';
+          if (override) {
+            return url;
+          }
+          state = PATH_START;
+        } else {
+          host = parseHost(buffer, !url.isSpecial());
+          if (host === null) {
+            return FAILURE;
+          }
+          url.host = host === 'localhost' ? '' : host;
+          if (override) {
+            return url;
+          }
+          buffer = '';
+          state = PATH_START;
+        }
+      } else {
+        buffer += c;
+      }
+      break;
+    case PATH_START:
+      if (url.isSpecial()) {
+        state = PATH;
+        if (c !== '/'
+            && c !== '\\') {
+          p--;
+        }
+      } else if (!override
+                 && c === '?') {
+        url.query = '';
+        state = QUERY;
+      } else if (!override
+                 && c === '#') {
+        url.fragment = '';
+        state = FRAGMENT;
+      } else if (c !== undefined) {
+        state = PATH;
+        if (c !== '/') {
+          p--;
+        }
+      } else if (override
+                 && url.host === null) {
+        url.path.push('');
+      }
+      break;
+    case PATH:
+      if (c === undefined
+          || c === '/'
+          || (url.isSpecial() && c === '\\')
+          || (!override && (c === '?' || c === '#'))) {
+        var slash = c === '/'
+                    || (url.isSpecial() && c === '\\');
+        if (isDoubleDot(buffer)) {
+          url.shortenPath();
+          if (!slash) {
+            url.path.push('');
+          }
+        } else if (isSingleDot(buffer)) {
+          if (!slash) {
+            url.path.push('');
+          }
+        } else {
+          if (url.scheme === 'file'
+              && url.path.length === 0
+              && isWindowsDriveLetter(buffer)) {
+            buffer = buffer.charAt(0) + ':';
+          }
+          url.path.push(buffer);
+        }
+        buffer = '';
+        if (c === '?') {
+          url.query = '';
+          state = QUERY;
+        } else if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        }
+      } else {
+        buffer += percentEncode(c, pathSet);
+      }
+      break;
+    case OPAQUE_PATH:
+      if (c === '?') {
+        url.query = '';
+        state = QUERY;
+      } else if (c === '#') {
+        url.fragment = '';
+        state = FRAGMENT;
+      } else if (c !== undefined) {
+        url.path += percentEncode(c, c0ControlSet);
+      }
+      break;
+    case QUERY:
+      if (c === undefined
+          || (!override && c === '#')) {
+        url.query += percentEncode(buffer, url.isSpecial() ? specialQuerySet : querySet);
+        buffer = '';
+        if (c === '#') {
+          url.fragment = '';
+          state = FRAGMENT;
+        }
+      } else {
+        buffer += c;
+      }
+      break;
+    case FRAGMENT:
+      if (c !== undefined) {
+        url.fragment += percentEncode(c, fragmentSet);
+      }
+      break;
+    }
+  }
+  return url;
+}
+
+//
+// application/x-www-form-urlencoded
+//
+
+function formUrlencodedSet(c) {
+  return !(isASCIIAlphanumeric(String.fromCharCode(c))
+           || c === 0x2a
+           || c === 0x2d
+           || c === 0x2e
+           || c === 0x5f);
+}
+
+function parseUrlencoded(s) {
+  var list = [];
+  s.split('&').forEach(function(seq) {
+    if (seq === '') {
+      return;
+    }
+    var i = seq.indexOf('=');
+    var name = i !== -1 ? seq.slice(0, i) : seq;
+    var value = i !== -1 ? seq.slice(i + 1) : '';
+    list.push([
+      utf8Decode(percentDecode(name.replace(/\+/g, ' '))),
+      utf8Decode(percentDecode(value.replace(/\+/g, ' '))),
+    ]);
+  });
+  return list;
+}
+
+function serializeUrlencoded(list) {
+  return list.map(function(pair) {
+    return percentEncode(pair[0], formUrlencodedSet, true) + '=' + percentEncode(pair[1], formUrlencodedSet, true);
+  }).join('&');
+}
+
+//
+// URLSearchParams
+//
+
+function URLSearchParams(init) {
+  if (!(this instanceof URLSearchParams)) {
+    throw new TypeError("Class constructor URLSearchParams cannot be invoked without 'new'");
+  }
+
+  this._list = [];
+  this._url = null;
+  if (init === undefined
+      || init === null) {
+    return;
+  } else if (init instanceof URLSearchParams) {
+    this._list = init._list.map(function(pair) {
+      return pair.slice();
+    });
+  } else if (typeof init === 'object'
+             || typeof init === 'function') {
+    if (typeof init.length === 'number'
+        && typeof init !== 'function') {
+      for (var i = 0; i < init.length; i++) {
+        var pair = init[i];
+        if ((typeof pair !== 'object' && typeof pair !== 'function')
+            || pair === null
+            || typeof pair.length !== 'number') {
+          throw invalidTuple();
+        } else if (pair.length !== 2) {
+          throw invalidTuple();
+        }
+        this._list.push([toUSVString(pair[0]), toUSVString(pair[1])]);
+      }
+    } else {
+      Object.keys(init).forEach(function(k) {
+        this._list.push([toUSVString(k), toUSVString(init[k])]);
+      }, this);
+    }
+  } else {
+    init = toUSVString(init);
+    this._list = parseUrlencoded(init.charAt(0) === '?' ? init.slice(1) : init);
+  }
+}
+
+function invalidTuple() {
+  var e = new TypeError('Each query pair must be an iterable [name, value] tuple');
+  e.code = 'ERR_INVALID_TUPLE';
+  return e;
+}
+
+function missingArgs(names) {
+  var e = new TypeError('The ' + names.map(function(n) {
+    return '"' + n + '"';
+  }).join(' and ') + ' argument' + (names.length > 1 ? 's' : '') + ' must be specified');
+  e.code = 'ERR_MISSING_ARGS';
+  return e;
+}
+
+URLSearchParams.prototype._update = function _update() {
+  if (this._url !== null) {
+    var s = serializeUrlencoded(this._list);
+    this._url.query = s !== '' ? s : null;
+  }
+};
+
+URLSearchParams.prototype.append = function append(name, value) {
+  if (arguments.length < 2) {
+    throw missingArgs(['name', 'value']);
+  }
+  this._list.push([toUSVString(name), toUSVString(value)]);
+  this._update();
+};
+
+URLSearchParams.prototype.delete = function(name, value) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  if (value !== undefined) {
+    value = toUSVString(value);
+  }
+  this._list = this._list.filter(function(pair) {
+    return !(pair[0] === name && (value === undefined || pair[1] === value));
+  });
+  this._update();
+};
+
+URLSearchParams.prototype.get = function get(name) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  for (var i = 0; i < this._list.length; i++) {
+    if (this._list[i][0] === name) {
+      return this._list[i][1];
+    }
+  }
+  return null;
+};
+
+URLSearchParams.prototype.getAll = function getAll(name) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  return this._list.filter(function(pair) {
+    return pair[0] === name;
+  }).map(function(pair) {
+    return pair[1];
+  });
+};
+
+URLSearchParams.prototype.has = function has(name, value) {
+  if (arguments.length < 1) {
+    throw missingArgs(['name']);
+  }
+  name = toUSVString(name);
+  if (value !== undefined) {
+    value = toUSVString(value);
+  }
+  return this._list.some(function(pair) {
+    return pair[0] === name
+           && (value === undefined || pair[1] === value);
+  });
+};
+
+URLSearchParams.prototype.set = function set(name, value) {
+  if (arguments.length < 2) {
+    throw missingArgs(['name', 'value']);
+  }
+  name = toUSVString(name);
+  value = toUSVString(value);
+  var found = false;
+  this._list = this._list.filter(function(pair) {
+    if (pair[0] !== name) {
+      return true;
+    } else if (!found) {
+      pair[1] = value;
+      found = true;
+      return true;
+    }
+    return false;
+  });
+  if (!found) {
+    this._list.push([name, value]);
+  }
+  this._update();
+};
+
+URLSearchParams.prototype.sort = function sort() {
+  // stable sort by code units
+  var list = this._list.map(function(pair, i) {
+    return [pair, i];
+  });
+  list.sort(function(a, b) {
+    if (a[0][0] < b[0][0]) {
+      return -1;
+    } else if (a[0][0] > b[0][0]) {
+      return 1;
+    }
+    return a[1] - b[1];
+  });
+  this._list = list.map(function(e) {
+    return e[0];
+  });
+  this._update();
+};
+
+URLSearchParams.prototype.forEach = function forEach(callback, thisArg) {
+  if (typeof callback !== 'function') {
+    throw invalidArgType('callback', 'function', callback);
+  }
+  for (var i = 0; i < this._list.length; i++) {
+    callback.call(thisArg, this._list[i][1], this._list[i][0], this);
+  }
+};
+
+function Iterator(params, kind) {
+  this._params = params;
+  this._kind = kind;
+  this._index = 0;
+}
+
+Iterator.prototype.next = function next() {
+  var list = this._params._list;
+  if (this._index >= list.length) {
+    return { value: undefined, done: true };
+  }
+  var pair = list[this._index++];
+  switch (this._kind) {
+  case 'keys':
+    return { value: pair[0], done: false };
+  case 'values':
+    return { value: pair[1], done: false };
+  }
+  return { value: [pair[0], pair[1]], done: false };
+};
+
+URLSearchParams.prototype.keys = function keys() {
+  return new Iterator(this, 'keys');
+};
+
+URLSearchParams.prototype.values = function values() {
+  return new Iterator(this, 'values');
+};
+
+URLSearchParams.prototype.entries = function entries() {
+  return new Iterator(this, 'entries');
+};
+
+URLSearchParams.prototype.toString = function toString() {
+  return serializeUrlencoded(this._list);
+};
+
+Object.defineProperty(URLSearchParams.prototype, 'size', {
+  get: function() {
+    return this._list.length;
+  },
+  configurable: true,
+});
+
+URLSearchParams.prototype[inspect.custom] = function(depth, options) {
+  if (typeof depth === 'number'
+      && depth < 0) {
+    return '[URLSearchParams]';
+  }
+  if (this._list.length === 0) {
+    return 'URLSearchParams {}';
+  }
+  return 'URLSearchParams { ' + this._list.map(function(pair) {
+    return inspect(pair[0], options) + ' => ' + inspect(pair[1], options);
+  }).join(', ') + ' }';
+};
+
+if (typeof Symbol === 'function'
+    && Symbol.iterator) {
+  URLSearchParams.prototype[Symbol.iterator] = URLSearchParams.prototype.entries;
+  Iterator.prototype[Symbol.iterator] = function() {
+    return this;
+  };
+}
+
+exports.URLSearchParams = URLSearchParams;
+
+//
+// URL
+//
+
+function URL(input, base) {
+  if (!(this instanceof URL)) {
+    throw new TypeError("Class constructor URL cannot be invoked without 'new'");
+  } else if (arguments.length < 1) {
+    throw missingArgs(['url']);
+  }
+
+  input = toUSVString(input);
+  var b;
+  if (base !== undefined) {
+    b = parse(toUSVString(base));
+    if (b === FAILURE) {
+      throw invalidURL(String(base));
+    }
+  }
+  var url = parse(input, b);
+  if (url === FAILURE) {
+    throw invalidURL(input);
+  }
+  this._url = url;
+  this._searchParams = new URLSearchParams(url.query || '');
+  this._searchParams._url = url;
+}
+
+URL.canParse = function canParse(input, base) {
+  if (arguments.length < 1) {
+    throw missingArgs(['url']);
+  }
+  var b;
+  if (base !== undefined) {
+    b = parse(toUSVString(base));
+    if (b === FAILURE) {
+      return false;
+    }
+  }
+  return parse(toUSVString(input), b) !== FAILURE;
+};
+
+function reparse(self, value, state) {
+  parse(toUSVString(value), null, self._url, state);
+}
+
+Object.defineProperties(URL.prototype, {
+  href: {
+    get: function() {
+      return this._url.serialize();
+    },
+    set: function(value) {
+      value = toUSVString(value);
+      var url = parse(value);
+      if (url === FAILURE) {
+        throw invalidURL(value);
+      }
+      this._url = url;
+      this._searchParams._list = parseUrlencoded(url.query || '');
+      this._searchParams._url = url;
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  origin: {
+    get: function() {
+      return this._url.origin();
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  protocol: {
+    get: function() {
+      return this._url.scheme + ':';
+    },
+    set: function(value) {
+      reparse(this, toUSVString(value) + ':', SCHEME_START);
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  username: {
+    get: function() {
+      return this._url.username;
+    },
+    set: function(value) {
+      if (!this._url.cannotHaveUsernamePasswordPort()) {
+        this._url.username = percentEncode(toUSVString(value), userinfoSet);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  password: {
+    get: function() {
+      return this._url.password;
+    },
+    set: function(value) {
+      if (!this._url.cannotHaveUsernamePasswordPort()) {
+        this._url.password = percentEncode(toUSVString(value), userinfoSet);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  host: {
+    get: function() {
+      var url = this._url;
+      if (url.host === null) {
+        return '';
+      }
+      return url.port !== null ? url.host + ':' + url.port : url.host;
+    },
+    set: function(value) {
+      if (!this._url.hasOpaquePath()) {
+        reparse(this, value, HOST);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  hostname: {
+    get: function() {
+      return this._url.host !== null ? this._url.host : '';
+    },
+    set: function(value) {
+      if (!this._url.hasOpaquePath()) {
+        reparse(this, value, HOSTNAME);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  port: {
+    get: function() {
+      return this._url.port !== null ? String(this._url.port) : '';
+    },
+    set: function(value) {
+      if (!this._url.cannotHaveUsernamePasswordPort()) {
+        value = toUSVString(value);
+        if (value === '') {
+          this._url.port = null;
+        } else {
+          reparse(this, value, PORT);
+        }
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  pathname: {
+    get: function() {
+      return this._url.serializePath();
+    },
+    set: function(value) {
+      if (!this._url.hasOpaquePath()) {
+        this._url.path = [];
+        reparse(this, value, PATH_START);
+      }
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  search: {
+    get: function() {
+      var q = this._url.query;
+      return q !== null && q !== '' ? '?' + q : '';
+    },
+    set: function(value) {
+      value = toUSVString(value);
+      if (value === '') {
+        this._url.query = null;
+        this._searchParams._list = [];
+        return;
+      }
+      if (value.charAt(0) === '?') {
+        value = value.slice(1);
+      }
+      this._url.query = '';
+      reparse(this, value, QUERY);
+      this._searchParams._list = parseUrlencoded(value);
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  searchParams: {
+    get: function() {
+      return this._searchParams;
+    },
+    enumerable: true,
+    configurable: true,
+  },
+  hash: {
+    get: function() {
+      var f = this._url.fragment;
+      return f !== null && f !== '' ? '#' + f : '';
+    },
+    set: function(value) {
+      value = toUSVString(value);
+      if (value === '') {
+        this._url.fragment = null;
+        return;
+      }
+      if (value.charAt(0) === '#') {
+        value = value.slice(1);
+      }
+      this._url.fragment = '';
+      reparse(this, value, FRAGMENT);
+    },
+    enumerable: true,
+    configurable: true,
+  },
+});
+
+URL.prototype.toString = function toString() {
+  return this.href;
+};
+
+URL.prototype.toJSON = function toJSON() {
+  return this.href;
+};
+
+URL.prototype[inspect.custom] = function(depth, options) {
+  if (typeof depth === 'number'
+      && depth < 0) {
+    return this;
+  }
+  var o = {};
+  ['href', 'origin', 'protocol', 'username', 'password', 'host', 'hostname', 'port', 'pathname', 'search', 'searchParams', 'hash'].forEach(function(k) {
+    o[k] = this[k];
+  }, this);
+  return 'URL ' + inspect(o, options);
+};
+
+exports.URL = URL;
+
+exports.isURL = function isURL(v) {
+  return v instanceof URL;
+};
@@ -0,0 +1,104 @@
+//
+// otto.module :: internal/util.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var _inspect = require('./util/inspect');
+
+var inspect = _inspect.inspect;
+
+exports.normalizeEncoding = function normalizeEncoding(enc) {
+  if (enc === undefined
+      || enc === null
+      || enc === '') {
+    return 'utf8';
+  }
+  switch (String(enc).toLowerCase()) {
+  case 'utf8':
+  case 'utf-8':
+    return 'utf8';
+  case 'ucs2':
+  case 'ucs-2':
+  case 'utf16le':
+  case 'utf-16le':
+    return 'utf16le';
+  case 'latin1':
+  case 'binary':
+    return 'latin1';
+  case 'base64':
+  case 'base64url':
+  case 'ascii':
+  case 'hex':
+    return String(enc).toLowerCase();
+  }
+};
+
+exports.unknownEncoding = function unknownEncoding(enc) {
+  var e = new TypeError('Unknown encoding: ' + enc);
+  e.code = 'ERR_UNKNOWN_ENCODING';
+  return e;
+};
+
+function received(v) {
+  if (v === undefined
+      || v === null) {
+    return String(v);
+  } else if (typeof v === 'function') {
+    return _inspect.getName(v) ? 'function ' + _inspect.getName(v) : inspect(v);
+  } else if (typeof v === 'object') {
+    return _inspect.getName(v.constructor) ? 'an instance of ' + _inspect.getName(v.constructor) : inspect(v, { depth: -1 });
+  }
+  var s = inspect(v);
+  return 'type ' + typeof v + ' (' + (s.length > 28 ? s.slice(0, 25) + '...' : s) + ')';
+}
+
+exports.invalidArgType = function invalidArgType(name, type, v) {
+  var s = / argument$/.test(name) ? 'The ' + name : 'The "' + name + '" ' + (name.indexOf('.') !== -1 ? 'property' : 'argument');
+  var e = new TypeError(s + ' must be ' + type + '. Received ' + received(v));
+  e.code = 'ERR_INVALID_ARG_TYPE';
+  return e;
+};
+
+exports.invalidArgValue = function invalidArgValue(name, v) {
+  var e = new TypeError('The ' + (name.indexOf('.') !== -1 ? 'property' : 'argument') + " '" + name + "' is invalid. Received " + inspect(v));
+  e.code = 'ERR_INVALID_ARG_VALUE';
+  return e;
+};
+
+exports.outOfRange = function outOfRange(name, range, v) {
+  var s;
+  if (typeof v === 'number'
+      && Math.floor(v) === v
+      && Math.abs(v) > 0x100000000) {
+    s = String(v);
+    var i = s.length;
+    var start = s.charAt(0) === '-' ? 1 : 0;
+    var t = '';
+    for (; i >= start + 4; i -= 3) {
+      t = '_' + s.slice(i - 3, i) + t;
+    }
+    s = s.slice(0, i) + t;
+  } else {
+    s = inspect(v);
+  }
+  var e = new RangeError('The value of "' + name + '" is out of range. It must be ' + range + '. Received ' + s);
+  e.code = 'ERR_OUT_OF_RANGE';
+  return e;
+};
+
+exports.validatePort = function validatePort(port, name, describe) {
+  if ((typeof port !== 'number' && typeof port !== 'string')
+      || (typeof port === 'string' && port.trim() === '')
+      || +port !== (+port >>> 0)
+      || port > 0xffff) {
+    var e = new RangeError(name + ' should be >= 0 and < 65536. Received ' + (describe || received)(port) + '.');
+    e.code = 'ERR_SOCKET_BAD_PORT';
+    throw e;
+  }
+  return +port;
+};
@@ -0,0 +1,129 @@
+//
+// otto.module :: internal/util/comparisons.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+var propertyIsEnumerable = Object.prototype.propertyIsEnumerable;
+var toString = Object.prototype.toString;
+
+function is(a, b) {
+  if (a === b) {
+    return a !== 0 || 1 / a === 1 / b;
+  }
+  return a !== a && b !== b;
+}
+
+function isBoxed(tag) {
+  return tag === 'Number'
+         || tag === 'String'
+         || tag === 'Boolean';
+}
+
+function compare(a, b, strict, memos) {
+  if (strict ? is(a, b) : a == b || (a !== a && b !== b)) { // eslint-disable-line eqeqeq
+    return true;
+  }
+
+  if (strict) {
+    if (typeof a !== 'object'
+        || typeof b !== 'object'
+        || a === null
+        || b === null) {
+      return false;
+    } else if (Object.getPrototypeOf(a) !== Object.getPrototypeOf(b)) {
+      return false;
+    }
+  } else if (a === null
+             || typeof a !== 'object') {
+    return b === null
+           || typeof b !== 'object' ? a == b : false; // eslint-disable-line eqeqeq
+  } else if (b === null
+             || typeof b !== 'object') {
+    return false;
+  }
+
+  var tag = toString.call(a);
+  if (tag !== toString.call(b)) {
+    return false;
+  }
+  tag = tag.slice(8, -1);
+
+  if (tag === 'Array') {
+    if (a.length !== b.length) {
+      return false;
+    }
+  } else if (tag === 'Date') {
+    if (a.getTime() !== b.getTime()) {
+      return false;
+    }
+  } else if (tag === 'RegExp') {
+    if (String(a) !== String(b)
+        || a.lastIndex !== b.lastIndex) {
+      return false;
+    }
+  } else if (tag === 'Error'
+             || a instanceof Error) {
+    if (a.message !== b.message
+        || a.name !== b.name) {
+      return false;
+    }
+  } else if (isBoxed(tag)) {
+    if (!is(a.valueOf(), b.valueOf())) {
+      return false;
+    }
+  } else if (typeof a.equals === 'function'
+             && typeof a.compare === 'function'
+             && a.constructor === b.constructor
+             && a.constructor.isBuffer
+             && a.constructor.isBuffer(a)) {
+    if (!a.equals(b)) {
+      return false;
+    }
+  }
+
+  var ka = Object.keys(a);
+  var kb = Object.keys(b);
+  if (ka.length !== kb.length) {
+    return false;
+  }
+  var i;
+  for (i = 0; i < ka.length; i++) {
+    if (!(hasOwnProperty.call(b, ka[i])
+          && propertyIsEnumerable.call(b, ka[i]))) {
+      return false;
+    }
+  }
+
+  // circular
+  for (i = 0; i < memos.length; i++) {
+    if (memos[i][0] === a
+        && memos[i][1] === b) {
+      return true;
+    }
+  }
+  memos.push([a, b]);
+  try {
+    for (i = 0; i < ka.length; i++) {
+      if (!compare(a[ka[i]], b[ka[i]], strict, memos)) {
+        return false;
+      }
+    }
+  } finally {
+    memos.pop();
+  }
+  return true;
+}
+
+exports.isDeepEqual = function isDeepEqual(a, b) {
+  return compare(a, b, false, []);
+};
+
+exports.isDeepStrictEqual = function isDeepStrictEqual(a, b) {
+  return compare(a, b, true, []);
+};
@@ -0,0 +1,689 @@
+//
+// otto.module :: internal/util/inspect.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var hasOwnProperty = Object.prototype.hasOwnProperty;
+var toString = Object.prototype.toString;
+
+var customInspectSymbol = typeof Symbol === 'function' ? Symbol.for('nodejs.util.inspect.custom') : 'inspect';
+
+var defaultOptions = {
+  showHidden: false,
+  depth: 2,
+  colors: false,
+  customInspect: true,
+  maxArrayLength: 100,
+  maxStringLength: 10000,
+  breakLength: 80,
+  compact: 3,
+  sorted: false,
+};
+
+var colors = {
+  bold: [1, 22],
+  italic: [3, 23],
+  underline: [4, 24],
+  inverse: [7, 27],
+  white: [37, 39],
+  grey: [90, 39],
+  black: [30, 39],
+  blue: [34, 39],
+  cyan: [36, 39],
+  green: [32, 39],
+  magenta: [35, 39],
+  red: [31, 39],
+  yellow: [33, 39],
+};
+colors.gray = colors.grey;
+
+var styles = {
+  special: 'cyan',
+  number: 'yellow',
+  bigint: 'yellow',
+  boolean: 'yellow',
+  undefined: 'grey',
+  null: 'bold',
+  string: 'green',
+  symbol: 'green',
+  date: 'magenta',
+  regexp: 'red',
+  module: 'underline',
+};
+
+var meta = {
+  '\b': '\\b',
+  '\t': '\\t',
+  '\n': '\\n',
+  '\v': '\\v',
+  '\f': '\\f',
+  '\r': '\\r',
+  '\\': '\\\\',
+};
+
+var keyRx = /^[A-Za-z_][0-9A-Za-z_]*$/;
+
+function tagOf(v) {
+  return toString.call(v).slice(8, -1);
+}
+
+function getName(fn) {
+  if (typeof fn !== 'function') {
+    return '';
+  } else if (typeof fn.name === 'string') {
+    return fn.name;
+  }
+  var m = /^\s*function\s*([^\s(]*)/.exec(Function.prototype.toString.call(fn));
+  return m ? m[1] : '';
+}
+
+function getConstructorName(obj) {
+  var proto = Object.getPrototypeOf(obj);
+  while (proto !== null) {
+    var desc = Object.getOwnPropertyDescriptor(proto, 'constructor');
+    if (desc
+        && typeof desc.value === 'function'
+        && getName(desc.value) !== '') {
+      return getName(desc.value);
+    }
+    proto = Object.getPrototypeOf(proto);
+  }
+  return null;
+}
+
+function stylize(ctx, s, type) {
+  if (ctx.colors) {
+    var style = inspect.styles[type];
+    if (style !== undefined) {
+      var color = inspect.colors[style];
+      if (color !== undefined) {
+        return '\u001b[' + color[0] + 'm' + s + '\u001b[' + color[1] + 'm';
+      }
+    }
+  }
+  return s;
+}
+
+function quote(s) {
+  var q = '\'';
+  if (s.indexOf(q) !== -1) {
+    if (s.indexOf('"') === -1) {
+      q = '"';
+    } else if (s.indexOf('`') === -1) {
+      q = '`';
+    }
+  }
+
+  var r = '';
+  for (var i = 0; i < s.length; i++) {
+    var c = s[i];
+    if (c === q) {
+      r += '\\' + c;
+    } else if (hasOwnProperty.call(meta, c)) {
+      r += meta[c];
+    } else if (c < ' ' || c === '\x7f') {
+      var h = c.charCodeAt(0).toString(16).toUpperCase();
+      r += '\\x' + (h.length < 2 ? '0' + h : h);
+    } else {
+      r += c;
+    }
+  }
+  return q + r + q;
+}
+
+function formatPrimitive(ctx, v) {
+  switch (typeof v) {
+  case 'string':
+    var trailer = '';
+    if (v.length > ctx.maxStringLength) {
+      var n = v.length - ctx.maxStringLength;
+      v = v.slice(0, ctx.maxStringLength);
+      trailer = '... ' + n + ' more character' + (n > 1 ? 's' : '');
+    }
+    return stylize(ctx, quote(v), 'string') + trailer;
+  case 'number':
+    return stylize(ctx, v === 0 && 1 / v < 0 ? '-0' : String(v), 'number');
+  case 'boolean':
+    return stylize(ctx, String(v), 'boolean');
+  case 'undefined':
+    return stylize(ctx, 'undefined', 'undefined');
+  case 'symbol':
+    return stylize(ctx, String(v), 'symbol');
+  case 'bigint':
+    return stylize(ctx, String(v) + 'n', 'bigint');
+  }
+  return stylize(ctx, 'null', 'null');
+}
+
+function formatKey(ctx, key) {
+  if (keyRx.test(key)) {
+    return key;
+  }
+  return stylize(ctx, quote(key), 'string');
+}
+
+function formatProperty(ctx, obj, key, depth, array) {
+  var desc = Object.getOwnPropertyDescriptor(obj, key) || { value: obj[key], enumerable: true };
+  var s;
+  if (desc.get !== undefined || desc.set !== undefined) {
+    if (desc.get !== undefined) {
+      s = stylize(ctx, desc.set !== undefined ? '[Getter/Setter]' : '[Getter]', 'special');
+    } else {
+      s = stylize(ctx, '[Setter]', 'special');
+    }
+  } else {
+    ctx.indentationLvl += 2;
+    s = formatValue(ctx, desc.value, depth + 1);
+    ctx.indentationLvl -= 2;
+  }
+  if (array) {
+    return s;
+  }
+
+  var name = formatKey(ctx, key);
+  if (!desc.enumerable) {
+    name = '[' + name + ']';
+  }
+  return name + ': ' + s;
+}
+
+function getKeys(ctx, obj) {
+  var keys = ctx.showHidden ? Object.getOwnPropertyNames(obj) : Object.keys(obj);
+  if (ctx.sorted) {
+    keys.sort(typeof ctx.sorted === 'function' ? ctx.sorted : undefined);
+  }
+  return keys;
+}
+
+function isIndex(key, length) {
+  var n = Number(key);
+  return String(n >>> 0) === key
+         && n < length;
+}
+
+function formatList(ctx, obj, depth, keys) {
+  var output = [];
+  var length = obj.length;
+  var max = Math.min(ctx.maxArrayLength, length);
+  var holes = 0;
+  var i;
+  for (i = 0; i < max; i++) {
+    if (!hasOwnProperty.call(obj, i)) {
+      holes++;
+      continue;
+    } else if (holes > 0) {
+      output.push(stylize(ctx, '<' + holes + ' empty item' + (holes > 1 ? 's' : '') + '>', 'undefined'));
+      holes = 0;
+    }
+    output.push(formatProperty(ctx, obj, String(i), depth, true));
+  }
+  if (holes > 0) {
+    output.push(stylize(ctx, '<' + holes + ' empty item' + (holes > 1 ? 's' : '') + '>', 'undefined'));
+  }
+  if (max < length) {
+    var n = length - max;
+    output.push('... ' + n + ' more item' + (n > 1 ? 's' : ''));
+  }
+  for (i = 0; i < keys.length; i++) {
+    if (!isIndex(keys[i], length)
+        && (keys[i] !== 'length' || ctx.showHidden)) {
+      output.push(formatProperty(ctx, obj, keys[i], depth, false));
+    }
+  }
+  return output;
+}
+
+function groupArrayElements(ctx, output, v) {
+  var totalLength = 0;
+  var maxLength = 0;
+  var outputLength = output.length;
+  if (ctx.maxArrayLength < output.length) {
+    // ignore "... n more items"
+    outputLength--;
+  }
+  var separatorSpace = 2;
+  var dataLen = new Array(outputLength);
+  var i;
+  for (i = 0; i < outputLength; i++) {
+    var len = ctx.colors ? output[i].replace(/\u001b\[\d\d?m/g, '').length : output[i].length;
+    dataLen[i] = len;
+    totalLength += len + separatorSpace;
+    if (maxLength < len) {
+      maxLength = len;
+    }
+  }
+
+  var actualMax = maxLength + separatorSpace;
+  if (actualMax * 3 + ctx.indentationLvl < ctx.breakLength
+      && (totalLength / actualMax > 5 || maxLength <= 6)) {
+    var averageBias = Math.sqrt(actualMax - totalLength / output.length);
+    var biasedMax = Math.max(actualMax - 3 - averageBias, 1);
+    var columns = Math.min(
+      Math.round(Math.sqrt(2.5 * biasedMax * outputLength) / biasedMax),
+      Math.floor((ctx.breakLength - ctx.indentationLvl) / actualMax),
+      ctx.compact * 4,
+      15
+    );
+    if (columns <= 1) {
+      return output;
+    }
+
+    var maxLineLength = [];
+    for (i = 0; i < columns; i++) {
+      var lineLength = 0;
+      for (var j = i; j < output.length; j += columns) {
+        if (dataLen[j] > lineLength) {
+          lineLength = dataLen[j];
+        }
+      }
+      maxLineLength.push(lineLength + separatorSpace);
+    }
+    var padStart = true;
+    if (v !== undefined) {
+      for (i = 0; i < output.length; i++) {
+        if (typeof v[i] !== 'number'
+            && typeof v[i] !== 'bigint') {
+          padStart = false;
+          break;
+        }
+      }
+    }
+
+    var tmp = [];
+    for (i = 0; i < outputLength; i += columns) {
+      var max = Math.min(i + columns, outputLength);
+      var s = '';
+      var k = i;
+      for (; k < max - 1; k++) {
+        s += pad(output[k] + ', ', maxLineLength[k - i] + output[k].length - dataLen[k], padStart);
+      }
+      if (padStart) {
+        s += pad(output[k], maxLineLength[k - i] + output[k].length - dataLen[k] - separatorSpace, true);
+      } else {
+        s += output[k];
+      }
+      tmp.push(s);
+    }
+    if (ctx.maxArrayLength < output.length) {
+      tmp.push(output[outputLength]);
+    }
+    output = tmp;
+  }
+  return output;
+}
+
+function pad(s, n, start) {
+  var p = n > s.length ? new Array(n - s.length + 1).join(' ') : '';
+  return start ? p + s : s + p;
+}
+
+function reduceToSingleString(ctx, output, base, braces, array, depth, v) {
+  if (ctx.compact !== true) {
+    if (typeof ctx.compact === 'number'
+        && ctx.compact >= 1) {
+      var entries = output.length;
+      if (array
+          && entries > 6) {
+        output = groupArrayElements(ctx, output, v);
+      }
+      if (ctx.currentDepth - depth < ctx.compact
+          && entries === output.length) {
+        var start = output.length + ctx.indentationLvl + braces[0].length + base.length + 10;
+        if (isBelowBreakLength(ctx, output, start, base)) {
+          var joined = output.join(', ');
+          if (joined.indexOf('\n') === -1) {
+            return (base ? base + ' ' : '') + braces[0] + ' ' + joined + ' ' + braces[1];
+          }
+        }
+      }
+    }
+    var indentation = '\n' + new Array(ctx.indentationLvl + 1).join(' ');
+    return (base ? base + ' ' : '') + braces[0] + indentation + '  ' + output.join(',' + indentation + '  ') + indentation + braces[1];
+  }
+
+  if (isBelowBreakLength(ctx, output, 0, base)) {
+    return braces[0] + (base ? ' ' + base : '') + ' ' + output.join(', ') + ' ' + braces[1];
+  }
+  var ind = new Array(ctx.indentationLvl + 1).join(' ');
+  return (base ? base + ' ' : '') + braces[0] + ' ' + output.join(',\n' + ind + '  ') + ' ' + braces[1];
+}
+
+function isBelowBreakLength(ctx, output, start, base) {
+  var total = output.length + start;
+  if (total + output.length > ctx.breakLength) {
+    return false;
+  }
+  for (var i = 0; i < output.length; i++) {
+    total += ctx.colors ? output[i].replace(/\u001b\[\d\d?m/g, '').length : output[i].length;
+    if (total > ctx.breakLength) {
+      return false;
+    }
+  }
+  return base === ''
+         || base.indexOf('\n') === -1;
+}
+
+function formatError(err) {
+  var stack = err.stack;
+  if (typeof stack === 'string'
+      && stack !== '') {
+    return stack.replace(/\n+$/, '');
+  }
+  return '[' + Error.prototype.toString.call(err) + ']';
+}
+
+function formatValue(ctx, v, depth) {
+  if (v === null
+      || (typeof v !== 'object'
+          && typeof v !== 'function')) {
+    return formatPrimitive(ctx, v);
+  }
+
+  if (ctx.customInspect) {
+    var fn = v[customInspectSymbol];
+    if (typeof fn === 'function'
+        && fn !== inspect
+        && !(v.constructor && v.constructor.prototype === v)) {
+      var opts = {};
+      for (var k in ctx) {
+        if (hasOwnProperty.call(defaultOptions, k)) {
+          opts[k] = ctx[k];
+        }
+      }
+      opts.depth = ctx.depth === null ? null : ctx.depth - depth;
+      opts.stylize = function(s, type) {
+        return stylize(ctx, s, type);
+      };
+      var r = fn.call(v, opts.depth, opts, inspect);
+      if (r !== v) {
+        return typeof r === 'string' ? r : formatValue(ctx, r, depth);
+      }
+    }
+  }
+
+  if (ctx.seen.indexOf(v) !== -1) {
+    var i = ctx.circular.indexOf(v);
+    if (i === -1) {
+      ctx.circular.push(v);
+      i = ctx.circular.length - 1;
+    }
+    return stylize(ctx, '[Circular *' + (i + 1) + ']', 'special');
+  }
+  return formatRaw(ctx, v, depth);
+}
+
+function formatRaw(ctx, v, depth) {
+  var keys = getKeys(ctx, v);
+  var tag = tagOf(v);
+  var name = getConstructorName(v);
+  var prefix = name === null ? '[' + tag + ': null prototype] ' : name !== 'Object' ? name + ' ' : '';
+  var base = '';
+  var braces = ['{', '}'];
+  var array = false;
+
+  switch (tag) {
+  case 'Array':
+    array = true;
+    braces = [(name !== 'Array' ? prefix : '') + '[', ']'];
+    if (v.length === 0
+        && keys.length === 0) {
+      return braces[0] + ']';
+    }
+    break;
+  case 'Arguments':
+    array = true;
+    braces = ['[Arguments] [', ']'];
+    if (v.length === 0) {
+      return braces[0] + ']';
+    }
+    break;
+  case 'Function':
+    var fn = getName(v);
+    base = stylize(ctx, '[Function' + (fn ? ': ' + fn : ' (anonymous)') + ']', 'special');
+    keys = keys.filter(function(k) {
+      return k !== 'prototype';
+    });
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'RegExp':
+    base = stylize(ctx, RegExp.prototype.toString.call(v), 'regexp');
+    keys = keys.filter(function(k) {
+      return k !== 'lastIndex';
+    });
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'Date':
+    base = stylize(ctx, isNaN(v.getTime()) ? 'Invalid Date' : v.toISOString(), 'date');
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'Error':
+    base = formatError(v);
+    keys = keys.filter(function(k) {
+      return k !== 'stack'
+             && k !== 'message';
+    });
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  case 'Number':
+  case 'String':
+  case 'Boolean':
+    base = '[' + tag + ': ' + formatPrimitive(ctx, v.valueOf()) + ']';
+    if (tag === 'String') {
+      keys = keys.filter(function(k) {
+        return !isIndex(k, v.length)
+               && k !== 'length';
+      });
+    }
+    if (keys.length === 0) {
+      return base;
+    }
+    break;
+  default:
+    if (keys.length === 0) {
+      return prefix + '{}';
+    }
+    braces[0] = prefix + '{';
+  }
+
+  if (ctx.depth !== null
+      && depth > ctx.depth) {
+    return stylize(ctx, '[' + (name || tag) + ']', 'special');
+  }
+
+  ctx.seen.push(v);
+  ctx.currentDepth = depth;
+  var output;
+  if (array) {
+    output = formatList(ctx, v, depth, keys);
+  } else {
+    output = keys.map(function(k) {
+      return formatProperty(ctx, v, k, depth, false);
+    });
+  }
+  ctx.seen.pop();
+
+  var i = ctx.circular.indexOf(v);
+  if (i !== -1) {
+    var ref = stylize(ctx, '<ref *' + (i + 1) + '>', 'special');
+    if (base) {
+      base = ref + ' ' + base;
+    } else {
+      braces[0] = ref + ' ' + braces[0];
+    }
+  }
+  return reduceToSingleString(ctx, output, base, braces, array, depth, v);
+}
+
+function inspect(v, opts) {
+  var ctx = {
+    seen: [],
+    circular: [],
+    indentationLvl: 0,
+    currentDepth: 0,
+  };
+  var k;
+  for (k in inspect.defaultOptions) {
+    if (hasOwnProperty.call(inspect.defaultOptions, k)) {
+      ctx[k] = inspect.defaultOptions[k];
+    }
+  }
+  if (typeof opts === 'boolean') {
+    // legacy
+    ctx.showHidden = opts;
+    if (arguments.length > 2) {
+      ctx.depth = arguments[2];
+    }
+    if (arguments.length > 3) {
+      ctx.colors = arguments[3];
+    }
+  } else if (opts !== null
+             && typeof opts === 'object') {
+    for (k in opts) {
+      if (hasOwnProperty.call(opts, k)) {
+        ctx[k] = opts[k];
+      }
+    }
+  }
+  if (ctx.depth === Infinity) {
+    ctx.depth = null;
+  }
+  return formatValue(ctx, v, 0);
+}
+
+inspect.custom = customInspectSymbol;
+inspect.defaultOptions = defaultOptions;
+inspect.colors = colors;
+inspect.styles = styles;
+
+function formatNumber(v, fn) {
+  if (typeof v === 'object'
+      && v !== null) {
+    return 'NaN';
+  }
+  v = fn(v);
+  return v === 0 && 1 / v < 0 ? '-0' : String(v);
+}
+
+function format() {
+  return formatWithOptions.apply(undefined, [undefined].concat(Array.prototype.slice.call(arguments)));
+}
+
+function formatWithOptions(opts) {
+  var args = Array.prototype.slice.call(arguments, 1);
+  var ctx = opts || {};
+  var s = '';
+  var a = 0;
+  if (typeof args[0] === 'string') {
+    var f = args[0];
+    a = 1;
+    var last = 0;
+    for (var i = 0; i < f.length - 1; i++) {
+      if (f[i] !== '%') {
+        continue;
+      }
+
+      var c = f[i + 1];
+      var r;
+      if (c === '%') {
+        s += f.slice(last, i) + '%';
+        last = i + 2;
+        i++;
+        continue;
+      } else if (a >= args.length) {
+        continue;
+      }
+      var v = args[a];
+      switch (c) {
+      case 's':
+        if (typeof v === 'string') {
+          r = v;
+        } else if (typeof v === 'number') {
+          r = formatNumber(v, Number);
+        } else if (typeof v === 'bigint') {
+          r = String(v) + 'n';
+        } else if (v !== null
+                   && typeof v === 'object'
+                   && !(v instanceof Error)
+                   && typeof v.toString === 'function'
+                   && v.toString !== Object.prototype.toString
+                   && v.toString !== Array.prototype.toString) {
+          r = String(v);
+        } else {
+          r = inspect(v, extend(ctx, { depth: 0, colors: false }));
+        }
+        break;
+      case 'd':
+        r = formatNumber(v, Number);
+        break;
+      case 'i':
+        r = formatNumber(v, parseInt);
+        break;
+      case 'f':
+        r = formatNumber(v, parseFloat);
+        break;
+      case 'j':
+        try {
+          r = JSON.stringify(v);
+        } catch (e) {
+          r = '[Circular]';
+        }
+        break;
+      case 'o':
+        r = inspect(v, extend(ctx, { showHidden: true, depth: 4 }));
+        break;
+      case 'O':
+        r = inspect(v, ctx);
+        break;
+      case 'c':
+        r = '';
+        break;
+      default:
+        continue;
+      }
+      s += f.slice(last, i) + r;
+      last = i + 2;
+      i++;
+      a++;
+    }
+    s += f.slice(last);
+  }
+  for (; a < args.length; a++) {
+    var x = args[a];
+    s += (s || a > 0 ? ' ' : '') + (typeof x === 'string' ? x : inspect(x, ctx));
+  }
+  return s;
+}
+
+function extend(dst, src) {
+  var r = {};
+  var k;
+  for (k in dst) {
+    if (hasOwnProperty.call(dst, k)) {
+      r[k] = dst[k];
+    }
+  }
+  for (k in src) {
+    if (hasOwnProperty.call(src, k)) {
+      r[k] = src[k];
+    }
+  }
+  return r;
+}
+
+exports.inspect = inspect;
+exports.format = format;
+exports.formatWithOptions = formatWithOptions;
+exports.getName = getName;
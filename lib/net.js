@@ -0,0 +1,564 @@
+//
+// otto.module :: net.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('net');
+var EventEmitter = require('./events');
+var Duplex = require('./stream').Duplex;
+var timers = require('./timers');
+var util = require('./internal/util');
+
+function validateHost(host, name) {
+  if (host !== null
+      && host !== undefined
+      && typeof host !== 'string') {
+    throw util.invalidArgType(name, 'of type string', host);
+  }
+  return host;
+}
+
+function normalizeArgs(args) {
+  args = Array.prototype.slice.call(args);
+  var cb = typeof args[args.length - 1] === 'function' ? args.pop() : null;
+  var options;
+  if (args[0] !== null
+      && typeof args[0] === 'object') {
+    options = args[0];
+  } else {
+    options = {
+      port: args[0],
+      host: typeof args[1] === 'string' ? args[1] : undefined,
+    };
+  }
+  return [options, cb];
+}
+
+//
+// Socket
+//
+
+function Socket(options) {
+  if (!(this instanceof Socket)) {
+    return new Socket(options);
+  }
+
+  if (options === null
+      || options === undefined) {
+    options = {};
+  } else if (typeof options !== 'object') {
+    throw util.invalidArgType('options', 'of type object', options);
+  }
+
+  Duplex.call(this, {
+    allowHalfOpen: options.allowHalfOpen === true,
+    // 'close' is emitted with hadError
+    emitClose: false,
+  });
+
+  this.connecting = false;
+  this.bytesRead = 0;
+  this.bytesWritten = 0;
+  this.timeout = 0;
+  Object.defineProperties(this, {
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _connectReq: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _server: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _timer: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _unref: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+function handleGetter(side, name) {
+  return {
+    get: function() {
+      return this._handle ? this._handle[side][name] : undefined;
+    },
+    configurable: true,
+  };
+}
+
+Socket.prototype = Object.create(Duplex.prototype, {
+  constructor: {
+    value: Socket,
+    writable: true,
+    configurable: true,
+  },
+  localAddress: handleGetter('local', 'address'),
+  localFamily: handleGetter('local', 'family'),
+  localPort: handleGetter('local', 'port'),
+  remoteAddress: handleGetter('remote', 'address'),
+  remoteFamily: handleGetter('remote', 'family'),
+  remotePort: handleGetter('remote', 'port'),
+  pending: {
+    get: function() {
+      return !this._handle || this.connecting;
+    },
+    configurable: true,
+  },
+  readyState: {
+    get: function() {
+      if (this.connecting) {
+        return 'opening';
+      } else if (this.readable && this.writable) {
+        return 'open';
+      } else if (this.readable && !this.writable) {
+        return 'readOnly';
+      } else if (!this.readable && this.writable) {
+        return 'writeOnly';
+      }
+      return 'closed';
+    },
+    configurable: true,
+  },
+});
+
+Socket.prototype.connect = function connect() {
+  var args = normalizeArgs(arguments);
+  var options = args[0];
+  var cb = args[1];
+  if (options.port === undefined
+      || options.port === null) {
+    var e = new TypeError('The "options" or "port" or "path" argument must be specified');
+    e.code = 'ERR_MISSING_ARGS';
+    throw e;
+  }
+  var port = util.validatePort(options.port, 'Port');
+  var host = validateHost(options.host, 'options.host') || 'localhost';
+
+  if (cb) {
+    this.once('connect', cb);
+  }
+  this.connecting = true;
+  try {
+    this._connectReq = binding.connect(host, port, onconnect.bind(null, this));
+  } catch (err) {
+    this.connecting = false;
+    throw err;
+  }
+  return this;
+};
+
+function onconnect(self, err, handle) {
+  self._connectReq = null;
+  if (self.destroyed) {
+    if (handle) {
+      handle.close();
+    }
+    return;
+  }
+  self.connecting = false;
+  if (err) {
+    self.destroy(err);
+    return;
+  }
+  self._attach(handle);
+  self.emit('connect');
+  self.emit('ready');
+}
+
+Socket.prototype._attach = function _attach(handle) {
+  this._handle = handle;
+  if (this._unref) {
+    handle.unref();
+  }
+  this._refreshTimeout();
+};
+
+// defers fn until the socket is connected
+function afterConnect(self, fn) {
+  if (self.connecting) {
+    self.once('connect', fn);
+    return true;
+  }
+  return false;
+}
+
+Socket.prototype._read = function _read(n) {
+  var self = this;
+  if (afterConnect(this, function() {
+    self._read(n);
+  }) || !this._handle) {
+    return;
+  }
+
+  this._handle.read(n, function(err, buf) {
+    if (err) {
+      self.destroy(err);
+    } else if (buf === null) {
+      self.push(null);
+    } else {
+      self.bytesRead += buf.length;
+      self._refreshTimeout();
+      self.push(buf);
+    }
+  });
+};
+
+Socket.prototype._write = function _write(chunk, encoding, cb) {
+  this._writev([{ chunk: chunk, encoding: encoding }], cb);
+};
+
+Socket.prototype._writev = function _writev(chunks, cb) {
+  var self = this;
+  if (afterConnect(this, function() {
+    self._writev(chunks, cb);
+  })) {
+    return;
+  } else if (!this._handle) {
+    var e = new Error('This socket is closed');
+    e.code = 'ERR_SOCKET_CLOSED';
+    cb(e);
+    return;
+  }
+
+  var body = Buffer.concat(chunks.map(function(c) {
+    return Buffer.isBuffer(c.chunk) ? c.chunk : Buffer.from(c.chunk, c.encoding);
+  }));
+  this.bytesWritten += body.length;
+  this._refreshTimeout();
+  this._handle.write(body, cb);
+};
+
+Socket.prototype._final = function _final(cb) {
+  var self = this;
+  if (afterConnect(this, function() {
+    self._final(cb);
+  })) {
+    return;
+  } else if (!this._handle) {
+    cb();
+    return;
+  }
+
+  this._handle.shutdown(cb);
+};
+
+Socket.prototype._destroy = function _destroy(err, cb) {
+  this._clearTimeout();
+  if (this._connectReq) {
+    this._connectReq.abort();
+    this._connectReq = null;
+  }
+  this.connecting = false;
+  if (this._handle) {
+    this._handle.close();
+    this._handle = null;
+  }
+  cb(err);
+  process.nextTick(emitCloseNT, this, !!err);
+
+  var server = this._server;
+  if (server) {
+    this._server = null;
+    server._connections--;
+    server._emitCloseIfDrained();
+  }
+};
+
+function emitCloseNT(self, hadError) {
+  self.emit('close', hadError);
+}
+
+Socket.prototype.address = function address() {
+  return this._handle ? this._handle.local : {};
+};
+
+Socket.prototype.setTimeout = function setTimeout(msecs, cb) {
+  if (typeof msecs !== 'number') {
+    throw util.invalidArgType('msecs', 'of type number', msecs);
+  } else if (!(msecs >= 0)) {
+    throw util.outOfRange('msecs', '>= 0', msecs);
+  }
+  if (cb !== undefined) {
+    if (typeof cb !== 'function') {
+      throw util.invalidArgType('callback', 'of type function', cb);
+    }
+    if (msecs === 0) {
+      this.removeListener('timeout', cb);
+    } else {
+      this.once('timeout', cb);
+    }
+  }
+  this.timeout = msecs;
+  this._clearTimeout();
+  if (msecs > 0
+      && !this.destroyed) {
+    var self = this;
+    this._timer = timers.setTimeout(function() {
+      self._timer = null;
+      self.emit('timeout');
+    }, msecs);
+    this._timer.unref();
+  }
+  return this;
+};
+
+Socket.prototype._refreshTimeout = function _refreshTimeout() {
+  if (this._timer) {
+    this._timer.refresh();
+  }
+};
+
+Socket.prototype._clearTimeout = function _clearTimeout() {
+  if (this._timer) {
+    timers.clearTimeout(this._timer);
+    this._timer = null;
+  }
+};
+
+Socket.prototype.setNoDelay = function setNoDelay(noDelay) {
+  if (this._handle) {
+    this._handle.setNoDelay(noDelay === undefined ? true : !!noDelay);
+  }
+  return this;
+};
+
+Socket.prototype.setKeepAlive = function setKeepAlive(enable, initialDelay) {
+  if (this._handle) {
+    this._handle.setKeepAlive(!!enable, ~~initialDelay);
+  }
+  return this;
+};
+
+Socket.prototype.ref = function ref() {
+  this._unref = false;
+  if (this._handle) {
+    this._handle.ref();
+  }
+  return this;
+};
+
+Socket.prototype.unref = function unref() {
+  this._unref = true;
+  if (this._handle) {
+    this._handle.unref();
+  }
+  return this;
+};
+
+//
+// Server
+//
+
+function Server(options, connectionListener) {
+  if (!(this instanceof Server)) {
+    return new Server(options, connectionListener);
+  }
+
+  if (typeof options === 'function') {
+    connectionListener = options;
+    options = {};
+  } else if (options === null
+             || options === undefined) {
+    options = {};
+  } else if (typeof options !== 'object'
+             || Array.isArray(options)) {
+    throw util.invalidArgType('options', 'of type object', options);
+  }
+
+  EventEmitter.call(this);
+
+  this.allowHalfOpen = options.allowHalfOpen === true;
+  Object.defineProperties(this, {
+    _handle: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _connections: {
+      value: 0,
+      writable: true,
+      configurable: true,
+    },
+    _unref: {
+      value: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+  if (connectionListener) {
+    this.on('connection', connectionListener);
+  }
+}
+
+Server.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Server,
+    writable: true,
+    configurable: true,
+  },
+  listening: {
+    get: function() {
+      return this._handle !== null;
+    },
+    configurable: true,
+  },
+});
+
+Server.prototype.listen = function listen() {
+  var args = normalizeArgs(arguments);
+  var options = args[0];
+  var cb = args[1];
+  var port = options.port === undefined || options.port === null ? 0 : util.validatePort(options.port, 'options.port');
+  var host = validateHost(options.host, 'options.host');
+
+  if (this._handle) {
+    var e = new Error('Listen method has been called more than once without closing.');
+    e.code = 'ERR_SERVER_ALREADY_LISTEN';
+    throw e;
+  }
+  if (cb) {
+    this.once('listening', cb);
+  }
+
+  try {
+    this._handle = binding.listen(host, port, onconnection.bind(null, this));
+  } catch (err) {
+    process.nextTick(emitErrorNT, this, err);
+    return this;
+  }
+  if (this._unref) {
+    this._handle.unref();
+  }
+  process.nextTick(emitListeningNT, this);
+  return this;
+};
+
+function emitErrorNT(self, err) {
+  self.emit('error', err);
+}
+
+function emitListeningNT(self) {
+  if (self._handle) {
+    self.emit('listening');
+  }
+}
+
+function onconnection(server, handle) {
+  var socket = new Socket({ allowHalfOpen: server.allowHalfOpen });
+  socket._attach(handle);
+  socket._server = server;
+  server._connections++;
+  server.emit('connection', socket);
+}
+
+Server.prototype.address = function address() {
+  return this._handle ? this._handle.address : null;
+};
+
+Server.prototype.close = function close(cb) {
+  if (typeof cb === 'function') {
+    if (this._handle) {
+      this.once('close', cb);
+    } else {
+      this.once('close', function() {
+        var e = new Error('Server is not running.');
+        e.code = 'ERR_SERVER_NOT_RUNNING';
+        cb(e);
+      });
+    }
+  }
+
+  if (this._handle) {
+    this._handle.close();
+    this._handle = null;
+    this._emitCloseIfDrained();
+  } else {
+    process.nextTick(emitCloseNT, this);
+  }
+  return this;
+};
+
+// 'close' is emitted after all the connections are ended
+Server.prototype._emitCloseIfDrained = function _emitCloseIfDrained() {
+  if (!this._handle
+      && this._connections === 0) {
+    process.nextTick(emitCloseNT, this);
+  }
+};
+
+Server.prototype.getConnections = function getConnections(cb) {
+  process.nextTick(cb, null, this._connections);
+  return this;
+};
+
+Server.prototype.ref = function ref() {
+  this._unref = false;
+  if (this._handle) {
+    this._handle.ref();
+  }
+  return this;
+};
+
+Server.prototype.unref = function unref() {
+  this._unref = true;
+  if (this._handle) {
+    this._handle.unref();
+  }
+  return this;
+};
+
+//
+// API
+//
+
+function connect() {
+  var options = normalizeArgs(arguments)[0];
+  var socket = new Socket(options);
+  if (options.timeout) {
+    socket.setTimeout(options.timeout);
+  }
+  return socket.connect.apply(socket, arguments);
+}
+
+function createServer(options, connectionListener) {
+  return new Server(options, connectionListener);
+}
+
+function isIP(input) {
+  return binding.isIP(input);
+}
+
+function isIPv4(input) {
+  return isIP(input) === 4;
+}
+
+function isIPv6(input) {
+  return isIP(input) === 6;
+}
+
+exports.Server = Server;
+exports.Socket = Socket;
+exports.Stream = Socket;
+exports.connect = connect;
+exports.createConnection = connect;
+exports.createServer = createServer;
+exports.isIP = isIP;
+exports.isIPv4 = isIPv4;
+exports.isIPv6 = isIPv6;
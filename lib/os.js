@@ -0,0 +1,89 @@
+//
+// otto.module :: os.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('os');
+
+exports.EOL = binding.EOL;
+exports.devNull = binding.devNull;
+
+exports.constants = {
+  signals: binding.signals,
+};
+
+exports.platform = function platform() {
+  return binding.platform;
+};
+
+exports.type = function type() {
+  return binding.type;
+};
+
+exports.arch = function arch() {
+  return binding.arch;
+};
+
+exports.endianness = function endianness() {
+  return binding.endianness;
+};
+
+exports.release = function release() {
+  return binding.release();
+};
+
+exports.hostname = function hostname() {
+  return binding.hostname();
+};
+
+exports.homedir = function homedir() {
+  return binding.homedir();
+};
+
+exports.tmpdir = function tmpdir() {
+  return binding.tmpdir();
+};
+
+exports.userInfo = function userInfo() {
+  var u = binding.userInfo();
+  return {
+    uid: u.uid,
+    gid: u.gid,
+    username: u.username,
+    homedir: u.homedir,
+    shell: u.shell,
+  };
+};
+
+exports.cpus = function cpus() {
+  var list = binding.cpus();
+  var r = [];
+  for (var i = 0; i < list.length; i++) {
+    var t = list[i].times;
+    r.push({
+      model: list[i].model,
+      speed: list[i].speed,
+      times: {
+        user: t.user,
+        nice: t.nice,
+        sys: t.sys,
+        idle: t.idle,
+        irq: t.irq,
+      },
+    });
+  }
+  return r;
+};
+
+exports.totalmem = function totalmem() {
+  return binding.totalmem();
+};
+
+exports.freemem = function freemem() {
+  return binding.freemem();
+};
@@ -0,0 +1,137 @@
+//
+// otto.module :: querystring.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var url = require('./internal/url');
+
+function unescape(s, decodeSpaces) {
+  try {
+    return decodeURIComponent(s);
+  } catch (e) {
+    return url.percentDecode(decodeSpaces ? s.replace(/\+/g, ' ') : s);
+  }
+}
+
+exports.unescape = unescape;
+
+exports.escape = function escape(s) {
+  if (typeof s !== 'string') {
+    s = typeof s === 'object' ? String(s) : s + '';
+  }
+  try {
+    return encodeURIComponent(s);
+  } catch (e) {
+    var err = new URIError('URI malformed');
+    err.code = 'ERR_INVALID_URI';
+    throw err;
+  }
+};
+
+exports.parse = function parse(qs, sep, eq, options) {
+  var o = Object.create(null);
+  if (typeof qs !== 'string'
+      || qs.length === 0) {
+    return o;
+  }
+
+  sep = sep ? String(sep) : '&';
+  eq = eq ? String(eq) : '=';
+  var maxKeys = 1000;
+  if (options
+      && typeof options.maxKeys === 'number') {
+    maxKeys = options.maxKeys > 0 ? options.maxKeys : 0;
+  }
+  var decode = exports.unescape;
+  if (options
+      && typeof options.decodeURIComponent === 'function') {
+    decode = options.decodeURIComponent;
+  }
+  var custom = decode !== unescape;
+
+  var list = qs.split(sep);
+  if (maxKeys > 0
+      && list.length > maxKeys) {
+    list = list.slice(0, maxKeys);
+  }
+  list.forEach(function(s) {
+    if (s === '') {
+      return;
+    }
+    var i = s.indexOf(eq);
+    var k = decodeStr(i !== -1 ? s.slice(0, i) : s);
+    var v = decodeStr(i !== -1 ? s.slice(i + eq.length) : '');
+    if (!(k in o)) {
+      o[k] = v;
+    } else if (Array.isArray(o[k])) {
+      o[k].push(v);
+    } else {
+      o[k] = [o[k], v];
+    }
+  });
+  return o;
+
+  function decodeStr(s) {
+    s = s.replace(/\+/g, custom ? '%20' : ' ');
+    if (!custom
+        && s.indexOf('%') === -1) {
+      return s;
+    }
+    try {
+      return decode(s);
+    } catch (e) {
+      return unescape(s, true);
+    }
+  }
+};
+
+function stringifyPrimitive(v) {
+  switch (typeof v) {
+  case 'string':
+    return v;
+  case 'number':
+    return isFinite(v) ? String(v) : '';
+  case 'bigint':
+    return String(v);
+  case 'boolean':
+    return v ? 'true' : 'false';
+  }
+  return '';
+}
+
+exports.stringify = function stringify(obj, sep, eq, options) {
+  if (obj === null
+      || typeof obj !== 'object') {
+    return '';
+  }
+
+  sep = sep || '&';
+  eq = eq || '=';
+  var encode = exports.escape;
+  if (options
+      && typeof options.encodeURIComponent === 'function') {
+    encode = options.encodeURIComponent;
+  }
+
+  var list = [];
+  Object.keys(obj).forEach(function(k) {
+    var v = obj[k];
+    var ks = encode(stringifyPrimitive(k)) + eq;
+    if (Array.isArray(v)) {
+      v.forEach(function(v) {
+        list.push(ks + encode(stringifyPrimitive(v)));
+      });
+    } else {
+      list.push(ks + encode(stringifyPrimitive(v)));
+    }
+  });
+  return list.join(sep);
+};
+
+exports.decode = exports.parse;
+exports.encode = exports.stringify;
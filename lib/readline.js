@@ -0,0 +1,462 @@
+//
+// otto.module :: readline.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var EventEmitter = require('./events');
+var StringDecoder = require('./string_decoder').StringDecoder;
+var util = require('./internal/util');
+
+var kHistorySize = 30;
+var kMincrlfDelay = 100;
+
+function useAfterClose() {
+  var e = new Error('readline was closed');
+  e.code = 'ERR_USE_AFTER_CLOSE';
+  return e;
+}
+
+function validateString(v, name) {
+  if (typeof v !== 'string') {
+    throw util.invalidArgType(name, 'of type string', v);
+  }
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+//
+// Interface
+//
+
+function Interface(input, output, completer, terminal) {
+  if (!(this instanceof Interface)) {
+    return new Interface(input, output, completer, terminal);
+  }
+
+  EventEmitter.call(this);
+
+  var historySize;
+  var prompt = '> ';
+  var crlfDelay;
+  if (input
+      && typeof input.on !== 'function') {
+    // options
+    output = input.output;
+    completer = input.completer;
+    terminal = input.terminal;
+    historySize = input.historySize;
+    if (input.prompt !== undefined) {
+      prompt = input.prompt;
+    }
+    crlfDelay = input.crlfDelay;
+    input = input.input;
+  }
+  if (input === undefined
+      || input === null) {
+    input = process.stdin;
+  }
+  if (output === undefined) {
+    output = process.stdout;
+  }
+  if (completer !== undefined) {
+    validateFunction(completer, 'completer');
+  }
+  if (historySize === undefined) {
+    historySize = kHistorySize;
+  } else if (!(typeof historySize === 'number' && historySize >= 0)) {
+    throw util.outOfRange('historySize', '>= 0', historySize);
+  }
+
+  this.input = input;
+  this.output = output;
+  this.completer = completer;
+  this.terminal = false;
+  this.historySize = historySize;
+  this.history = [];
+  this.crlfDelay = crlfDelay ? Math.max(kMincrlfDelay, crlfDelay) : kMincrlfDelay;
+  this.line = '';
+  this.cursor = 0;
+  this.closed = false;
+  this.paused = false;
+  Object.defineProperties(this, {
+    _prompt: {
+      value: prompt,
+      writable: true,
+      configurable: true,
+    },
+    _oldPrompt: {
+      value: '',
+      writable: true,
+      configurable: true,
+    },
+    _questionCallback: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _lineBuffer: {
+      value: null,
+      writable: true,
+      configurable: true,
+    },
+    _sawReturnAt: {
+      value: 0,
+      writable: true,
+      configurable: true,
+    },
+    _decoder: {
+      value: new StringDecoder('utf8'),
+      writable: true,
+      configurable: true,
+    },
+  });
+
+  var self = this;
+  function ondata(data) {
+    self._normalWrite(data);
+  }
+  function onend() {
+    if (typeof self._lineBuffer === 'string'
+        && self._lineBuffer.length > 0) {
+      self.emit('line', self._lineBuffer);
+    }
+    self.close();
+  }
+  function onerror(err) {
+    self.emit('error', err);
+  }
+  input.on('error', onerror);
+  input.on('data', ondata);
+  input.on('end', onend);
+  this.once('close', function() {
+    input.removeListener('data', ondata);
+    input.removeListener('error', onerror);
+    input.removeListener('end', onend);
+  });
+
+  input.resume();
+}
+
+Interface.prototype = Object.create(EventEmitter.prototype, {
+  constructor: {
+    value: Interface,
+    writable: true,
+    configurable: true,
+  },
+});
+
+Interface.prototype.setPrompt = function setPrompt(prompt) {
+  this._prompt = prompt;
+};
+
+Interface.prototype.getPrompt = function getPrompt() {
+  return this._prompt;
+};
+
+Interface.prototype.prompt = function prompt(preserveCursor) {
+  if (this.paused) {
+    this.resume();
+  }
+  this._writeToOutput(this._prompt);
+};
+
+Interface.prototype.question = function question(query, options, cb) {
+  cb = typeof options === 'function' ? options : cb;
+  if (typeof cb !== 'function') {
+    return;
+  }
+  if (this.closed) {
+    throw useAfterClose();
+  }
+
+  if (this._questionCallback) {
+    this.prompt();
+  } else {
+    this._oldPrompt = this._prompt;
+    this.setPrompt(query);
+    this._questionCallback = cb;
+    this.prompt();
+  }
+};
+
+Interface.prototype._writeToOutput = function _writeToOutput(s) {
+  validateString(s, 'stringToWrite');
+  if (this.output !== null
+      && this.output !== undefined) {
+    this.output.write(s);
+  }
+};
+
+Interface.prototype._onLine = function _onLine(line) {
+  if (this._questionCallback) {
+    var cb = this._questionCallback;
+    this._questionCallback = null;
+    this.setPrompt(this._oldPrompt);
+    cb(line);
+  } else {
+    this.emit('line', line);
+  }
+};
+
+Interface.prototype._normalWrite = function _normalWrite(b) {
+  if (b === undefined) {
+    return;
+  }
+  var s = typeof b === 'string' ? b : this._decoder.write(b);
+  if (this._sawReturnAt
+      && Date.now() - this._sawReturnAt <= this.crlfDelay) {
+    if (s.charAt(0) === '\n') {
+      s = s.slice(1);
+    }
+    this._sawReturnAt = 0;
+  }
+
+  // split lines on \r\n, \n and \r
+  var lines = [];
+  var start = 0;
+  for (var i = 0; i < s.length; i++) {
+    var c = s.charAt(i);
+    if (c === '\n'
+        || c === '\r') {
+      lines.push(s.slice(start, i));
+      if (c === '\r'
+          && s.charAt(i + 1) === '\n') {
+        i++;
+      }
+      start = i + 1;
+    }
+  }
+  if (lines.length === 0) {
+    if (s) {
+      this._lineBuffer = (this._lineBuffer || '') + s;
+    }
+    return;
+  }
+
+  if (this._lineBuffer) {
+    lines[0] = this._lineBuffer + lines[0];
+  }
+  this._sawReturnAt = s.charAt(s.length - 1) === '\r' ? Date.now() : 0;
+  this._lineBuffer = s.slice(start);
+  for (i = 0; i < lines.length && !this.closed; i++) {
+    this._onLine(lines[i]);
+  }
+};
+
+Interface.prototype.write = function write(d, key) {
+  if (this.closed) {
+    throw useAfterClose();
+  }
+  if (this.paused) {
+    this.resume();
+  }
+  this._normalWrite(d);
+};
+
+Interface.prototype.pause = function pause() {
+  if (this.paused) {
+    return;
+  }
+  this.input.pause();
+  this.paused = true;
+  this.emit('pause');
+  return this;
+};
+
+Interface.prototype.resume = function resume() {
+  if (!this.paused) {
+    return;
+  }
+  this.input.resume();
+  this.paused = false;
+  this.emit('resume');
+  return this;
+};
+
+Interface.prototype.close = function close() {
+  if (this.closed) {
+    return;
+  }
+  this.pause();
+  this.closed = true;
+  this.emit('close');
+};
+
+Interface.prototype.getCursorPos = function getCursorPos() {
+  var s = this._prompt + this.line.slice(0, this.cursor);
+  return {
+    rows: 0,
+    cols: s.length,
+  };
+};
+
+// async iteration without for await...of; next(callback) calls back with
+// (err, { value, done }) for each line
+Interface.prototype.asyncIterator = function asyncIterator(options) {
+  var self = this;
+  var highWaterMark = options && options.highWaterMark !== undefined ? options.highWaterMark : 1024;
+  var lines = [];
+  var waiting = [];
+  var error = null;
+  var done = false;
+
+  function online(line) {
+    if (waiting.length) {
+      waiting.shift()(null, { value: line, done: false });
+    } else {
+      lines.push(line);
+      if (lines.length >= highWaterMark) {
+        self.pause();
+      }
+    }
+  }
+  function onclose() {
+    finish();
+  }
+  function onerror(err) {
+    error = err;
+    finish();
+  }
+  function finish() {
+    if (done) {
+      return;
+    }
+    done = true;
+    self.removeListener('line', online);
+    self.removeListener('close', onclose);
+    self.removeListener('error', onerror);
+    while (waiting.length) {
+      var cb = waiting.shift();
+      if (error) {
+        cb(error);
+        error = null;
+      } else {
+        cb(null, { value: undefined, done: true });
+      }
+    }
+  }
+  this.on('line', online);
+  this.on('close', onclose);
+  this.on('error', onerror);
+
+  return {
+    next: function next(callback) {
+      validateFunction(callback, 'callback');
+      if (lines.length) {
+        var line = lines.shift();
+        if (lines.length < highWaterMark
+            && !done
+            && self.paused
+            && !self.closed) {
+          self.resume();
+        }
+        callback(null, { value: line, done: false });
+      } else if (error) {
+        var err = error;
+        error = null;
+        callback(err);
+      } else if (done) {
+        callback(null, { value: undefined, done: true });
+      } else {
+        waiting.push(callback);
+      }
+    },
+    return: function(callback) {
+      lines = [];
+      finish();
+      self.close();
+      if (typeof callback === 'function') {
+        callback(null, { value: undefined, done: true });
+      }
+    },
+  };
+};
+
+function createInterface(input, output, completer, terminal) {
+  return new Interface(input, output, completer, terminal);
+}
+
+//
+// cursor
+//
+
+var CSI = '\x1b[';
+
+function write(stream, data, callback) {
+  if (!stream) {
+    if (typeof callback === 'function') {
+      process.nextTick(callback, null);
+    }
+    return true;
+  }
+  return stream.write(data, callback);
+}
+
+function cursorTo(stream, x, y, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  if (typeof y === 'function') {
+    callback = y;
+    y = undefined;
+  }
+  if (x !== x) {
+    throw util.invalidArgValue('x', x);
+  } else if (y !== y) {
+    throw util.invalidArgValue('y', y);
+  } else if (typeof x !== 'number'
+             && typeof y !== 'number') {
+    return write(null, '', callback);
+  }
+  var data = typeof y !== 'number' ? CSI + (x + 1) + 'G' : CSI + (y + 1) + ';' + (x + 1) + 'H';
+  return write(stream, data, callback);
+}
+
+function moveCursor(stream, dx, dy, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  var data = '';
+  if (dx < 0) {
+    data += CSI + -dx + 'D';
+  } else if (dx > 0) {
+    data += CSI + dx + 'C';
+  }
+  if (dy < 0) {
+    data += CSI + -dy + 'A';
+  } else if (dy > 0) {
+    data += CSI + dy + 'B';
+  }
+  return write(stream, data, callback);
+}
+
+function clearLine(stream, dir, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  var type = dir < 0 ? '1K' : dir > 0 ? '0K' : '2K';
+  return write(stream, CSI + type, callback);
+}
+
+function clearScreenDown(stream, callback) {
+  if (callback !== undefined) {
+    validateFunction(callback, 'callback');
+  }
+  return write(stream, CSI + '0J', callback);
+}
+
+exports.Interface = Interface;
+exports.createInterface = createInterface;
+exports.clearLine = clearLine;
+exports.clearScreenDown = clearScreenDown;
+exports.cursorTo = cursorTo;
+exports.moveCursor = moveCursor;
@@ -0,0 +1,2067 @@
+//
+// otto.module :: stream.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('stream');
+var Buffer = require('./buffer').Buffer;
+var EventEmitter = require('./events');
+var StringDecoder = require('./string_decoder').StringDecoder;
+var util = require('./internal/util');
+
+//
+// errors
+//
+
+function makeError(Base, code, msg) {
+  var e = new Base(msg);
+  e.code = code;
+  return e;
+}
+
+function methodNotImplemented(name) {
+  return makeError(Error, 'ERR_METHOD_NOT_IMPLEMENTED', 'The ' + name + ' method is not implemented');
+}
+
+function streamDestroyed(name) {
+  return makeError(Error, 'ERR_STREAM_DESTROYED', 'Cannot call ' + name + ' after a stream was destroyed');
+}
+
+function streamAlreadyFinished(name) {
+  return makeError(Error, 'ERR_STREAM_ALREADY_FINISHED', 'Cannot call ' + name + ' after a stream was finished');
+}
+
+function streamNullValues() {
+  return makeError(TypeError, 'ERR_STREAM_NULL_VALUES', 'May not write null values to stream');
+}
+
+function streamPrematureClose() {
+  return makeError(Error, 'ERR_STREAM_PREMATURE_CLOSE', 'Premature close');
+}
+
+function multipleCallback() {
+  return makeError(Error, 'ERR_MULTIPLE_CALLBACK', 'Callback called multiple times');
+}
+
+function invalidChunk(v) {
+  return util.invalidArgType('chunk', 'of type string or an instance of Buffer, TypedArray, or DataView', v);
+}
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+//
+// utilities
+//
+
+function nop() {
+}
+
+function once(fn) {
+  var called = false;
+  return function() {
+    if (!called) {
+      called = true;
+      return fn.apply(this, arguments);
+    }
+  };
+}
+
+function inherits(ctor, base) {
+  ctor.prototype = Object.create(base.prototype, {
+    constructor: {
+      value: ctor,
+      writable: true,
+      configurable: true,
+    },
+  });
+}
+
+function hidden(obj, name, value) {
+  Object.defineProperty(obj, name, {
+    value: value,
+    writable: true,
+    configurable: true,
+  });
+}
+
+function getters(proto, props) {
+  Object.keys(props).forEach(function(k) {
+    var p = props[k];
+    Object.defineProperty(proto, k, {
+      get: typeof p === 'function' ? p : p.get,
+      set: p.set,
+      configurable: true,
+    });
+  });
+}
+
+function getHighWaterMark(state, options, duplexKey, isDuplex) {
+  var hwm = options.highWaterMark;
+  var name = 'options.highWaterMark';
+  if ((hwm === undefined || hwm === null)
+      && isDuplex) {
+    hwm = options[duplexKey];
+    name = 'options.' + duplexKey;
+  }
+  if (hwm !== undefined
+      && hwm !== null) {
+    if (!(typeof hwm === 'number' && hwm >= 0 && hwm < Infinity)) {
+      throw util.invalidArgValue(name, hwm);
+    }
+    return Math.floor(hwm);
+  }
+  return state.objectMode ? 16 : 16 * 1024;
+}
+
+//
+// destroy
+//
+
+function checkError(err, w, r) {
+  if (err) {
+    if (w
+        && !w.errored) {
+      w.errored = err;
+    }
+    if (r
+        && !r.errored) {
+      r.errored = err;
+    }
+  }
+}
+
+function destroy(err, cb) {
+  var self = this;
+  var r = this._readableState;
+  var w = this._writableState;
+  if ((w && w.destroyed)
+      || (r && r.destroyed)) {
+    if (typeof cb === 'function') {
+      cb();
+    }
+    return this;
+  }
+
+  checkError(err, w, r);
+  if (w) {
+    w.destroyed = true;
+  }
+  if (r) {
+    r.destroyed = true;
+  }
+
+  var called = false;
+  function onDestroy(err) {
+    if (called) {
+      return;
+    }
+    called = true;
+
+    checkError(err, w, r);
+    if (w) {
+      w.closed = true;
+    }
+    if (r) {
+      r.closed = true;
+    }
+    if (typeof cb === 'function') {
+      cb(err);
+    }
+    if (err) {
+      process.nextTick(emitErrorCloseNT, self, err);
+    } else {
+      process.nextTick(emitCloseNT, self);
+    }
+  }
+
+  try {
+    this._destroy(err || null, onDestroy);
+  } catch (e) {
+    onDestroy(e);
+  }
+  return this;
+}
+
+function emitErrorCloseNT(self, err) {
+  emitErrorNT(self, err);
+  emitCloseNT(self);
+}
+
+function emitCloseNT(self) {
+  var r = self._readableState;
+  var w = self._writableState;
+  if (w) {
+    w.closeEmitted = true;
+  }
+  if (r) {
+    r.closeEmitted = true;
+  }
+  if ((w && w.emitClose)
+      || (r && r.emitClose)) {
+    self.emit('close');
+  }
+}
+
+function emitErrorNT(self, err) {
+  var r = self._readableState;
+  var w = self._writableState;
+  if ((w && w.errorEmitted)
+      || (r && r.errorEmitted)) {
+    return;
+  }
+  if (w) {
+    w.errorEmitted = true;
+  }
+  if (r) {
+    r.errorEmitted = true;
+  }
+  self.emit('error', err);
+}
+
+function errorOrDestroy(stream, err, sync) {
+  var r = stream._readableState;
+  var w = stream._writableState;
+  if ((w && w.destroyed)
+      || (r && r.destroyed)) {
+    return;
+  }
+  if ((r && r.autoDestroy)
+      || (w && w.autoDestroy)) {
+    stream.destroy(err);
+  } else if (err) {
+    checkError(err, w, r);
+    if (sync) {
+      process.nextTick(emitErrorNT, stream, err);
+    } else {
+      emitErrorNT(stream, err);
+    }
+  }
+}
+
+function isDestroyed() {
+  var r = this._readableState;
+  var w = this._writableState;
+  return !!((r && r.destroyed) || (w && w.destroyed));
+}
+
+function setDestroyed(v) {
+  if (this._readableState) {
+    this._readableState.destroyed = v;
+  }
+  if (this._writableState) {
+    this._writableState.destroyed = v;
+  }
+}
+
+//
+// Stream
+//
+
+function Stream(options) {
+  EventEmitter.call(this, options);
+}
+
+inherits(Stream, EventEmitter);
+
+//
+// Readable
+//
+
+function ReadableState(options, stream, isDuplex) {
+  this.objectMode = !!options.objectMode;
+  if (isDuplex) {
+    this.objectMode = this.objectMode || !!options.readableObjectMode;
+  }
+  this.highWaterMark = getHighWaterMark(this, options, 'readableHighWaterMark', isDuplex);
+  this.buffer = [];
+  this.length = 0;
+  this.pipes = [];
+  this.flowing = null;
+  this.ended = false;
+  this.endEmitted = false;
+  this.reading = false;
+  this.sync = true;
+  this.needReadable = false;
+  this.emittedReadable = false;
+  this.readableListening = false;
+  this.resumeScheduled = false;
+  this.paused = null;
+  this.errorEmitted = false;
+  this.emitClose = options.emitClose !== false;
+  this.autoDestroy = options.autoDestroy !== false;
+  this.destroyed = false;
+  this.errored = null;
+  this.closed = false;
+  this.closeEmitted = false;
+  this.defaultEncoding = options.defaultEncoding || 'utf8';
+  this.awaitDrain = 0;
+  this.readingMore = false;
+  this.dataEmitted = false;
+  this.decoder = null;
+  this.encoding = null;
+  if (options.encoding) {
+    this.decoder = new StringDecoder(options.encoding);
+    this.encoding = this.decoder.encoding;
+  }
+}
+
+function Readable(options) {
+  if (!(this instanceof Readable)) {
+    return new Readable(options);
+  }
+
+  options = options || {};
+  hidden(this, '_readableState', new ReadableState(options, this, this instanceof Duplex));
+  if (typeof options.read === 'function') {
+    this._read = options.read;
+  }
+  if (typeof options.destroy === 'function') {
+    this._destroy = options.destroy;
+  }
+  Stream.call(this, options);
+}
+
+inherits(Readable, Stream);
+
+Readable.ReadableState = ReadableState;
+
+Readable.prototype.destroy = destroy;
+
+Readable.prototype._destroy = function _destroy(err, cb) {
+  cb(err);
+};
+
+Readable.prototype.push = function push(chunk, encoding) {
+  return readableAddChunk(this, chunk, encoding, false);
+};
+
+Readable.prototype.unshift = function unshift(chunk, encoding) {
+  return readableAddChunk(this, chunk, encoding, true);
+};
+
+function readableAddChunk(stream, chunk, encoding, addToFront) {
+  var state = stream._readableState;
+  var err;
+  if (!state.objectMode) {
+    if (typeof chunk === 'string') {
+      encoding = encoding || state.defaultEncoding;
+      if (state.encoding !== encoding) {
+        if (addToFront
+            && state.encoding) {
+          chunk = Buffer.from(chunk, encoding).toString(state.encoding);
+        } else {
+          chunk = Buffer.from(chunk, encoding);
+          encoding = '';
+        }
+      }
+    } else if (Buffer.isBuffer(chunk)) {
+      encoding = '';
+    } else if (chunk !== null
+               && chunk !== undefined) {
+      err = invalidChunk(chunk);
+    }
+  }
+
+  if (err) {
+    errorOrDestroy(stream, err);
+  } else if (chunk === null) {
+    state.reading = false;
+    onEofChunk(stream, state);
+  } else if (state.objectMode
+             || (chunk && chunk.length > 0)) {
+    if (addToFront) {
+      if (state.endEmitted) {
+        errorOrDestroy(stream, makeError(Error, 'ERR_STREAM_UNSHIFT_AFTER_END_EVENT', 'stream.unshift() after end event'));
+      } else if (state.destroyed
+                 || state.errored) {
+        return false;
+      } else {
+        addChunk(stream, state, chunk, true);
+      }
+    } else if (state.ended) {
+      errorOrDestroy(stream, makeError(Error, 'ERR_STREAM_PUSH_AFTER_EOF', 'stream.push() after EOF'));
+    } else if (state.destroyed
+               || state.errored) {
+      return false;
+    } else {
+      state.reading = false;
+      if (state.decoder
+          && !encoding) {
+        chunk = state.decoder.write(chunk);
+        if (state.objectMode
+            || chunk.length !== 0) {
+          addChunk(stream, state, chunk, false);
+        } else {
+          maybeReadMore(stream, state);
+        }
+      } else {
+        addChunk(stream, state, chunk, false);
+      }
+    }
+  } else if (!addToFront) {
+    state.reading = false;
+    maybeReadMore(stream, state);
+  }
+  return !state.ended
+         && (state.length < state.highWaterMark || state.length === 0);
+}
+
+function addChunk(stream, state, chunk, addToFront) {
+  if (state.flowing
+      && state.length === 0
+      && !state.sync
+      && stream.listenerCount('data') > 0) {
+    state.awaitDrain = 0;
+    state.dataEmitted = true;
+    stream.emit('data', chunk);
+  } else {
+    state.length += state.objectMode ? 1 : chunk.length;
+    if (addToFront) {
+      state.buffer.unshift(chunk);
+    } else {
+      state.buffer.push(chunk);
+    }
+    if (state.needReadable) {
+      emitReadable(stream);
+    }
+  }
+  maybeReadMore(stream, state);
+}
+
+Readable.prototype.isPaused = function isPaused() {
+  var state = this._readableState;
+  return state.paused === true
+         || state.flowing === false;
+};
+
+Readable.prototype.setEncoding = function setEncoding(enc) {
+  var state = this._readableState;
+  var decoder = new StringDecoder(enc);
+  state.decoder = decoder;
+  state.encoding = decoder.encoding;
+
+  var content = '';
+  state.buffer.forEach(function(b) {
+    content += decoder.write(b);
+  });
+  state.buffer = content !== '' ? [content] : [];
+  state.length = content.length;
+  return this;
+};
+
+var MAX_HWM = 0x40000000;
+
+function computeNewHighWaterMark(n) {
+  if (n >= MAX_HWM) {
+    return MAX_HWM;
+  }
+  n--;
+  n |= n >>> 1;
+  n |= n >>> 2;
+  n |= n >>> 4;
+  n |= n >>> 8;
+  n |= n >>> 16;
+  return n + 1;
+}
+
+function howMuchToRead(n, state) {
+  if (n <= 0
+      || (state.length === 0 && state.ended)) {
+    return 0;
+  } else if (state.objectMode) {
+    return 1;
+  } else if (n !== n) {
+    // read everything, or only the head if flowing
+    if (state.flowing
+        && state.length) {
+      return state.buffer[0].length;
+    }
+    return state.length;
+  } else if (n <= state.length) {
+    return n;
+  }
+  return state.ended ? state.length : 0;
+}
+
+Readable.prototype.read = function read(n) {
+  if (n === undefined) {
+    n = NaN;
+  } else if (Math.floor(n) !== n) {
+    n = parseInt(n, 10);
+  }
+  var state = this._readableState;
+  var nOrig = n;
+
+  if (n > state.highWaterMark) {
+    state.highWaterMark = computeNewHighWaterMark(n);
+  }
+  if (n !== 0) {
+    state.emittedReadable = false;
+  }
+  if (n === 0
+      && state.needReadable
+      && ((state.highWaterMark !== 0 ? state.length >= state.highWaterMark : state.length > 0) || state.ended)) {
+    if (state.length === 0
+        && state.ended) {
+      endReadable(this);
+    } else {
+      emitReadable(this);
+    }
+    return null;
+  }
+
+  n = howMuchToRead(n, state);
+  if (n === 0
+      && state.ended) {
+    if (state.length === 0) {
+      endReadable(this);
+    }
+    return null;
+  }
+
+  var doRead = state.needReadable;
+  if (state.length === 0
+      || state.length - n < state.highWaterMark) {
+    doRead = true;
+  }
+  if (state.ended
+      || state.reading
+      || state.destroyed
+      || state.errored) {
+    doRead = false;
+  } else if (doRead) {
+    state.reading = true;
+    state.sync = true;
+    if (state.length === 0) {
+      state.needReadable = true;
+    }
+    try {
+      this._read(state.highWaterMark);
+    } catch (e) {
+      errorOrDestroy(this, e);
+    }
+    state.sync = false;
+    if (!state.reading) {
+      n = howMuchToRead(nOrig, state);
+    }
+  }
+
+  var ret = n > 0 ? fromList(n, state) : null;
+  if (ret === null) {
+    state.needReadable = state.length <= state.highWaterMark;
+    n = 0;
+  } else {
+    state.length -= n;
+    state.awaitDrain = 0;
+  }
+
+  if (state.length === 0) {
+    if (!state.ended) {
+      state.needReadable = true;
+    }
+    if (nOrig !== n
+        && state.ended) {
+      endReadable(this);
+    }
+  }
+  if (ret !== null
+      && !state.errorEmitted
+      && !state.closeEmitted) {
+    state.dataEmitted = true;
+    this.emit('data', ret);
+  }
+  return ret;
+};
+
+Readable.prototype._read = function _read(n) {
+  throw methodNotImplemented('_read()');
+};
+
+function onEofChunk(stream, state) {
+  if (state.ended) {
+    return;
+  }
+  if (state.decoder) {
+    var chunk = state.decoder.end();
+    if (chunk
+        && chunk.length) {
+      state.buffer.push(chunk);
+      state.length += state.objectMode ? 1 : chunk.length;
+    }
+  }
+  state.ended = true;
+
+  if (state.sync) {
+    emitReadable(stream);
+  } else {
+    state.needReadable = false;
+    state.emittedReadable = true;
+    emitReadable_(stream);
+  }
+}
+
+function emitReadable(stream) {
+  var state = stream._readableState;
+  state.needReadable = false;
+  if (!state.emittedReadable) {
+    state.emittedReadable = true;
+    process.nextTick(emitReadable_, stream);
+  }
+}
+
+function emitReadable_(stream) {
+  var state = stream._readableState;
+  if (!state.destroyed
+      && !state.errored
+      && (state.length || state.ended)) {
+    stream.emit('readable');
+    state.emittedReadable = false;
+  }
+  state.needReadable = !state.flowing
+                       && !state.ended
+                       && state.length <= state.highWaterMark;
+  flow(stream);
+}
+
+function maybeReadMore(stream, state) {
+  if (!state.readingMore) {
+    state.readingMore = true;
+    process.nextTick(maybeReadMore_, stream, state);
+  }
+}
+
+function maybeReadMore_(stream, state) {
+  while (!state.reading
+         && !state.ended
+         && (state.length < state.highWaterMark || (state.flowing && state.length === 0))) {
+    var len = state.length;
+    stream.read(0);
+    if (len === state.length) {
+      break;
+    }
+  }
+  state.readingMore = false;
+}
+
+function fromList(n, state) {
+  if (state.length === 0) {
+    return null;
+  }
+
+  var ret;
+  if (state.objectMode) {
+    ret = state.buffer.shift();
+  } else if (!n
+             || n >= state.length) {
+    if (state.decoder) {
+      ret = state.buffer.join('');
+    } else if (state.buffer.length === 1) {
+      ret = state.buffer[0];
+    } else {
+      ret = Buffer.concat(state.buffer, state.length);
+    }
+    state.buffer = [];
+  } else {
+    ret = consume(n, state.buffer, state.decoder);
+  }
+  return ret;
+}
+
+function consume(n, list, hasStrings) {
+  var first = list[0];
+  if (n < first.length) {
+    list[0] = first.slice(n);
+    return first.slice(0, n);
+  } else if (n === first.length) {
+    return list.shift();
+  }
+
+  var parts = [];
+  while (n > 0) {
+    var c = list[0];
+    if (n < c.length) {
+      parts.push(c.slice(0, n));
+      list[0] = c.slice(n);
+      break;
+    }
+    parts.push(list.shift());
+    n -= c.length;
+  }
+  return hasStrings ? parts.join('') : Buffer.concat(parts);
+}
+
+function endReadable(stream) {
+  var state = stream._readableState;
+  if (!state.endEmitted) {
+    state.ended = true;
+    process.nextTick(endReadableNT, state, stream);
+  }
+}
+
+function endReadableNT(state, stream) {
+  if (!state.errored
+      && !state.closeEmitted
+      && !state.endEmitted
+      && state.length === 0) {
+    state.endEmitted = true;
+    stream.emit('end');
+
+    if (stream.writable
+        && stream.allowHalfOpen === false) {
+      process.nextTick(endWritableNT, stream);
+    } else if (state.autoDestroy) {
+      var w = stream._writableState;
+      if (!w
+          || (w.autoDestroy && (w.finished || w.writable === false))) {
+        stream.destroy();
+      }
+    }
+  }
+}
+
+function endWritableNT(stream) {
+  if (stream.writable
+      && !stream.writableEnded
+      && !stream.destroyed) {
+    stream.end();
+  }
+}
+
+Readable.prototype.pipe = function pipe(dest, pipeOpts) {
+  var src = this;
+  var state = this._readableState;
+  state.pipes.push(dest);
+
+  var doEnd = (!pipeOpts || pipeOpts.end !== false)
+              && dest !== process.stdout
+              && dest !== process.stderr;
+  var endFn = doEnd ? onend : unpipe;
+  if (state.endEmitted) {
+    process.nextTick(endFn);
+  } else {
+    src.once('end', endFn);
+  }
+
+  dest.on('unpipe', onunpipe);
+  function onunpipe(readable, unpipeInfo) {
+    if (readable === src
+        && unpipeInfo
+        && unpipeInfo.hasUnpiped === false) {
+      unpipeInfo.hasUnpiped = true;
+      cleanup();
+    }
+  }
+
+  function onend() {
+    dest.end();
+  }
+
+  var ondrain = pipeOnDrain(src);
+  dest.on('drain', ondrain);
+
+  var cleanedUp = false;
+  function cleanup() {
+    dest.removeListener('close', onclose);
+    dest.removeListener('finish', onfinish);
+    dest.removeListener('drain', ondrain);
+    dest.removeListener('error', onerror);
+    dest.removeListener('unpipe', onunpipe);
+    src.removeListener('end', onend);
+    src.removeListener('end', unpipe);
+    src.removeListener('data', ondata);
+    cleanedUp = true;
+
+    if (state.awaitDrain
+        && (!dest._writableState || dest._writableState.needDrain)) {
+      ondrain();
+    }
+  }
+
+  src.on('data', ondata);
+  function ondata(chunk) {
+    if (dest.write(chunk) === false) {
+      if (!cleanedUp
+          && state.pipes.indexOf(dest) !== -1) {
+        state.awaitDrain++;
+      }
+      src.pause();
+    }
+  }
+
+  function onerror(err) {
+    unpipe();
+    dest.removeListener('error', onerror);
+    if (dest.listenerCount('error') === 0) {
+      var s = dest._writableState || dest._readableState;
+      if (s
+          && !s.errorEmitted) {
+        errorOrDestroy(dest, err);
+      } else {
+        dest.emit('error', err);
+      }
+    }
+  }
+  dest.prependListener('error', onerror);
+
+  function onclose() {
+    dest.removeListener('finish', onfinish);
+    unpipe();
+  }
+  dest.once('close', onclose);
+
+  function onfinish() {
+    dest.removeListener('close', onclose);
+    unpipe();
+  }
+  dest.once('finish', onfinish);
+
+  function unpipe() {
+    src.unpipe(dest);
+  }
+
+  dest.emit('pipe', src);
+
+  if (dest.writableNeedDrain === true) {
+    if (state.flowing) {
+      state.awaitDrain++;
+      src.pause();
+    }
+  } else if (!state.flowing) {
+    src.resume();
+  }
+  return dest;
+};
+
+function pipeOnDrain(src) {
+  return function pipeOnDrainFunctionResult() {
+    var state = src._readableState;
+    if (state.awaitDrain) {
+      state.awaitDrain--;
+    }
+    if (state.awaitDrain === 0
+        && src.listenerCount('data') > 0) {
+      src.resume();
+    }
+  };
+}
+
+Readable.prototype.unpipe = function unpipe(dest) {
+  var state = this._readableState;
+  if (state.pipes.length === 0) {
+    return this;
+  }
+
+  if (!dest) {
+    var dests = state.pipes;
+    state.pipes = [];
+    this.pause();
+    for (var i = 0; i < dests.length; i++) {
+      dests[i].emit('unpipe', this, { hasUnpiped: false });
+    }
+    return this;
+  }
+
+  var index = state.pipes.indexOf(dest);
+  if (index === -1) {
+    return this;
+  }
+  state.pipes.splice(index, 1);
+  if (state.pipes.length === 0) {
+    this.pause();
+  }
+  dest.emit('unpipe', this, { hasUnpiped: false });
+  return this;
+};
+
+Readable.prototype.on = Readable.prototype.addListener = function on(ev, fn) {
+  var res = Stream.prototype.on.call(this, ev, fn);
+  var state = this._readableState;
+
+  if (ev === 'data') {
+    state.readableListening = this.listenerCount('readable') > 0;
+    if (state.flowing !== false) {
+      this.resume();
+    }
+  } else if (ev === 'readable') {
+    if (!state.endEmitted
+        && !state.readableListening) {
+      state.readableListening = state.needReadable = true;
+      state.flowing = false;
+      state.emittedReadable = false;
+      if (state.length) {
+        emitReadable(this);
+      } else if (!state.reading) {
+        process.nextTick(nReadingNextTick, this);
+      }
+    }
+  }
+  return res;
+};
+
+Readable.prototype.off = Readable.prototype.removeListener = function removeListener(ev, fn) {
+  var res = Stream.prototype.removeListener.call(this, ev, fn);
+  if (ev === 'readable') {
+    process.nextTick(updateReadableListening, this);
+  }
+  return res;
+};
+
+Readable.prototype.removeAllListeners = function removeAllListeners(ev) {
+  var res = Stream.prototype.removeAllListeners.apply(this, arguments);
+  if (ev === 'readable'
+      || ev === undefined) {
+    process.nextTick(updateReadableListening, this);
+  }
+  return res;
+};
+
+function updateReadableListening(self) {
+  var state = self._readableState;
+  state.readableListening = self.listenerCount('readable') > 0;
+  if (state.resumeScheduled
+      && state.paused === false) {
+    state.flowing = true;
+  } else if (self.listenerCount('data') > 0) {
+    self.resume();
+  } else if (!state.readableListening) {
+    state.flowing = null;
+  }
+}
+
+function nReadingNextTick(self) {
+  self.read(0);
+}
+
+Readable.prototype.resume = function resume() {
+  var state = this._readableState;
+  if (!state.flowing) {
+    state.flowing = !state.readableListening;
+    if (!state.resumeScheduled) {
+      state.resumeScheduled = true;
+      process.nextTick(resume_, this, state);
+    }
+  }
+  state.paused = false;
+  return this;
+};
+
+function resume_(stream, state) {
+  if (!state.reading) {
+    stream.read(0);
+  }
+  state.resumeScheduled = false;
+  stream.emit('resume');
+  flow(stream);
+  if (state.flowing
+      && !state.reading) {
+    stream.read(0);
+  }
+}
+
+Readable.prototype.pause = function pause() {
+  var state = this._readableState;
+  if (state.flowing !== false) {
+    state.flowing = false;
+    this.emit('pause');
+  }
+  state.paused = true;
+  return this;
+};
+
+function flow(stream) {
+  var state = stream._readableState;
+  while (state.flowing
+         && stream.read() !== null) {
+  }
+}
+
+Readable.from = function from(iterable, opts) {
+  var read;
+  if (typeof iterable === 'string'
+      || Buffer.isBuffer(iterable)) {
+    read = function() {
+      this.push(iterable);
+      this.push(null);
+    };
+  } else if (Array.isArray(iterable)) {
+    var i = 0;
+    read = function() {
+      if (i < iterable.length) {
+        var v = iterable[i++];
+        if (v === null) {
+          this.destroy(streamNullValues());
+        } else {
+          this.push(v);
+        }
+      } else {
+        this.push(null);
+      }
+    };
+  } else {
+    throw util.invalidArgType('iterable', 'an instance of Array', iterable);
+  }
+
+  var options = {
+    objectMode: true,
+    highWaterMark: 1,
+  };
+  Object.keys(opts || {}).forEach(function(k) {
+    options[k] = opts[k];
+  });
+  options.read = read;
+  return new Readable(options);
+};
+
+getters(Readable.prototype, {
+  readable: {
+    get: function() {
+      var r = this._readableState;
+      return !!r
+             && r.readable !== false
+             && !r.destroyed
+             && !r.errorEmitted
+             && !r.endEmitted;
+    },
+    set: function(v) {
+      if (this._readableState) {
+        this._readableState.readable = !!v;
+      }
+    },
+  },
+  readableAborted: function() {
+    var r = this._readableState;
+    return !!(r.readable !== false && (r.destroyed || r.errored) && !r.endEmitted);
+  },
+  readableDidRead: function() {
+    return this._readableState.dataEmitted;
+  },
+  readableEncoding: function() {
+    return this._readableState ? this._readableState.encoding : null;
+  },
+  readableEnded: function() {
+    return this._readableState ? this._readableState.endEmitted : false;
+  },
+  readableFlowing: {
+    get: function() {
+      return this._readableState.flowing;
+    },
+    set: function(v) {
+      if (this._readableState) {
+        this._readableState.flowing = v;
+      }
+    },
+  },
+  readableHighWaterMark: function() {
+    return this._readableState.highWaterMark;
+  },
+  readableLength: function() {
+    return this._readableState ? this._readableState.length : undefined;
+  },
+  readableObjectMode: function() {
+    return this._readableState ? this._readableState.objectMode : false;
+  },
+  closed: function() {
+    return this._readableState ? this._readableState.closed : false;
+  },
+  errored: function() {
+    return this._readableState ? this._readableState.errored : null;
+  },
+  destroyed: {
+    get: isDestroyed,
+    set: setDestroyed,
+  },
+});
+
+//
+// Writable
+//
+
+function WritableState(options, stream, isDuplex) {
+  this.objectMode = !!options.objectMode;
+  if (isDuplex) {
+    this.objectMode = this.objectMode || !!options.writableObjectMode;
+  }
+  this.highWaterMark = getHighWaterMark(this, options, 'writableHighWaterMark', isDuplex);
+  this.finalCalled = false;
+  this.needDrain = false;
+  this.ending = false;
+  this.ended = false;
+  this.finished = false;
+  this.destroyed = false;
+  this.decodeStrings = options.decodeStrings !== false;
+  this.defaultEncoding = options.defaultEncoding || 'utf8';
+  this.length = 0;
+  this.writing = false;
+  this.corked = 0;
+  this.sync = true;
+  this.bufferProcessing = false;
+  this.onwrite = onwrite.bind(undefined, stream);
+  this.writecb = null;
+  this.writelen = 0;
+  this.afterWriteTickInfo = null;
+  this.buffered = [];
+  this.bufferedIndex = 0;
+  this.allBuffers = true;
+  this.allNoop = true;
+  this.pendingcb = 0;
+  this.prefinished = false;
+  this.errorEmitted = false;
+  this.emitClose = options.emitClose !== false;
+  this.autoDestroy = options.autoDestroy !== false;
+  this.errored = null;
+  this.closed = false;
+  this.closeEmitted = false;
+  this.onFinished = [];
+}
+
+WritableState.prototype.getBuffer = function getBuffer() {
+  return this.buffered.slice(this.bufferedIndex);
+};
+
+function resetBuffer(state) {
+  state.buffered = [];
+  state.bufferedIndex = 0;
+  state.allBuffers = true;
+  state.allNoop = true;
+}
+
+function Writable(options) {
+  if (!(this instanceof Writable)
+      && !(this instanceof Duplex)) {
+    return new Writable(options);
+  }
+
+  options = options || {};
+  hidden(this, '_writableState', new WritableState(options, this, this instanceof Duplex));
+  if (typeof options.write === 'function') {
+    this._write = options.write;
+  }
+  if (typeof options.writev === 'function') {
+    this._writev = options.writev;
+  }
+  if (typeof options.destroy === 'function') {
+    this._destroy = options.destroy;
+  }
+  if (typeof options.final === 'function') {
+    this._final = options.final;
+  }
+  Stream.call(this, options);
+}
+
+inherits(Writable, Stream);
+
+Writable.WritableState = WritableState;
+
+Writable.prototype.pipe = function pipe() {
+  errorOrDestroy(this, makeError(Error, 'ERR_STREAM_CANNOT_PIPE', 'Cannot pipe, not readable'));
+};
+
+Writable.prototype.write = function write(chunk, encoding, cb) {
+  return writeImpl(this, chunk, encoding, cb) === true;
+};
+
+function writeImpl(stream, chunk, encoding, cb) {
+  var state = stream._writableState;
+  if (typeof encoding === 'function') {
+    cb = encoding;
+    encoding = state.defaultEncoding;
+  } else {
+    if (!encoding) {
+      encoding = state.defaultEncoding;
+    } else if (encoding !== 'buffer'
+               && !Buffer.isEncoding(encoding)) {
+      throw util.unknownEncoding(encoding);
+    }
+    if (typeof cb !== 'function') {
+      cb = nop;
+    }
+  }
+
+  if (chunk === null) {
+    throw streamNullValues();
+  } else if (!state.objectMode) {
+    if (typeof chunk === 'string') {
+      if (state.decodeStrings) {
+        chunk = Buffer.from(chunk, encoding);
+        encoding = 'buffer';
+      }
+    } else if (Buffer.isBuffer(chunk)) {
+      encoding = 'buffer';
+    } else {
+      throw invalidChunk(chunk);
+    }
+  }
+
+  var err;
+  if (state.ending) {
+    err = makeError(Error, 'ERR_STREAM_WRITE_AFTER_END', 'write after end');
+  } else if (state.destroyed) {
+    err = streamDestroyed('write');
+  }
+  if (err) {
+    process.nextTick(cb, err);
+    errorOrDestroy(stream, err, true);
+    return err;
+  }
+  state.pendingcb++;
+  return writeOrBuffer(stream, state, chunk, encoding, cb);
+}
+
+function writeOrBuffer(stream, state, chunk, encoding, callback) {
+  var len = state.objectMode ? 1 : chunk.length;
+  state.length += len;
+  var ret = state.length < state.highWaterMark;
+  if (!ret) {
+    state.needDrain = true;
+  }
+
+  if (state.writing
+      || state.corked
+      || state.errored) {
+    state.buffered.push({
+      chunk: chunk,
+      encoding: encoding,
+      callback: callback,
+    });
+    if (state.allBuffers
+        && encoding !== 'buffer') {
+      state.allBuffers = false;
+    }
+    if (state.allNoop
+        && callback !== nop) {
+      state.allNoop = false;
+    }
+  } else {
+    state.writelen = len;
+    state.writecb = callback;
+    state.writing = true;
+    state.sync = true;
+    stream._write(chunk, encoding, state.onwrite);
+    state.sync = false;
+  }
+  return ret
+         && !state.errored
+         && !state.destroyed;
+}
+
+function doWrite(stream, state, writev, len, chunk, encoding, cb) {
+  state.writelen = len;
+  state.writecb = cb;
+  state.writing = true;
+  state.sync = true;
+  if (state.destroyed) {
+    state.onwrite(streamDestroyed('write'));
+  } else if (writev) {
+    stream._writev(chunk, state.onwrite);
+  } else {
+    stream._write(chunk, encoding, state.onwrite);
+  }
+  state.sync = false;
+}
+
+function onwriteError(stream, state, err, cb) {
+  --state.pendingcb;
+  cb(err);
+  errorBuffer(state);
+  errorOrDestroy(stream, err);
+}
+
+function onwrite(stream, err) {
+  var state = stream._writableState;
+  var sync = state.sync;
+  var cb = state.writecb;
+  if (typeof cb !== 'function') {
+    errorOrDestroy(stream, multipleCallback());
+    return;
+  }
+
+  state.writing = false;
+  state.writecb = null;
+  state.length -= state.writelen;
+  state.writelen = 0;
+
+  if (err) {
+    if (!state.errored) {
+      state.errored = err;
+    }
+    if (stream._readableState
+        && !stream._readableState.errored) {
+      stream._readableState.errored = err;
+    }
+    if (sync) {
+      process.nextTick(onwriteError, stream, state, err, cb);
+    } else {
+      onwriteError(stream, state, err, cb);
+    }
+  } else {
+    if (state.buffered.length > state.bufferedIndex) {
+      clearBuffer(stream, state);
+    }
+    if (sync) {
+      if (state.afterWriteTickInfo !== null
+          && state.afterWriteTickInfo.cb === cb) {
+        state.afterWriteTickInfo.count++;
+      } else {
+        state.afterWriteTickInfo = {
+          count: 1,
+          cb: cb,
+          stream: stream,
+          state: state,
+        };
+        process.nextTick(afterWriteTick, state.afterWriteTickInfo);
+      }
+    } else {
+      afterWrite(stream, state, 1, cb);
+    }
+  }
+}
+
+function afterWriteTick(info) {
+  info.state.afterWriteTickInfo = null;
+  afterWrite(info.stream, info.state, info.count, info.cb);
+}
+
+function afterWrite(stream, state, count, cb) {
+  if (!state.ending
+      && !stream.destroyed
+      && state.length === 0
+      && state.needDrain) {
+    state.needDrain = false;
+    stream.emit('drain');
+  }
+  while (count-- > 0) {
+    state.pendingcb--;
+    cb();
+  }
+  if (state.destroyed) {
+    errorBuffer(state);
+  }
+  finishMaybe(stream, state);
+}
+
+function errorBuffer(state) {
+  if (state.writing) {
+    return;
+  }
+
+  for (var i = state.bufferedIndex; i < state.buffered.length; i++) {
+    var b = state.buffered[i];
+    state.length -= state.objectMode ? 1 : b.chunk.length;
+    b.callback(state.errored || streamDestroyed('write'));
+  }
+  var fns = state.onFinished.splice(0);
+  for (i = 0; i < fns.length; i++) {
+    fns[i](state.errored || streamDestroyed('end'));
+  }
+  resetBuffer(state);
+}
+
+function clearBuffer(stream, state) {
+  if (state.corked
+      || state.bufferProcessing
+      || state.destroyed) {
+    return;
+  }
+
+  var buffered = state.buffered;
+  var i = state.bufferedIndex;
+  var n = buffered.length - i;
+  if (!n) {
+    return;
+  }
+
+  state.bufferProcessing = true;
+  if (n > 1
+      && stream._writev) {
+    state.pendingcb -= n - 1;
+    var callback = state.allNoop ? nop : function(err) {
+      for (var j = i; j < buffered.length; j++) {
+        buffered[j].callback(err);
+      }
+    };
+    var chunks = buffered.slice(i);
+    chunks.allBuffers = state.allBuffers;
+    doWrite(stream, state, true, state.length, chunks, '', callback);
+    resetBuffer(state);
+  } else {
+    do {
+      var b = buffered[i];
+      buffered[i++] = null;
+      doWrite(stream, state, false, state.objectMode ? 1 : b.chunk.length, b.chunk, b.encoding, b.callback);
+    } while (i < buffered.length
+             && !state.writing);
+
+    if (i === buffered.length) {
+      resetBuffer(state);
+    } else if (i > 256) {
+      buffered.splice(0, i);
+      state.bufferedIndex = 0;
+    } else {
+      state.bufferedIndex = i;
+    }
+  }
+  state.bufferProcessing = false;
+}
+
+Writable.prototype._write = function _write(chunk, encoding, cb) {
+  if (this._writev) {
+    this._writev([{ chunk: chunk, encoding: encoding }], cb);
+  } else {
+    throw methodNotImplemented('_write()');
+  }
+};
+
+Writable.prototype._writev = null;
+
+Writable.prototype.cork = function cork() {
+  this._writableState.corked++;
+};
+
+Writable.prototype.uncork = function uncork() {
+  var state = this._writableState;
+  if (state.corked) {
+    state.corked--;
+    if (!state.writing) {
+      clearBuffer(this, state);
+    }
+  }
+};
+
+Writable.prototype.setDefaultEncoding = function setDefaultEncoding(encoding) {
+  if (typeof encoding === 'string') {
+    encoding = encoding.toLowerCase();
+  }
+  if (!Buffer.isEncoding(encoding)) {
+    throw util.unknownEncoding(encoding);
+  }
+  this._writableState.defaultEncoding = encoding;
+  return this;
+};
+
+Writable.prototype.end = function end(chunk, encoding, cb) {
+  var state = this._writableState;
+  if (typeof chunk === 'function') {
+    cb = chunk;
+    chunk = null;
+    encoding = null;
+  } else if (typeof encoding === 'function') {
+    cb = encoding;
+    encoding = null;
+  }
+
+  var err;
+  if (chunk !== null
+      && chunk !== undefined) {
+    var ret = writeImpl(this, chunk, encoding);
+    if (ret instanceof Error) {
+      err = ret;
+    }
+  }
+  if (state.corked) {
+    state.corked = 1;
+    this.uncork();
+  }
+
+  if (err) {
+    // already reported
+  } else if (!state.errored
+             && !state.ending) {
+    state.ending = true;
+    finishMaybe(this, state, true);
+    state.ended = true;
+  } else if (state.finished) {
+    err = streamAlreadyFinished('end');
+  } else if (state.destroyed) {
+    err = streamDestroyed('end');
+  }
+
+  if (typeof cb === 'function') {
+    if (err
+        || state.finished) {
+      process.nextTick(cb, err);
+    } else {
+      state.onFinished.push(cb);
+    }
+  }
+  return this;
+};
+
+function needFinish(state) {
+  return state.ending
+         && !state.destroyed
+         && state.length === 0
+         && !state.errored
+         && state.buffered.length === 0
+         && !state.finished
+         && !state.writing
+         && !state.errorEmitted
+         && !state.closeEmitted;
+}
+
+function callFinal(stream, state) {
+  var called = false;
+  function onFinish(err) {
+    if (called) {
+      errorOrDestroy(stream, multipleCallback());
+      return;
+    }
+    called = true;
+
+    state.pendingcb--;
+    if (err) {
+      var fns = state.onFinished.splice(0);
+      for (var i = 0; i < fns.length; i++) {
+        fns[i](err);
+      }
+      errorOrDestroy(stream, err, state.sync);
+    } else if (needFinish(state)) {
+      state.prefinished = true;
+      stream.emit('prefinish');
+      state.pendingcb++;
+      process.nextTick(finish, stream, state);
+    }
+  }
+
+  state.sync = true;
+  state.pendingcb++;
+  try {
+    stream._final(onFinish);
+  } catch (e) {
+    onFinish(e);
+  }
+  state.sync = false;
+}
+
+function prefinish(stream, state) {
+  if (!state.prefinished
+      && !state.finalCalled) {
+    if (typeof stream._final === 'function'
+        && !state.destroyed) {
+      state.finalCalled = true;
+      callFinal(stream, state);
+    } else {
+      state.prefinished = true;
+      stream.emit('prefinish');
+    }
+  }
+}
+
+function finishMaybe(stream, state, sync) {
+  if (needFinish(state)) {
+    prefinish(stream, state);
+    if (state.pendingcb === 0) {
+      if (sync) {
+        state.pendingcb++;
+        process.nextTick(function() {
+          if (needFinish(state)) {
+            finish(stream, state);
+          } else {
+            state.pendingcb--;
+          }
+        });
+      } else if (needFinish(state)) {
+        state.pendingcb++;
+        finish(stream, state);
+      }
+    }
+  }
+}
+
+function finish(stream, state) {
+  state.pendingcb--;
+  state.finished = true;
+
+  var fns = state.onFinished.splice(0);
+  for (var i = 0; i < fns.length; i++) {
+    fns[i]();
+  }
+  stream.emit('finish');
+
+  if (state.autoDestroy) {
+    var r = stream._readableState;
+    if (!r
+        || (r.autoDestroy && (r.endEmitted || r.readable === false))) {
+      stream.destroy();
+    }
+  }
+}
+
+Writable.prototype.destroy = function(err, cb) {
+  var state = this._writableState;
+  if (!state.destroyed
+      && (state.bufferedIndex < state.buffered.length || state.onFinished.length)) {
+    process.nextTick(errorBuffer, state);
+  }
+  destroy.call(this, err, cb);
+  return this;
+};
+
+Writable.prototype._destroy = function _destroy(err, cb) {
+  cb(err);
+};
+
+getters(Writable.prototype, {
+  writable: {
+    get: function() {
+      var w = this._writableState;
+      return !!w
+             && w.writable !== false
+             && !w.destroyed
+             && !w.errored
+             && !w.ending
+             && !w.ended;
+    },
+    set: function(v) {
+      if (this._writableState) {
+        this._writableState.writable = !!v;
+      }
+    },
+  },
+  writableFinished: function() {
+    return this._writableState ? this._writableState.finished : false;
+  },
+  writableObjectMode: function() {
+    return this._writableState ? this._writableState.objectMode : false;
+  },
+  writableEnded: function() {
+    return this._writableState ? this._writableState.ending : false;
+  },
+  writableNeedDrain: function() {
+    var w = this._writableState;
+    return w ? !w.destroyed && !w.ending && w.needDrain : false;
+  },
+  writableHighWaterMark: function() {
+    return this._writableState && this._writableState.highWaterMark;
+  },
+  writableCorked: function() {
+    return this._writableState ? this._writableState.corked : 0;
+  },
+  writableLength: function() {
+    return this._writableState && this._writableState.length;
+  },
+  closed: function() {
+    return this._writableState ? this._writableState.closed : false;
+  },
+  errored: function() {
+    return this._writableState ? this._writableState.errored : null;
+  },
+  destroyed: {
+    get: isDestroyed,
+    set: setDestroyed,
+  },
+});
+
+//
+// Duplex
+//
+
+function Duplex(options) {
+  if (!(this instanceof Duplex)) {
+    return new Duplex(options);
+  }
+
+  Readable.call(this, options);
+  Writable.call(this, options);
+
+  if (options) {
+    this.allowHalfOpen = options.allowHalfOpen !== false;
+    if (options.readable === false) {
+      this._readableState.readable = false;
+      this._readableState.ended = true;
+      this._readableState.endEmitted = true;
+    }
+    if (options.writable === false) {
+      this._writableState.writable = false;
+      this._writableState.ending = true;
+      this._writableState.ended = true;
+      this._writableState.finished = true;
+    }
+  } else {
+    this.allowHalfOpen = true;
+  }
+}
+
+inherits(Duplex, Readable);
+
+Object.getOwnPropertyNames(Writable.prototype).forEach(function(k) {
+  if (!Object.prototype.hasOwnProperty.call(Duplex.prototype, k)
+      && !Object.prototype.hasOwnProperty.call(Readable.prototype, k)) {
+    Object.defineProperty(Duplex.prototype, k, Object.getOwnPropertyDescriptor(Writable.prototype, k));
+  }
+});
+
+getters(Duplex.prototype, {
+  closed: function() {
+    return this._readableState.closed
+           && this._writableState.closed;
+  },
+  errored: function() {
+    return this._readableState.errored
+           || this._writableState.errored;
+  },
+});
+
+//
+// Transform
+//
+
+function Transform(options) {
+  if (!(this instanceof Transform)) {
+    return new Transform(options);
+  }
+
+  Duplex.call(this, options);
+  this._readableState.sync = false;
+  hidden(this, '_transformCallback', null);
+
+  if (options) {
+    if (typeof options.transform === 'function') {
+      this._transform = options.transform;
+    }
+    if (typeof options.flush === 'function') {
+      this._flush = options.flush;
+    }
+  }
+  this.on('prefinish', transformPrefinish);
+}
+
+inherits(Transform, Duplex);
+
+function transformFinal(cb) {
+  var self = this;
+  if (typeof this._flush === 'function'
+      && !this.destroyed) {
+    this._flush(function(err, data) {
+      if (err) {
+        if (cb) {
+          cb(err);
+        } else {
+          self.destroy(err);
+        }
+        return;
+      }
+      if (data !== null
+          && data !== undefined) {
+        self.push(data);
+      }
+      self.push(null);
+      if (cb) {
+        cb();
+      }
+    });
+  } else {
+    this.push(null);
+    if (cb) {
+      cb();
+    }
+  }
+}
+
+function transformPrefinish() {
+  if (this._final !== transformFinal) {
+    transformFinal.call(this);
+  }
+}
+
+Transform.prototype._final = transformFinal;
+
+Transform.prototype._transform = function _transform(chunk, encoding, cb) {
+  throw methodNotImplemented('_transform()');
+};
+
+Transform.prototype._write = function _write(chunk, encoding, callback) {
+  var self = this;
+  var r = this._readableState;
+  var w = this._writableState;
+  var length = r.length;
+
+  this._transform(chunk, encoding, function(err, val) {
+    if (err) {
+      callback(err);
+      return;
+    }
+    if (val !== null
+        && val !== undefined) {
+      self.push(val);
+    }
+    if (w.ended
+        || length === r.length
+        || r.length < r.highWaterMark) {
+      callback();
+    } else {
+      self._transformCallback = callback;
+    }
+  });
+};
+
+Transform.prototype._read = function _read() {
+  if (this._transformCallback) {
+    var cb = this._transformCallback;
+    this._transformCallback = null;
+    cb();
+  }
+};
+
+//
+// PassThrough
+//
+
+function PassThrough(options) {
+  if (!(this instanceof PassThrough)) {
+    return new PassThrough(options);
+  }
+
+  Transform.call(this, options);
+}
+
+inherits(PassThrough, Transform);
+
+PassThrough.prototype._transform = function _transform(chunk, encoding, cb) {
+  cb(null, chunk);
+};
+
+//
+// finished
+//
+
+function isReadableStream(stream) {
+  return !!stream
+         && typeof stream.pipe === 'function'
+         && typeof stream.on === 'function'
+         && (!stream._writableState || (stream._readableState && stream._readableState.readable !== false))
+         && (!stream._writableState || stream._readableState);
+}
+
+function isWritableStream(stream) {
+  return !!stream
+         && typeof stream.write === 'function'
+         && typeof stream.on === 'function'
+         && (!stream._readableState || (stream._writableState && stream._writableState.writable !== false));
+}
+
+function finished(stream, options, callback) {
+  if (arguments.length === 2) {
+    callback = options;
+    options = {};
+  } else if (options === null
+             || options === undefined) {
+    options = {};
+  }
+  validateFunction(callback, 'callback');
+  callback = once(callback);
+
+  var readable = options.readable !== undefined ? options.readable : isReadableStream(stream);
+  var writable = options.writable !== undefined ? options.writable : isWritableStream(stream);
+  var r = stream._readableState;
+  var w = stream._writableState;
+
+  var s = w || r;
+  var willEmitClose = !!s
+                      && s.autoDestroy
+                      && s.emitClose
+                      && !s.closed
+                      && isReadableStream(stream) === readable
+                      && isWritableStream(stream) === writable;
+  var readableEnded = !!(r && r.endEmitted);
+  var writableFinished = !!(w && w.finished);
+
+  function onfinish() {
+    writableFinished = true;
+    if (stream.destroyed) {
+      willEmitClose = false;
+    }
+    if (willEmitClose
+        && (!stream.readable || readable)) {
+      return;
+    }
+    if (!readable
+        || readableEnded) {
+      callback.call(stream);
+    }
+  }
+
+  function onend() {
+    readableEnded = true;
+    if (stream.destroyed) {
+      willEmitClose = false;
+    }
+    if (willEmitClose
+        && (!stream.writable || writable)) {
+      return;
+    }
+    if (!writable
+        || writableFinished) {
+      callback.call(stream);
+    }
+  }
+
+  function onerror(err) {
+    callback.call(stream, err);
+  }
+
+  function onclose() {
+    var err = (w && w.errored) || (r && r.errored);
+    if (err) {
+      return callback.call(stream, err);
+    }
+    if ((readable && !readableEnded)
+        || (writable && !writableFinished)) {
+      return callback.call(stream, streamPrematureClose());
+    }
+    callback.call(stream);
+  }
+
+  stream.on('end', onend);
+  stream.on('finish', onfinish);
+  if (options.error !== false) {
+    stream.on('error', onerror);
+  }
+  stream.on('close', onclose);
+
+  if ((w && w.closeEmitted)
+      || (r && r.closeEmitted)) {
+    process.nextTick(onclose);
+  } else if ((!readable || readableEnded)
+             && (!writable || writableFinished)) {
+    process.nextTick(function() {
+      callback.call(stream);
+    });
+  }
+
+  return function cleanup() {
+    callback = nop;
+    stream.removeListener('end', onend);
+    stream.removeListener('finish', onfinish);
+    stream.removeListener('error', onerror);
+    stream.removeListener('close', onclose);
+  };
+}
+
+//
+// pipeline
+//
+
+function pipeline() {
+  var streams = Array.prototype.slice.call(arguments);
+  var callback = streams.pop();
+  validateFunction(callback, 'streams[stream.length - 1]');
+  if (streams.length === 1
+      && Array.isArray(streams[0])) {
+    streams = streams[0];
+  }
+  if (streams.length < 2) {
+    throw makeError(TypeError, 'ERR_MISSING_ARGS', 'The "streams" argument must be specified');
+  }
+
+  var error;
+  var destroys = [];
+  var finishCount = streams.length;
+
+  function finish(err) {
+    if (err
+        && (!error || error.code === 'ERR_STREAM_PREMATURE_CLOSE')) {
+      error = err;
+    }
+    var final = --finishCount === 0;
+    if (!error
+        && !final) {
+      return;
+    }
+    while (destroys.length) {
+      destroys.shift()(error);
+    }
+    if (final) {
+      process.nextTick(callback, error);
+    }
+  }
+
+  streams.forEach(function(stream, i) {
+    var reading = i < streams.length - 1;
+    var writing = i > 0;
+    var done = false;
+    finished(stream, { readable: reading, writable: writing }, function(err) {
+      done = !err;
+      finish(err);
+    });
+    destroys.push(function(err) {
+      if (!done) {
+        done = true;
+        if (typeof stream.destroy === 'function') {
+          stream.destroy(err || streamDestroyed('pipe'));
+        }
+      }
+    });
+    if (i > 0) {
+      streams[i - 1].pipe(stream);
+    }
+  });
+  return streams[streams.length - 1];
+}
+
+//
+// host streams
+//
+
+function fromReader(handle) {
+  var r = new Readable({
+    read: function(n) {
+      var self = this;
+      handle.read(n, function(err, buf) {
+        if (err) {
+          self.destroy(err);
+        } else {
+          self.push(buf);
+        }
+      });
+    },
+    destroy: function(err, cb) {
+      handle.close();
+      cb(err);
+    },
+  });
+  // a paused stream does not keep the event loop alive
+  r.on('pause', function() {
+    handle.unref();
+  });
+  r.on('resume', function() {
+    handle.ref();
+  });
+  return r;
+}
+
+function fromWriter(handle) {
+  return new Writable({
+    write: function(chunk, encoding, cb) {
+      handle.write(chunk, cb);
+    },
+    final: function(cb) {
+      handle.close(cb);
+    },
+    destroy: function(err, cb) {
+      handle.close(function() {
+        cb(err);
+      });
+    },
+  });
+}
+
+binding.setup(fromReader, fromWriter);
+
+module.exports = Stream;
+Stream.Stream = Stream;
+Stream.Readable = Readable;
+Stream.Writable = Writable;
+Stream.Duplex = Duplex;
+Stream.Transform = Transform;
+Stream.PassThrough = PassThrough;
+Stream.finished = finished;
+Stream.pipeline = pipeline;
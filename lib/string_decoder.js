@@ -0,0 +1,266 @@
+//
+// otto.module :: string_decoder.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var Buffer = require('./buffer').Buffer;
+var util = require('./internal/util');
+
+function normalizeEncoding(enc) {
+  var s = util.normalizeEncoding(enc);
+  if (s === undefined) {
+    throw util.unknownEncoding(enc);
+  }
+  return s;
+}
+
+function StringDecoder(encoding) {
+  this.encoding = normalizeEncoding(encoding);
+  switch (this.encoding) {
+  case 'utf8':
+    this.fillLast = utf8FillLast;
+    this.text = utf8Text;
+    this.end = utf8End;
+    break;
+  case 'utf16le':
+    this.fillLast = utf16FillLast;
+    this.text = utf16Text;
+    this.end = utf16End;
+    break;
+  case 'base64':
+  case 'base64url':
+    this.text = base64Text;
+    this.end = base64End;
+    break;
+  default:
+    this.write = simpleWrite;
+    this.end = simpleEnd;
+    return;
+  }
+  this.lastNeed = 0;
+  this.lastTotal = 0;
+  this.lastChar = Buffer.alloc(4);
+}
+
+exports.StringDecoder = StringDecoder;
+
+StringDecoder.prototype.write = function write(buf) {
+  if (typeof buf === 'string') {
+    return buf;
+  } else if (!Buffer.isBuffer(buf)) {
+    buf = Buffer.from(buf);
+  }
+  if (buf.length === 0) {
+    return '';
+  }
+
+  var r;
+  var i = 0;
+  if (this.lastNeed) {
+    r = this.fillLast(buf);
+    if (r === undefined) {
+      return '';
+    }
+    i = this.lastNeed;
+    this.lastNeed = 0;
+  }
+  if (i < buf.length) {
+    return r ? r + this.text(buf, i) : this.text(buf, i);
+  }
+  return r || '';
+};
+
+StringDecoder.prototype.fillLast = function fillLast(buf) {
+  if (this.lastNeed <= buf.length) {
+    buf.copy(this.lastChar, this.lastTotal - this.lastNeed, 0, this.lastNeed);
+    return this.lastChar.toString(this.encoding, 0, this.lastTotal);
+  }
+  buf.copy(this.lastChar, this.lastTotal - this.lastNeed, 0, buf.length);
+  this.lastNeed -= buf.length;
+};
+
+// returns the number of bytes of a UTF-8 sequence starting with the byte, 0
+// for a single byte, -1 for a continuation byte, or -2 for an invalid byte
+function utf8CheckByte(c) {
+  if (c <= 0x7f) {
+    return 0;
+  } else if (c >> 5 === 0x06) {
+    return 2;
+  } else if (c >> 4 === 0x0e) {
+    return 3;
+  } else if (c >> 3 === 0x1e) {
+    return 4;
+  }
+  return c >> 6 === 0x02 ? -1 : -2;
+}
+
+function utf8CheckIncomplete(self, buf, i) {
+  var j = buf.length - 1;
+  if (j < i) {
+    return 0;
+  }
+  var n = utf8CheckByte(buf[j]);
+  if (n >= 0) {
+    if (n > 0) {
+      self.lastNeed = n - 1;
+    }
+    return n;
+  } else if (--j < i
+             || n === -2) {
+    return 0;
+  }
+  n = utf8CheckByte(buf[j]);
+  if (n >= 0) {
+    if (n > 0) {
+      self.lastNeed = n - 2;
+    }
+    return n;
+  } else if (--j < i
+             || n === -2) {
+    return 0;
+  }
+  n = utf8CheckByte(buf[j]);
+  if (n >= 0) {
+    if (n === 2) {
+      n = 0;
+    } else if (n > 0) {
+      self.lastNeed = n - 3;
+    }
+    return n;
+  }
+  return 0;
+}
+
+function utf8CheckExtraBytes(self, buf) {
+  for (var i = 0; i < self.lastNeed && i < buf.length; i++) {
+    if ((buf[i] & 0xc0) !== 0x80) {
+      self.lastNeed = i;
+      return '\ufffd';
+    }
+  }
+}
+
+function utf8FillLast(buf) {
+  var p = this.lastTotal - this.lastNeed;
+  var r = utf8CheckExtraBytes(this, buf);
+  if (r !== undefined) {
+    return r;
+  } else if (this.lastNeed <= buf.length) {
+    buf.copy(this.lastChar, p, 0, this.lastNeed);
+    return this.lastChar.toString(this.encoding, 0, this.lastTotal);
+  }
+  buf.copy(this.lastChar, p, 0, buf.length);
+  this.lastNeed -= buf.length;
+}
+
+function utf8Text(buf, i) {
+  var total = utf8CheckIncomplete(this, buf, i);
+  if (!this.lastNeed) {
+    return buf.toString('utf8', i, buf.length);
+  }
+  this.lastTotal = total;
+  var end = buf.length - (total - this.lastNeed);
+  buf.copy(this.lastChar, 0, end, buf.length);
+  return buf.toString('utf8', i, end);
+}
+
+function utf8End(buf) {
+  var r = buf && buf.length ? this.write(buf) : '';
+  if (this.lastNeed) {
+    this.lastNeed = 0;
+    return r + '\ufffd';
+  }
+  return r;
+}
+
+function utf16Text(buf, i) {
+  var end = buf.length;
+  var c;
+  if ((end - i) % 2 === 0) {
+    // keep a high surrogate for the next write
+    c = buf[end - 2] | (buf[end - 1] << 8);
+    if (0xd800 <= c && c <= 0xdbff) {
+      this.lastNeed = 2;
+      this.lastTotal = 4;
+      this.lastChar[0] = buf[end - 2];
+      this.lastChar[1] = buf[end - 1];
+      return buf.toString('utf16le', i, end - 2);
+    }
+    return buf.toString('utf16le', i, end);
+  }
+  if (end - i >= 3) {
+    // keep a high surrogate with the odd byte
+    c = buf[end - 3] | (buf[end - 2] << 8);
+    if (0xd800 <= c && c <= 0xdbff) {
+      this.lastNeed = 1;
+      this.lastTotal = 4;
+      buf.copy(this.lastChar, 0, end - 3, end);
+      return buf.toString('utf16le', i, end - 3);
+    }
+  }
+  this.lastNeed = 1;
+  this.lastTotal = 2;
+  this.lastChar[0] = buf[end - 1];
+  return buf.toString('utf16le', i, end - 1);
+}
+
+function utf16FillLast(buf) {
+  if (this.lastTotal === 2) {
+    var c = this.lastChar[0] | (buf[0] << 8);
+    if (0xd800 <= c && c <= 0xdbff) {
+      // wait for the low surrogate
+      this.lastNeed = 3;
+      this.lastTotal = 4;
+    }
+  }
+  return StringDecoder.prototype.fillLast.call(this, buf);
+}
+
+function utf16End(buf) {
+  var r = buf && buf.length ? this.write(buf) : '';
+  if (this.lastNeed) {
+    var end = this.lastTotal - this.lastNeed;
+    this.lastNeed = 0;
+    return r + this.lastChar.toString('utf16le', 0, end);
+  }
+  return r;
+}
+
+function base64Text(buf, i) {
+  var n = (buf.length - i) % 3;
+  if (n === 0) {
+    return buf.toString(this.encoding, i, buf.length);
+  }
+  this.lastNeed = 3 - n;
+  this.lastTotal = 3;
+  buf.copy(this.lastChar, 0, buf.length - n, buf.length);
+  return buf.toString(this.encoding, i, buf.length - n);
+}
+
+function base64End(buf) {
+  var r = buf && buf.length ? this.write(buf) : '';
+  if (this.lastNeed) {
+    var end = 3 - this.lastNeed;
+    this.lastNeed = 0;
+    return r + this.lastChar.toString(this.encoding, 0, end);
+  }
+  return r;
+}
+
+function simpleWrite(buf) {
+  if (typeof buf === 'string') {
+    return buf;
+  } else if (!Buffer.isBuffer(buf)) {
+    buf = Buffer.from(buf);
+  }
+  return buf.toString(this.encoding);
+}
+
+function simpleEnd(buf) {
+  return buf && buf.length ? this.write(buf) : '';
+}
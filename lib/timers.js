@@ -0,0 +1,198 @@
+//
+// otto.module :: timers.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('timers');
+var util = require('./internal/util');
+
+var TIMEOUT_MAX = 2147483647;
+
+function validateFunction(v, name) {
+  if (typeof v !== 'function') {
+    throw util.invalidArgType(name, 'of type function', v);
+  }
+}
+
+function hidden(o, k, v) {
+  Object.defineProperty(o, k, {
+    value: v,
+    writable: true,
+    configurable: true,
+  });
+}
+
+binding.setupTimers(function processTimer(timer) {
+  var id = timer._id;
+  try {
+    var args = timer._timerArgs;
+    if (args === undefined) {
+      timer._onTimeout();
+    } else {
+      timer._onTimeout.apply(timer, args);
+    }
+  } finally {
+    if (timer._repeat === null
+        && timer._id === id) {
+      timer._destroyed = true;
+    }
+  }
+}, function processImmediate(immediate) {
+  immediate._destroyed = true;
+  var argv = immediate._argv;
+  if (argv === undefined) {
+    immediate._onImmediate();
+  } else {
+    immediate._onImmediate.apply(immediate, argv);
+  }
+});
+
+//
+// Timeout
+//
+
+function Timeout(callback, after, args, isRepeat) {
+  after *= 1;
+  if (!(after >= 1 && after <= TIMEOUT_MAX)) {
+    if (after > TIMEOUT_MAX) {
+      process.emitWarning(after + ' does not fit into a 32-bit signed integer.\nTimeout duration was set to 1.', 'TimeoutOverflowWarning');
+    }
+    after = 1;
+  }
+  this._idleTimeout = after;
+  this._onTimeout = callback;
+  this._timerArgs = args;
+  this._repeat = isRepeat ? after : null;
+  this._destroyed = false;
+  hidden(this, '_refed', true);
+  hidden(this, '_id', binding.start(this, after, isRepeat, true));
+}
+
+Timeout.prototype.refresh = function refresh() {
+  if (this._idleTimeout !== -1) {
+    binding.clear(this._id);
+    this._id = binding.start(this, this._idleTimeout, this._repeat !== null, this._refed);
+    this._destroyed = false;
+  }
+  return this;
+};
+
+Timeout.prototype.ref = function ref() {
+  this._refed = true;
+  binding.ref(this._id, true);
+  return this;
+};
+
+Timeout.prototype.unref = function unref() {
+  this._refed = false;
+  binding.ref(this._id, false);
+  return this;
+};
+
+Timeout.prototype.hasRef = function hasRef() {
+  return this._refed;
+};
+
+Timeout.prototype.close = function close() {
+  clearTimeout(this);
+  return this;
+};
+
+Timeout.prototype.valueOf = function valueOf() {
+  return this._id;
+};
+
+//
+// Immediate
+//
+
+function Immediate(callback, args) {
+  this._onImmediate = callback;
+  this._argv = args;
+  this._destroyed = false;
+  hidden(this, '_refed', true);
+  hidden(this, '_id', binding.scheduleImmediate(this, true));
+}
+
+Immediate.prototype.ref = function ref() {
+  this._refed = true;
+  binding.ref(this._id, true);
+  return this;
+};
+
+Immediate.prototype.unref = function unref() {
+  this._refed = false;
+  binding.ref(this._id, false);
+  return this;
+};
+
+Immediate.prototype.hasRef = function hasRef() {
+  return this._refed;
+};
+
+//
+// API
+//
+
+function clear(timer) {
+  if (timer) {
+    if (typeof timer === 'object') {
+      if (!timer._destroyed) {
+        timer._destroyed = true;
+        binding.clear(timer._id);
+      }
+      if (timer instanceof Timeout) {
+        timer._idleTimeout = -1;
+      }
+    } else if (typeof timer === 'number'
+               || typeof timer === 'string') {
+      binding.clear(+timer);
+    }
+  }
+}
+
+function setTimeout(callback, after) {
+  validateFunction(callback, 'callback');
+  var args = arguments.length > 2 ? Array.prototype.slice.call(arguments, 2) : undefined;
+  return new Timeout(callback, after, args, false);
+}
+
+function clearTimeout(timer) {
+  clear(timer);
+}
+
+function setInterval(callback, repeat) {
+  validateFunction(callback, 'callback');
+  var args = arguments.length > 2 ? Array.prototype.slice.call(arguments, 2) : undefined;
+  return new Timeout(callback, repeat, args, true);
+}
+
+function clearInterval(timer) {
+  clear(timer);
+}
+
+function setImmediate(callback) {
+  validateFunction(callback, 'callback');
+  var args = arguments.length > 1 ? Array.prototype.slice.call(arguments, 1) : undefined;
+  return new Immediate(callback, args);
+}
+
+function clearImmediate(immediate) {
+  if (immediate
+      && !immediate._destroyed) {
+    immediate._destroyed = true;
+    binding.clear(immediate._id);
+  }
+}
+
+exports.setTimeout = setTimeout;
+exports.clearTimeout = clearTimeout;
+exports.setInterval = setInterval;
+exports.clearInterval = clearInterval;
+exports.setImmediate = setImmediate;
+exports.clearImmediate = clearImmediate;
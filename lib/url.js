@@ -0,0 +1,851 @@
+//
+// otto.module :: url.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var path = require('./path');
+var querystring = require('./querystring');
+var idna = require('./internal/idna');
+var internal = require('./internal/url');
+
+var URL = internal.URL;
+var URLSearchParams = internal.URLSearchParams;
+
+exports.URL = URL;
+exports.URLSearchParams = URLSearchParams;
+exports.domainToASCII = internal.domainToASCII;
+exports.domainToUnicode = internal.domainToUnicode;
+
+//
+// legacy API
+//
+
+function Url() {
+  this.protocol = null;
+  this.slashes = null;
+  this.auth = null;
+  this.host = null;
+  this.port = null;
+  this.hostname = null;
+  this.hash = null;
+  this.search = null;
+  this.query = null;
+  this.pathname = null;
+  this.path = null;
+  this.href = null;
+}
+
+exports.Url = Url;
+
+var protocolPattern = /^[a-z0-9.+-]+:/i;
+var portPattern = /:[0-9]*$/;
+var hostPattern = /^\/\/[^@/]+@[^@/]+/;
+var simplePathPattern = /^(\/\/?(?:[^/?\s][^?\s]*)?)(\?[^\s]*)?$/;
+var forbiddenHostChars = /[\x00\t\n\r #%/:<>?@[\\\]^|]/;
+var forbiddenHostCharsIPv6 = /[\x00\t\n\r #%/<>?@\\^|]/;
+var hostnameMaxLen = 255;
+
+var hostlessProtocol = {
+  'javascript': true,
+  'javascript:': true,
+};
+var slashedProtocol = {
+  'http': true,
+  'http:': true,
+  'https': true,
+  'https:': true,
+  'ftp': true,
+  'ftp:': true,
+  'gopher': true,
+  'gopher:': true,
+  'file': true,
+  'file:': true,
+  'ws': true,
+  'ws:': true,
+  'wss': true,
+  'wss:': true,
+};
+var autoEscape = {
+  '\t': '%09',
+  '\n': '%0A',
+  '\r': '%0D',
+  ' ': '%20',
+  '"': '%22',
+  '\'': '%27',
+  '<': '%3C',
+  '>': '%3E',
+  '\\': '%5C',
+  '^': '%5E',
+  '`': '%60',
+  '{': '%7B',
+  '|': '%7C',
+  '}': '%7D',
+};
+
+function has(o, k) {
+  return k !== undefined
+         && Object.prototype.hasOwnProperty.call(o, k);
+}
+
+function isIPv6Hostname(s) {
+  return s.charAt(0) === '['
+         && s.charAt(s.length - 1) === ']';
+}
+
+Url.prototype.parse = function parse(url, parseQueryString, slashesDenoteHost) {
+  if (typeof url !== 'string') {
+    throw internal.invalidArgType('url', 'string', url);
+  }
+
+  // trim, and convert backslashes before the query string
+  var rest = url.replace(/^[\x00-\x20\u00a0\ufeff]+|[\x00-\x20\u00a0\ufeff]+$/g, '');
+  var split = rest.length;
+  ['?', '#'].forEach(function(c) {
+    var i = rest.indexOf(c);
+    if (i !== -1
+        && i < split) {
+      split = i;
+    }
+  });
+  var hasHash = rest.indexOf('#') !== -1;
+  var hasAt = rest.slice(0, split).indexOf('@') !== -1;
+  rest = rest.slice(0, split).replace(/\\/g, '/') + rest.slice(split);
+  var i;
+
+  if (!slashesDenoteHost
+      && !hasHash
+      && !hasAt) {
+    var simplePath = simplePathPattern.exec(rest);
+    if (simplePath) {
+      this.path = rest;
+      this.href = rest;
+      this.pathname = simplePath[1];
+      if (simplePath[2]) {
+        this.search = simplePath[2];
+        this.query = parseQueryString ? querystring.parse(this.search.slice(1)) : this.search.slice(1);
+      } else if (parseQueryString) {
+        this.search = null;
+        this.query = Object.create(null);
+      }
+      return this;
+    }
+  }
+
+  var proto = protocolPattern.exec(rest);
+  var lowerProto;
+  if (proto) {
+    proto = proto[0];
+    lowerProto = proto.toLowerCase();
+    this.protocol = lowerProto;
+    rest = rest.slice(proto.length);
+  }
+
+  var slashes;
+  if (slashesDenoteHost
+      || proto
+      || hostPattern.test(rest)) {
+    slashes = rest.slice(0, 2) === '//';
+    if (slashes
+        && !(proto && has(hostlessProtocol, lowerProto))) {
+      rest = rest.slice(2);
+      this.slashes = true;
+    }
+  }
+
+  if (!has(hostlessProtocol, lowerProto)
+      && (slashes || (proto && !has(slashedProtocol, proto)))) {
+    // the first instance of /, ? or # ends the host, and characters not
+    // allowed in a hostname are allowed only before the last @
+    var hostEnd = -1;
+    var atSign = -1;
+    var nonHost = -1;
+    for (i = 0; i < rest.length && hostEnd === -1; i++) {
+      switch (rest.charAt(i)) {
+      case '\t':
+      case '\n':
+      case '\r':
+      case ' ':
+      case '"':
+      case '%':
+      case '\'':
+      case ';':
+      case '<':
+      case '>':
+      case '\\':
+      case '^':
+      case '`':
+      case '{':
+      case '|':
+      case '}':
+        if (nonHost === -1) {
+          nonHost = i;
+        }
+        break;
+      case '#':
+      case '/':
+      case '?':
+        if (nonHost === -1) {
+          nonHost = i;
+        }
+        hostEnd = i;
+        break;
+      case '@':
+        atSign = i;
+        nonHost = -1;
+        break;
+      }
+    }
+    var start = 0;
+    if (atSign !== -1) {
+      this.auth = decodeURIComponent(rest.slice(0, atSign));
+      start = atSign + 1;
+    }
+    if (nonHost === -1) {
+      this.host = rest.slice(start);
+      rest = '';
+    } else {
+      this.host = rest.slice(start, nonHost);
+      rest = rest.slice(nonHost);
+    }
+
+    this.parseHost();
+    if (typeof this.hostname !== 'string') {
+      this.hostname = '';
+    }
+
+    var hostname = this.hostname;
+    var ipv6Hostname = isIPv6Hostname(hostname);
+    if (!ipv6Hostname) {
+      for (i = 0; i < hostname.length; i++) {
+        if ('/\\#?:'.indexOf(hostname.charAt(i)) !== -1) {
+          this.hostname = hostname.slice(0, i);
+          rest = '/' + hostname.slice(i) + rest;
+          break;
+        }
+      }
+    }
+
+    if (this.hostname.length > hostnameMaxLen) {
+      this.hostname = '';
+    } else {
+      this.hostname = this.hostname.toLowerCase();
+    }
+
+    if (this.hostname !== '') {
+      if (ipv6Hostname) {
+        if (forbiddenHostCharsIPv6.test(this.hostname)) {
+          throw internal.invalidURL(url);
+        }
+      } else {
+        try {
+          this.hostname = idna.toASCII(this.hostname);
+        } catch (e) {
+          this.hostname = '';
+        }
+        if (this.hostname === ''
+            || forbiddenHostChars.test(this.hostname)) {
+          throw internal.invalidURL(url);
+        }
+      }
+    }
+
+    this.host = (this.hostname || '') + (this.port ? ':' + this.port : '');
+
+    if (ipv6Hostname) {
+      this.hostname = this.hostname.slice(1, -1);
+      if (rest.charAt(0) !== '/') {
+        rest = '/' + rest;
+      }
+    }
+  }
+
+  if (!has(hostlessProtocol, lowerProto)) {
+    rest = rest.replace(/[\t\n\r "'<>\\^`{|}]/g, function(c) {
+      return autoEscape[c];
+    });
+  }
+
+  var questionIdx = -1;
+  var hashIdx = -1;
+  for (i = 0; i < rest.length; i++) {
+    var c = rest.charAt(i);
+    if (c === '#') {
+      this.hash = rest.slice(i);
+      hashIdx = i;
+      break;
+    } else if (c === '?'
+               && questionIdx === -1) {
+      questionIdx = i;
+    }
+  }
+
+  if (questionIdx !== -1) {
+    if (hashIdx === -1) {
+      this.search = rest.slice(questionIdx);
+      this.query = rest.slice(questionIdx + 1);
+    } else {
+      this.search = rest.slice(questionIdx, hashIdx);
+      this.query = rest.slice(questionIdx + 1, hashIdx);
+    }
+    if (parseQueryString) {
+      this.query = querystring.parse(this.query);
+    }
+  } else if (parseQueryString) {
+    this.search = null;
+    this.query = Object.create(null);
+  }
+
+  var firstIdx = questionIdx !== -1 && (hashIdx === -1 || questionIdx < hashIdx) ? questionIdx : hashIdx;
+  if (firstIdx === -1) {
+    if (rest.length > 0) {
+      this.pathname = rest;
+    }
+  } else if (firstIdx > 0) {
+    this.pathname = rest.slice(0, firstIdx);
+  }
+  if (has(slashedProtocol, lowerProto)
+      && this.hostname
+      && !this.pathname) {
+    this.pathname = '/';
+  }
+
+  if (this.pathname
+      || this.search) {
+    this.path = (this.pathname || '') + (this.search || '');
+  }
+
+  this.href = this.format();
+  return this;
+};
+
+Url.prototype.parseHost = function parseHost() {
+  var host = this.host;
+  var port = portPattern.exec(host);
+  if (port) {
+    port = port[0];
+    if (port !== ':') {
+      this.port = port.slice(1);
+    }
+    host = host.slice(0, host.length - port.length);
+  }
+  if (host) {
+    this.hostname = host;
+  }
+};
+
+Url.prototype.format = function format() {
+  var auth = this.auth || '';
+  if (auth) {
+    auth = encodeURIComponent(auth).replace(/%3A/gi, ':') + '@';
+  }
+
+  var protocol = this.protocol || '';
+  var pathname = this.pathname || '';
+  var hash = this.hash || '';
+  var host = '';
+  var query = '';
+
+  if (this.host) {
+    host = auth + this.host;
+  } else if (this.hostname) {
+    host = auth + (this.hostname.indexOf(':') !== -1 && !isIPv6Hostname(this.hostname) ? '[' + this.hostname + ']' : this.hostname);
+    if (this.port) {
+      host += ':' + this.port;
+    }
+  }
+
+  if (this.query !== null
+      && typeof this.query === 'object') {
+    query = querystring.stringify(this.query);
+  }
+
+  var search = this.search || (query && '?' + query) || '';
+
+  if (protocol
+      && protocol.charAt(protocol.length - 1) !== ':') {
+    protocol += ':';
+  }
+
+  pathname = pathname.replace(/[?#]/g, function(c) {
+    return c === '#' ? '%23' : '%3F';
+  });
+
+  // only the slashed protocols get the //, unless they had them to begin
+  // with
+  if (this.slashes
+      || has(slashedProtocol, protocol)) {
+    if (this.slashes
+        || host) {
+      if (pathname
+          && pathname.charAt(0) !== '/') {
+        pathname = '/' + pathname;
+      }
+      host = '//' + host;
+    } else if (protocol.slice(0, 4) === 'file') {
+      host = '//';
+    }
+  }
+
+  search = search.replace(/#/g, '%23');
+
+  if (hash
+      && hash.charAt(0) !== '#') {
+    hash = '#' + hash;
+  }
+  if (search
+      && search.charAt(0) !== '?') {
+    search = '?' + search;
+  }
+  return protocol + host + pathname + search + hash;
+};
+
+Url.prototype.resolve = function resolve(relative) {
+  return this.resolveObject(urlParse(relative, false, true)).format();
+};
+
+Url.prototype.resolveObject = function resolveObject(relative) {
+  if (typeof relative === 'string') {
+    var rel = new Url();
+    rel.parse(relative, false, true);
+    relative = rel;
+  }
+
+  var result = new Url();
+  Object.keys(this).forEach(function(k) {
+    result[k] = this[k];
+  }, this);
+
+  // hash is always overridden, even href="" will remove it
+  result.hash = relative.hash;
+
+  if (relative.href === '') {
+    result.href = result.format();
+    return result;
+  }
+
+  // hrefs like //foo/bar always cut to the protocol
+  if (relative.slashes
+      && !relative.protocol) {
+    Object.keys(relative).forEach(function(k) {
+      if (k !== 'protocol') {
+        result[k] = relative[k];
+      }
+    });
+    if (has(slashedProtocol, result.protocol)
+        && result.hostname
+        && !result.pathname) {
+      result.path = result.pathname = '/';
+    }
+    result.href = result.format();
+    return result;
+  }
+
+  var relPath;
+  if (relative.protocol
+      && relative.protocol !== result.protocol) {
+    // anything other than the slashed protocols is assumed to be absolute
+    if (!has(slashedProtocol, relative.protocol)) {
+      Object.keys(relative).forEach(function(k) {
+        result[k] = relative[k];
+      });
+      result.href = result.format();
+      return result;
+    }
+
+    result.protocol = relative.protocol;
+    if (!relative.host
+        && !/^file:?$/.test(relative.protocol)
+        && !has(hostlessProtocol, relative.protocol)) {
+      relPath = (relative.pathname || '').split('/');
+      while (relPath.length && !(relative.host = relPath.shift())) {
+        // skip empty segments
+      }
+      if (!relative.host) {
+        relative.host = '';
+      }
+      if (!relative.hostname) {
+        relative.hostname = '';
+      }
+      if (relPath[0] !== '') {
+        relPath.unshift('');
+      }
+      if (relPath.length < 2) {
+        relPath.unshift('');
+      }
+      result.pathname = relPath.join('/');
+    } else {
+      result.pathname = relative.pathname;
+    }
+    result.search = relative.search;
+    result.query = relative.query;
+    result.host = relative.host || '';
+    result.auth = relative.auth;
+    result.hostname = relative.hostname || relative.host;
+    result.port = relative.port;
+    if (result.pathname
+        || result.search) {
+      result.path = (result.pathname || '') + (result.search || '');
+    }
+    result.slashes = result.slashes || relative.slashes;
+    result.href = result.format();
+    return result;
+  }
+
+  var isSourceAbs = result.pathname && result.pathname.charAt(0) === '/';
+  var isRelAbs = relative.host || (relative.pathname && relative.pathname.charAt(0) === '/');
+  var mustEndAbs = isRelAbs || isSourceAbs || (result.host && relative.pathname);
+  var removeAllDots = mustEndAbs;
+  var srcPath = (result.pathname && result.pathname.split('/')) || [];
+  var noLeadingSlashes = result.protocol && !has(slashedProtocol, result.protocol);
+  relPath = (relative.pathname && relative.pathname.split('/')) || [];
+
+  // relative links like ../.. should be able to crawl up to the hostname for
+  // non-slashed urls
+  if (noLeadingSlashes) {
+    result.hostname = '';
+    result.port = null;
+    if (result.host) {
+      if (srcPath[0] === '') {
+        srcPath[0] = result.host;
+      } else {
+        srcPath.unshift(result.host);
+      }
+    }
+    result.host = '';
+    if (relative.protocol) {
+      relative.hostname = null;
+      relative.port = null;
+      if (relative.host) {
+        if (relPath[0] === '') {
+          relPath[0] = relative.host;
+        } else {
+          relPath.unshift(relative.host);
+        }
+      }
+      relative.host = null;
+    }
+    mustEndAbs = mustEndAbs && (relPath[0] === '' || srcPath[0] === '');
+  }
+
+  var authInHost;
+  if (isRelAbs) {
+    if (relative.host
+        || relative.host === '') {
+      if (result.host !== relative.host) {
+        result.auth = null;
+      }
+      result.host = relative.host;
+      result.port = relative.port;
+    }
+    if (relative.hostname
+        || relative.hostname === '') {
+      if (result.hostname !== relative.hostname) {
+        result.auth = null;
+      }
+      result.hostname = relative.hostname;
+    }
+    result.search = relative.search;
+    result.query = relative.query;
+    srcPath = relPath;
+  } else if (relPath.length) {
+    // throw away the existing file, and take the new path instead
+    srcPath.pop();
+    srcPath = srcPath.concat(relPath);
+    result.search = relative.search;
+    result.query = relative.query;
+  } else if (relative.search !== null
+             && relative.search !== undefined) {
+    // just pull out the search
+    if (noLeadingSlashes) {
+      result.hostname = result.host = srcPath.shift();
+      authInHost = result.host && result.host.indexOf('@') > 0 && result.host.split('@');
+      if (authInHost) {
+        result.auth = authInHost.shift();
+        result.host = result.hostname = authInHost.shift();
+      }
+    }
+    result.search = relative.search;
+    result.query = relative.query;
+    if (result.pathname !== null
+        || result.search !== null) {
+      result.path = (result.pathname || '') + (result.search || '');
+    }
+    result.href = result.format();
+    return result;
+  }
+
+  if (!srcPath.length) {
+    result.pathname = null;
+    result.path = result.search ? '/' + result.search : null;
+    result.href = result.format();
+    return result;
+  }
+
+  // if a url ends in . or .., then it must get a trailing slash
+  var last = srcPath[srcPath.length - 1];
+  var hasTrailingSlash = ((result.host || relative.host || srcPath.length > 1) && (last === '.' || last === '..')) || last === '';
+
+  // strip single dots, and resolve double dots to parent dir
+  var up = 0;
+  for (var i = srcPath.length - 1; i >= 0; i--) {
+    last = srcPath[i];
+    if (last === '.') {
+      srcPath.splice(i, 1);
+    } else if (last === '..') {
+      srcPath.splice(i, 1);
+      up++;
+    } else if (up) {
+      srcPath.splice(i, 1);
+      up--;
+    }
+  }
+
+  // restore leading ..s if the path is allowed to go above the root
+  if (!mustEndAbs
+      && !removeAllDots) {
+    for (; up > 0; up--) {
+      srcPath.unshift('..');
+    }
+  }
+
+  if (mustEndAbs
+      && srcPath[0] !== ''
+      && (!srcPath[0] || srcPath[0].charAt(0) !== '/')) {
+    srcPath.unshift('');
+  }
+
+  if (hasTrailingSlash
+      && srcPath.join('/').slice(-1) !== '/') {
+    srcPath.push('');
+  }
+
+  var isAbsolute = srcPath[0] === '' || (srcPath[0] && srcPath[0].charAt(0) === '/');
+
+  // put the host back
+  if (noLeadingSlashes) {
+    result.hostname = result.host = isAbsolute ? '' : srcPath.length ? srcPath.shift() : '';
+    authInHost = result.host && result.host.indexOf('@') > 0 ? result.host.split('@') : false;
+    if (authInHost) {
+      result.auth = authInHost.shift();
+      result.host = result.hostname = authInHost.shift();
+    }
+  }
+
+  mustEndAbs = mustEndAbs || (result.host && srcPath.length);
+
+  if (mustEndAbs
+      && !isAbsolute) {
+    srcPath.unshift('');
+  }
+
+  if (srcPath.length > 0) {
+    result.pathname = srcPath.join('/');
+  } else {
+    result.pathname = null;
+    result.path = null;
+  }
+
+  if (result.pathname !== null
+      || result.search !== null) {
+    result.path = (result.pathname || '') + (result.search || '');
+  }
+  result.auth = relative.auth || result.auth;
+  result.slashes = result.slashes || relative.slashes;
+  result.href = result.format();
+  return result;
+};
+
+function urlParse(url, parseQueryString, slashesDenoteHost) {
+  if (url instanceof Url) {
+    return url;
+  }
+  var u = new Url();
+  u.parse(url, parseQueryString, slashesDenoteHost);
+  return u;
+}
+
+exports.parse = urlParse;
+
+exports.resolve = function resolve(source, relative) {
+  return urlParse(source, false, true).resolve(relative);
+};
+
+exports.resolveObject = function resolveObject(source, relative) {
+  if (!source) {
+    return relative;
+  }
+  return urlParse(source, false, true).resolveObject(relative);
+};
+
+exports.format = function format(urlObject, options) {
+  if (typeof urlObject === 'string') {
+    urlObject = urlParse(urlObject);
+  } else if (typeof urlObject !== 'object'
+             || urlObject === null) {
+    throw internal.invalidArgType('urlObject', 'object or string', urlObject);
+  } else if (urlObject instanceof URL) {
+    var fragment = true;
+    var unicode = false;
+    var search = true;
+    var auth = true;
+    if (options) {
+      if (options.fragment !== undefined) {
+        fragment = Boolean(options.fragment);
+      }
+      if (options.unicode !== undefined) {
+        unicode = Boolean(options.unicode);
+      }
+      if (options.search !== undefined) {
+        search = Boolean(options.search);
+      }
+      if (options.auth !== undefined) {
+        auth = Boolean(options.auth);
+      }
+    }
+    var u = urlObject;
+    var s = u.protocol;
+    if (u.host !== ''
+        || u.protocol === 'file:') {
+      s += '//';
+      if (auth
+          && (u.username !== '' || u.password !== '')) {
+        s += u.username + (u.password !== '' ? ':' + u.password : '') + '@';
+      }
+      s += unicode ? internal.domainToUnicode(u.hostname) : u.hostname;
+      if (u.port !== '') {
+        s += ':' + u.port;
+      }
+    }
+    s += u.pathname;
+    if (search) {
+      s += u.search;
+    }
+    if (fragment) {
+      s += u.hash;
+    }
+    return s;
+  }
+  return Url.prototype.format.call(urlObject);
+};
+
+//
+// file URL
+//
+
+function isWindows(options) {
+  if (options
+      && options.windows !== undefined) {
+    return Boolean(options.windows);
+  }
+  return process.platform === 'win32';
+}
+
+exports.fileURLToPath = function fileURLToPath(u, options) {
+  if (typeof u === 'string') {
+    u = new URL(u);
+  } else if (!(u instanceof URL)) {
+    throw internal.invalidArgType('path', 'string or an instance of URL', u);
+  }
+  if (u.protocol !== 'file:') {
+    var e = new TypeError('The URL must be of scheme file');
+    e.code = 'ERR_INVALID_URL_SCHEME';
+    throw e;
+  }
+
+  var windows = isWindows(options);
+  var pathname = u.pathname;
+  if (/%2f/i.test(pathname)
+      || (windows && /%5c/i.test(pathname))) {
+    throw invalidFileURLPath('must not include encoded ' + (windows ? '\\ or / characters' : '/ characters'));
+  }
+  if (windows) {
+    pathname = decodeURIComponent(pathname.replace(/\//g, '\\'));
+    if (u.hostname !== '') {
+      // UNC path
+      return '\\\\' + internal.domainToUnicode(u.hostname) + pathname;
+    }
+    var letter = pathname.charCodeAt(1) | 0x20;
+    if (letter < 0x61
+        || letter > 0x7a
+        || pathname.charAt(2) !== ':') {
+      throw invalidFileURLPath('must be absolute');
+    }
+    return pathname.slice(1);
+  } else if (u.hostname !== '') {
+    e = new TypeError('File URL host must be "localhost" or empty on ' + process.platform);
+    e.code = 'ERR_INVALID_FILE_URL_HOST';
+    throw e;
+  }
+  return decodeURIComponent(pathname);
+};
+
+function invalidFileURLPath(msg) {
+  var e = new TypeError('File URL path ' + msg);
+  e.code = 'ERR_INVALID_FILE_URL_PATH';
+  return e;
+}
+
+exports.pathToFileURL = function pathToFileURL(filepath, options) {
+  if (typeof filepath !== 'string') {
+    throw internal.invalidArgType('path', 'string', filepath);
+  }
+
+  var windows = isWindows(options);
+  var p = windows ? path.win32 : path.posix;
+  if (windows
+      && filepath.slice(0, 2) === '\\\\') {
+    // UNC path
+    var i = filepath.indexOf('\\', 2);
+    if (i <= 2) {
+      var e = new TypeError('The argument \'path\' must be a UNC path. Received ' + JSON.stringify(filepath));
+      e.code = 'ERR_INVALID_ARG_VALUE';
+      throw e;
+    }
+    var u = new URL('file://');
+    u.hostname = filepath.slice(2, i);
+    u.pathname = encodePathChars(filepath.slice(i).replace(/\\/g, '/'), false);
+    return u;
+  }
+
+  var resolved = p.isAbsolute(filepath) ? p.normalize(filepath) : p.join(process.cwd(), filepath);
+  var sep = filepath.charAt(filepath.length - 1);
+  if ((sep === '/' || (windows && sep === '\\'))
+      && resolved.charAt(resolved.length - 1) !== p.sep) {
+    resolved += '/';
+  }
+  if (windows) {
+    resolved = '/' + resolved.replace(/\\/g, '/');
+  }
+  return new URL('file://' + encodePathChars(resolved, !windows));
+};
+
+function encodePathChars(s, escapeBackslash) {
+  return s.replace(escapeBackslash ? /[%\\\t\n\r?#]/g : /[%\t\n\r?#]/g, function(c) {
+    return '%' + (c.charCodeAt(0) < 0x10 ? '0' : '') + c.charCodeAt(0).toString(16).toUpperCase();
+  });
+}
+
+exports.urlToHttpOptions = function urlToHttpOptions(u) {
+  var o = {
+    protocol: u.protocol,
+    hostname: u.hostname.charAt(0) === '[' ? u.hostname.slice(1, -1) : u.hostname,
+    hash: u.hash,
+    search: u.search,
+    pathname: u.pathname,
+    path: (u.pathname || '') + (u.search || ''),
+    href: u.href,
+  };
+  if (u.port !== '') {
+    o.port = Number(u.port);
+  }
+  if (u.username
+      || u.password) {
+    o.auth = decodeURIComponent(u.username) + ':' + decodeURIComponent(u.password);
+  }
+  return o;
+};
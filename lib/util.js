@@ -0,0 +1,264 @@
+//
+// otto.module :: util.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var _inspect = require('./internal/util/inspect');
+var comparisons = require('./internal/util/comparisons');
+
+var toString = Object.prototype.toString;
+
+function tagOf(v) {
+  return toString.call(v).slice(8, -1);
+}
+
+function isObjectLike(v) {
+  return v !== null
+         && typeof v === 'object';
+}
+
+exports.format = _inspect.format;
+exports.formatWithOptions = _inspect.formatWithOptions;
+exports.inspect = _inspect.inspect;
+exports.isDeepStrictEqual = comparisons.isDeepStrictEqual;
+
+exports.inherits = function inherits(ctor, superCtor) {
+  if (typeof ctor !== 'function') {
+    throw new TypeError('ctor must be a Function');
+  } else if (typeof superCtor !== 'function') {
+    throw new TypeError('superCtor must be a Function');
+  } else if (superCtor.prototype === undefined) {
+    throw new TypeError('superCtor.prototype must be an Object');
+  }
+
+  ctor.super_ = superCtor;
+  ctor.prototype = Object.create(superCtor.prototype, {
+    constructor: {
+      value: ctor,
+      enumerable: false,
+      writable: true,
+      configurable: true,
+    },
+  });
+};
+
+exports.deprecate = function deprecate(fn, msg, code) {
+  if (typeof fn !== 'function') {
+    throw new TypeError('fn must be a Function');
+  } else if (code !== undefined
+             && typeof code !== 'string') {
+    throw new TypeError('code must be a String');
+  }
+
+  if (process.noDeprecation === true) {
+    return fn;
+  }
+
+  var warned = false;
+
+  function deprecated() {
+    if (!warned) {
+      warned = true;
+      process.emitWarning(msg, 'DeprecationWarning', code);
+    }
+    if (this instanceof deprecated) {
+      var self = Object.create(fn.prototype);
+      var r = fn.apply(self, arguments);
+      return r !== null && (typeof r === 'object' || typeof r === 'function') ? r : self;
+    }
+    return fn.apply(this, arguments);
+  }
+  deprecated.prototype = fn.prototype;
+
+  return deprecated;
+};
+
+exports.callbackify = function callbackify(original) {
+  if (typeof original !== 'function') {
+    throw new TypeError('original must be a Function');
+  }
+
+  return function callbackified() {
+    var args = Array.prototype.slice.call(arguments);
+    var cb = args.pop();
+    if (typeof cb !== 'function') {
+      throw new TypeError('the last argument must be a Function');
+    }
+
+    var self = this;
+
+    function resolve(v) {
+      process.nextTick(function() {
+        cb.call(self, null, v);
+      });
+    }
+
+    function reject(reason) {
+      if (!reason) {
+        var err = new Error('Promise was rejected with a falsy value');
+        err.code = 'ERR_FALSY_VALUE_REJECTION';
+        err.reason = reason;
+        reason = err;
+      }
+      process.nextTick(function() {
+        cb.call(self, reason);
+      });
+    }
+
+    var r;
+    try {
+      r = original.apply(this, args);
+    } catch (e) {
+      reject(e);
+      return;
+    }
+    if (r !== null
+        && (typeof r === 'object' || typeof r === 'function')
+        && typeof r.then === 'function') {
+      r.then(resolve, reject);
+    } else {
+      resolve(r);
+    }
+  };
+};
+
+function getenv(k) {
+  var env = process.env;
+  return typeof env.__get__ === 'function' ? env.__get__(k) : env[k];
+}
+
+var debugs = Object.create(null);
+var debugEnv;
+
+exports.debuglog = function debuglog(section) {
+  if (debugEnv === undefined) {
+    debugEnv = (getenv('NODE_DEBUG') || '').split(/[\s,]+/).filter(Boolean).map(function(s) {
+      return s.toUpperCase();
+    });
+  }
+
+  section = section.toUpperCase();
+  if (!(section in debugs)) {
+    var enabled = debugEnv.some(function(s) {
+      if (s === '*') {
+        return true;
+      } else if (s.slice(-1) === '*') {
+        return section.slice(0, s.length - 1) === s.slice(0, -1);
+      }
+      return s === section;
+    });
+    if (enabled) {
+      debugs[section] = function debug() {
+        var s = exports.format.apply(exports, arguments);
+        process.emit('debuglog', section, s);
+        if (typeof console !== 'undefined') {
+          console.error(section + ' ' + process.pid + ': ' + s);
+        }
+      };
+      debugs[section].enabled = true;
+    } else {
+      debugs[section] = function debug() {};
+      debugs[section].enabled = false;
+    }
+  }
+  return debugs[section];
+};
+
+exports.types = {
+  isDate: function isDate(v) {
+    return isObjectLike(v) && tagOf(v) === 'Date';
+  },
+  isRegExp: function isRegExp(v) {
+    return isObjectLike(v) && tagOf(v) === 'RegExp';
+  },
+  isNativeError: function isNativeError(v) {
+    return isObjectLike(v) && tagOf(v) === 'Error';
+  },
+  isNumberObject: function isNumberObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'Number';
+  },
+  isStringObject: function isStringObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'String';
+  },
+  isBooleanObject: function isBooleanObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'Boolean';
+  },
+  isBoxedPrimitive: function isBoxedPrimitive(v) {
+    return exports.types.isNumberObject(v)
+           || exports.types.isStringObject(v)
+           || exports.types.isBooleanObject(v);
+  },
+  isArgumentsObject: function isArgumentsObject(v) {
+    return isObjectLike(v) && tagOf(v) === 'Arguments';
+  },
+  isPromise: function isPromise(v) {
+    return isObjectLike(v) && tagOf(v) === 'Promise';
+  },
+  isMap: function isMap(v) {
+    return isObjectLike(v) && tagOf(v) === 'Map';
+  },
+  isSet: function isSet(v) {
+    return isObjectLike(v) && tagOf(v) === 'Set';
+  },
+  isGeneratorFunction: function isGeneratorFunction(v) {
+    return typeof v === 'function' && tagOf(v) === 'GeneratorFunction';
+  },
+  isAsyncFunction: function isAsyncFunction(v) {
+    return typeof v === 'function' && tagOf(v) === 'AsyncFunction';
+  },
+};
+
+// legacy
+exports.isArray = Array.isArray;
+
+exports.isBoolean = function isBoolean(v) {
+  return typeof v === 'boolean';
+};
+
+exports.isNull = function isNull(v) {
+  return v === null;
+};
+
+exports.isNullOrUndefined = function isNullOrUndefined(v) {
+  return v === null
+         || v === undefined;
+};
+
+exports.isNumber = function isNumber(v) {
+  return typeof v === 'number';
+};
+
+exports.isString = function isString(v) {
+  return typeof v === 'string';
+};
+
+exports.isUndefined = function isUndefined(v) {
+  return v === undefined;
+};
+
+exports.isRegExp = exports.types.isRegExp;
+
+exports.isObject = function isObject(v) {
+  return isObjectLike(v);
+};
+
+exports.isDate = exports.types.isDate;
+
+exports.isError = function isError(v) {
+  return exports.types.isNativeError(v)
+         || v instanceof Error;
+};
+
+exports.isFunction = function isFunction(v) {
+  return typeof v === 'function';
+};
+
+exports.isPrimitive = function isPrimitive(v) {
+  return v === null
+         || (typeof v !== 'object' && typeof v !== 'function');
+};
@@ -0,0 +1,141 @@
+//
+// otto.module :: zlib.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+'use strict';
+
+var binding = process.binding('zlib');
+var Buffer = require('./buffer').Buffer;
+var kMaxLength = require('./buffer').kMaxLength;
+var util = require('./internal/util');
+
+var constants = {
+  Z_NO_FLUSH: 0,
+  Z_PARTIAL_FLUSH: 1,
+  Z_SYNC_FLUSH: 2,
+  Z_FULL_FLUSH: 3,
+  Z_FINISH: 4,
+  Z_BLOCK: 5,
+  Z_OK: 0,
+  Z_STREAM_END: 1,
+  Z_NEED_DICT: 2,
+  Z_ERRNO: -1,
+  Z_STREAM_ERROR: -2,
+  Z_DATA_ERROR: -3,
+  Z_MEM_ERROR: -4,
+  Z_BUF_ERROR: -5,
+  Z_VERSION_ERROR: -6,
+  Z_NO_COMPRESSION: 0,
+  Z_BEST_SPEED: 1,
+  Z_BEST_COMPRESSION: 9,
+  Z_DEFAULT_COMPRESSION: -1,
+  Z_FILTERED: 1,
+  Z_HUFFMAN_ONLY: 2,
+  Z_RLE: 3,
+  Z_FIXED: 4,
+  Z_DEFAULT_STRATEGY: 0,
+  ZLIB_VERNUM: 4865,
+  DEFLATE: 1,
+  INFLATE: 2,
+  GZIP: 3,
+  GUNZIP: 4,
+  DEFLATERAW: 5,
+  INFLATERAW: 6,
+  UNZIP: 7,
+  Z_MIN_WINDOWBITS: 8,
+  Z_MAX_WINDOWBITS: 15,
+  Z_DEFAULT_WINDOWBITS: 15,
+  Z_MIN_CHUNK: 64,
+  Z_MAX_CHUNK: Infinity,
+  Z_DEFAULT_CHUNK: 16384,
+  Z_MIN_MEMLEVEL: 1,
+  Z_MAX_MEMLEVEL: 9,
+  Z_DEFAULT_MEMLEVEL: 8,
+  Z_MIN_LEVEL: -1,
+  Z_MAX_LEVEL: 9,
+  Z_DEFAULT_LEVEL: -1,
+};
+
+var codes = {
+  Z_OK: 0,
+  Z_STREAM_END: 1,
+  Z_NEED_DICT: 2,
+  Z_ERRNO: -1,
+  Z_STREAM_ERROR: -2,
+  Z_DATA_ERROR: -3,
+  Z_MEM_ERROR: -4,
+  Z_BUF_ERROR: -5,
+  Z_VERSION_ERROR: -6,
+};
+Object.keys(codes).forEach(function(k) {
+  codes[codes[k]] = k;
+});
+
+exports.constants = constants;
+exports.codes = codes;
+
+function toBuffer(buffer) {
+  if (typeof buffer === 'string') {
+    return Buffer.from(buffer);
+  } else if (!Buffer.isBuffer(buffer)) {
+    throw util.invalidArgType('buffer', 'of type string or an instance of Buffer, TypedArray, DataView, or ArrayBuffer', buffer);
+  }
+  return buffer;
+}
+
+function option(opts, name, min, max, def) {
+  var v = opts[name];
+  if (v === undefined) {
+    return def;
+  } else if (typeof v !== 'number') {
+    throw util.invalidArgType('options.' + name, 'of type number', v);
+  } else if (!(v >= min && v <= max)) {
+    throw util.outOfRange('options.' + name, '>= ' + min + ' and <= ' + max, v);
+  }
+  return Math.floor(v);
+}
+
+function dictionary(opts) {
+  var dict = opts.dictionary;
+  if (dict !== undefined
+      && !Buffer.isBuffer(dict)) {
+    throw util.invalidArgType('options.dictionary', 'an instance of Buffer, TypedArray, DataView, or ArrayBuffer', dict);
+  }
+  return dict;
+}
+
+function compress(format) {
+  return function(buffer, opts) {
+    buffer = toBuffer(buffer);
+    opts = opts || {};
+    var level = option(opts, 'level', constants.Z_MIN_LEVEL, constants.Z_MAX_LEVEL, constants.Z_DEFAULT_LEVEL);
+    return binding.compress(format, buffer, level, dictionary(opts));
+  };
+}
+
+function decompress(format) {
+  return function(buffer, opts) {
+    buffer = toBuffer(buffer);
+    opts = opts || {};
+    var maxOutputLength = option(opts, 'maxOutputLength', 1, kMaxLength, kMaxLength);
+    var buf = binding.decompress(format, buffer, maxOutputLength, dictionary(opts));
+    if (!buf) {
+      var e = new RangeError('Cannot create a Buffer larger than ' + maxOutputLength + ' bytes');
+      e.code = 'ERR_BUFFER_TOO_LARGE';
+      throw e;
+    }
+    return buf;
+  };
+}
+
+exports.gzipSync = compress('gzip');
+exports.gunzipSync = decompress('gzip');
+exports.deflateSync = compress('deflate');
+exports.inflateSync = decompress('deflate');
+exports.deflateRawSync = compress('deflateRaw');
+exports.inflateRawSync = decompress('deflateRaw');
+exports.unzipSync = decompress('unzip');
@@ -12,7 +12,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 )
 
@@ -27,9 +26,22 @@ func (vm *Otto) Register(l Loader) {
 	vm.mu.Lock()
 	defer vm.mu.Unlock()
 
+	if l, ok := l.(fsLoader); ok {
+		l.setFS(vm.fs)
+	}
 	vm.loaders = append(vm.loaders, l)
 }
 
+type fsLoader interface {
+	setFS(FS)
+}
+
+func setFS(l Loader, fsys FS) {
+	if l, ok := l.(fsLoader); ok {
+		l.setFS(fsys)
+	}
+}
+
 func (vm *Otto) Load(id string) ([]byte, error) {
 	for _, l := range vm.loaders {
 		switch b, err := l.Load(id); {
@@ -46,7 +58,7 @@ func (vm *Otto) Load(id string) ([]byte, error) {
 }
 
 func (vm *Otto) Resolve(id, wd string) (string, error) {
-	wd, err := filepath.Abs(wd)
+	wd, err := vm.fs.Abs(wd)
 	if err != nil {
 		return "", ModuleError{
 			ID:  id,
@@ -81,10 +93,24 @@ func (e ModuleError) Error() string {
 }
 
 type FileLoader struct {
+	FS FS
+}
+
+func (l *FileLoader) setFS(fsys FS) {
+	if l.FS == nil {
+		l.FS = fsys
+	}
+}
+
+func (l *FileLoader) fsys() FS {
+	if l.FS == nil {
+		return OSFS{}
+	}
+	return l.FS
 }
 
 func (l *FileLoader) Load(id string) ([]byte, error) {
-	fi, err := os.Stat(id)
+	fi, err := l.fsys().Stat(id)
 	switch {
 	case err != nil:
 		id, err = l.Resolve(id, ".")
@@ -94,7 +120,7 @@ func (l *FileLoader) Load(id string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return os.ReadFile(id)
+	return l.fsys().ReadFile(id)
 }
 
 func (l *FileLoader) Resolve(id, wd string) (string, error) {
@@ -103,10 +129,10 @@ func (l *FileLoader) Resolve(id, wd string) (string, error) {
 	}
 
 	id = filepath.Join(wd, id)
-	fi, err := os.Stat(id)
+	fi, err := l.fsys().Stat(id)
 	if err != nil {
 		for _, ext := range []string{".js", ".json"} {
-			fi, err = os.Stat(id + ext)
+			fi, err = l.fsys().Stat(id + ext)
 			if err == nil {
 				id += ext
 				break
@@ -120,7 +146,7 @@ func (l *FileLoader) Resolve(id, wd string) (string, error) {
 		return "", ErrModule
 	}
 
-	id, err = filepath.EvalSymlinks(id)
+	id, err = l.fsys().EvalSymlinks(id)
 	if err != nil {
 		return "", err
 	}
@@ -128,17 +154,32 @@ func (l *FileLoader) Resolve(id, wd string) (string, error) {
 }
 
 type FolderLoader struct {
+	FS   FS
 	File Loader
 }
 
+func (l *FolderLoader) setFS(fsys FS) {
+	if l.FS == nil {
+		l.FS = fsys
+	}
+	setFS(l.File, fsys)
+}
+
+func (l *FolderLoader) fsys() FS {
+	if l.FS == nil {
+		return OSFS{}
+	}
+	return l.FS
+}
+
 func (l *FolderLoader) Load(id string) ([]byte, error) {
-	if fi, err := os.Stat(id); err != nil || fi.IsDir() {
+	if fi, err := l.fsys().Stat(id); err != nil || fi.IsDir() {
 		id, err = l.Resolve(id, ".")
 		if err != nil {
 			return nil, err
 		}
 	}
-	return os.ReadFile(id)
+	return l.fsys().ReadFile(id)
 }
 
 func (l *FolderLoader) Resolve(id, wd string) (string, error) {
@@ -147,12 +188,12 @@ func (l *FolderLoader) Resolve(id, wd string) (string, error) {
 	}
 
 	wd = filepath.Join(wd, id)
-	if fi, err := os.Stat(wd); err != nil || !fi.IsDir() {
+	if fi, err := l.fsys().Stat(wd); err != nil || !fi.IsDir() {
 		return "", ErrModule
 	}
 
 	p := filepath.Join(wd, "package.json")
-	if b, err := os.ReadFile(p); err == nil {
+	if b, err := l.fsys().ReadFile(p); err == nil {
 		var pkg Package
 		if err := json.Unmarshal(b, &pkg); err != nil {
 			return "", PackageError{
@@ -203,12 +244,28 @@ func isPath(id string) bool {
 }
 
 type NodeModulesLoader struct {
+	FS     FS
 	File   Loader
 	Folder Loader
 }
 
+func (l *NodeModulesLoader) setFS(fsys FS) {
+	if l.FS == nil {
+		l.FS = fsys
+	}
+	setFS(l.File, fsys)
+	setFS(l.Folder, fsys)
+}
+
+func (l *NodeModulesLoader) fsys() FS {
+	if l.FS == nil {
+		return OSFS{}
+	}
+	return l.FS
+}
+
 func (l *NodeModulesLoader) Load(id string) ([]byte, error) {
-	fi, err := os.Stat(id)
+	fi, err := l.fsys().Stat(id)
 	switch {
 	case err != nil:
 		id, err = l.Resolve(id, ".")
@@ -218,7 +275,7 @@ func (l *NodeModulesLoader) Load(id string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return os.ReadFile(id)
+	return l.fsys().ReadFile(id)
 }
 
 func (l *NodeModulesLoader) Resolve(id, wd string) (string, error) {
@@ -0,0 +1,292 @@
+//
+// otto.module :: loop.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+type loop struct {
+	mu    sync.Mutex
+	tasks []func() error
+	refs  int
+	wake  chan struct{}
+
+	id         int64
+	timers     timerHeap
+	immediates []*timer
+	active     map[int64]*timer
+	refed      int
+
+	processTimer     otto.Value
+	processImmediate otto.Value
+	processTicks     otto.Value
+	depth            int
+}
+
+type timer struct {
+	id     int64
+	obj    otto.Value
+	when   time.Time
+	repeat time.Duration
+	ref    bool
+	index  int
+}
+
+func (vm *Otto) Run(src any) (otto.Value, error) {
+	return vm.callback(func() (otto.Value, error) {
+		return vm.Otto.Run(src)
+	})
+}
+
+func (vm *Otto) Call(src string, this any, args ...any) (otto.Value, error) {
+	return vm.callback(func() (otto.Value, error) {
+		return vm.Otto.Call(src, this, args...)
+	})
+}
+
+func (vm *Otto) MakeCallback(fn, this otto.Value, args ...any) (otto.Value, error) {
+	return vm.callback(func() (otto.Value, error) {
+		return fn.Call(this, args...)
+	})
+}
+
+func (vm *Otto) callback(fn func() (otto.Value, error)) (otto.Value, error) {
+	l := &vm.loop
+	l.depth++
+	defer func() { l.depth-- }()
+
+	v, err := fn()
+	if err == nil && l.depth == 1 && l.processTicks.IsFunction() {
+		_, err = l.processTicks.Call(otto.UndefinedValue())
+	}
+	return v, err
+}
+
+func (vm *Otto) task_queue_setTickCallback(call otto.FunctionCall) otto.Value {
+	vm.loop.processTicks = call.Argument(0)
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) Enqueue(fn func() error) {
+	l := &vm.loop
+	l.mu.Lock()
+	l.tasks = append(l.tasks, fn)
+	l.mu.Unlock()
+	l.notify()
+}
+
+func (vm *Otto) Ref() {
+	l := &vm.loop
+	l.mu.Lock()
+	l.refs++
+	l.mu.Unlock()
+}
+
+func (vm *Otto) Unref() {
+	l := &vm.loop
+	l.mu.Lock()
+	if l.refs > 0 {
+		l.refs--
+	}
+	l.mu.Unlock()
+	l.notify()
+}
+
+func (vm *Otto) RunLoop(ctx context.Context) error {
+	l := &vm.loop
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// timers
+		if err := vm.runTimers(ctx); err != nil {
+			return err
+		}
+		if !l.alive() {
+			return nil
+		}
+		// poll
+		if len(l.immediates) == 0 && !l.pending() {
+			if err := l.wait(ctx); err != nil {
+				return err
+			}
+		}
+		if err := vm.runTasks(ctx); err != nil {
+			return err
+		}
+		// check
+		if err := vm.runImmediates(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (vm *Otto) runTimers(ctx context.Context) error {
+	l := &vm.loop
+	now := time.Now()
+	for len(l.timers) > 0 && !l.timers[0].when.After(now) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		t := heap.Pop(&l.timers).(*timer)
+		if t.repeat == 0 {
+			l.remove(t)
+		}
+		_, err := vm.MakeCallback(l.processTimer, otto.UndefinedValue(), t.obj)
+		if _, ok := l.active[t.id]; ok && t.index == -1 {
+			t.when = time.Now().Add(t.repeat)
+			heap.Push(&l.timers, t)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vm *Otto) runTasks(ctx context.Context) error {
+	l := &vm.loop
+	l.mu.Lock()
+	tasks := l.tasks
+	l.tasks = nil
+	l.mu.Unlock()
+	for i, fn := range tasks {
+		err := ctx.Err()
+		if err == nil {
+			_, err = vm.callback(func() (otto.Value, error) {
+				return otto.UndefinedValue(), fn()
+			})
+			i++
+		}
+		if err != nil {
+			l.mu.Lock()
+			l.tasks = append(tasks[i:len(tasks):len(tasks)], l.tasks...)
+			l.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+func (vm *Otto) runImmediates(ctx context.Context) error {
+	l := &vm.loop
+	list := l.immediates
+	l.immediates = nil
+	for i, t := range list {
+		if _, ok := l.active[t.id]; !ok {
+			continue
+		}
+		err := ctx.Err()
+		if err == nil {
+			l.remove(t)
+			_, err = vm.MakeCallback(l.processImmediate, otto.UndefinedValue(), t.obj)
+			i++
+		}
+		if err != nil {
+			l.immediates = append(list[i:len(list):len(list)], l.immediates...)
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *loop) wait(ctx context.Context) error {
+	var timeout <-chan time.Time
+	if len(l.timers) > 0 {
+		t := time.NewTimer(time.Until(l.timers[0].when))
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case <-l.wake:
+	case <-timeout:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (l *loop) notify() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (l *loop) pending() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.tasks) > 0
+}
+
+func (l *loop) alive() bool {
+	if l.refed > 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.refs > 0 || len(l.tasks) > 0
+}
+
+func (l *loop) add(t *timer) int64 {
+	l.id++
+	t.id = l.id
+	t.index = -1
+	l.active[t.id] = t
+	if t.ref {
+		l.refed++
+	}
+	return t.id
+}
+
+func (l *loop) remove(t *timer) {
+	delete(l.active, t.id)
+	if t.ref {
+		l.refed--
+	}
+}
+
+type timerHeap []*timer
+
+func (h timerHeap) Len() int { return len(h) }
+
+func (h timerHeap) Less(i, j int) bool {
+	if h[i].when.Equal(h[j].when) {
+		return h[i].id < h[j].id
+	}
+	return h[i].when.Before(h[j].when)
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x any) {
+	t := x.(*timer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() any {
+	old := *h
+	n := len(old) - 1
+	t := old[n]
+	old[n] = nil
+	t.index = -1
+	*h = old[:n]
+	return t
+}
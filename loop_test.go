@@ -0,0 +1,378 @@
+//
+// otto.module :: loop_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hattya/otto.module"
+	"github.com/robertkrimen/otto"
+)
+
+func TestTimers(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var timers = require('timers');
+		var log = [];
+		setTimeout(function(a, b) {
+			log.push('timeout:' + a + b);
+		}, 10, 'a', 'b');
+		setTimeout(function() {
+			log.push('timeout:0');
+		});
+		var i = 0;
+		var interval = setInterval(function() {
+			log.push('interval:' + ++i);
+			if (i === 3) {
+				clearInterval(interval);
+			}
+		}, 1);
+		var cleared = setTimeout(function() {
+			log.push('cleared');
+		}, 1);
+		clearTimeout(cleared);
+		var unref = setTimeout(function() {
+			log.push('unref');
+		}, 1000).unref();
+		var immediate = setImmediate(function(v) {
+			log.push('immediate:' + v + ':' + (this === immediate));
+			setImmediate(function() {
+				log.push('immediate:next');
+			});
+		}, 1);
+		clearImmediate(setImmediate(function() {
+			log.push('cleared');
+		}));
+		var refresh = setTimeout(function() {
+			log.push('refresh');
+		}, 5);
+		setTimeout(function() {
+			refresh.refresh();
+		}, 1);
+		[
+			timers.setTimeout === setTimeout,
+			unref.hasRef(),
+			unref.ref().hasRef(),
+			unref.unref().hasRef(),
+			typeof +unref,
+			immediate.hasRef(),
+		].join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "true,false,true,false,number,true"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.sort().join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "immediate:1:true,immediate:next,interval:1,interval:2,interval:3,refresh,timeout:0,timeout:ab"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if v, err := vm.Run(`log.indexOf('immediate:1:true') < log.indexOf('immediate:next') && log.indexOf('timeout:0') < log.indexOf('timeout:ab')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if !v.IsBoolean() || v.String() != "true" {
+		t.Errorf("unexpected order: %v", v)
+	}
+
+	for _, src := range []string{
+		`setTimeout()`,
+		`setInterval('')`,
+		`setImmediate(null)`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", src)
+		}
+	}
+}
+
+func TestTimers_Overflow(t *testing.T) {
+	var warnings []*module.Warning
+	vm, err := module.New(module.WithWarningFunc(func(w *module.Warning) {
+		warnings = append(warnings, w)
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	if _, err := vm.Run(`setTimeout(function() {}, Math.pow(2, 31))._idleTimeout`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if g, e := len(warnings), 1; g != e {
+		t.Fatalf("expected %v, got %v", e, g)
+	}
+	if g, e := warnings[0].Name, "TimeoutOverflowWarning"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestLoop_Enqueue(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	if _, err := vm.Run(`var log = [];`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Ref()
+	go func() {
+		for i := range 3 {
+			time.Sleep(time.Millisecond)
+			vm.Enqueue(func() error {
+				_, err := vm.Call(`log.push`, nil, i)
+				return err
+			})
+		}
+		vm.Enqueue(func() error {
+			_, err := vm.Run(`setTimeout(function() { log.push('timeout'); });`)
+			return err
+		})
+		vm.Unref()
+	}()
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "0,1,2,timeout"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestLoop_Context(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	if _, err := vm.Run(`var n = 0; var interval = setInterval(function() { n++; }, 1);`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := vm.RunLoop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if v, err := vm.Run(`n > 0`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if v.String() != "true" {
+		t.Error("expected interval to run")
+	}
+
+	// pending tasks are kept
+	vm.Enqueue(func() error {
+		_, err := vm.Run(`n = -1;`)
+		return err
+	})
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := vm.RunLoop(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+	if _, err := vm.Run(`clearInterval(interval);`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`n`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "-1"; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+}
+
+func TestLoop_Error(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var log = [];
+		setImmediate(function() {
+			throw new Error('immediate');
+		});
+		setImmediate(function() {
+			log.push('immediate');
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err == nil {
+		t.Fatal("expected error")
+	} else if g, e := module.Wrap(err).Error(), "Error: immediate"; !strings.HasPrefix(g, e) {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	errTask := errors.New("task")
+	vm.Enqueue(func() error {
+		return errTask
+	})
+	vm.Enqueue(func() error {
+		_, err := vm.Run(`log.push('task');`)
+		return err
+	})
+	if err := vm.RunLoop(context.Background()); err != errTask {
+		t.Fatalf("expected %v, got %v", errTask, err)
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "immediate,task"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestNextTick(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var log = [];
+		setTimeout(function() {
+			log.push('timeout');
+			process.nextTick(function() {
+				log.push('tick:timeout');
+			});
+			queueMicrotask(function() {
+				log.push('microtask:timeout');
+			});
+		});
+		setImmediate(function() {
+			log.push('immediate');
+			process.nextTick(function(a, b) {
+				log.push('tick:immediate:' + a + b);
+			}, 'a', 'b');
+		});
+		process.nextTick(function() {
+			log.push('tick');
+			queueMicrotask(function() {
+				log.push('microtask:tick');
+			});
+			process.nextTick(function() {
+				log.push('tick:tick');
+			});
+		});
+		queueMicrotask(function() {
+			log.push('microtask');
+			process.nextTick(function() {
+				log.push('tick:microtask');
+			});
+			queueMicrotask(function() {
+				log.push('microtask:microtask');
+			});
+		});
+		log.push('main');
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "main,tick,tick:tick,microtask,microtask:tick,microtask:microtask,tick:microtask"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`[log.indexOf('timeout'), log.indexOf('immediate')].map(function(i) { return log.slice(i, i + 3); }).join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "timeout,tick:timeout,microtask:timeout,immediate,tick:immediate:ab"; !strings.HasPrefix(g, e) {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, src := range []string{
+		`process.nextTick()`,
+		`queueMicrotask({})`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", src)
+		}
+	}
+}
+
+func TestNextTick_Host(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	vm.Set("host", func() {
+		if _, err := vm.Call(`push`, nil, "host"); err != nil {
+			t.Error(module.Wrap(err))
+		}
+	})
+	src := `
+		var log = [];
+		function push(s) {
+			process.nextTick(function() {
+				log.push('tick:' + s);
+			});
+			log.push(s);
+		}
+		function f() {
+			host();
+			log.push('f');
+		}
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if _, err := vm.Call(`f`, nil); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	fn, err := vm.Get("push")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.MakeCallback(fn, otto.UndefinedValue(), "callback"); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "host,f,tick:host,callback,tick:callback"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	// error
+	if _, err := vm.Run(`process.nextTick(function() { throw new Error('tick'); }); process.nextTick(push, 'next');`); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := vm.Run(`undefined`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.slice(-2).join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "next,tick:next"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
@@ -11,10 +11,13 @@
 package module
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sync"
 
 	"github.com/robertkrimen/otto"
@@ -32,13 +35,69 @@ type Otto struct {
 	loaders  []Loader
 	bindings map[string]Binding
 	cache    map[string]otto.Value
+	buffer   buffer
+	stream   stream
+	loop     loop
+
+	fs               FS
+	exec             ExecPolicy
+	transport        http.RoundTripper
+	net              NetPolicy
+	stdin            io.Reader
+	stdout           io.Writer
+	stderr           io.Writer
+	logger           *slog.Logger
+	platform         string
+	argv             []string
+	argv0            string
+	execPath         string
+	execArgv         []string
+	warn             func(*Warning)
+	handlerErrorFunc func(*HandlerError)
+	deprecation      DeprecationMode
+	coverage         *Coverage
 }
 
-func New() (*Otto, error) {
+type Option func(*Otto)
+
+func New(opts ...Option) (*Otto, error) {
 	vm := &Otto{
 		Otto:     otto.New(),
 		bindings: make(map[string]Binding),
 		cache:    make(map[string]otto.Value),
+		fs:       OSFS{},
+		stdin:    os.Stdin,
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+	}
+	vm.loop.wake = make(chan struct{}, 1)
+	vm.loop.active = make(map[int64]*timer)
+	for _, o := range opts {
+		o(vm)
+	}
+	if vm.warn == nil {
+		vm.warn = warningWriter(vm.stderr)
+	}
+	if vm.platform == "" {
+		vm.platform = platform()
+	}
+	if vm.transport == nil && vm.net != nil {
+		vm.transport = vm.netTransport()
+	}
+	if vm.execPath == "" {
+		vm.execPath = execPath()
+	}
+	if vm.argv0 == "" {
+		switch {
+		case len(vm.argv) > 0:
+			vm.argv0 = vm.argv[0]
+		case vm.argv == nil && len(os.Args) > 0:
+			vm.argv0 = os.Args[0]
+		}
+	}
+	if vm.argv == nil {
+		// the arguments of the host are not for the script
+		vm.argv = []string{vm.execPath}
 	}
 	vm.init()
 
@@ -64,6 +123,41 @@ func (vm *Otto) init() {
 		o.Set("resolve", vm.resolve)
 		return nil
 	})
+	if vm.coverage != nil {
+		vm.Set(covFunc, vm.coverage_file)
+	}
+	vm.Bind("buffer", vm.buffer_binding)
+	vm.Bind("child_process", vm.child_process_binding)
+	vm.Bind("console", vm.console_binding)
+	vm.Bind("crypto", vm.crypto_binding)
+	vm.Bind("fs", func(o *otto.Object) error {
+		o.Set("readFile", vm.fs_readFile)
+		o.Set("writeFile", vm.fs_writeFile)
+		o.Set("exists", vm.fs_exists)
+		o.Set("stat", vm.fs_stat)
+		o.Set("lstat", vm.fs_lstat)
+		o.Set("readdir", vm.fs_readdir)
+		o.Set("mkdir", vm.fs_mkdir)
+		o.Set("rm", vm.fs_rm)
+		o.Set("rename", vm.fs_rename)
+		o.Set("realpath", vm.fs_realpath)
+		return nil
+	})
+	vm.Bind("http", vm.http_binding)
+	vm.Bind("net", vm.net_binding)
+	vm.Bind("os", vm.os_binding)
+	vm.Bind("stream", vm.stream_binding)
+	vm.Bind("task_queue", func(o *otto.Object) error {
+		o.Set("setTickCallback", vm.task_queue_setTickCallback)
+		return nil
+	})
+	vm.Bind("timers", vm.timers_binding)
+	vm.Bind("warning", func(o *otto.Object) error {
+		o.Set("create", vm.warning_create)
+		o.Set("emit", vm.warning_emit)
+		return nil
+	})
+	vm.Bind("zlib", vm.zlib_binding)
 }
 
 func (vm *Otto) bootstrap(id string) (v otto.Value, err error) {
@@ -77,7 +171,7 @@ func (vm *Otto) bootstrap(id string) (v otto.Value, err error) {
 	if err != nil {
 		return
 	}
-	fn, err := vm.Run(script)
+	fn, err := vm.Otto.Run(script)
 	if err != nil {
 		return
 	}
@@ -95,12 +189,20 @@ func (vm *Otto) compile(call otto.FunctionCall) otto.Value {
 	if err != nil {
 		return vm.throw(err)
 	}
+	b = vm.wrap(b)
+	// instrument
+	if _, ok := files[id]; !ok && vm.coverage != nil {
+		b, err = vm.coverage.instrument(id, b)
+		if err != nil {
+			return vm.throw(err)
+		}
+	}
 	// compile
-	script, err := vm.Compile(id, vm.wrap(b))
+	script, err := vm.Compile(id, b)
 	if err != nil {
 		return vm.throw(err)
 	}
-	v, _ := vm.Run(script)
+	v, _ := vm.Otto.Run(script)
 	return v
 }
 
@@ -145,7 +247,7 @@ func (vm *Otto) resolve(call otto.FunctionCall) otto.Value {
 }
 
 func (vm *Otto) process() otto.Value {
-	v, _ := vm.Run([]byte("(function() {\nfunction process() {\n}\nreturn new process();\n})();"))
+	v, _ := vm.Otto.Run([]byte("(function() {\nfunction process() {\n}\nreturn new process();\n})();"))
 	o := v.Object()
 	o.Set("binding", vm.binding)
 	env, _ := vm.Object(`({})`)
@@ -153,11 +255,16 @@ func (vm *Otto) process() otto.Value {
 	env.Set("__has__", vm.env_has)
 	env.Set("__set__", vm.env_set)
 	o.Set("env", env)
-	if runtime.GOOS == "windows" {
-		o.Set("platform", "win32")
-	} else {
-		o.Set("platform", runtime.GOOS)
-	}
+	o.Set("cwd", vm.cwd)
+	o.Set("pid", os.Getpid())
+	o.Set("platform", vm.platform)
+	o.Set("arch", arch())
+	o.Set("argv", vm.stringArray(vm.argv))
+	o.Set("argv0", vm.argv0)
+	o.Set("execArgv", vm.stringArray(vm.execArgv))
+	o.Set("execPath", vm.execPath)
+	o.Set("noDeprecation", vm.deprecation == NoDeprecation)
+	o.Set("throwDeprecation", vm.deprecation == ThrowDeprecation)
 	return v
 }
 
@@ -174,6 +281,15 @@ func (vm *Otto) binding(call otto.FunctionCall) otto.Value {
 	return v
 }
 
+func (vm *Otto) cwd(call otto.FunctionCall) otto.Value {
+	wd, err := vm.fs.Abs(".")
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, _ := vm.ToValue(wd)
+	return v
+}
+
 func (vm *Otto) env_get(call otto.FunctionCall) otto.Value {
 	k, err := vm.toString("key", call.Argument(0))
 	if err != nil {
@@ -212,6 +328,12 @@ func (vm *Otto) env_set(call otto.FunctionCall) otto.Value {
 }
 
 func (vm *Otto) throw(err error) otto.Value {
+	var re *rangeError
+	if errors.As(err, &re) {
+		v := vm.MakeRangeError(re.Error())
+		v.Object().Set("code", "ERR_OUT_OF_RANGE")
+		panic(v)
+	}
 	return Throw(vm.Otto, err)
 }
 
@@ -221,3 +343,15 @@ func (vm *Otto) toString(name string, v otto.Value) (string, error) {
 	}
 	return v.ToString()
 }
+
+func (vm *Otto) toBytes(name string, v otto.Value) ([]byte, error) {
+	if !v.IsObject() {
+		return nil, fmt.Errorf("%v must be a Buffer or Array", name)
+	}
+	o := v.Object()
+	n, err := lengthOf(name, o)
+	if err != nil {
+		return nil, err
+	}
+	return getBytes(o, 0, n)
+}
@@ -575,6 +575,217 @@ func TestBinding_VMError(t *testing.T) {
 	}
 }
 
+func TestProcess_EventEmitter(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var EventEmitter = require('events');
+		var calls = [];
+		process.once('event', function(v) {
+			calls.push(v);
+		});
+		process.emit('event', 1);
+		process.emit('event', 2);
+		[process instanceof EventEmitter, calls.join(), typeof process.binding].join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "true,1,function"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestUtil(t *testing.T) {
+	var w *module.Warning
+	vm, err := module.New(module.WithWarningFunc(func(warning *module.Warning) {
+		w = warning
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var util = require('util');
+		var o = { a: [1, 'b', { c: null }], d: new Date(0), e: /e/g };
+		o.self = o;
+		var fn = util.deprecate(function() {
+			return 1;
+		}, 'fn is deprecated', 'DEP0000');
+		[
+			util.format('%s:%d:%j', 'a', 42, { b: 1 }),
+			util.inspect(o),
+			util.isDeepStrictEqual({ a: [1, 2] }, { a: [1, 2] }),
+			fn() + fn(),
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		`a:42:{"b":1}`,
+		`<ref *1> {`,
+		`  a: [ 1, 'b', { c: null } ],`,
+		`  d: 1970-01-01T00:00:00.000Z,`,
+		`  e: /e/g,`,
+		`  self: [Circular *1]`,
+		`}`,
+		`true`,
+		`2`,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if w == nil {
+		t.Error("expected warning")
+	} else if g, e := w.Name+" "+w.Code+" "+w.Message, "DeprecationWarning DEP0000 fn is deprecated"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestUtil_Callbackify(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var util = require('util');
+		var log = [];
+		util.callbackify(function(v) {
+			return v * 2;
+		})(21, function(err, v) {
+			log.push('resolve:' + err + ':' + v);
+		});
+		util.callbackify(function() {
+			throw null;
+		})(function(err) {
+			log.push('reject:' + err.code);
+		});
+		log.push('main');
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`log.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "main,resolve:null:42,reject:ERR_FALSY_VALUE_REJECTION"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestAssert(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var assert = require('assert');
+		assert.ok(true);
+		assert.strictEqual(1, 1);
+		assert.deepStrictEqual({ a: [1, { b: 2 }] }, { a: [1, { b: 2 }] });
+		assert.notDeepStrictEqual({ a: 1 }, { a: '1' });
+		assert.throws(function() {
+			throw new TypeError('error');
+		}, TypeError);
+		assert.doesNotThrow(function() {});
+		assert.match('abc', /b/);
+		try {
+			assert.deepStrictEqual({ a: 1 }, { a: 2 });
+		} catch (e) {
+			[e instanceof assert.AssertionError, e.code, e.operator, e.message].join('\n');
+		}
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		`true`,
+		`ERR_ASSERTION`,
+		`deepStrictEqual`,
+		`Expected values to be strictly deep-equal:`,
+		`+ actual - expected`,
+		``,
+		`  {`,
+		`+   a: 1`,
+		`-   a: 2`,
+		`  }`,
+		``,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	if _, err := vm.Run(`require('assert').strictEqual(1, 2);`); err == nil {
+		t.Error("expected error")
+	} else if !strings.HasPrefix(err.Error(), "AssertionError [ERR_ASSERTION]: Expected values to be strictly equal:") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestURL(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var url = require('url');
+		var u = new URL('HTTP://user@\u4f8b\u3048.\u30c6\u30b9\u30c8:80/a/../b?x=1#h');
+		u.searchParams.append('y', 'a b');
+		[
+			u.href,
+			u.origin,
+			URL === url.URL && URLSearchParams === url.URLSearchParams,
+			new URL('http://h/😀').pathname,
+			url.parse('http://a.com/b?c=d', true).query.c,
+			url.resolve('http://a/b/c/d;p?q', '../../g'),
+			url.fileURLToPath('file:///a%20b'),
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		`http://user@xn--r8jz45g.xn--zckzah/b?x=1&y=a+b#h`,
+		`http://xn--r8jz45g.xn--zckzah`,
+		`true`,
+		`/%F0%9F%98%80`,
+		`d`,
+		`http://a/g`,
+		`/a b`,
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.ToSlash(filepath.Join(wd, "file"))
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if v, err := vm.Run(`require('url').pathToFileURL('file').href`); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "file://"+p; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	vm, err = module.New(module.WithPlatform("win32"))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src = `
+		var url = require('url');
+		[url.fileURLToPath('file:///C:/a%20b'), url.pathToFileURL('C:\\a b').href].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "C:\\a b\nfile:///C:/a%20b"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
 func TestEnv_Get(t *testing.T) {
 	vm, err := module.New()
 	if err != nil {
@@ -0,0 +1,427 @@
+//
+// otto.module :: net.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+// NetPolicy checks the addresses with op "dial" and "listen" before name
+// resolution, and with op "connect" for each address which is dialed after
+// that.
+type NetPolicy interface {
+	Check(op, address string) error
+}
+
+type NetPolicyFunc func(op, address string) error
+
+func (f NetPolicyFunc) Check(op, address string) error {
+	return f(op, address)
+}
+
+// AddressPolicy matches the addresses before name resolution. Each entry is
+// "host:port" or "host" which allows any port, and host can be a CIDR.
+//
+// A host name can resolve to any address, so loopback, private and link-local
+// addresses are allowed to connect only when Dial lists them as an address or
+// a CIDR.
+type AddressPolicy struct {
+	Dial   []string
+	Listen []string
+}
+
+func (p *AddressPolicy) Check(op, address string) error {
+	var list []string
+	switch op {
+	case "dial":
+		list = p.Dial
+	case "connect":
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			break
+		}
+		a, err := netip.ParseAddr(host)
+		if err != nil {
+			break
+		} else if a = a.Unmap(); a.IsGlobalUnicast() && !a.IsPrivate() {
+			return nil
+		}
+		list = p.Dial
+	case "listen":
+		list = p.Listen
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || !slices.ContainsFunc(list, func(s string) bool {
+		h, p, err := net.SplitHostPort(s)
+		if err != nil {
+			h, p = s, ""
+		}
+		if p != "" && p != port {
+			return false
+		}
+		if pfx, err := netip.ParsePrefix(h); err == nil {
+			a, err := netip.ParseAddr(host)
+			return err == nil && pfx.Contains(a.Unmap())
+		}
+		return strings.EqualFold(h, host)
+	}) {
+		return fmt.Errorf("%v not allowed: %v", op, address)
+	}
+	return nil
+}
+
+// WithNetPolicy sets the policy which checks the addresses of outgoing
+// connections and listeners. Without a policy, all of them are allowed.
+func WithNetPolicy(p NetPolicy) Option {
+	return func(vm *Otto) {
+		vm.net = p
+	}
+}
+
+func (vm *Otto) net_binding(o *otto.Object) error {
+	o.Set("connect", vm.net_connect)
+	o.Set("listen", vm.net_listen)
+	o.Set("isIP", vm.net_isIP)
+	return nil
+}
+
+func (vm *Otto) net_connect(call otto.FunctionCall) otto.Value {
+	host, err := vm.toString("host", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	port, err := call.Argument(1).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	onconnect := call.Argument(2)
+
+	addr := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	if err := vm.checkNet("dial", addr); err != nil {
+		return vm.throwNet(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	vm.Ref()
+	go func() {
+		c, err := vm.dialer().DialContext(ctx, "tcp", addr)
+		vm.Enqueue(func() error {
+			vm.Unref()
+			cancel()
+			if err != nil {
+				e, ok := vm.netError(err)
+				if !ok {
+					e, ok = vm.accessDenied(err, "Net")
+				}
+				if !ok {
+					e = vm.streamError(err)
+				}
+				_, err = onconnect.Call(otto.UndefinedValue(), e)
+				return err
+			}
+			_, err := onconnect.Call(otto.UndefinedValue(), otto.NullValue(), vm.newSocket(c))
+			return err
+		})
+	}()
+
+	o, _ := vm.Object(`({})`)
+	o.Set("abort", func(call otto.FunctionCall) otto.Value {
+		cancel()
+		return otto.UndefinedValue()
+	})
+	return o.Value()
+}
+
+func (vm *Otto) net_listen(call otto.FunctionCall) otto.Value {
+	var host string
+	if v := call.Argument(0); v.IsDefined() && !v.IsNull() {
+		var err error
+		if host, err = vm.toString("host", v); err != nil {
+			return vm.throw(err)
+		}
+	}
+	port, err := call.Argument(1).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	onconnection := call.Argument(2)
+
+	l, err := vm.listen(host, port)
+	if err != nil {
+		return vm.throwNet(err)
+	}
+	s := &netServer{
+		vm:    vm,
+		l:     l,
+		refed: true,
+	}
+	vm.Ref()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			vm.Enqueue(func() error {
+				if s.closed {
+					c.Close()
+					return nil
+				}
+				_, err := onconnection.Call(otto.UndefinedValue(), vm.newSocket(c))
+				return err
+			})
+		}
+	}()
+
+	o, _ := vm.Object(`({})`)
+	o.Set("address", vm.addressOf(l.Addr()))
+	o.Set("close", s.close)
+	o.Set("ref", s.ref)
+	o.Set("unref", s.unref)
+	return o.Value()
+}
+
+func (vm *Otto) net_isIP(call otto.FunctionCall) otto.Value {
+	var n int
+	if v := call.Argument(0); v.IsString() {
+		if a, err := netip.ParseAddr(v.String()); err == nil {
+			if a.Is4() {
+				n = 4
+			} else {
+				n = 6
+			}
+		}
+	}
+	v, _ := vm.ToValue(n)
+	return v
+}
+
+func (vm *Otto) checkNet(op, address string) error {
+	if vm.net != nil {
+		if err := vm.net.Check(op, address); err != nil {
+			return &permissionError{err}
+		}
+	}
+	return nil
+}
+
+func (vm *Otto) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	if err := vm.checkNet("dial", address); err != nil {
+		return nil, err
+	}
+	return vm.dialer().DialContext(ctx, network, address)
+}
+
+// dialer returns a net.Dialer which checks the resolved addresses before
+// connecting to them.
+func (vm *Otto) dialer() *net.Dialer {
+	d := new(net.Dialer)
+	if vm.net != nil {
+		d.Control = func(_, address string, _ syscall.RawConn) error {
+			return vm.checkNet("connect", address)
+		}
+	}
+	return d
+}
+
+func (vm *Otto) listen(host string, port int64) (net.Listener, error) {
+	addr := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	if err := vm.checkNet("listen", addr); err != nil {
+		return nil, err
+	}
+	return net.Listen("tcp", addr)
+}
+
+func (vm *Otto) throwNet(err error) otto.Value {
+	if v, ok := vm.netError(err); ok {
+		panic(v)
+	}
+	return vm.throwPermission(err, "Net")
+}
+
+type netServer struct {
+	vm     *Otto
+	l      net.Listener
+	refed  bool
+	closed bool
+}
+
+func (s *netServer) close(call otto.FunctionCall) otto.Value {
+	if !s.closed {
+		s.closed = true
+		s.l.Close()
+		if s.refed {
+			s.vm.Unref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (s *netServer) ref(call otto.FunctionCall) otto.Value {
+	if !s.refed {
+		s.refed = true
+		if !s.closed {
+			s.vm.Ref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (s *netServer) unref(call otto.FunctionCall) otto.Value {
+	if s.refed {
+		s.refed = false
+		if !s.closed {
+			s.vm.Unref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) newSocket(c net.Conn) *otto.Object {
+	o := vm.newReaderHandle(c, c, vm.socketError)
+	w := vm.newWriterHandle(c, closeWriter{c}, false, vm.socketError)
+	v, _ := w.Get("write")
+	o.Set("write", v)
+	v, _ = w.Get("close")
+	o.Set("shutdown", v)
+	o.Set("local", vm.addressOf(c.LocalAddr()))
+	o.Set("remote", vm.addressOf(c.RemoteAddr()))
+	o.Set("setNoDelay", func(call otto.FunctionCall) otto.Value {
+		if c, ok := c.(*net.TCPConn); ok {
+			b, _ := call.Argument(0).ToBoolean()
+			c.SetNoDelay(b)
+		}
+		return otto.UndefinedValue()
+	})
+	o.Set("setKeepAlive", func(call otto.FunctionCall) otto.Value {
+		if c, ok := c.(*net.TCPConn); ok {
+			b, _ := call.Argument(0).ToBoolean()
+			c.SetKeepAlive(b)
+			if ms, _ := call.Argument(1).ToInteger(); b && ms > 0 {
+				c.SetKeepAlivePeriod(time.Duration(ms) * time.Millisecond)
+			}
+		}
+		return otto.UndefinedValue()
+	})
+	return o
+}
+
+// closeWriter shuts down the writing side of the connection. The error is
+// ignored like libuv does since the peer may have already closed it.
+type closeWriter struct {
+	c net.Conn
+}
+
+func (w closeWriter) Close() error {
+	if c, ok := w.c.(interface{ CloseWrite() error }); ok {
+		c.CloseWrite()
+	}
+	return nil
+}
+
+func (vm *Otto) socketError(err error) otto.Value {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "read" || opErr.Op == "write") {
+		for _, e := range netErrnos {
+			if errors.Is(err, e.errno) {
+				v := vm.MakeCustomError("Error", opErr.Op+" "+e.code)
+				o := v.Object()
+				o.Set("errno", -int(e.errno))
+				o.Set("code", e.code)
+				o.Set("syscall", opErr.Op)
+				return v
+			}
+		}
+	}
+	return vm.streamError(err)
+}
+
+var netErrnos = []struct {
+	errno syscall.Errno
+	code  string
+	desc  string
+}{
+	{syscall.EACCES, "EACCES", "permission denied"},
+	{syscall.EADDRINUSE, "EADDRINUSE", "address already in use"},
+	{syscall.EADDRNOTAVAIL, "EADDRNOTAVAIL", "address not available"},
+	{syscall.ECONNREFUSED, "ECONNREFUSED", "connection refused"},
+	{syscall.ECONNRESET, "ECONNRESET", "connection reset by peer"},
+	{syscall.EHOSTUNREACH, "EHOSTUNREACH", "host is unreachable"},
+	{syscall.ENETUNREACH, "ENETUNREACH", "network is unreachable"},
+	{syscall.EPIPE, "EPIPE", "broken pipe"},
+	{syscall.ETIMEDOUT, "ETIMEDOUT", "connection timed out"},
+}
+
+// netError converts the errors of the net package like libuv does.
+func (vm *Otto) netError(err error) (otto.Value, bool) {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		v := vm.MakeCustomError("Error", "getaddrinfo ENOTFOUND "+dnsErr.Name)
+		o := v.Object()
+		o.Set("errno", -3008)
+		o.Set("code", "ENOTFOUND")
+		o.Set("syscall", "getaddrinfo")
+		o.Set("hostname", dnsErr.Name)
+		return v, true
+	case errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "listen"):
+		for _, e := range netErrnos {
+			if !errors.Is(err, e.errno) {
+				continue
+			}
+			addr := fmt.Sprint(opErr.Addr)
+			var msg, op string
+			if opErr.Op == "dial" {
+				op = "connect"
+				msg = fmt.Sprintf("%v %v %v", op, e.code, addr)
+			} else {
+				op = "listen"
+				msg = fmt.Sprintf("%v %v: %v %v", op, e.code, e.desc, addr)
+			}
+			v := vm.MakeCustomError("Error", msg)
+			o := v.Object()
+			o.Set("errno", -int(e.errno))
+			o.Set("code", e.code)
+			o.Set("syscall", op)
+			if host, port, err := net.SplitHostPort(addr); err == nil {
+				o.Set("address", host)
+				if n, err := strconv.Atoi(port); err == nil {
+					o.Set("port", n)
+				}
+			}
+			return v, true
+		}
+	}
+	return otto.UndefinedValue(), false
+}
+
+func (vm *Otto) addressOf(a net.Addr) otto.Value {
+	o, _ := vm.Object(`({})`)
+	if a, ok := a.(*net.TCPAddr); ok {
+		family := "IPv6"
+		if a.IP.To4() != nil {
+			family = "IPv4"
+		}
+		o.Set("address", a.IP.String())
+		o.Set("family", family)
+		o.Set("port", a.Port)
+	}
+	return o.Value()
+}
@@ -0,0 +1,321 @@
+//
+// otto.module :: net_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestNet(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var net = require('net');
+		var out = [];
+		var remote;
+		var server = net.createServer(function(sock) {
+			remote = sock.remoteAddress;
+			sock.setEncoding('utf8');
+			var buf = '';
+			sock.on('data', function(data) {
+				buf += data;
+				for (var i; (i = buf.indexOf('\n')) !== -1; buf = buf.slice(i + 1)) {
+					sock.write(buf.slice(0, i).toUpperCase() + '\n');
+				}
+			});
+			sock.on('end', function() { out.push('server end'); });
+			sock.on('close', function(hadError) { out.push('server close ' + hadError); });
+		});
+		server.on('close', function() { out.push('close'); });
+		server.listen(0, '127.0.0.1', function() {
+			var addr = server.address();
+			out.push('listening ' + addr.address + ' ' + addr.family);
+			var c = net.connect(addr.port, '127.0.0.1', function() {
+				out.push('connect ' + (c.remotePort === addr.port));
+			});
+			// written before the connection is established
+			c.write('hello\nwor');
+			c.end('ld\n');
+			c.setEncoding('utf8');
+			var data = '';
+			c.on('data', function(s) { data += s; });
+			c.on('end', function() { out.push('client end ' + JSON.stringify(data)); });
+			c.on('close', function(hadError) {
+				out.push('client close ' + hadError + ' ' + c.bytesWritten + ' ' + c.bytesRead);
+				out.push('remote ' + remote);
+				server.close();
+			});
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"listening 127.0.0.1 IPv4",
+		"connect true",
+		"server end",
+		"server close false",
+		`client end "HELLO\nWORLD\n"`,
+		"client close false 12 12",
+		"remote 127.0.0.1",
+		"close",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestNetServer(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var net = require('net');
+		var out = [];
+		var server = net.createServer({ allowHalfOpen: true }, function(sock) {
+			var lines = [];
+			sock.setEncoding('utf8');
+			sock.on('data', function(s) { lines.push(s.trim()); });
+			sock.on('end', function() {
+				sock.end(lines.length + ' lines\n');
+			});
+		});
+		server.on('close', function() { out.push('close'); });
+		server.listen(0, '127.0.0.1');
+		server.address().port;
+	`
+	v, err := vm.Run(src)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	done := make(chan error)
+	go func() {
+		done <- vm.RunLoop(context.Background())
+	}()
+
+	c, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%v", v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(c, "a\n")
+	c.(*net.TCPConn).CloseWrite()
+	s, err := bufio.NewReader(c).ReadString('\n')
+	c.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, e := s, "1 lines\n"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	vm.Enqueue(func() error {
+		_, err := vm.Otto.Run(`server.close()`)
+		return err
+	})
+	if err := <-done; err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "close"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestNetPolicy(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	vm, err := module.New(module.WithNetPolicy(&module.AddressPolicy{
+		Dial:   []string{"127.0.0.1:" + port},
+		Listen: []string{"127.0.0.0/8"},
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("port", port)
+
+	src := `
+		var net = require('net');
+		var out = [];
+		function onerror(e) {
+			out.push([e.message, e.code, e.permission].join());
+		}
+		try {
+			net.connect(80, 'example.com');
+		} catch (e) {
+			onerror(e);
+		}
+		net.createServer().on('error', onerror).listen(0, '0.0.0.0');
+		require('http').createServer().on('error', onerror).listen(0, '::1');
+		var server = net.createServer().listen(0, '127.0.0.2', function() {
+			out.push('listening');
+			server.close();
+		});
+		net.connect(+port, '127.0.0.1', function() {
+			out.push('connect');
+		}).on('close', function() {
+			out.push('close');
+		}).resume();
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"dial not allowed: example.com:80,ERR_ACCESS_DENIED,Net",
+		"listen not allowed: 0.0.0.0:0,ERR_ACCESS_DENIED,Net",
+		"listen not allowed: [::1]:0,ERR_ACCESS_DENIED,Net",
+		"listening",
+		"connect",
+		"close",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestAddressPolicy(t *testing.T) {
+	p := &module.AddressPolicy{
+		Dial:   []string{"example.com:443", "10.0.0.0/8", "[::1]:80"},
+		Listen: []string{"localhost", ":8080"},
+	}
+	for _, tt := range []struct {
+		op, addr string
+	}{
+		{"dial", "example.com:443"},
+		{"dial", "EXAMPLE.com:443"},
+		{"dial", "10.1.2.3:22"},
+		{"dial", "[::ffff:10.0.0.1]:22"},
+		{"dial", "[::1]:80"},
+		{"connect", "10.1.2.3:22"},
+		{"connect", "[::1]:80"},
+		{"connect", "93.184.215.14:443"},
+		{"listen", "localhost:0"},
+		{"listen", ":8080"},
+	} {
+		if err := p.Check(tt.op, tt.addr); err != nil {
+			t.Errorf("%v %v: unexpected error: %v", tt.op, tt.addr, err)
+		}
+	}
+	for _, tt := range []struct {
+		op, addr string
+	}{
+		{"dial", "example.com:80"},
+		{"dial", "www.example.com:443"},
+		{"dial", "11.0.0.1:22"},
+		{"dial", "[::1]:443"},
+		{"dial", "localhost:8080"},
+		{"listen", "127.0.0.1:0"},
+		{"listen", ":8081"},
+		{"connect", "example.com:443"},
+		{"connect", "127.0.0.1:443"},
+		{"connect", "192.168.0.1:443"},
+		{"connect", "[fe80::1]:443"},
+		{"connect", "[::1]:443"},
+		{"dial", "example.com"},
+	} {
+		if err := p.Check(tt.op, tt.addr); err == nil {
+			t.Errorf("%v %v: expected error", tt.op, tt.addr)
+		}
+	}
+}
+
+func TestNetError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("addr", addr)
+
+	src := `
+		var net = require('net');
+		var out = [];
+		var c = net.connect(+addr.split(':')[1], '127.0.0.1');
+		c.on('error', function(e) {
+			out.push([e.code, e.errno < 0, e.syscall, e.address + ':' + e.port === addr, e.message === 'connect ECONNREFUSED ' + addr].join());
+		});
+		c.on('close', function(hadError) { out.push('close ' + hadError); });
+		var d = net.connect(+addr.split(':')[1], '127.0.0.1');
+		d.on('connect', function() { out.push('connect'); });
+		d.on('close', function(hadError) { out.push('destroyed ' + hadError); });
+		d.destroy();
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "destroyed false\nECONNREFUSED,true,connect,true,true\nclose true"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`net.connect()`, `TypeError: The "options" or "port" or "path" argument must be specified`},
+		{`net.connect(65536)`, "RangeError: Port should be >= 0 and < 65536. Received type number (65536)."},
+		{`net.connect({ port: 80, host: 1 })`, `TypeError: The "options.host" property must be of type string. Received type number (1)`},
+		{`net.createServer().listen(-1)`, "RangeError: options.port should be >= 0 and < 65536. Received type number (-1)."},
+		{`var s = net.createServer().listen(0); try { s.listen(0); } finally { s.close(); }`, "Error: Listen method has been called more than once without closing."},
+		{`net.connect(80).setTimeout(-1)`, `RangeError: The value of "msecs" is out of range. It must be >= 0. Received -1`},
+	} {
+		if _, err := vm.Run(tt.src); err == nil {
+			t.Errorf("%v: expected error", tt.src)
+		} else if g, e := module.Wrap(err).Error(), tt.err; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+
+	if v, err := vm.Run(`[net.isIP('127.0.0.1'), net.isIP('::1'), net.isIP('fe80::1%eth0'), net.isIP('01.2.3.4'), net.isIP('localhost'), net.isIPv4('::1'), net.isIPv6('::1')].join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "4,6,6,0,0,false,true"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
@@ -0,0 +1,235 @@
+//
+// otto.module :: os.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"os/user"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+func WithPlatform(platform string) Option {
+	return func(vm *Otto) {
+		vm.platform = platform
+	}
+}
+
+func platform() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "win32"
+	case "solaris", "illumos":
+		return "sunos"
+	}
+	return runtime.GOOS
+}
+
+func arch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x64"
+	case "386":
+		return "ia32"
+	case "ppc64le":
+		return "ppc64"
+	case "mips64le":
+		return "mips64el"
+	case "mipsle":
+		return "mipsel"
+	}
+	return runtime.GOARCH
+}
+
+func (vm *Otto) os_binding(o *otto.Object) error {
+	o.Set("platform", vm.platform)
+	o.Set("arch", arch())
+	switch vm.platform {
+	case "win32":
+		o.Set("EOL", "\r\n")
+		o.Set("devNull", `\\.\nul`)
+		o.Set("type", "Windows_NT")
+	default:
+		o.Set("EOL", "\n")
+		o.Set("devNull", "/dev/null")
+		switch vm.platform {
+		case "sunos":
+			o.Set("type", "SunOS")
+		case "aix":
+			o.Set("type", "AIX")
+		case "freebsd":
+			o.Set("type", "FreeBSD")
+		case "openbsd":
+			o.Set("type", "OpenBSD")
+		case "netbsd":
+			o.Set("type", "NetBSD")
+		case "android":
+			o.Set("type", "Linux")
+		default:
+			o.Set("type", strings.ToUpper(vm.platform[:1])+vm.platform[1:])
+		}
+	}
+	if binary.NativeEndian.Uint16([]byte{1, 0}) == 1 {
+		o.Set("endianness", "LE")
+	} else {
+		o.Set("endianness", "BE")
+	}
+	sigs, _ := vm.Object(`({})`)
+	for k, v := range signals {
+		sigs.Set(k, int(v))
+	}
+	o.Set("signals", sigs)
+	o.Set("hostname", vm.os_hostname)
+	o.Set("homedir", vm.os_homedir)
+	o.Set("tmpdir", vm.os_tmpdir)
+	o.Set("userInfo", vm.os_userInfo)
+	o.Set("release", vm.os_release)
+	o.Set("cpus", vm.os_cpus)
+	o.Set("totalmem", vm.os_totalmem)
+	o.Set("freemem", vm.os_freemem)
+	return nil
+}
+
+func (vm *Otto) os_hostname(call otto.FunctionCall) otto.Value {
+	name, err := os.Hostname()
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, _ := vm.ToValue(name)
+	return v
+}
+
+func (vm *Otto) os_homedir(call otto.FunctionCall) otto.Value {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, _ := vm.ToValue(dir)
+	return v
+}
+
+func (vm *Otto) os_tmpdir(call otto.FunctionCall) otto.Value {
+	dir := os.TempDir()
+	if len(dir) > 1 && os.IsPathSeparator(dir[len(dir)-1]) && !strings.HasSuffix(dir, ":\\") {
+		dir = dir[:len(dir)-1]
+	}
+	v, _ := vm.ToValue(dir)
+	return v
+}
+
+func (vm *Otto) os_userInfo(call otto.FunctionCall) otto.Value {
+	u, err := user.Current()
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	o, _ := vm.Object(`({})`)
+	for k, s := range map[string]string{
+		"uid": u.Uid,
+		"gid": u.Gid,
+	} {
+		if i, err := strconv.Atoi(s); err == nil {
+			o.Set(k, i)
+		} else {
+			o.Set(k, -1)
+		}
+	}
+	o.Set("username", u.Username)
+	o.Set("homedir", u.HomeDir)
+	if sh := os.Getenv("SHELL"); sh != "" && runtime.GOOS != "windows" {
+		o.Set("shell", sh)
+	} else {
+		o.Set("shell", nil)
+	}
+	return o.Value()
+}
+
+func (vm *Otto) os_release(call otto.FunctionCall) otto.Value {
+	var s string
+	if b, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+		s = string(bytes.TrimSpace(b))
+	}
+	v, _ := vm.ToValue(s)
+	return v
+}
+
+func (vm *Otto) os_cpus(call otto.FunctionCall) otto.Value {
+	var model string
+	var speed int
+	if f, err := os.Open("/proc/cpuinfo"); err == nil {
+		defer f.Close()
+
+		sc := bufio.NewScanner(f)
+		for sc.Scan() && (model == "" || speed == 0) {
+			k, v, ok := strings.Cut(sc.Text(), ":")
+			if !ok {
+				continue
+			}
+			switch strings.TrimSpace(k) {
+			case "model name":
+				model = strings.TrimSpace(v)
+			case "cpu MHz":
+				f, _ := strconv.ParseFloat(strings.TrimSpace(v), 64)
+				speed = int(f)
+			}
+		}
+	}
+
+	cpus := make([]any, runtime.NumCPU())
+	for i := range cpus {
+		cpus[i] = map[string]any{
+			"model": model,
+			"speed": speed,
+			"times": map[string]any{
+				"user": 0,
+				"nice": 0,
+				"sys":  0,
+				"idle": 0,
+				"irq":  0,
+			},
+		}
+	}
+	v, _ := vm.ToValue(cpus)
+	return v
+}
+
+func (vm *Otto) os_totalmem(call otto.FunctionCall) otto.Value {
+	v, _ := vm.ToValue(meminfo("MemTotal"))
+	return v
+}
+
+func (vm *Otto) os_freemem(call otto.FunctionCall) otto.Value {
+	v, _ := vm.ToValue(meminfo("MemAvailable"))
+	return v
+}
+
+func meminfo(key string) int64 {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), ":")
+		if ok && k == key {
+			v = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(v), "kB"))
+			n, _ := strconv.ParseInt(v, 10, 64)
+			return n * 1024
+		}
+	}
+	return 0
+}
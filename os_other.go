@@ -0,0 +1,24 @@
+//
+// otto.module :: os_other.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+//go:build !unix
+
+package module
+
+var signals = map[string]int{
+	"SIGHUP":   1,
+	"SIGINT":   2,
+	"SIGILL":   4,
+	"SIGABRT":  22,
+	"SIGFPE":   8,
+	"SIGKILL":  9,
+	"SIGSEGV":  11,
+	"SIGTERM":  15,
+	"SIGBREAK": 21,
+	"SIGWINCH": 28,
+}
@@ -0,0 +1,106 @@
+//
+// otto.module :: os_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestOS(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	hostname, _ := os.Hostname()
+	home, _ := os.UserHomeDir()
+	src := `
+		var os = require('os');
+		var cpus = os.cpus();
+		var u = os.userInfo();
+		[
+			os.platform() === process.platform,
+			os.arch() === process.arch,
+			os.hostname(),
+			os.homedir(),
+			typeof os.tmpdir(),
+			typeof os.release(),
+			/^[BL]E$/.test(os.endianness()),
+			cpus.length > 0 && typeof cpus[0].model === 'string' && typeof cpus[0].times.idle === 'number',
+			typeof u.username === 'string' && typeof u.uid === 'number',
+			typeof os.totalmem() === 'number' && os.totalmem() >= os.freemem(),
+			os.constants.signals.SIGINT,
+			os.constants.signals.SIGTERM,
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"true",
+		"true",
+		hostname,
+		home,
+		"string",
+		"string",
+		"true",
+		"true",
+		"true",
+		"true",
+		"2",
+		"15",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestOS_Platform(t *testing.T) {
+	for _, tt := range []struct {
+		platform, eol, typ, sep string
+	}{
+		{"linux", "\n", "Linux", "/"},
+		{"darwin", "\n", "Darwin", "/"},
+		{"freebsd", "\n", "FreeBSD", "/"},
+		{"win32", "\r\n", "Windows_NT", `\`},
+	} {
+		vm, err := module.New(module.WithPlatform(tt.platform))
+		if err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+
+		src := `
+			var os = require('os');
+			var path = require('path');
+			[process.platform, os.platform(), os.EOL, os.type(), path.sep].join('|');
+		`
+		if v, err := vm.Run(src); err != nil {
+			t.Error(module.Wrap(err))
+		} else if g, e := v.String(), strings.Join([]string{tt.platform, tt.platform, tt.eol, tt.typ, tt.sep}, "|"); g != e {
+			t.Errorf("expected %q, got %q", e, g)
+		}
+	}
+
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	e := runtime.GOOS
+	if e == "windows" {
+		e = "win32"
+	}
+	if v, err := vm.Run(`require('os').platform();`); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g := v.String(); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
@@ -0,0 +1,86 @@
+//
+// otto.module :: process.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/robertkrimen/otto"
+)
+
+func WithArgv(argv ...string) Option {
+	return func(vm *Otto) {
+		vm.argv = append([]string{}, argv...)
+	}
+}
+
+func WithArgv0(argv0 string) Option {
+	return func(vm *Otto) {
+		vm.argv0 = argv0
+	}
+}
+
+func WithExecPath(path string) Option {
+	return func(vm *Otto) {
+		vm.execPath = path
+	}
+}
+
+func WithExecArgv(argv ...string) Option {
+	return func(vm *Otto) {
+		vm.execArgv = append([]string{}, argv...)
+	}
+}
+
+func execPath() string {
+	if p, err := os.Executable(); err == nil {
+		return p
+	} else if len(os.Args) > 0 {
+		if p, err := filepath.Abs(os.Args[0]); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func (vm *Otto) RunMain(ctx context.Context, id string, args ...string) error {
+	path, err := vm.fs.Abs(id)
+	if err != nil {
+		return err
+	}
+	argv0 := vm.execPath
+	if len(vm.argv) > 0 {
+		argv0 = vm.argv[0]
+	}
+	vm.argv = append([]string{argv0, path}, args...)
+	process, err := vm.Otto.Get("process")
+	if err != nil {
+		return err
+	}
+	process.Object().Set("argv", vm.stringArray(vm.argv))
+
+	if !isPath(id) && !filepath.IsAbs(id) {
+		id = "./" + id
+	}
+	if _, err := vm.Call("require", nil, id); err != nil {
+		return err
+	}
+	return vm.RunLoop(ctx)
+}
+
+func (vm *Otto) stringArray(a []string) otto.Value {
+	o, _ := vm.Object(`[]`)
+	for i, s := range a {
+		o.Set(strconv.Itoa(i), s)
+	}
+	return o.Value()
+}
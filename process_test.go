@@ -0,0 +1,98 @@
+//
+// otto.module :: process_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestProcess_Argv(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("args", os.Args)
+
+	src := `
+		[
+			Array.isArray(process.argv),
+			process.argv[0] === process.execPath,
+			process.argv.length,
+			process.argv0 === args[0],
+			JSON.stringify(process.execArgv),
+		].join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "true,true,1,true,[]"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	vm, err = module.New(
+		module.WithArgv("/usr/bin/otto", "script.js", "-v"),
+		module.WithArgv0("otto"),
+		module.WithExecPath("/usr/bin/otto"),
+		module.WithExecArgv("--stack-size=100"),
+	)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src = `
+		process.argv.push('x');
+		JSON.stringify([process.argv, process.argv0, process.execPath, process.execArgv]);
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), `[["/usr/bin/otto","script.js","-v","x"],"otto","/usr/bin/otto",["--stack-size=100"]]`; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	vm, err = module.New(module.WithArgv("otto", "script.js"))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`process.argv0`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "otto"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestRunMain(t *testing.T) {
+	var b strings.Builder
+	vm, err := module.New(module.WithStdout(&b), module.WithExecPath("/usr/bin/otto"))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Register(new(module.FileLoader))
+
+	main, _ := filepath.Abs(filepath.Join("testdata", "main.js"))
+	if err := vm.RunMain(context.Background(), "testdata/main.js", "-v", "a b"); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if g, e := b.String(), main+"\n"+`["-v","a b"]`+"\ndone\n"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if v, err := vm.Run(`process.argv.join()`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "/usr/bin/otto,"+main+",-v,a b"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	if err := vm.RunMain(context.Background(), "testdata/missing.js"); err == nil {
+		t.Error("expected error")
+	}
+}
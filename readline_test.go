@@ -0,0 +1,145 @@
+//
+// otto.module :: readline_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestReadline(t *testing.T) {
+	var stdout strings.Builder
+	vm, err := module.New(module.WithStdin(strings.NewReader("alice\r\n1\n2\n\n3")), module.WithStdout(&stdout))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var readline = require('readline');
+		var out = [];
+		var rl = readline.createInterface({ prompt: '> ' });
+		rl.question('name? ', function(name) {
+			out.push('name ' + name);
+			rl.prompt();
+			rl.on('line', function(line) {
+				out.push('line ' + JSON.stringify(line));
+				rl.prompt();
+			});
+		});
+		rl.on('close', function() {
+			out.push('close');
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"name alice",
+		`line "1"`,
+		`line "2"`,
+		`line ""`,
+		`line "3"`,
+		"close",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := stdout.String(), "name? > > > > > "; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestReadline_AsyncIterator(t *testing.T) {
+	vm, err := module.New(module.WithStdin(strings.NewReader("a\nb\nc\n")))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var readline = require('readline');
+		var out = [];
+		var it = readline.createInterface({ output: null }).asyncIterator();
+		(function next() {
+			it.next(function(err, r) {
+				if (r.done) {
+					out.push('done');
+				} else {
+					out.push(r.value);
+					setTimeout(next, 1);
+				}
+			});
+		})();
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "a\nb\nc\ndone"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestReadline_Close(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	vm, err := module.New(module.WithStdin(pr))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	go io.WriteString(pw, "quit\n")
+
+	src := `
+		var readline = require('readline');
+		var out = [];
+		var rl = readline.createInterface({ input: process.stdin, output: null });
+		rl.on('line', function(line) {
+			out.push(line);
+			rl.close();
+		});
+		rl.on('close', function() {
+			out.push('close');
+		});
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "quit\nclose"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	src = `
+		try {
+			rl.question('?', function() {});
+		} catch (e) {
+			[e.code, e.message].join();
+		}
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "ERR_USE_AFTER_CLOSE,readline was closed"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
@@ -0,0 +1,310 @@
+//
+// otto.module :: stream.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"errors"
+	"io"
+
+	"github.com/robertkrimen/otto"
+)
+
+func WithStdin(r io.Reader) Option {
+	return func(vm *Otto) {
+		vm.stdin = r
+	}
+}
+
+type stream struct {
+	fromReader otto.Value
+	fromWriter otto.Value
+}
+
+func (vm *Otto) stream_binding(o *otto.Object) error {
+	o.Set("setup", vm.stream_setup)
+	o.Set("stdin", vm.stream_stdin)
+	o.Set("stdout", vm.stream_stdout)
+	o.Set("stderr", vm.stream_stderr)
+	return nil
+}
+
+func (vm *Otto) stream_setup(call otto.FunctionCall) otto.Value {
+	fromReader := call.Argument(0)
+	fromWriter := call.Argument(1)
+	if !fromReader.IsFunction() || !fromWriter.IsFunction() {
+		return vm.throw(errors.New("fromReader and fromWriter must be a Function"))
+	}
+
+	vm.stream.fromReader = fromReader
+	vm.stream.fromWriter = fromWriter
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) stream_stdin(call otto.FunctionCall) otto.Value {
+	v, err := vm.newReadable(vm.stdin, nil)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) stream_stdout(call otto.FunctionCall) otto.Value {
+	v, err := vm.newWritable(vm.stdout, nil, true)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) stream_stderr(call otto.FunctionCall) otto.Value {
+	v, err := vm.newWritable(vm.stderr, nil, true)
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) NewReadable(r io.Reader) (otto.Value, error) {
+	return vm.newReadable(r, nil)
+}
+
+func (vm *Otto) NewWritable(w io.Writer) (otto.Value, error) {
+	return vm.newWritable(w, nil, false)
+}
+
+func (vm *Otto) newReadable(r io.Reader, c io.Closer) (otto.Value, error) {
+	if err := vm.loadStream(); err != nil {
+		return otto.UndefinedValue(), err
+	}
+	return vm.stream.fromReader.Call(otto.UndefinedValue(), vm.newReaderHandle(r, c, nil))
+}
+
+func (vm *Otto) newWritable(w io.Writer, c io.Closer, sync bool) (otto.Value, error) {
+	if err := vm.loadStream(); err != nil {
+		return otto.UndefinedValue(), err
+	}
+	return vm.stream.fromWriter.Call(otto.UndefinedValue(), vm.newWriterHandle(w, c, sync, nil))
+}
+
+func (vm *Otto) loadStream() error {
+	if vm.stream.fromReader.IsFunction() {
+		return nil
+	}
+	_, err := vm.Otto.Run(`require('stream')`)
+	return err
+}
+
+func (vm *Otto) newReaderHandle(r io.Reader, c io.Closer, errorf func(error) otto.Value) *otto.Object {
+	h := &readerHandle{vm: vm, r: r, c: c, errorf: errorf, refed: true}
+	if h.errorf == nil {
+		h.errorf = vm.streamError
+	}
+	o, _ := vm.Object(`({})`)
+	o.Set("read", h.read)
+	o.Set("ref", h.ref)
+	o.Set("unref", h.unref)
+	o.Set("close", h.close)
+	return o
+}
+
+func (vm *Otto) newWriterHandle(w io.Writer, c io.Closer, sync bool, errorf func(error) otto.Value) *otto.Object {
+	h := &writerHandle{vm: vm, w: w, c: c, sync: sync, errorf: errorf}
+	if h.errorf == nil {
+		h.errorf = vm.streamError
+	}
+	o, _ := vm.Object(`({})`)
+	o.Set("write", h.write)
+	o.Set("close", h.close)
+	return o
+}
+
+func (vm *Otto) streamError(err error) otto.Value {
+	return vm.MakeCustomError("Error", err.Error())
+}
+
+// readerHandle reads from an io.Reader on its own goroutine, and delivers
+// the results to the event loop.
+type readerHandle struct {
+	vm      *Otto
+	r       io.Reader
+	c       io.Closer
+	errorf  func(error) otto.Value
+	err     error
+	pending bool
+	refed   bool
+	closed  bool
+}
+
+func (h *readerHandle) read(call otto.FunctionCall) otto.Value {
+	n, err := call.Argument(0).ToInteger()
+	if err != nil || n <= 0 {
+		n = 16 * 1024
+	}
+	cb := call.Argument(1)
+	switch {
+	case h.closed || h.pending:
+		return otto.UndefinedValue()
+	case h.err != nil:
+		if err := h.done(cb, h.err); err != nil {
+			return h.vm.throw(err)
+		}
+		return otto.UndefinedValue()
+	}
+
+	h.pending = true
+	if h.refed {
+		h.vm.Ref()
+	}
+	go func() {
+		b := make([]byte, n)
+		var i int
+		var err error
+		for i == 0 && err == nil {
+			i, err = h.r.Read(b)
+		}
+		h.vm.Enqueue(func() error {
+			if h.closed {
+				return nil
+			}
+			h.pending = false
+			if h.refed {
+				h.vm.Unref()
+			}
+
+			if i == 0 {
+				return h.done(cb, err)
+			}
+			h.err = err
+			v, err := h.vm.NewBuffer(b[:i])
+			if err != nil {
+				return err
+			}
+			_, err = cb.Call(otto.UndefinedValue(), otto.NullValue(), v)
+			return err
+		})
+	}()
+	return otto.UndefinedValue()
+}
+
+func (h *readerHandle) done(cb otto.Value, err error) error {
+	if err == io.EOF {
+		_, err = cb.Call(otto.UndefinedValue(), otto.NullValue(), otto.NullValue())
+	} else {
+		_, err = cb.Call(otto.UndefinedValue(), h.errorf(err))
+	}
+	return err
+}
+
+func (h *readerHandle) ref(call otto.FunctionCall) otto.Value {
+	if !h.refed {
+		h.refed = true
+		if h.pending {
+			h.vm.Ref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (h *readerHandle) unref(call otto.FunctionCall) otto.Value {
+	if h.refed {
+		h.refed = false
+		if h.pending {
+			h.vm.Unref()
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (h *readerHandle) close(call otto.FunctionCall) otto.Value {
+	if h.closed {
+		return otto.UndefinedValue()
+	}
+	h.closed = true
+	if h.pending && h.refed {
+		h.vm.Unref()
+	}
+	h.pending = false
+	if h.c != nil {
+		h.c.Close()
+	}
+	return otto.UndefinedValue()
+}
+
+// writerHandle writes to an io.Writer on its own goroutine, and reports the
+// results to the event loop. If sync is true, it writes on the event loop
+// like console.
+type writerHandle struct {
+	vm     *Otto
+	w      io.Writer
+	c      io.Closer
+	errorf func(error) otto.Value
+	sync   bool
+	closed bool
+}
+
+func (h *writerHandle) write(call otto.FunctionCall) otto.Value {
+	b, err := h.vm.toBuffer("chunk", call.Argument(0))
+	if err != nil {
+		return h.vm.throw(err)
+	}
+	cb := call.Argument(1)
+
+	if h.sync {
+		e := otto.UndefinedValue()
+		if _, err := h.w.Write(b); err != nil {
+			e = h.errorf(err)
+		}
+		if _, err := cb.Call(otto.UndefinedValue(), e); err != nil {
+			return h.vm.throw(err)
+		}
+		return otto.UndefinedValue()
+	}
+	b = append([]byte(nil), b...)
+	h.vm.Ref()
+	go func() {
+		_, err := h.w.Write(b)
+		h.vm.Enqueue(func() error {
+			h.vm.Unref()
+			e := otto.UndefinedValue()
+			if err != nil {
+				e = h.errorf(err)
+			}
+			_, err := cb.Call(otto.UndefinedValue(), e)
+			return err
+		})
+	}()
+	return otto.UndefinedValue()
+}
+
+func (h *writerHandle) close(call otto.FunctionCall) otto.Value {
+	cb := call.Argument(0)
+	if h.closed || h.c == nil {
+		h.closed = true
+		if _, err := cb.Call(otto.UndefinedValue()); err != nil {
+			return h.vm.throw(err)
+		}
+		return otto.UndefinedValue()
+	}
+	h.closed = true
+
+	h.vm.Ref()
+	go func() {
+		err := h.c.Close()
+		h.vm.Enqueue(func() error {
+			h.vm.Unref()
+			e := otto.UndefinedValue()
+			if err != nil {
+				e = h.errorf(err)
+			}
+			_, err := cb.Call(otto.UndefinedValue(), e)
+			return err
+		})
+	}()
+	return otto.UndefinedValue()
+}
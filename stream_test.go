@@ -0,0 +1,308 @@
+//
+// otto.module :: stream_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestStream(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var stream = require('stream');
+		var out = [];
+
+		var r = new stream.Readable({ read: function() {} });
+		r.on('data', function(c) { out.push('data ' + c); });
+		r.on('end', function() { out.push('end'); });
+		r.on('close', function() { out.push('close'); });
+		r.push('a');
+		r.push('b');
+		r.push(null);
+
+		var chunks = [];
+		var w = new stream.Writable({
+			highWaterMark: 3,
+			write: function(c, e, cb) {
+				chunks.push(c.toString());
+				setTimeout(cb, 1);
+			},
+		});
+		out.push('write ' + w.write('ab') + ' ' + w.write('cd') + ' ' + w.writableLength);
+		w.on('drain', function() {
+			out.push('drain');
+			w.end('ef');
+		});
+		w.on('finish', function() { out.push('finish ' + chunks.join('|')); });
+
+		var res = [];
+		stream.pipeline(
+			stream.Readable.from(['x', 'y', 'z']),
+			new stream.Transform({
+				transform: function(c, e, cb) { cb(null, c.toString().toUpperCase()); },
+				flush: function(cb) { cb(null, '!'); },
+			}),
+			new stream.PassThrough(),
+			new stream.Writable({
+				objectMode: true,
+				write: function(c, e, cb) {
+					res.push(c);
+					cb();
+				},
+			}),
+			function(err) { out.push('pipeline ' + err + ' ' + res.join('')); }
+		);
+		stream.pipeline(
+			stream.Readable.from(['q']),
+			new stream.Transform({ transform: function(c, e, cb) { cb(new Error('boom')); } }),
+			new stream.PassThrough(),
+			function(err) { out.push('pipeline ' + err.message); }
+		);
+
+		var u = new stream.Readable({ read: function() {} });
+		u.setEncoding('utf8');
+		u.on('readable', function() {
+			var c;
+			while ((c = u.read()) !== null) {
+				out.push('readable ' + c);
+			}
+		});
+		var euro = Buffer.from('€');
+		u.push(euro.slice(0, 1));
+		u.push(euro.slice(1));
+		u.push(null);
+
+		var d = new stream.Duplex({
+			read: function() {
+				this.push('r');
+				this.push(null);
+			},
+			write: function(c, e, cb) {
+				out.push('duplex write ' + c);
+				cb();
+			},
+		});
+		d.on('data', function(c) { out.push('duplex data ' + c); });
+		d.on('finish', function() { out.push('duplex finish'); });
+		d.end('w');
+
+		var e = new stream.Writable({ write: function(c, e, cb) { cb(); } });
+		e.on('error', function(e) { out.push(e.code); });
+		e.end();
+		e.write('x');
+
+		var p = new stream.PassThrough();
+		stream.finished(p, function(err) { out.push('finished ' + (err && err.code)); });
+		p.destroy();
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"write true false 4",
+		"duplex write w",
+		"data a",
+		"data b",
+		"readable €",
+		"duplex data r",
+		"duplex finish",
+		"ERR_STREAM_WRITE_AFTER_END",
+		"finished ERR_STREAM_PREMATURE_CLOSE",
+		"end",
+		"close",
+		"pipeline boom",
+		"pipeline undefined XYZ!",
+		"drain",
+		"finish ab|cd|ef",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`new stream.Readable({ highWaterMark: -1 })`, "TypeError: The property 'options.highWaterMark' is invalid. Received -1"},
+		{`new stream.Duplex({ writableHighWaterMark: 'a' })`, "TypeError: The property 'options.writableHighWaterMark' is invalid. Received 'a'"},
+		{`new stream.Writable().write(null)`, "TypeError: May not write null values to stream"},
+		{`new stream.Writable().write(1)`, `TypeError: The "chunk" argument must be of type string or an instance of Buffer, TypedArray, or DataView. Received type number (1)`},
+		{`new stream.Writable().write('a', 'enc')`, "TypeError: Unknown encoding: enc"},
+		{`new stream.Readable().read()`, "Error: The _read() method is not implemented"},
+		{`stream.Readable.from(1)`, `TypeError: The "iterable" argument must be an instance of Array. Received type number (1)`},
+		{`stream.pipeline(new stream.PassThrough(), function() {})`, `TypeError: The "streams" argument must be specified`},
+		{`stream.pipeline(new stream.PassThrough(), new stream.PassThrough())`, `TypeError: The "streams[stream.length - 1]" property must be of type function. Received an instance of PassThrough`},
+	} {
+		_, err := vm.Run(`var stream = require('stream');` + tt.src)
+		if err == nil {
+			err = vm.RunLoop(context.Background())
+		}
+		if err == nil {
+			t.Errorf("%v: expected error", tt.src)
+		} else if g, e := module.Wrap(err).Error(), tt.err; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+}
+
+func TestNewReadable(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range []struct {
+		r   io.Reader
+		out string
+	}{
+		{strings.NewReader("hello, world"), "data hello, world\nend"},
+		{iotest.OneByteReader(strings.NewReader("abc")), "data a\ndata b\ndata c\nend"},
+		{iotest.DataErrReader(strings.NewReader("abc")), "data abc\nend"},
+		{io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errors.New("broken"))), "data abc\nerror broken"},
+	} {
+		r, err := vm.NewReadable(tt.r)
+		if err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		vm.Set("r", r)
+		src := `
+			var out = [];
+			r.setEncoding('utf8');
+			r.on('data', function(c) { out.push('data ' + c); });
+			r.on('end', function() { out.push('end'); });
+			r.on('error', function(e) { out.push('error ' + e.message); });
+		`
+		if _, err := vm.Run(src); err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		if err := vm.RunLoop(context.Background()); err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+		if v, err := vm.Run(`out.join('\n')`); err != nil {
+			t.Fatal(module.Wrap(err))
+		} else if g, e := v.String(), tt.out; g != e {
+			t.Errorf("expected %q, got %q", e, g)
+		}
+	}
+
+	// destroy releases the event loop
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	r, err := vm.NewReadable(pr)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("r", r)
+	src := `
+		var out = [];
+		r.on('data', function() {});
+		r.on('close', function() { out.push('close'); });
+		setTimeout(function() { r.destroy(); }, 1);
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "close"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestNewWritable(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	var b strings.Builder
+	w, err := vm.NewWritable(&b)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	r, err := vm.NewReadable(strings.NewReader(strings.Repeat("x", 100000)))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("w", w)
+	vm.Set("r", r)
+	src := `
+		var out = [];
+		w.write('head:', function() { out.push('written'); });
+		w.on('finish', function() { out.push('finish'); });
+		r.pipe(w);
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "written\nfinish"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := b.String(), "head:"+strings.Repeat("x", 100000); g != e {
+		t.Errorf("expected %v bytes, got %v bytes", len(e), len(g))
+	}
+
+	w, err = vm.NewWritable(errWriter{})
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	vm.Set("w", w)
+	src = `
+		var out = [];
+		w.on('error', function(e) { out.push('error ' + e.message); });
+		w.on('close', function() { out.push('close'); });
+		w.write('a', function(e) { out.push('cb ' + e.message); });
+	`
+	if _, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if err := vm.RunLoop(context.Background()); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if v, err := vm.Run(`out.join('\n')`); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), "cb broken pipe\nerror broken pipe\nclose"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
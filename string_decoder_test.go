@@ -0,0 +1,67 @@
+//
+// otto.module :: string_decoder_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestStringDecoder(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var StringDecoder = require('string_decoder').StringDecoder;
+		function decode(enc, chunks) {
+			var d = new StringDecoder(enc);
+			return chunks.map(function(c) {
+				return d.write(c);
+			}).concat(d.end()).join('|');
+		}
+		[
+			decode('utf8', [[0x61, 0xe2], [0x82], [0xac, 0xf0, 0x9f], [0x98, 0x80]]),
+			decode('utf8', [[0xe2, 0x82], [0x41]]),
+			decode('utf8', [[0xe2, 0x82]]),
+			decode('utf16le', [[0x61], [0x00, 0x3d, 0xd8], [0x00, 0xde]]),
+			decode('utf16le', [[0x3d, 0xd8, 0x00], [0xde]]),
+			decode('utf16le', [[0x3d], [0xd8, 0x00], [0xde, 0x61], [0x00]]),
+			decode('base64', [[0x61], [0x62], [0x63, 0x64]]),
+			decode('hex', [[0xca], [0xfe]]),
+			decode('latin1', [[0xe9]]),
+			decode(undefined, ['abc']),
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		"a||€|😀|",
+		"|�A|",
+		"|�",
+		"|a|😀|",
+		"|😀|",
+		"||😀|a|",
+		"||YWJj|ZA==",
+		"ca|fe|",
+		"é|",
+		"abc|",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	if _, err := vm.Run(`new (require('string_decoder').StringDecoder)('_');`); err == nil {
+		t.Error("expected error")
+	} else if !strings.HasPrefix(err.Error(), "TypeError: Unknown encoding: _") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
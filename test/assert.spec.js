@@ -0,0 +1,197 @@
+//
+// otto.module :: assert.spec.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+const assert = require('../lib/assert');
+
+const { AssertionError } = assert;
+
+function catchError(fn) {
+  try {
+    fn();
+  } catch (e) {
+    return e;
+  }
+  return undefined;
+}
+
+describe('assert', () => {
+  describe('()', () => {
+    it('should pass a truthy value', () => {
+      expect(() => assert(1)).not.toThrow();
+      expect(() => assert.ok(true)).not.toThrow();
+    });
+
+    it('should throw AssertionError', () => {
+      expect(() => assert()).toThrow(AssertionError);
+      expect(() => assert.ok(0)).toThrow('The expression evaluated to a falsy value:\n\n  assert.ok(0)\n');
+      expect(() => assert.ok(0, 'message')).toThrow('message');
+    });
+  });
+
+  describe('.AssertionError', () => {
+    it('should carry actual, expected and operator', () => {
+      const err = catchError(() => assert.strictEqual(1, 2));
+
+      expect(err).toBeInstanceOf(AssertionError);
+      expect(err).toBeInstanceOf(Error);
+      expect(err.name).toBe('AssertionError');
+      expect(err.code).toBe('ERR_ASSERTION');
+      expect(err.actual).toBe(1);
+      expect(err.expected).toBe(2);
+      expect(err.operator).toBe('strictEqual');
+      expect(err.generatedMessage).toBe(true);
+      expect(err.message).toBe('Expected values to be strictly equal:\n\n1 !== 2\n');
+    });
+
+    it('should use the specified message', () => {
+      const err = new AssertionError({ message: 'message', actual: 1, expected: 2 });
+
+      expect(err.message).toBe('message');
+      expect(err.generatedMessage).toBe(false);
+      expect(String(err)).toBe('AssertionError [ERR_ASSERTION]: message');
+    });
+  });
+
+  describe('.fail()', () => {
+    it('should throw AssertionError', () => {
+      expect(() => assert.fail()).toThrow('Failed');
+      expect(() => assert.fail('message')).toThrow('message');
+      expect(() => assert.fail(new TypeError('error'))).toThrow(TypeError);
+    });
+  });
+
+  describe('.equal()', () => {
+    it('should compare with ==', () => {
+      expect(() => assert.equal(1, '1')).not.toThrow();
+      expect(() => assert.equal(NaN, NaN)).not.toThrow();
+      expect(() => assert.equal(1, 2)).toThrow('1 == 2');
+      expect(() => assert.notEqual(1, 2)).not.toThrow();
+      expect(() => assert.notEqual(1, '1')).toThrow('1 != \'1\'');
+    });
+
+    it('should throw TypeError', () => {
+      expect(() => assert.equal(1)).toThrow(TypeError);
+    });
+  });
+
+  describe('.strictEqual()', () => {
+    it('should compare with Object.is', () => {
+      expect(() => assert.strictEqual(NaN, NaN)).not.toThrow();
+      expect(() => assert.strictEqual(1, '1')).toThrow(AssertionError);
+      expect(() => assert.strictEqual(0, -0)).toThrow(AssertionError);
+      expect(() => assert.notStrictEqual(1, '1')).not.toThrow();
+      expect(() => assert.notStrictEqual(1, 1)).toThrow('Expected "actual" to be strictly unequal to: 1');
+    });
+  });
+
+  describe('.deepEqual()', () => {
+    it('should compare loosely', () => {
+      expect(() => assert.deepEqual({ a: [1] }, { a: ['1'] })).not.toThrow();
+      expect(() => assert.deepEqual({ a: 1 }, { a: 2 })).toThrow(AssertionError);
+      expect(() => assert.notDeepEqual({ a: 1 }, { a: 2 })).not.toThrow();
+      expect(() => assert.notDeepEqual({ a: 1 }, { a: '1' })).toThrow(AssertionError);
+    });
+  });
+
+  describe('.deepStrictEqual()', () => {
+    it('should compare strictly', () => {
+      expect(() => assert.deepStrictEqual({ a: [1, { b: 2 }] }, { a: [1, { b: 2 }] })).not.toThrow();
+      expect(() => assert.deepStrictEqual({ a: 1 }, { a: '1' })).toThrow(AssertionError);
+      expect(() => assert.notDeepStrictEqual({ a: 1 }, { a: '1' })).not.toThrow();
+      expect(() => assert.notDeepStrictEqual({ a: 1 }, { a: 1 })).toThrow(AssertionError);
+    });
+
+    it('should show a diff', () => {
+      const err = catchError(() => assert.deepStrictEqual({ a: 1, b: [2] }, { a: 2, b: [2] }));
+
+      expect(err.message).toBe([
+        'Expected values to be strictly deep-equal:',
+        '+ actual - expected',
+        '',
+        '  {',
+        '+   a: 1,',
+        '-   a: 2,',
+        '    b: [',
+        '      2',
+        '    ]',
+        '  }',
+        '',
+      ].join('\n'));
+    });
+  });
+
+  describe('.throws()', () => {
+    const thrower = () => { throw new TypeError('error'); };
+
+    it('should pass when an error is thrown', () => {
+      expect(() => assert.throws(thrower)).not.toThrow();
+      expect(() => assert.throws(thrower, TypeError)).not.toThrow();
+      expect(() => assert.throws(thrower, /^TypeError: error$/)).not.toThrow();
+      expect(() => assert.throws(thrower, (e) => e.message === 'error')).not.toThrow();
+      expect(() => assert.throws(thrower, { name: 'TypeError', message: /err/ })).not.toThrow();
+    });
+
+    it('should throw AssertionError', () => {
+      expect(() => assert.throws(() => {})).toThrow('Missing expected exception.');
+      expect(() => assert.throws(() => {}, TypeError)).toThrow('Missing expected exception (TypeError).');
+      expect(() => assert.throws(() => {}, 'message')).toThrow('Missing expected exception: message');
+      expect(() => assert.throws(thrower, /x/)).toThrow(AssertionError);
+      expect(() => assert.throws(thrower, () => false)).toThrow(AssertionError);
+      expect(() => assert.throws(thrower, { message: 'x' })).toThrow(AssertionError);
+    });
+
+    it('should rethrow an unexpected error', () => {
+      expect(() => assert.throws(thrower, RangeError)).toThrow(AssertionError);
+    });
+  });
+
+  describe('.doesNotThrow()', () => {
+    it('should pass when no error is thrown', () => {
+      expect(() => assert.doesNotThrow(() => {})).not.toThrow();
+    });
+
+    it('should throw AssertionError', () => {
+      expect(() => assert.doesNotThrow(() => { throw new Error('error'); })).toThrow('Got unwanted exception.\nActual message: "error"');
+      expect(() => assert.doesNotThrow(() => { throw new TypeError('error'); }, TypeError)).toThrow(AssertionError);
+    });
+
+    it('should rethrow an unexpected error', () => {
+      expect(() => assert.doesNotThrow(() => { throw new TypeError('error'); }, RangeError)).toThrow(TypeError);
+    });
+  });
+
+  describe('.match()', () => {
+    it('should test a string', () => {
+      expect(() => assert.match('abc', /b/)).not.toThrow();
+      expect(() => assert.match('abc', /x/)).toThrow('The input did not match the regular expression /x/.');
+      expect(() => assert.match(1, /1/)).toThrow(AssertionError);
+      expect(() => assert.doesNotMatch('abc', /x/)).not.toThrow();
+      expect(() => assert.doesNotMatch('abc', /b/)).toThrow(AssertionError);
+    });
+
+    it('should throw TypeError', () => {
+      expect(() => assert.match('abc', 'b')).toThrow(TypeError);
+    });
+  });
+
+  describe('.ifError()', () => {
+    it('should throw a non-nullish value', () => {
+      expect(() => assert.ifError(null)).not.toThrow();
+      expect(() => assert.ifError(undefined)).not.toThrow();
+      expect(() => assert.ifError(new Error('error'))).toThrow('ifError got unwanted exception: error');
+    });
+  });
+
+  describe('.strict', () => {
+    it('should use strict comparisons', () => {
+      expect(assert.strict.strict).toBe(assert.strict);
+      expect(() => assert.strict.equal(1, '1')).toThrow(AssertionError);
+      expect(() => assert.strict.deepEqual([1], ['1'])).toThrow(AssertionError);
+    });
+  });
+});
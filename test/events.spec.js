@@ -0,0 +1,337 @@
+//
+// otto.module :: events.spec.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+const EventEmitter = require('../lib/events');
+
+describe('events', () => {
+  it('should export EventEmitter', () => {
+    expect(EventEmitter.EventEmitter).toBe(EventEmitter);
+  });
+
+  describe('.defaultMaxListeners', () => {
+    it('is 10', () => {
+      expect(EventEmitter.defaultMaxListeners).toBe(10);
+    });
+
+    it('should throw RangeError', () => {
+      expect(() => { EventEmitter.defaultMaxListeners = -1; }).toThrow(RangeError);
+      expect(() => { EventEmitter.defaultMaxListeners = NaN; }).toThrow(RangeError);
+      expect(() => { EventEmitter.defaultMaxListeners = '1'; }).toThrow(RangeError);
+    });
+  });
+
+  describe('.listenerCount()', () => {
+    it('should return the number of listeners', () => {
+      const e = new EventEmitter();
+      e.on('event', () => {});
+      e.on('event', () => {});
+
+      expect(EventEmitter.listenerCount(e, 'event')).toBe(2);
+      expect(EventEmitter.listenerCount(e, '_')).toBe(0);
+    });
+  });
+
+  describe('EventEmitter', () => {
+    describe('#emit()', () => {
+      it('should call listeners with arguments', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        e.on('event', function listener(a, b) {
+          calls.push([this, a, b]);
+        });
+
+        expect(e.emit('event', 1, 2)).toBe(true);
+        expect(e.emit('_')).toBe(false);
+        expect(calls).toHaveLength(1);
+        expect(calls[0][0]).toBe(e);
+        expect(calls[0].slice(1)).toEqual([1, 2]);
+      });
+
+      it('should call listeners in order', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        e.on('event', () => calls.push(1));
+        e.on('event', () => calls.push(2));
+        e.prependListener('event', () => calls.push(0));
+        e.emit('event');
+
+        expect(calls).toEqual([0, 1, 2]);
+      });
+
+      it('should not call listeners added during emit', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        e.on('event', () => {
+          calls.push(1);
+          e.on('event', () => calls.push(2));
+        });
+        e.emit('event');
+
+        expect(calls).toEqual([1]);
+      });
+
+      it('should throw an unhandled error', () => {
+        const e = new EventEmitter();
+        const err = new Error('error');
+
+        expect(() => e.emit('error', err)).toThrow(err);
+        expect(() => e.emit('error', 'error')).toThrow('Unhandled error. (error)');
+        let ex;
+        try {
+          e.emit('error', 'error');
+        } catch (x) {
+          ex = x;
+        }
+        expect(ex.code).toBe('ERR_UNHANDLED_ERROR');
+        expect(ex.context).toBe('error');
+      });
+
+      it('should not throw a handled error', () => {
+        const e = new EventEmitter();
+        const errors = [];
+        e.on('error', (err) => errors.push(err));
+
+        expect(e.emit('error', 'error')).toBe(true);
+        expect(errors).toEqual(['error']);
+      });
+    });
+
+    describe('#on()', () => {
+      it('should throw TypeError', () => {
+        const e = new EventEmitter();
+
+        expect(() => e.on('event')).toThrow(TypeError);
+        expect(() => e.on('event', {})).toThrow(TypeError);
+      });
+
+      it('should emit "newListener"', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        const listener = () => {};
+        e.on('newListener', (type, fn) => {
+          calls.push([type, fn, e.listenerCount(type)]);
+        });
+        e.on('event', listener);
+        e.once('event', listener);
+
+        expect(calls).toEqual([
+          ['event', listener, 0],
+          ['event', listener, 1],
+        ]);
+      });
+
+      it('should emit a warning when listeners exceed the limit', () => {
+        const e = new EventEmitter();
+        const emitWarning = jest.fn();
+        const orig = process.emitWarning;
+        process.emitWarning = emitWarning;
+        try {
+          e.setMaxListeners(1);
+          e.on('event', () => {});
+          e.on('event', () => {});
+          e.on('event', () => {});
+        } finally {
+          process.emitWarning = orig;
+        }
+
+        expect(emitWarning).toHaveBeenCalledTimes(1);
+        expect(emitWarning.mock.calls[0][1]).toBe('MaxListenersExceededWarning');
+      });
+
+      it('should be chainable', () => {
+        const e = new EventEmitter();
+
+        expect(e.on('event', () => {})).toBe(e);
+        expect(e.addListener('event', () => {})).toBe(e);
+        expect(e.prependListener('event', () => {})).toBe(e);
+        expect(e.once('event', () => {})).toBe(e);
+        expect(e.prependOnceListener('event', () => {})).toBe(e);
+      });
+    });
+
+    describe('#once()', () => {
+      it('should call a listener only once', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        e.once('event', (v) => calls.push(v));
+        e.prependOnceListener('event', (v) => calls.push(-v));
+        e.emit('event', 1);
+        e.emit('event', 2);
+
+        expect(calls).toEqual([-1, 1]);
+        expect(e.listenerCount('event')).toBe(0);
+      });
+
+      it('should be removed by the original listener', () => {
+        const e = new EventEmitter();
+        const listener = jest.fn();
+        e.once('event', listener);
+        e.removeListener('event', listener);
+        e.emit('event');
+
+        expect(listener).not.toHaveBeenCalled();
+      });
+    });
+
+    describe('#removeListener()', () => {
+      it('should remove the last added listener', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        const listener = () => calls.push(1);
+        e.on('event', listener);
+        e.on('event', () => calls.push(2));
+        e.on('event', listener);
+        e.off('event', listener);
+        e.emit('event');
+
+        expect(calls).toEqual([1, 2]);
+      });
+
+      it('should emit "removeListener"', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        const listener = () => {};
+        e.on('removeListener', (type, fn) => calls.push([type, fn]));
+        e.once('event', listener);
+        e.removeListener('event', listener);
+        e.removeListener('event', listener);
+
+        expect(calls).toEqual([['event', listener]]);
+      });
+
+      it('should not affect the current emit', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        const listener = () => calls.push(2);
+        e.on('event', () => {
+          calls.push(1);
+          e.removeListener('event', listener);
+        });
+        e.on('event', listener);
+        e.emit('event');
+        e.emit('event');
+
+        expect(calls).toEqual([1, 2, 1]);
+      });
+    });
+
+    describe('#removeAllListeners()', () => {
+      it('should remove listeners of the specified event', () => {
+        const e = new EventEmitter();
+        e.on('a', () => {});
+        e.on('b', () => {});
+        e.removeAllListeners('a');
+
+        expect(e.eventNames()).toEqual(['b']);
+      });
+
+      it('should remove all listeners', () => {
+        const e = new EventEmitter();
+        const calls = [];
+        e.on('a', () => {});
+        e.on('b', () => {});
+        e.on('removeListener', (type) => calls.push(type));
+        e.removeAllListeners();
+
+        expect(e.eventNames()).toEqual([]);
+        expect(calls).toEqual(['a', 'b']);
+      });
+    });
+
+    describe('#listeners()', () => {
+      it('should return a copy of listeners', () => {
+        const e = new EventEmitter();
+        const listener = () => {};
+        e.on('event', listener);
+        e.once('event', listener);
+
+        const listeners = e.listeners('event');
+        expect(listeners).toHaveLength(2);
+        expect(listeners[0]).toBe(listener);
+        expect(listeners[1]).toBe(listener);
+        listeners.pop();
+        expect(e.listeners('event')).toHaveLength(2);
+        expect(e.listeners('_')).toEqual([]);
+      });
+    });
+
+    describe('#rawListeners()', () => {
+      it('should return a copy of listeners including wrappers', () => {
+        const e = new EventEmitter();
+        const listener = jest.fn();
+        e.once('event', listener);
+
+        const listeners = e.rawListeners('event');
+        expect(listeners).toHaveLength(1);
+        expect(listeners[0]).not.toBe(listener);
+        expect(listeners[0].listener).toBe(listener);
+        listeners[0]();
+        expect(listener).toHaveBeenCalledTimes(1);
+        expect(e.rawListeners('event')).toEqual([]);
+      });
+    });
+
+    describe('#listenerCount()', () => {
+      it('should return the number of listeners', () => {
+        const e = new EventEmitter();
+        const listener = () => {};
+        e.on('event', listener);
+        e.once('event', listener);
+        e.on('event', () => {});
+
+        expect(e.listenerCount('event')).toBe(3);
+        expect(e.listenerCount('event', listener)).toBe(2);
+        expect(e.listenerCount('_')).toBe(0);
+      });
+    });
+
+    describe('#eventNames()', () => {
+      it('should return event names', () => {
+        const e = new EventEmitter();
+
+        expect(e.eventNames()).toEqual([]);
+        e.on('a', () => {});
+        e.on('b', () => {});
+        expect(e.eventNames()).toEqual(['a', 'b']);
+      });
+    });
+
+    describe('#setMaxListeners()', () => {
+      it('should throw RangeError', () => {
+        const e = new EventEmitter();
+
+        expect(() => e.setMaxListeners(-1)).toThrow(RangeError);
+        expect(() => e.setMaxListeners(NaN)).toThrow(RangeError);
+      });
+
+      it('should set the limit', () => {
+        const e = new EventEmitter();
+
+        expect(e.getMaxListeners()).toBe(EventEmitter.defaultMaxListeners);
+        expect(e.setMaxListeners(0)).toBe(e);
+        expect(e.getMaxListeners()).toBe(0);
+      });
+    });
+
+    describe('inheritance', () => {
+      it('should work without calling the constructor', () => {
+        function Emitter() {}
+        Emitter.prototype = Object.create(EventEmitter.prototype);
+
+        const a = new Emitter();
+        const b = new Emitter();
+        const listener = jest.fn();
+        a.on('event', listener);
+        b.emit('event');
+
+        expect(listener).not.toHaveBeenCalled();
+        expect(a.emit('event')).toBe(true);
+      });
+    });
+  });
+});
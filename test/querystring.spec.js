@@ -0,0 +1,97 @@
+//
+// otto.module :: querystring.spec.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+const querystring = require('../lib/querystring');
+
+describe('querystring', () => {
+  describe('.parse()', () => {
+    it('should parse a query string', () => {
+      expect(querystring.parse('a=1&b=x+y&a=2&c&=d&&e=f=g')).toEqual({
+        a: ['1', '2'],
+        b: 'x y',
+        c: '',
+        '': 'd',
+        e: 'f=g',
+      });
+      expect(querystring.parse('%E3%81%82=%41%zz&%E3%81=1')).toEqual({ '\u3042': 'A%zz', '\ufffd': '1' });
+      expect(Object.getPrototypeOf(querystring.parse('a=1'))).toBeNull();
+    });
+
+    it('should parse with custom separators', () => {
+      expect(querystring.parse('a:1;b:2', ';', ':')).toEqual({ a: '1', b: '2' });
+      expect(querystring.parse('a=1&&b=2', '&&')).toEqual({ a: '1', b: '2' });
+    });
+
+    it('should limit the number of keys', () => {
+      expect(querystring.parse('a=1&b=2&c=3', null, null, { maxKeys: 2 })).toEqual({ a: '1', b: '2' });
+      expect(querystring.parse('&&a=1', null, null, { maxKeys: 2 })).toEqual({});
+      expect(Object.keys(querystring.parse(new Array(1002).join('a&'))).length).toBe(1);
+      expect(Object.keys(querystring.parse('a=1&b=2&c=3', null, null, { maxKeys: 0 })).length).toBe(3);
+    });
+
+    it('should use a custom decoder', () => {
+      const decodeURIComponent = (s) => `[${s}]`;
+
+      expect(querystring.parse('a+b=c', null, null, { decodeURIComponent })).toEqual({ '[a%20b]': '[c]' });
+    });
+
+    it('should return an empty object on a non-string', () => {
+      expect(querystring.parse(5)).toEqual({});
+      expect(querystring.parse('')).toEqual({});
+    });
+  });
+
+  describe('.stringify()', () => {
+    it('should stringify an object', () => {
+      expect(querystring.stringify({
+        a: [1, 2],
+        b: 'x y',
+        c: true,
+        d: null,
+        e: {},
+        f: NaN,
+        '\u00e9': "!'()*~",
+      })).toBe("a=1&a=2&b=x%20y&c=true&d=&e=&f=&%C3%A9=!'()*~");
+      expect(querystring.stringify('a')).toBe('');
+    });
+
+    it('should stringify with custom separators', () => {
+      expect(querystring.stringify({ a: 1, b: 2 }, ';', ':')).toBe('a:1;b:2');
+    });
+
+    it('should use a custom encoder', () => {
+      const encodeURIComponent = (s) => s.toUpperCase();
+
+      expect(querystring.stringify({ a: 'b' }, null, null, { encodeURIComponent })).toBe('A=B');
+    });
+  });
+
+  describe('.escape()', () => {
+    it('should escape a string', () => {
+      expect(querystring.escape('a b&\u00e9')).toBe('a%20b%26%C3%A9');
+      expect(querystring.escape(1)).toBe('1');
+    });
+
+    it('should throw an error on a lone surrogate', () => {
+      expect(() => querystring.escape('\ud800')).toThrow(URIError);
+    });
+  });
+
+  describe('.unescape()', () => {
+    it('should unescape a string', () => {
+      expect(querystring.unescape('a%20b+c')).toBe('a b+c');
+      expect(querystring.unescape('%zz%41+', true)).toBe('%zzA ');
+      expect(querystring.unescape('%E3%81')).toBe('\ufffd');
+    });
+  });
+
+  it('should have aliases', () => {
+    expect(querystring.decode).toBe(querystring.parse);
+    expect(querystring.encode).toBe(querystring.stringify);
+  });
+});
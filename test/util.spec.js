@@ -0,0 +1,181 @@
+//
+// otto.module :: util.spec.js
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+const util = require('../lib/util');
+
+describe('util', () => {
+  describe('.format()', () => {
+    it('should format placeholders', () => {
+      expect(util.format('%s %d %i %f', 'a', '42', 4.2, '4.2')).toBe('a 42 4 4.2');
+      expect(util.format('%j', { a: 1 })).toBe('{"a":1}');
+      expect(util.format('%o', [1])).toBe('[ 1, [length]: 1 ]');
+      expect(util.format('%O', { a: 1 })).toBe('{ a: 1 }');
+      expect(util.format('%%s %s', 'a')).toBe('%s a');
+    });
+
+    it('should append extra arguments', () => {
+      expect(util.format('%s', 'a', 'b', 1)).toBe('a b 1');
+      expect(util.format(1, 'a', { b: 2 })).toBe('1 a { b: 2 }');
+    });
+
+    it('should leave missing placeholders', () => {
+      expect(util.format('%s %s', 'a')).toBe('a %s');
+    });
+
+    it('should handle circular structures in %j', () => {
+      const o = {};
+      o.o = o;
+
+      expect(util.format('%j', o)).toBe('[Circular]');
+    });
+  });
+
+  describe('.inspect()', () => {
+    it('should inspect primitives', () => {
+      expect(util.inspect('a')).toBe("'a'");
+      expect(util.inspect("'")).toBe('"\'"');
+      expect(util.inspect(-0)).toBe('-0');
+      expect(util.inspect(null)).toBe('null');
+      expect(util.inspect(undefined)).toBe('undefined');
+    });
+
+    it('should inspect objects', () => {
+      expect(util.inspect({ a: 1, 'b-c': [2, 3] })).toBe("{ a: 1, 'b-c': [ 2, 3 ] }");
+      expect(util.inspect({ a: { b: { c: { d: {} } } } })).toBe('{ a: { b: { c: [Object] } } }');
+      expect(util.inspect([1, , 3])).toBe('[ 1, <1 empty item>, 3 ]'); // eslint-disable-line no-sparse-arrays
+      expect(util.inspect(function f() {})).toBe('[Function: f]');
+      expect(util.inspect(new Error('error')).split('\n')[0]).toBe('Error: error');
+    });
+
+    it('should inspect circular structures', () => {
+      const o = { a: [] };
+      o.a.push(o);
+
+      expect(util.inspect(o)).toBe('<ref *1> { a: [ [Circular *1] ] }');
+    });
+
+    it('should respect options', () => {
+      expect(util.inspect({ a: { b: {} } }, { depth: 0 })).toBe('{ a: [Object] }');
+      expect(util.inspect([1, 2, 3], { maxArrayLength: 1 })).toBe('[ 1, ... 2 more items ]');
+      expect(util.inspect({ b: 1, a: 2 }, { sorted: true })).toBe('{ a: 2, b: 1 }');
+      expect(util.inspect(1, { colors: true })).toBe('\u001b[33m1\u001b[39m');
+    });
+
+    it('should call custom inspect', () => {
+      const o = {
+        [util.inspect.custom]: (depth) => `custom ${depth}`,
+      };
+
+      expect(util.inspect(o)).toBe('custom 2');
+      expect(util.inspect({ a: o })).toBe('{ a: custom 1 }');
+    });
+  });
+
+  describe('.inherits()', () => {
+    it('should set up the prototype chain', () => {
+      function Base() {}
+      Base.prototype.name = () => 'base';
+      function Derived() {}
+      util.inherits(Derived, Base);
+
+      expect(Derived.super_).toBe(Base);
+      expect(new Derived().name()).toBe('base');
+      expect(new Derived()).toBeInstanceOf(Base);
+      expect(Derived.prototype.constructor).toBe(Derived);
+    });
+
+    it('should throw TypeError', () => {
+      expect(() => util.inherits(null, Object)).toThrow(TypeError);
+      expect(() => util.inherits(Object, null)).toThrow(TypeError);
+    });
+  });
+
+  describe('.deprecate()', () => {
+    it('should emit a warning once', () => {
+      const emitWarning = jest.fn();
+      const orig = process.emitWarning;
+      process.emitWarning = emitWarning;
+      let n;
+      try {
+        const fn = util.deprecate(() => 1, 'deprecated', 'DEP0000');
+        n = fn() + fn();
+      } finally {
+        process.emitWarning = orig;
+      }
+
+      expect(n).toBe(2);
+      expect(emitWarning).toHaveBeenCalledTimes(1);
+      expect(emitWarning).toHaveBeenCalledWith('deprecated', 'DeprecationWarning', 'DEP0000');
+    });
+  });
+
+  describe('.callbackify()', () => {
+    it('should call back with the resolved value', (done) => {
+      util.callbackify(async (v) => v * 2)(21, (err, v) => {
+        expect(err).toBeNull();
+        expect(v).toBe(42);
+        done();
+      });
+    });
+
+    it('should call back with the rejected reason', (done) => {
+      util.callbackify(() => Promise.reject(null))((err) => {
+        expect(err.code).toBe('ERR_FALSY_VALUE_REJECTION');
+        expect(err.reason).toBeNull();
+        done();
+      });
+    });
+
+    it('should call back on the next tick', (done) => {
+      let called = false;
+      util.callbackify((v) => v)(1, () => {
+        called = true;
+        done();
+      });
+      expect(called).toBe(false);
+    });
+  });
+
+  describe('.debuglog()', () => {
+    it('should be disabled by default', () => {
+      expect(util.debuglog('otto').enabled).toBe(false);
+    });
+  });
+
+  describe('.isDeepStrictEqual()', () => {
+    it('should compare values', () => {
+      expect(util.isDeepStrictEqual({ a: [1, { b: 2 }] }, { a: [1, { b: 2 }] })).toBe(true);
+      expect(util.isDeepStrictEqual({ a: 1 }, { a: '1' })).toBe(false);
+      expect(util.isDeepStrictEqual([1, 2], [2, 1])).toBe(false);
+      expect(util.isDeepStrictEqual(NaN, NaN)).toBe(true);
+      expect(util.isDeepStrictEqual(0, -0)).toBe(false);
+      expect(util.isDeepStrictEqual(new Date(0), new Date(0))).toBe(true);
+      expect(util.isDeepStrictEqual(/a/g, /a/i)).toBe(false);
+    });
+
+    it('should handle circular structures', () => {
+      const a = {};
+      a.a = a;
+      const b = {};
+      b.a = b;
+
+      expect(util.isDeepStrictEqual(a, b)).toBe(true);
+    });
+  });
+
+  describe('.types', () => {
+    it('should check types', () => {
+      expect(util.types.isDate(new Date())).toBe(true);
+      expect(util.types.isRegExp(/a/)).toBe(true);
+      expect(util.types.isNativeError(new TypeError())).toBe(true);
+      expect(util.types.isBoxedPrimitive(Object(1))).toBe(true);
+      expect(util.types.isPromise(Promise.resolve())).toBe(true);
+      expect(util.types.isDate({})).toBe(false);
+    });
+  });
+});
@@ -0,0 +1,32 @@
+'use strict';
+
+var n = 0;
+
+function f(x) {
+  if (x > 0) {
+    n++;
+  } else if (x < 0) n--;
+
+  return x ? 'true' : 'false';
+}
+
+var g = function(a, b) {
+  return a || b && 0;
+};
+
+f(1);
+f(0);
+g(0, 1);
+
+switch (n) {
+case 0:
+case 1:
+  n = 2;
+  break;
+default:
+  n = 3;
+}
+
+for (var i = 0; i < 2; i++) n++;
+
+module.exports = n;
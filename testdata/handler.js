@@ -0,0 +1,47 @@
+var ticks = 0;
+setInterval(function() {
+  ticks++;
+}, 1);
+
+module.exports = function(req) {
+  switch (req.path) {
+  case '/text':
+    return {
+      headers: {
+        'Content-Type': 'text/plain',
+        'Set-Cookie': ['a=1', 'b=2'],
+      },
+      body: req.method + ' ' + req.url + ' ' + req.query.q + ' ' + req.headers['x-custom'],
+    };
+  case '/buffer':
+    return {
+      status: 201,
+      body: Buffer.concat([Buffer.from('<'), req.body, Buffer.from('>')]),
+    };
+  case '/json':
+    return {
+      body: {
+        host: req.headers.host,
+        query: req.query,
+      },
+    };
+  case '/timer':
+    return { body: String(ticks > 0) };
+  case '/cyclic':
+    var o = {};
+    o.o = o;
+    return {
+      headers: { 'X-Custom': 'custom' },
+      body: o,
+    };
+  case '/throw':
+    var e = new TypeError('invalid value');
+    e.code = 'ERR_INVALID_ARG_VALUE';
+    throw e;
+  case '/string':
+    throw 'oops';
+  case '/status':
+    return { status: 42 };
+  }
+  return 'not found';
+};
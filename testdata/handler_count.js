@@ -0,0 +1,5 @@
+var n = 0;
+
+module.exports = function(req) {
+  return { body: String(++n) };
+};
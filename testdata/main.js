@@ -0,0 +1,5 @@
+console.log(process.argv[1]);
+console.log(JSON.stringify(process.argv.slice(2)));
+setTimeout(function() {
+  console.log('done');
+}, 1);
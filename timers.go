@@ -0,0 +1,103 @@
+//
+// otto.module :: timers.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+func (vm *Otto) timers_binding(o *otto.Object) error {
+	o.Set("setupTimers", vm.timers_setupTimers)
+	o.Set("start", vm.timers_start)
+	o.Set("scheduleImmediate", vm.timers_scheduleImmediate)
+	o.Set("clear", vm.timers_clear)
+	o.Set("ref", vm.timers_ref)
+	return nil
+}
+
+func (vm *Otto) timers_setupTimers(call otto.FunctionCall) otto.Value {
+	vm.loop.processTimer = call.Argument(0)
+	vm.loop.processImmediate = call.Argument(1)
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) timers_start(call otto.FunctionCall) otto.Value {
+	ms, err := call.Argument(1).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	repeat, _ := call.Argument(2).ToBoolean()
+	ref, _ := call.Argument(3).ToBoolean()
+
+	l := &vm.loop
+	t := &timer{
+		obj:  call.Argument(0),
+		when: time.Now().Add(time.Duration(ms) * time.Millisecond),
+		ref:  ref,
+	}
+	if repeat {
+		t.repeat = time.Duration(ms) * time.Millisecond
+	}
+	id := l.add(t)
+	heap.Push(&l.timers, t)
+	v, _ := vm.ToValue(id)
+	return v
+}
+
+func (vm *Otto) timers_scheduleImmediate(call otto.FunctionCall) otto.Value {
+	ref, _ := call.Argument(1).ToBoolean()
+
+	l := &vm.loop
+	t := &timer{
+		obj: call.Argument(0),
+		ref: ref,
+	}
+	id := l.add(t)
+	l.immediates = append(l.immediates, t)
+	v, _ := vm.ToValue(id)
+	return v
+}
+
+func (vm *Otto) timers_clear(call otto.FunctionCall) otto.Value {
+	id, err := call.Argument(0).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	l := &vm.loop
+	if t, ok := l.active[id]; ok {
+		l.remove(t)
+		if t.index != -1 {
+			heap.Remove(&l.timers, t.index)
+		}
+	}
+	return otto.UndefinedValue()
+}
+
+func (vm *Otto) timers_ref(call otto.FunctionCall) otto.Value {
+	id, err := call.Argument(0).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	ref, _ := call.Argument(1).ToBoolean()
+
+	l := &vm.loop
+	if t, ok := l.active[id]; ok && t.ref != ref {
+		t.ref = ref
+		if ref {
+			l.refed++
+		} else {
+			l.refed--
+		}
+	}
+	return otto.UndefinedValue()
+}
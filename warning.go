@@ -0,0 +1,106 @@
+//
+// otto.module :: warning.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+type DeprecationMode int
+
+const (
+	DeprecationWarning DeprecationMode = iota
+	NoDeprecation
+	ThrowDeprecation
+)
+
+func WithDeprecation(mode DeprecationMode) Option {
+	return func(vm *Otto) {
+		vm.deprecation = mode
+	}
+}
+
+func WithWarningFunc(fn func(*Warning)) Option {
+	return func(vm *Otto) {
+		vm.warn = fn
+	}
+}
+
+func WithWarningWriter(w io.Writer) Option {
+	return WithWarningFunc(warningWriter(w))
+}
+
+func warningWriter(w io.Writer) func(*Warning) {
+	return func(warning *Warning) {
+		fmt.Fprintf(w, "(otto:%v) %v\n", os.Getpid(), warning)
+	}
+}
+
+type Warning struct {
+	Name    string
+	Message string
+	Code    string
+	Detail  string
+	Stack   string
+}
+
+func (w *Warning) String() string {
+	var b strings.Builder
+	if w.Code != "" {
+		fmt.Fprintf(&b, "[%v] ", w.Code)
+	}
+	fmt.Fprintf(&b, "%v: %v", w.Name, w.Message)
+	if w.Detail != "" {
+		fmt.Fprintf(&b, "\n%v", w.Detail)
+	}
+	return b.String()
+}
+
+func (vm *Otto) warning_create(call otto.FunctionCall) otto.Value {
+	name, err := vm.toString("type", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	msg, err := vm.toString("warning", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+
+	return vm.MakeCustomError(name, msg)
+}
+
+func (vm *Otto) warning_emit(call otto.FunctionCall) otto.Value {
+	v := call.Argument(0)
+	if !v.IsObject() {
+		return vm.throw(fmt.Errorf("warning must be an Object"))
+	}
+
+	o := v.Object()
+	w := new(Warning)
+	for k, p := range map[string]*string{
+		"name":    &w.Name,
+		"message": &w.Message,
+		"code":    &w.Code,
+		"detail":  &w.Detail,
+		"stack":   &w.Stack,
+	} {
+		if v, _ := o.Get(k); v.IsDefined() {
+			*p, _ = v.ToString()
+		}
+	}
+	if vm.warn != nil {
+		vm.warn(w)
+	}
+	return otto.UndefinedValue()
+}
@@ -0,0 +1,210 @@
+//
+// otto.module :: warning_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+var emitWarningTests = []struct {
+	src string
+	w   module.Warning
+}{
+	{
+		src: `process.emitWarning('message');`,
+		w: module.Warning{
+			Name:    "Warning",
+			Message: "message",
+		},
+	},
+	{
+		src: `process.emitWarning('message', 'CustomWarning');`,
+		w: module.Warning{
+			Name:    "CustomWarning",
+			Message: "message",
+		},
+	},
+	{
+		src: `process.emitWarning('message', 'CustomWarning', 'CODE');`,
+		w: module.Warning{
+			Name:    "CustomWarning",
+			Message: "message",
+			Code:    "CODE",
+		},
+	},
+	{
+		src: `process.emitWarning('message', function() {});`,
+		w: module.Warning{
+			Name:    "Warning",
+			Message: "message",
+		},
+	},
+	{
+		src: `process.emitWarning('message', { type: 'CustomWarning', code: 'CODE', detail: 'detail' });`,
+		w: module.Warning{
+			Name:    "CustomWarning",
+			Message: "message",
+			Code:    "CODE",
+			Detail:  "detail",
+		},
+	},
+	{
+		src: `var w = new Error('message'); w.name = 'CustomWarning'; process.emitWarning(w);`,
+		w: module.Warning{
+			Name:    "CustomWarning",
+			Message: "message",
+		},
+	},
+}
+
+func TestEmitWarning(t *testing.T) {
+	var w *module.Warning
+	vm, err := module.New(module.WithWarningFunc(func(warning *module.Warning) {
+		w = warning
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range emitWarningTests {
+		w = nil
+		if _, err := vm.Run(tt.src); err != nil {
+			t.Error(module.Wrap(err))
+		} else if w == nil {
+			t.Errorf("%v: expected warning", tt.src)
+		} else {
+			if !strings.Contains(w.Stack, ": "+tt.w.Message+"\n") {
+				t.Errorf("%v: unexpected stack %q", tt.src, w.Stack)
+			}
+			w.Stack = ""
+			if g, e := *w, tt.w; g != e {
+				t.Errorf("%v: expected %#v, got %#v", tt.src, e, g)
+			}
+		}
+	}
+}
+
+func TestEmitWarningError(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, src := range []string{
+		`process.emitWarning();`,
+		`process.emitWarning(null);`,
+		`process.emitWarning({});`,
+		`process.emitWarning('message', 0);`,
+		`process.emitWarning('message', 'Warning', 0);`,
+		`process.binding('warning').emit(null);`,
+	} {
+		if _, err := vm.Run(src); err == nil {
+			t.Errorf("%v: expected error", strings.Trim(src, ";"))
+		}
+	}
+}
+
+func TestEmitWarning_Listener(t *testing.T) {
+	var n int
+	vm, err := module.New(module.WithWarningFunc(func(*module.Warning) {
+		n++
+	}))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	src := `
+		var warnings = [];
+		process.on('warning', function(w) {
+			warnings.push(w.name + ': ' + w.message);
+		});
+		process.emitWarning('message');
+		warnings.join();
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Error(module.Wrap(err))
+	} else if g, e := v.String(), "Warning: message"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+	if g, e := n, 1; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+
+	if _, err := vm.Run(`process.removeAllListeners('warning'); process.emitWarning('message');`); err != nil {
+		t.Error(module.Wrap(err))
+	}
+	if g, e := n, 1; g != e {
+		t.Errorf("expected %v, got %v", e, g)
+	}
+}
+
+func TestDeprecation(t *testing.T) {
+	src := `
+		process.emitWarning('deprecated', 'DeprecationWarning', 'DEP0001');
+		process.emitWarning('deprecated', 'DeprecationWarning', 'DEP0001');
+		process.emitWarning('deprecated', 'DeprecationWarning', 'DEP0002');
+		process.emitWarning('deprecated', 'DeprecationWarning');
+		process.emitWarning('deprecated', 'DeprecationWarning');
+	`
+	for _, tt := range []struct {
+		mode module.DeprecationMode
+		n    int
+		err  bool
+	}{
+		{module.DeprecationWarning, 4, false},
+		{module.NoDeprecation, 0, false},
+		{module.ThrowDeprecation, 0, true},
+	} {
+		var n int
+		vm, err := module.New(
+			module.WithDeprecation(tt.mode),
+			module.WithWarningFunc(func(*module.Warning) {
+				n++
+			}),
+		)
+		if err != nil {
+			t.Fatal(module.Wrap(err))
+		}
+
+		switch _, err := vm.Run(src); {
+		case err != nil:
+			if !tt.err {
+				t.Error(module.Wrap(err))
+			} else if !strings.HasPrefix(err.Error(), "DeprecationWarning: deprecated") {
+				t.Errorf("unexpected error: %v", err)
+			}
+		case tt.err:
+			t.Error("expected error")
+		}
+		if g, e := n, tt.n; g != e {
+			t.Errorf("expected %v, got %v", e, g)
+		}
+	}
+}
+
+func TestWarningWriter(t *testing.T) {
+	var b bytes.Buffer
+	vm, err := module.New(module.WithWarningWriter(&b))
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	if _, err := vm.Run(`process.emitWarning('message', { type: 'DeprecationWarning', code: 'DEP0001', detail: 'detail' });`); err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	if g, e := b.String(), fmt.Sprintf("(otto:%v) [DEP0001] DeprecationWarning: message\ndetail\n", os.Getpid()); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
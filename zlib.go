@@ -0,0 +1,220 @@
+//
+// otto.module :: zlib.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+
+	"github.com/robertkrimen/otto"
+)
+
+type zlibError struct {
+	code  string
+	errno int
+	msg   string
+}
+
+func (e *zlibError) Error() string {
+	return e.msg
+}
+
+var (
+	errZlibHeader      = &zlibError{"Z_DATA_ERROR", -3, "incorrect header check"}
+	errZlibChecksum    = &zlibError{"Z_DATA_ERROR", -3, "incorrect data check"}
+	errZlibData        = &zlibError{"Z_DATA_ERROR", -3, "invalid compressed data"}
+	errZlibEOF         = &zlibError{"Z_BUF_ERROR", -5, "unexpected end of file"}
+	errZlibMissingDict = &zlibError{"Z_NEED_DICT", 2, "Missing dictionary"}
+	errZlibBadDict     = &zlibError{"Z_NEED_DICT", 2, "Bad dictionary"}
+)
+
+func (vm *Otto) throwZlib(err error, dict []byte) otto.Value {
+	var ze *zlibError
+	var corrupt flate.CorruptInputError
+	switch {
+	case errors.Is(err, gzip.ErrHeader), errors.Is(err, zlib.ErrHeader):
+		ze = errZlibHeader
+	case errors.Is(err, gzip.ErrChecksum), errors.Is(err, zlib.ErrChecksum):
+		ze = errZlibChecksum
+	case errors.Is(err, zlib.ErrDictionary):
+		if dict == nil {
+			ze = errZlibMissingDict
+		} else {
+			ze = errZlibBadDict
+		}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		ze = errZlibEOF
+	case errors.As(err, &corrupt):
+		ze = errZlibData
+	default:
+		return vm.throw(err)
+	}
+
+	v := vm.MakeCustomError("Error", ze.msg)
+	v.Object().Set("errno", ze.errno)
+	v.Object().Set("code", ze.code)
+	panic(v)
+}
+
+func (vm *Otto) zlib_binding(o *otto.Object) error {
+	o.Set("compress", vm.zlib_compress)
+	o.Set("decompress", vm.zlib_decompress)
+	return nil
+}
+
+func (vm *Otto) zlib_compress(call otto.FunctionCall) otto.Value {
+	format, err := vm.toString("format", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := vm.toBuffer("buffer", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	level, err := call.Argument(2).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	dict, _ := vm.bufferOf(call.Argument(3))
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch format {
+	case "gzip":
+		var gw *gzip.Writer
+		gw, err = gzip.NewWriterLevel(&buf, int(level))
+		if err == nil {
+			// unix
+			gw.OS = 3
+		}
+		w = gw
+	case "deflate":
+		w, err = zlib.NewWriterLevelDict(&buf, int(level), dict)
+	case "deflateRaw":
+		w, err = flate.NewWriterDict(&buf, int(level), dict)
+	default:
+		return vm.throw(errors.New("unknown format: " + format))
+	}
+	if err == nil {
+		if _, err = w.Write(b); err == nil {
+			err = w.Close()
+		}
+	}
+	if err != nil {
+		return vm.throw(err)
+	}
+	v, err := vm.NewBuffer(buf.Bytes())
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+func (vm *Otto) zlib_decompress(call otto.FunctionCall) otto.Value {
+	format, err := vm.toString("format", call.Argument(0))
+	if err != nil {
+		return vm.throw(err)
+	}
+	b, err := vm.toBuffer("buffer", call.Argument(1))
+	if err != nil {
+		return vm.throw(err)
+	}
+	maxOutputLength, err := call.Argument(2).ToInteger()
+	if err != nil {
+		return vm.throw(err)
+	}
+	dict, _ := vm.bufferOf(call.Argument(3))
+
+	if format == "unzip" {
+		if len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b {
+			format = "gzip"
+		} else {
+			format = "deflate"
+		}
+	}
+	var r io.ReadCloser
+	switch format {
+	case "gzip":
+		r, err = newGzipReader(b)
+	case "deflate":
+		r, err = zlib.NewReaderDict(bytes.NewReader(b), dict)
+	case "deflateRaw":
+		r = flate.NewReaderDict(bytes.NewReader(b), dict)
+	default:
+		return vm.throw(errors.New("unknown format: " + format))
+	}
+	if err != nil {
+		return vm.throwZlib(err, dict)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, maxOutputLength+1))
+	switch {
+	case err != nil:
+		return vm.throwZlib(err, dict)
+	case n > maxOutputLength:
+		return otto.NullValue()
+	}
+	v, err := vm.NewBuffer(buf.Bytes())
+	if err != nil {
+		return vm.throw(err)
+	}
+	return v
+}
+
+// gzipReader is a multistream gzip reader which reports trailing garbage as
+// a header error.
+type gzipReader struct {
+	r *bytes.Reader
+	z *gzip.Reader
+}
+
+func newGzipReader(b []byte) (*gzipReader, error) {
+	g := &gzipReader{r: bytes.NewReader(b)}
+	if err := g.header(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *gzipReader) header() (err error) {
+	if g.r.Len() >= 2 {
+		var magic [2]byte
+		g.r.ReadAt(magic[:], g.r.Size()-int64(g.r.Len()))
+		if magic != [2]byte{0x1f, 0x8b} {
+			return gzip.ErrHeader
+		}
+	}
+	if g.z == nil {
+		g.z, err = gzip.NewReader(g.r)
+	} else {
+		err = g.z.Reset(g.r)
+	}
+	if err == nil {
+		g.z.Multistream(false)
+	}
+	return
+}
+
+func (g *gzipReader) Read(p []byte) (int, error) {
+	n, err := g.z.Read(p)
+	if err == io.EOF && g.r.Len() > 0 {
+		err = g.header()
+	}
+	return n, err
+}
+
+func (g *gzipReader) Close() error {
+	return g.z.Close()
+}
@@ -0,0 +1,122 @@
+//
+// otto.module :: zlib_test.go
+//
+//   Copyright (c) 2026 Akinori Hattori <hattya@gmail.com>
+//
+//   SPDX-License-Identifier: MIT
+//
+
+package module_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hattya/otto.module"
+)
+
+func TestZlib(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(`{"a":1}`))
+	w.Close()
+	vm.Set("payload", buf.Bytes())
+
+	src := `
+		var zlib = require('zlib');
+		var data = 'hello, world';
+		var dict = Buffer.from('world');
+		[
+			zlib.gunzipSync(Buffer.from(payload)).toString(),
+			zlib.unzipSync(Buffer.from(payload)).toString(),
+			zlib.gunzipSync(zlib.gzipSync(data)).toString(),
+			zlib.gunzipSync(Buffer.concat([zlib.gzipSync('a'), zlib.gzipSync('b')])).toString(),
+			zlib.inflateSync(zlib.deflateSync(data, { level: 9 })).toString(),
+			zlib.unzipSync(zlib.deflateSync(data)).toString(),
+			zlib.inflateRawSync(zlib.deflateRawSync(Buffer.from(data), { level: 0 })).toString(),
+			zlib.inflateSync(zlib.deflateSync(data, { dictionary: dict }), { dictionary: dict }).toString(),
+			zlib.gzipSync('').slice(0, 10).toString('hex'),
+			zlib.deflateSync('hello', { level: 1 }).slice(0, 2).toString('hex'),
+			zlib.constants.Z_BEST_COMPRESSION,
+			zlib.codes[zlib.codes.Z_BUF_ERROR],
+		].join('\n');
+	`
+	if v, err := vm.Run(src); err != nil {
+		t.Fatal(module.Wrap(err))
+	} else if g, e := v.String(), strings.Join([]string{
+		`{"a":1}`,
+		`{"a":1}`,
+		"hello, world",
+		"ab",
+		"hello, world",
+		"hello, world",
+		"hello, world",
+		"hello, world",
+		"1f8b0800000000000003",
+		"7801",
+		"9",
+		"Z_BUF_ERROR",
+	}, "\n"); g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+
+	v, err := vm.Run(`require('zlib').gzipSync('payload')`)
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+	b, err := vm.Bytes(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	} else if g, e := string(b), "payload"; g != e {
+		t.Errorf("expected %q, got %q", e, g)
+	}
+}
+
+func TestZlibError(t *testing.T) {
+	vm, err := module.New()
+	if err != nil {
+		t.Fatal(module.Wrap(err))
+	}
+
+	for _, tt := range []struct {
+		src, err string
+	}{
+		{`zlib.gzipSync(1)`, `TypeError: The "buffer" argument must be of type string or an instance of Buffer, TypedArray, DataView, or ArrayBuffer. Received type number (1)`},
+		{`zlib.deflateSync('a', { level: '1' })`, `TypeError: The "options.level" property must be of type number. Received type string ('1')`},
+		{`zlib.deflateSync('a', { level: 10 })`, `RangeError: The value of "options.level" is out of range. It must be >= -1 and <= 9. Received 10`},
+		{`zlib.deflateSync('a', { dictionary: 'a' })`, `TypeError: The "options.dictionary" property must be an instance of Buffer, TypedArray, DataView, or ArrayBuffer. Received type string ('a')`},
+		{`zlib.gunzipSync('abc')`, "Error: incorrect header check"},
+		{`zlib.gunzipSync(Buffer.alloc(0))`, "Error: unexpected end of file"},
+		{`zlib.gunzipSync(zlib.gzipSync('a').slice(0, 12))`, "Error: unexpected end of file"},
+		{`zlib.gunzipSync(Buffer.concat([zlib.gzipSync('a'), Buffer.from('xx')]))`, "Error: incorrect header check"},
+		{`var b = zlib.gzipSync('abc'); b[b.length - 5] ^= 1; zlib.gunzipSync(b)`, "Error: incorrect data check"},
+		{`zlib.inflateSync('abc')`, "Error: incorrect header check"},
+		{`var b = zlib.deflateSync('abc'); b[b.length - 1] ^= 1; zlib.inflateSync(b)`, "Error: incorrect data check"},
+		{`zlib.inflateSync(zlib.deflateSync('a', { dictionary: Buffer.from('a') }))`, "Error: Missing dictionary"},
+		{`zlib.inflateSync(zlib.deflateSync('a', { dictionary: Buffer.from('a') }), { dictionary: Buffer.from('b') })`, "Error: Bad dictionary"},
+		{`zlib.inflateRawSync(Buffer.from([0xff]))`, "Error: invalid compressed data"},
+		{`zlib.gunzipSync(zlib.gzipSync('abc'), { maxOutputLength: 2 })`, "RangeError: Cannot create a Buffer larger than 2 bytes"},
+		{`zlib.gunzipSync(zlib.gzipSync('abc'), { maxOutputLength: 0 })`, `RangeError: The value of "options.maxOutputLength" is out of range. It must be >= 1 and <= 4294967296. Received 0`},
+	} {
+		if _, err := vm.Run(`var zlib = require('zlib');` + tt.src); err == nil {
+			t.Errorf("%v: expected error", tt.src)
+		} else if g, e := module.Wrap(err).Error(), tt.err; !strings.HasPrefix(g, e) {
+			t.Errorf("%v: expected %q, got %q", tt.src, e, g)
+		}
+	}
+}